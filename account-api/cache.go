@@ -0,0 +1,90 @@
+package account_api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// cacheTTLList is used for producer specific lists like extensions or binaries
+	cacheTTLList = 5 * time.Minute
+	// cacheTTLStatic is used for static store data like categories or software versions
+	cacheTTLStatic = 24 * time.Hour
+)
+
+func getResponseCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "shopware-cli", "account-api"), nil
+}
+
+// SetRefreshCache forces all cached reads to be fetched again from the API.
+func (c *Client) SetRefreshCache(refresh bool) {
+	c.refreshCache = refresh
+}
+
+// doCachedRequest behaves like doRequest, but serves GET requests from the local response cache as long as they are younger than ttl.
+func (c *Client) doCachedRequest(request *http.Request, ttl time.Duration) ([]byte, error) {
+	if request.Method != http.MethodGet {
+		return c.doRequest(request)
+	}
+
+	cacheFile, err := c.responseCacheFile(request)
+	if err != nil {
+		return c.doRequest(request)
+	}
+
+	if !c.refreshCache {
+		if stat, err := os.Stat(cacheFile); err == nil && time.Since(stat.ModTime()) < ttl {
+			if content, err := os.ReadFile(cacheFile); err == nil {
+				return content, nil
+			}
+		}
+	}
+
+	data, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// the responses contain private data of the account, so only the user can read them. Files and folders of older
+	// versions were readable by everyone, WriteFile and MkdirAll keep the mode of existing ones.
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o700); err == nil {
+		_ = os.Chmod(filepath.Dir(cacheFile), 0o700)
+
+		if err := os.WriteFile(cacheFile, data, 0o600); err == nil {
+			_ = os.Chmod(cacheFile, 0o600)
+		}
+	}
+
+	return data, nil
+}
+
+func (c *Client) responseCacheFile(request *http.Request) (string, error) {
+	cacheDir, err := getResponseCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s", c.GetUserID(), c.GetActiveCompanyID(), request.URL.String())))
+
+	return filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// InvalidateResponseCache removes all cached API responses.
+func InvalidateResponseCache() error {
+	cacheDir, err := getResponseCacheDir()
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(cacheDir)
+}
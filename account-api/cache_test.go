@@ -0,0 +1,47 @@
+package account_api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoCachedRequestRestrictsExistingCacheFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported")
+	}
+
+	producer := newAccountTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	request, err := producer.c.NewAuthenticatedRequest(context.Background(), "GET", ApiUrl+"/plugins", nil)
+	assert.NoError(t, err)
+
+	cacheFile, err := producer.c.responseCacheFile(request)
+	assert.NoError(t, err)
+
+	// a cache file written by an older version
+	assert.NoError(t, os.MkdirAll(filepath.Dir(cacheFile), os.ModePerm))
+	assert.NoError(t, os.Chmod(filepath.Dir(cacheFile), os.ModePerm))
+	assert.NoError(t, os.WriteFile(cacheFile, []byte(`[]`), os.ModePerm))
+	assert.NoError(t, os.Chmod(cacheFile, os.ModePerm))
+
+	producer.c.SetRefreshCache(true)
+
+	_, err = producer.c.doCachedRequest(request, cacheTTLList)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(cacheFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Dir(cacheFile))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
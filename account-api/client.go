@@ -16,6 +16,8 @@ type Client struct {
 	Token            token        `json:"token"`
 	ActiveMembership Membership   `json:"active_membership"`
 	Memberships      []Membership `json:"memberships"`
+	refreshCache     bool
+	uploadProgress   UploadProgress
 }
 
 func (c *Client) NewAuthenticatedRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
@@ -50,7 +52,12 @@ func (*Client) doRequest(request *http.Request) ([]byte, error) {
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf(string(data))
+		return nil, &apiError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	// Any write can change cached lists, so start with a fresh cache
+	if request.Method != http.MethodGet {
+		_ = InvalidateResponseCache()
 	}
 
 	return data, nil
@@ -156,6 +163,10 @@ func InvalidateTokenCache() error {
 		return err
 	}
 
+	if err := InvalidateResponseCache(); err != nil {
+		return err
+	}
+
 	if _, err := os.Stat(tokenFilePath); os.IsNotExist(err) {
 		return nil
 	}
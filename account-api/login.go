@@ -11,7 +11,8 @@ import (
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
 
-const ApiUrl = "https://api.shopware.com"
+// ApiUrl is the base url of the Shopware account API, tests point it to a local server.
+var ApiUrl = "https://api.shopware.com"
 
 type AccountConfig interface {
 	GetAccountEmail() string
@@ -135,7 +135,7 @@ func (e ProducerEndpoint) Extensions(ctx context.Context, criteria *ListExtensio
 		return nil, err
 	}
 
-	body, err := e.c.doRequest(r)
+	body, err := e.c.doCachedRequest(r, cacheTTLList)
 	if err != nil {
 		return nil, err
 	}
@@ -407,7 +407,7 @@ func (e ProducerEndpoint) GetSoftwareVersions(ctx context.Context, generation st
 		return nil, fmt.Errorf(errorFormat, err)
 	}
 
-	body, err := e.c.doRequest(r)
+	body, err := e.c.doCachedRequest(r, cacheTTLStatic)
 	if err != nil {
 		return nil, fmt.Errorf(errorFormat, err)
 	}
@@ -438,6 +438,15 @@ type Locale struct {
 	Name string `json:"name"`
 }
 
+// LocaleLanguage returns the language of a locale name like de_DE, it is empty for names too short to contain one.
+func LocaleLanguage(name string) string {
+	if len(name) < 2 {
+		return ""
+	}
+
+	return name[0:2]
+}
+
 type StoreAvailablity struct {
 	Id          int    `json:"id"`
 	Name        string `json:"name"`
@@ -527,7 +536,7 @@ func (e ProducerEndpoint) GetExtensionGeneralInfo(ctx context.Context) (*Extensi
 		return nil, fmt.Errorf("GetExtensionGeneralInfo: %v", err)
 	}
 
-	body, err := e.c.doRequest(r)
+	body, err := e.c.doCachedRequest(r, cacheTTLStatic)
 	if err != nil {
 		return nil, fmt.Errorf("GetExtensionGeneralInfo: %v", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/microcosm-cc/bluemonday"
 
@@ -58,7 +59,7 @@ func (e ProducerEndpoint) GetExtensionBinaries(ctx context.Context, extensionId
 		return nil, fmt.Errorf(errorFormat, err)
 	}
 
-	body, err := e.c.doRequest(r)
+	body, err := e.c.doCachedRequest(r, cacheTTLList)
 	if err != nil {
 		return nil, fmt.Errorf(errorFormat, err)
 	}
@@ -89,40 +90,12 @@ func (e ProducerEndpoint) UpdateExtensionBinaryInfo(ctx context.Context, extensi
 	return err
 }
 
+// CreateExtensionBinaryFile uploads the zip as new binary. It is not retried, as a failed request may have created the
+// binary already.
 func (e ProducerEndpoint) CreateExtensionBinaryFile(ctx context.Context, extensionId int, zipPath string) (*ExtensionBinary, error) {
 	errorFormat := "CreateExtensionBinaryFile: %v"
 
-	var b bytes.Buffer
-	w := multipart.NewWriter(&b)
-
-	fileWritter, err := w.CreateFormFile("file", filepath.Base(zipPath))
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	zipFile, err := os.Open(zipPath)
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	_, err = io.Copy(fileWritter, zipFile)
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	err = w.Close()
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	r, err := e.c.NewAuthenticatedRequest(ctx, "POST", fmt.Sprintf("%s/plugins/%d/binaries", ApiUrl, extensionId), &b)
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	r.Header.Set("content-type", w.FormDataContentType())
-
-	content, err := e.c.doRequest(r)
+	content, err := e.c.uploadFile(ctx, "POST", fmt.Sprintf("%s/plugins/%d/binaries", ApiUrl, extensionId), zipPath)
 	if err != nil {
 		return nil, fmt.Errorf(errorFormat, err)
 	}
@@ -136,39 +109,9 @@ func (e ProducerEndpoint) CreateExtensionBinaryFile(ctx context.Context, extensi
 	return binary[0], nil
 }
 
+// UpdateExtensionBinaryFile replaces the zip of the binary, the upload is retried on transient errors.
 func (e ProducerEndpoint) UpdateExtensionBinaryFile(ctx context.Context, extensionId, binaryId int, zipPath string) error {
-	errorFormat := "UpdateExtensionBinaryFile: %v"
-
-	var b bytes.Buffer
-	w := multipart.NewWriter(&b)
-
-	fileWritter, err := w.CreateFormFile("file", filepath.Base(zipPath))
-	if err != nil {
-		return fmt.Errorf(errorFormat, err)
-	}
-
-	zipFile, err := os.Open(zipPath)
-	if err != nil {
-		return fmt.Errorf(errorFormat, err)
-	}
-
-	if _, err = io.Copy(fileWritter, zipFile); err != nil {
-		return fmt.Errorf(errorFormat, err)
-	}
-
-	err = w.Close()
-	if err != nil {
-		return fmt.Errorf(errorFormat, err)
-	}
-
-	r, err := e.c.NewAuthenticatedRequest(ctx, "POST", fmt.Sprintf("%s/plugins/%d/binaries/%d/file", ApiUrl, extensionId, binaryId), &b)
-	if err != nil {
-		return fmt.Errorf(errorFormat, err)
-	}
-
-	r.Header.Set("content-type", w.FormDataContentType())
-
-	_, err = e.c.doRequest(r)
+	_, err := e.c.uploadFileWithRetry(ctx, "POST", fmt.Sprintf("%s/plugins/%d/binaries/%d/file", ApiUrl, extensionId, binaryId), zipPath)
 
 	return err
 }
@@ -354,6 +297,26 @@ func (e ProducerEndpoint) GetBinaryReviewResults(ctx context.Context, extensionI
 	return results, nil
 }
 
+// WaitForBinaryReview polls the review results until more than knownReviews results exist and the newest one is no longer pending.
+func (e ProducerEndpoint) WaitForBinaryReview(ctx context.Context, extensionId, binaryId, knownReviews int, interval time.Duration) (*BinaryReviewResult, error) {
+	for {
+		reviews, err := e.GetBinaryReviewResults(ctx, extensionId, binaryId)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(reviews) > knownReviews && !reviews[len(reviews)-1].IsPending() {
+			return &reviews[len(reviews)-1], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 type BinaryReviewResult struct {
 	Id       int `json:"id"`
 	BinaryId int `json:"binaryId"`
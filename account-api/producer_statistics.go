@@ -0,0 +1,244 @@
+package account_api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const statisticsPageSize = 100
+
+type ExtensionSale struct {
+	Id           int    `json:"id"`
+	CreationDate string `json:"creationDate"`
+	Plugin       struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"plugin"`
+	VariantType struct {
+		Name string `json:"name"`
+	} `json:"variantType"`
+	Price float64 `json:"price"`
+	// RefundDate is set, when the sale has been refunded
+	RefundDate string `json:"refundDate"`
+}
+
+type ExtensionDownloads struct {
+	Date      string `json:"date"`
+	Downloads int    `json:"downloads"`
+}
+
+// ExtensionStatistics contains the sales, refunds and downloads of an extension in a date range.
+type ExtensionStatistics struct {
+	Name      string  `json:"name"`
+	Sales     int     `json:"sales"`
+	Revenue   float64 `json:"revenue"`
+	Refunds   int     `json:"refunds"`
+	Refunded  float64 `json:"refunded"`
+	Downloads int     `json:"downloads"`
+}
+
+// GetSales returns all sales of the producer created between from and to, both days included. A zero from returns
+// all sales created until to.
+func (e ProducerEndpoint) GetSales(ctx context.Context, from, to time.Time) ([]ExtensionSale, error) {
+	errorFormat := "GetSales: %v"
+	sales := make([]ExtensionSale, 0)
+
+	for offset := 0; ; offset += statisticsPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(statisticsPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("orderBy", "creationDate")
+		query.Set("orderSequence", "asc")
+		query.Set("to", to.Format("2006-01-02"))
+
+		if !from.IsZero() {
+			query.Set("from", from.Format("2006-01-02"))
+		}
+
+		r, err := e.c.NewAuthenticatedRequest(ctx, "GET", fmt.Sprintf("%s/producers/%d/sales?%s", ApiUrl, e.GetId(), query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		body, err := e.c.doRequest(r)
+		if err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		var page []ExtensionSale
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		sales = append(sales, page...)
+
+		if len(page) < statisticsPageSize {
+			return sales, nil
+		}
+	}
+}
+
+// GetExtensionDownloads returns the downloads per day of the extension between from and to, both days included.
+func (e ProducerEndpoint) GetExtensionDownloads(ctx context.Context, extensionId int, from, to time.Time) ([]ExtensionDownloads, error) {
+	errorFormat := "GetExtensionDownloads: %v"
+
+	query := url.Values{}
+	query.Set("from", from.Format("2006-01-02"))
+	query.Set("to", to.Format("2006-01-02"))
+
+	r, err := e.c.NewAuthenticatedRequest(ctx, "GET", fmt.Sprintf("%s/plugins/%d/downloadstatistics?%s", ApiUrl, extensionId, query.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	body, err := e.c.doRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	var downloads []ExtensionDownloads
+	if err := json.Unmarshal(body, &downloads); err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	return downloads, nil
+}
+
+// allExtensions returns all extensions of the producer, page by page.
+func (e ProducerEndpoint) allExtensions(ctx context.Context) ([]Extension, error) {
+	extensions := make([]Extension, 0)
+
+	for offset := 0; ; offset += statisticsPageSize {
+		page, err := e.Extensions(ctx, &ListExtensionCriteria{Limit: statisticsPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+
+		extensions = append(extensions, page...)
+
+		if len(page) < statisticsPageSize {
+			return extensions, nil
+		}
+	}
+}
+
+// inDateRange reports whether the day of an API date like "2024-01-31 10:00:00" is between from and to.
+func inDateRange(date string, from, to time.Time) bool {
+	if len(date) < 10 {
+		return false
+	}
+
+	day := date[:10]
+
+	return day >= from.Format("2006-01-02") && day <= to.Format("2006-01-02")
+}
+
+// Statistics collects the sales, refunds and downloads of all extensions of the producer between from and to.
+// Sales are counted by their creation date and refunds by their refund date, so a refund of an earlier sale counts
+// in the range it was refunded in.
+func (e ProducerEndpoint) Statistics(ctx context.Context, from, to time.Time) ([]ExtensionStatistics, error) {
+	extensions, err := e.allExtensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*ExtensionStatistics)
+
+	for _, extension := range extensions {
+		if extension.Status.Name == "deleted" {
+			continue
+		}
+
+		downloads, err := e.GetExtensionDownloads(ctx, extension.Id, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		statistics := &ExtensionStatistics{Name: extension.Name}
+
+		for _, day := range downloads {
+			statistics.Downloads += day.Downloads
+		}
+
+		byName[extension.Name] = statistics
+	}
+
+	// sales created before the range can be refunded inside of it
+	sales, err := e.GetSales(ctx, time.Time{}, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sale := range sales {
+		sold := inDateRange(sale.CreationDate, from, to)
+		refunded := inDateRange(sale.RefundDate, from, to)
+
+		if !sold && !refunded {
+			continue
+		}
+
+		statistics, ok := byName[sale.Plugin.Name]
+		if !ok {
+			statistics = &ExtensionStatistics{Name: sale.Plugin.Name}
+			byName[sale.Plugin.Name] = statistics
+		}
+
+		if sold {
+			statistics.Sales++
+			statistics.Revenue += sale.Price
+		}
+
+		if refunded {
+			statistics.Refunds++
+			statistics.Refunded += sale.Price
+		}
+	}
+
+	result := make([]ExtensionStatistics, 0, len(byName))
+	for _, statistics := range byName {
+		result = append(result, *statistics)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// WriteStatisticsCSV writes the statistics with one row per extension and the range in the first columns.
+func WriteStatisticsCSV(writer io.Writer, from, to time.Time, statistics []ExtensionStatistics) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write([]string{"from", "to", "name", "sales", "revenue", "refunds", "refunded", "downloads"}); err != nil {
+		return err
+	}
+
+	for _, entry := range statistics {
+		row := []string{
+			from.Format("2006-01-02"),
+			to.Format("2006-01-02"),
+			entry.Name,
+			strconv.Itoa(entry.Sales),
+			strconv.FormatFloat(entry.Revenue, 'f', 2, 64),
+			strconv.Itoa(entry.Refunds),
+			strconv.FormatFloat(entry.Refunded, 'f', 2, 64),
+			strconv.Itoa(entry.Downloads),
+		}
+
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
@@ -0,0 +1,73 @@
+package account_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatisticsPaginatesExtensionsAndCountsRefundsByRefundDate(t *testing.T) {
+	producer := newAccountTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("x-shopware-token"))
+
+		switch r.URL.Path {
+		case "/plugins":
+			extensions := make([]map[string]interface{}, 0)
+
+			if r.URL.Query().Get("offset") == "" {
+				for i := 0; i < statisticsPageSize; i++ {
+					extensions = append(extensions, map[string]interface{}{"id": i, "name": fmt.Sprintf("Extension%03d", i)})
+				}
+			} else {
+				assert.Equal(t, "100", r.URL.Query().Get("offset"))
+				extensions = append(extensions, map[string]interface{}{"id": 100, "name": "FroshTools"})
+			}
+
+			_ = json.NewEncoder(w).Encode(extensions)
+		case "/plugins/100/downloadstatistics":
+			_, _ = w.Write([]byte(`[{"date": "2024-02-01", "downloads": 3}, {"date": "2024-02-02", "downloads": 4}]`))
+		case "/producers/42/sales":
+			assert.Empty(t, r.URL.Query().Get("from"))
+			assert.Equal(t, "2024-02-29", r.URL.Query().Get("to"))
+
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "creationDate": "2024-01-10 10:00:00", "plugin": {"id": 100, "name": "FroshTools"}, "price": 10, "refundDate": "2024-02-05 08:00:00"},
+				{"id": 2, "creationDate": "2024-01-12 10:00:00", "plugin": {"id": 100, "name": "FroshTools"}, "price": 10},
+				{"id": 3, "creationDate": "2024-02-03 10:00:00", "plugin": {"id": 100, "name": "FroshTools"}, "price": 20},
+				{"id": 4, "creationDate": "2024-02-10 10:00:00", "plugin": {"id": 100, "name": "FroshTools"}, "price": 30, "refundDate": "2024-02-11 10:00:00"}
+			]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	})
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	statistics, err := producer.Statistics(context.Background(), from, to)
+	assert.NoError(t, err)
+	assert.Len(t, statistics, 101)
+
+	assert.Equal(t, ExtensionStatistics{Name: "FroshTools", Sales: 2, Revenue: 50, Refunds: 2, Refunded: 40, Downloads: 7}, statistics[100])
+	assert.Equal(t, ExtensionStatistics{Name: "Extension000"}, statistics[0])
+}
+
+func TestWriteStatisticsCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	err := WriteStatisticsCSV(&buf, from, to, []ExtensionStatistics{
+		{Name: "FroshTools", Sales: 2, Revenue: 50, Refunds: 1, Refunded: 10.5, Downloads: 7},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "from,to,name,sales,revenue,refunds,refunded,downloads\n2024-02-01,2024-02-29,FroshTools,2,50.00,1,10.50,7\n", buf.String())
+}
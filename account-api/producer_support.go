@@ -0,0 +1,191 @@
+package account_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const supportTicketPageSize = 100
+
+// SupportTicketType selects the list of the producer, support tickets and compatibility inquiries share the same structure.
+type SupportTicketType string
+
+const (
+	SupportTicketTypeSupport       SupportTicketType = "supporttickets"
+	SupportTicketTypeCompatibility SupportTicketType = "compatibilityinquiries"
+)
+
+type SupportTicket struct {
+	Id     int    `json:"id"`
+	Title  string `json:"title"`
+	Status struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"status"`
+	Plugin struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"plugin"`
+	Customer struct {
+		Id          int    `json:"id"`
+		CompanyName string `json:"companyName"`
+	} `json:"customer"`
+	ShopwareVersion string                `json:"shopwareVersion"`
+	CreationDate    string                `json:"creationDate"`
+	LastChangeDate  string                `json:"lastChangeDate"`
+	Answers         []SupportTicketAnswer `json:"answers"`
+}
+
+// IsOpen reports whether the ticket still waits for an answer or a resolution.
+func (t SupportTicket) IsOpen() bool {
+	return t.Status.Name != "closed" && t.Status.Name != "resolved"
+}
+
+type SupportTicketAnswer struct {
+	Id           int    `json:"id"`
+	Text         string `json:"text"`
+	Author       string `json:"author"`
+	FromProducer bool   `json:"fromProducer"`
+	CreationDate string `json:"creationDate"`
+	Attachments  []struct {
+		Id         int    `json:"id"`
+		Name       string `json:"name"`
+		RemoteLink string `json:"remoteLink"`
+	} `json:"attachments"`
+}
+
+// SupportTickets returns the tickets of the given type, with openOnly closed and resolved tickets are skipped.
+func (e ProducerEndpoint) SupportTickets(ctx context.Context, ticketType SupportTicketType, openOnly bool) ([]SupportTicket, error) {
+	errorFormat := "SupportTickets: %v"
+	tickets := make([]SupportTicket, 0)
+
+	for offset := 0; ; offset += supportTicketPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(supportTicketPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("orderBy", "lastChangeDate")
+		query.Set("orderSequence", "desc")
+
+		r, err := e.c.NewAuthenticatedRequest(ctx, "GET", fmt.Sprintf("%s/producers/%d/%s?%s", ApiUrl, e.GetId(), ticketType, query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		body, err := e.c.doRequest(r)
+		if err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		var page []SupportTicket
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+
+		for _, ticket := range page {
+			if openOnly && !ticket.IsOpen() {
+				continue
+			}
+
+			tickets = append(tickets, ticket)
+		}
+
+		if len(page) < supportTicketPageSize {
+			return tickets, nil
+		}
+	}
+}
+
+// GetSupportTicket returns the ticket with all answers and their attachments.
+func (e ProducerEndpoint) GetSupportTicket(ctx context.Context, ticketType SupportTicketType, ticketId int) (*SupportTicket, error) {
+	errorFormat := "GetSupportTicket: %v"
+
+	r, err := e.c.NewAuthenticatedRequest(ctx, "GET", fmt.Sprintf("%s/producers/%d/%s/%d", ApiUrl, e.GetId(), ticketType, ticketId), nil)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	body, err := e.c.doRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	var ticket SupportTicket
+	if err := json.Unmarshal(body, &ticket); err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	return &ticket, nil
+}
+
+// DownloadSupportTicketAttachment returns the content of an attachment link of a ticket answer. Links can point to other
+// hosts like a storage bucket, so the account token is only sent to the account API.
+func (e ProducerEndpoint) DownloadSupportTicketAttachment(ctx context.Context, remoteLink string) ([]byte, error) {
+	errorFormat := "DownloadSupportTicketAttachment: %v"
+
+	link, err := url.Parse(remoteLink)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	if link.Scheme != "https" && link.Scheme != "http" {
+		return nil, fmt.Errorf(errorFormat, fmt.Sprintf("unsupported attachment link %s", remoteLink))
+	}
+
+	apiURL, err := url.Parse(ApiUrl)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	var r *http.Request
+
+	if link.Scheme == apiURL.Scheme && link.Host == apiURL.Host {
+		r, err = e.c.NewAuthenticatedRequest(ctx, "GET", remoteLink, nil)
+	} else {
+		r, err = http.NewRequestWithContext(ctx, "GET", remoteLink, http.NoBody)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	r.Header.Set("accept", "*/*")
+
+	content, err := e.c.doRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	return content, nil
+}
+
+// ReplyToSupportTicket posts the text as answer of the producer to the ticket.
+func (e ProducerEndpoint) ReplyToSupportTicket(ctx context.Context, ticketType SupportTicketType, ticketId int, text string) (*SupportTicketAnswer, error) {
+	errorFormat := "ReplyToSupportTicket: %v"
+
+	content, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	r, err := e.c.NewAuthenticatedRequest(ctx, "POST", fmt.Sprintf("%s/producers/%d/%s/%d/answers", ApiUrl, e.GetId(), ticketType, ticketId), bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	body, err := e.c.doRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	var answer SupportTicketAnswer
+	if err := json.Unmarshal(body, &answer); err != nil {
+		return nil, fmt.Errorf(errorFormat, err)
+	}
+
+	return &answer, nil
+}
@@ -0,0 +1,115 @@
+package account_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAccountTestServer(t *testing.T, handler http.HandlerFunc) ProducerEndpoint {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	apiUrl := ApiUrl
+	ApiUrl = server.URL
+
+	t.Cleanup(func() {
+		ApiUrl = apiUrl
+	})
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	return ProducerEndpoint{c: &Client{Token: token{Token: "secret"}}, producerId: 42}
+}
+
+func TestSupportTicketsPaginatesAndFiltersOpen(t *testing.T) {
+	producer := newAccountTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/producers/42/supporttickets", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("x-shopware-token"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+
+		tickets := make([]map[string]interface{}, 0)
+
+		if r.URL.Query().Get("offset") == "0" {
+			for i := 0; i < supportTicketPageSize; i++ {
+				tickets = append(tickets, map[string]interface{}{"id": i, "status": map[string]string{"name": "open"}})
+			}
+		} else {
+			assert.Equal(t, "100", r.URL.Query().Get("offset"))
+			tickets = append(tickets,
+				map[string]interface{}{"id": 100, "status": map[string]string{"name": "closed"}},
+				map[string]interface{}{"id": 101, "status": map[string]string{"name": "resolved"}},
+				map[string]interface{}{"id": 102, "status": map[string]string{"name": "waitingForProducer"}},
+			)
+		}
+
+		_ = json.NewEncoder(w).Encode(tickets)
+	})
+
+	tickets, err := producer.SupportTickets(context.Background(), SupportTicketTypeSupport, false)
+	assert.NoError(t, err)
+	assert.Len(t, tickets, 103)
+
+	open, err := producer.SupportTickets(context.Background(), SupportTicketTypeSupport, true)
+	assert.NoError(t, err)
+	assert.Len(t, open, 101)
+	assert.Equal(t, 102, open[100].Id)
+}
+
+func TestGetSupportTicketAndReply(t *testing.T) {
+	producer := newAccountTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("x-shopware-token"))
+
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "GET /producers/42/compatibilityinquiries/7":
+			_, _ = w.Write([]byte(`{"id": 7, "title": "Shopware 6.6", "answers": [{"id": 1, "text": "Hello", "fromProducer": false}]}`))
+		case "POST /producers/42/compatibilityinquiries/7/answers":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"text": "Thanks"}`, string(body))
+			_, _ = w.Write([]byte(`{"id": 2, "text": "Thanks", "fromProducer": true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ticket, err := producer.GetSupportTicket(context.Background(), SupportTicketTypeCompatibility, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "Shopware 6.6", ticket.Title)
+	assert.Len(t, ticket.Answers, 1)
+
+	answer, err := producer.ReplyToSupportTicket(context.Background(), SupportTicketTypeCompatibility, 7, "Thanks")
+	assert.NoError(t, err)
+	assert.True(t, answer.FromProducer)
+}
+
+func TestDownloadSupportTicketAttachmentSendsTokenOnlyToApi(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("x-shopware-token"))
+		_, _ = w.Write([]byte("from storage"))
+	}))
+	defer storage.Close()
+
+	producer := newAccountTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("x-shopware-token"))
+		_, _ = w.Write([]byte("from api"))
+	})
+
+	content, err := producer.DownloadSupportTicketAttachment(context.Background(), ApiUrl+"/attachments/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "from api", string(content))
+
+	content, err = producer.DownloadSupportTicketAttachment(context.Background(), storage.URL+"/bucket/file.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "from storage", string(content))
+
+	_, err = producer.DownloadSupportTicketAttachment(context.Background(), "file:///etc/passwd")
+	assert.Error(t, err)
+}
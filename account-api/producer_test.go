@@ -0,0 +1,14 @@
+package account_api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleLanguage(t *testing.T) {
+	assert.Equal(t, "de", LocaleLanguage("de_DE"))
+	assert.Equal(t, "en", LocaleLanguage("en"))
+	assert.Equal(t, "", LocaleLanguage("d"))
+	assert.Equal(t, "", LocaleLanguage(""))
+}
@@ -0,0 +1,143 @@
+package account_api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const uploadAttempts = 5
+
+// uploadRetryDelay is doubled after each failed attempt.
+var uploadRetryDelay = 2 * time.Second
+
+// UploadProgress is called while a file is uploaded with the sent and total bytes.
+type UploadProgress func(sent, total int64)
+
+// apiError is returned by doRequest for error responses, the message is the response body.
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return e.Body
+}
+
+// SetUploadProgress registers a callback for the progress of file uploads like extension binaries.
+func (c *Client) SetUploadProgress(progress UploadProgress) {
+	c.uploadProgress = progress
+}
+
+// uploadFileWithRetry uploads the file like uploadFile, but retries on network errors, rate limits and server errors.
+// The Store API has no resumable uploads, so each attempt sends the whole file again. A failed request may still have
+// been processed, so only use it for requests which can be repeated safely like replacing the file of a binary.
+func (c *Client) uploadFileWithRetry(ctx context.Context, method, url, path string) ([]byte, error) {
+	// a missing file is no reason to retry
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	delay := uploadRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		content, err := c.uploadFile(ctx, method, url, path)
+		if err == nil || attempt == uploadAttempts || !isTransientUploadError(ctx, err) {
+			return content, err
+		}
+
+		logging.FromContext(ctx).Warnf("Upload of %s failed (attempt %d of %d), retrying in %s: %v", filepath.Base(path), attempt, uploadAttempts, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// uploadFile sends the file as multipart form field "file". The file is streamed from disk.
+func (c *Client) uploadFile(ctx context.Context, method, url, path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// the multipart envelope is created upfront, so the request has a content length and the file is not buffered
+	var head bytes.Buffer
+	w := multipart.NewWriter(&head)
+
+	if _, err := w.CreateFormFile("file", filepath.Base(path)); err != nil {
+		return nil, err
+	}
+
+	headLength := head.Len()
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	tail := head.Bytes()[headLength:]
+	body := io.MultiReader(bytes.NewReader(head.Bytes()[:headLength]), &progressReader{reader: file, total: stat.Size(), progress: c.uploadProgress}, bytes.NewReader(tail))
+
+	r, err := c.NewAuthenticatedRequest(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ContentLength = int64(headLength) + stat.Size() + int64(len(tail))
+	r.Header.Set("content-type", w.FormDataContentType())
+
+	return c.doRequest(r)
+}
+
+func isTransientUploadError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var responseErr *apiError
+	if errors.As(err, &responseErr) {
+		return responseErr.StatusCode == http.StatusTooManyRequests || responseErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// everything else failed before a response was received, like timeouts or connection resets
+	return true
+}
+
+type progressReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.sent += int64(n)
+
+	if r.progress != nil && n > 0 {
+		r.progress(r.sent, r.total)
+	}
+
+	return n, err
+}
@@ -0,0 +1,111 @@
+package account_api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newUploadTestServer(t *testing.T, failures int, status int) (*httptest.Server, *int) {
+	t.Helper()
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		file, _, err := r.FormFile("file")
+		assert.NoError(t, err)
+
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "zip content", string(content))
+
+		if requests <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("failed"))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func writeUploadTestFile(t *testing.T) string {
+	t.Helper()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	path := filepath.Join(t.TempDir(), "FroshTools.zip")
+	assert.NoError(t, os.WriteFile(path, []byte("zip content"), os.ModePerm))
+
+	return path
+}
+
+func setUploadRetryDelay(t *testing.T) {
+	t.Helper()
+
+	delay := uploadRetryDelay
+	uploadRetryDelay = time.Millisecond
+
+	t.Cleanup(func() {
+		uploadRetryDelay = delay
+	})
+}
+
+func TestUploadFileWithRetryRetriesTransientErrors(t *testing.T) {
+	setUploadRetryDelay(t)
+	path := writeUploadTestFile(t)
+	server, requests := newUploadTestServer(t, 2, http.StatusServiceUnavailable)
+
+	content, err := (&Client{}).uploadFileWithRetry(context.Background(), "POST", server.URL, path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id": 1}`, string(content))
+	assert.Equal(t, 3, *requests)
+}
+
+func TestUploadFileWithRetryGivesUp(t *testing.T) {
+	setUploadRetryDelay(t)
+	path := writeUploadTestFile(t)
+	server, requests := newUploadTestServer(t, uploadAttempts, http.StatusBadGateway)
+
+	_, err := (&Client{}).uploadFileWithRetry(context.Background(), "POST", server.URL, path)
+
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, uploadAttempts, *requests)
+}
+
+func TestUploadFileWithRetryDoesNotRetryClientErrors(t *testing.T) {
+	setUploadRetryDelay(t)
+	path := writeUploadTestFile(t)
+	server, requests := newUploadTestServer(t, 1, http.StatusBadRequest)
+
+	_, err := (&Client{}).uploadFileWithRetry(context.Background(), "POST", server.URL, path)
+
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 1, *requests)
+}
+
+func TestUploadFileIsNotRetried(t *testing.T) {
+	setUploadRetryDelay(t)
+	path := writeUploadTestFile(t)
+	server, requests := newUploadTestServer(t, 1, http.StatusServiceUnavailable)
+
+	_, err := (&Client{}).uploadFile(context.Background(), "POST", server.URL, path)
+
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 1, *requests)
+}
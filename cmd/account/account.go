@@ -23,7 +23,30 @@ func Register(rootCmd *cobra.Command, onInit func(commandName string) (*ServiceC
 	accountRootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
 		ser, err := onInit(cmd.Name())
 		services = ser
-		return err
+		if err != nil {
+			return err
+		}
+
+		if services.AccountClient == nil {
+			return nil
+		}
+
+		if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+			services.AccountClient.SetRefreshCache(true)
+		}
+
+		return changeAPIMembership(cmd.Context(), services.AccountClient, selectedCompanyID(cmd))
 	}
+	accountRootCmd.PersistentFlags().Bool("refresh", false, "Ignore cached API responses and fetch them again")
+	accountRootCmd.PersistentFlags().Int("company", 0, "Company ID to use instead of the configured one")
 	rootCmd.AddCommand(accountRootCmd)
 }
+
+// selectedCompanyID returns the company passed by flag and falls back to the configured company.
+func selectedCompanyID(cmd *cobra.Command) int {
+	if companyID, _ := cmd.Flags().GetInt("company"); companyID > 0 {
+		return companyID
+	}
+
+	return services.Conf.GetAccountCompanyId()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	accountApi "github.com/FriendsOfShopware/shopware-cli/account-api"
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
 
@@ -44,7 +45,7 @@ var loginCmd = &cobra.Command{
 			return fmt.Errorf("login failed with error: %w", err)
 		}
 
-		if companyId := services.Conf.GetAccountCompanyId(); companyId > 0 {
+		if companyId := selectedCompanyID(cmd); companyId > 0 {
 			err = changeAPIMembership(cmd.Context(), client, companyId)
 
 			if err != nil {
@@ -80,6 +81,10 @@ func init() {
 }
 
 func askUserForEmailAndPassword() (string, string, error) {
+	if err := interaction.Ensure("Email", "set SHOPWARE_CLI_ACCOUNT_EMAIL and SHOPWARE_CLI_ACCOUNT_PASSWORD instead"); err != nil {
+		return "", "", err
+	}
+
 	emailPrompt := promptui.Prompt{
 		Label:    "Email",
 		Validate: emptyValidator,
@@ -125,5 +130,5 @@ func changeAPIMembership(ctx context.Context, client *accountApi.Client, company
 		}
 	}
 
-	return fmt.Errorf("could not find configured company with id %d", companyID)
+	return fmt.Errorf("could not find configured company with id %d, see account company list for all available companies", companyID)
 }
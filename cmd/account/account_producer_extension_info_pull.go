@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	accountApi "github.com/FriendsOfShopware/shopware-cli/account-api"
 	"github.com/FriendsOfShopware/shopware-cli/extension"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
@@ -105,7 +106,7 @@ var accountCompanyProducerExtensionInfoPullCmd = &cobra.Command{
 
 		for i, image := range storeImages {
 			imagePath := fmt.Sprintf("src/Resources/store/img-%d.png", i)
-			err := downloadFileTo(cmd.Context(), image.RemoteLink, fmt.Sprintf("%s/%s", zipExt.GetPath(), imagePath))
+			err := downloadFileTo(cmd.Context(), image.RemoteLink, filepath.Join(zipExt.GetPath(), imagePath))
 			if err != nil {
 				return fmt.Errorf("cannot download file: %w", err)
 			}
@@ -119,7 +120,7 @@ var accountCompanyProducerExtensionInfoPullCmd = &cobra.Command{
 		}
 
 		for _, info := range storeExt.Infos {
-			language := info.Locale.Name[0:2]
+			language := accountApi.LocaleLanguage(info.Locale.Name)
 
 			if language == "de" {
 				for _, element := range info.Tags {
@@ -183,7 +184,7 @@ var accountCompanyProducerExtensionInfoPullCmd = &cobra.Command{
 			return fmt.Errorf("cannot encode yaml: %w", err)
 		}
 
-		extCfgFile := fmt.Sprintf("%s/%s", zipExt.GetPath(), ".shopware-extension.yml")
+		extCfgFile := filepath.Join(zipExt.GetPath(), ".shopware-extension.yml")
 		err = os.WriteFile(extCfgFile, content, os.ModePerm)
 
 		if err != nil {
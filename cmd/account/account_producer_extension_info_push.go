@@ -59,7 +59,7 @@ var accountCompanyProducerExtensionInfoPushCmd = &cobra.Command{
 		metadata := zipExt.GetMetaData()
 
 		for _, info := range storeExt.Infos {
-			language := info.Locale.Name[0:2]
+			language := accountApi.LocaleLanguage(info.Locale.Name)
 
 			if language == "de" {
 				info.Name = metadata.Label.German
@@ -79,7 +79,7 @@ var accountCompanyProducerExtensionInfoPushCmd = &cobra.Command{
 
 		if extCfg != nil {
 			if extCfg.Store.Icon != nil {
-				err := p.UpdateExtensionIcon(cmd.Context(), storeExt.Id, fmt.Sprintf("%s/%s", zipExt.GetPath(), *extCfg.Store.Icon))
+				err := p.UpdateExtensionIcon(cmd.Context(), storeExt.Id, filepath.Join(zipExt.GetPath(), *extCfg.Store.Icon))
 				if err != nil {
 					return fmt.Errorf("cannot update extension icon due error: %w", err)
 				}
@@ -124,6 +124,14 @@ var accountCompanyProducerExtensionInfoPushCmd = &cobra.Command{
 			}
 		}
 
+		if generate, _ := cmd.Flags().GetBool("generate-description"); generate {
+			changelogVersions, _ := cmd.Flags().GetInt("changelog-versions")
+
+			if err := updateGeneratedDescription(storeExt, zipExt, changelogVersions); err != nil {
+				return fmt.Errorf("cannot generate store description: %w", err)
+			}
+		}
+
 		err = p.UpdateExtension(cmd.Context(), storeExt)
 
 		if err != nil {
@@ -198,7 +206,7 @@ func updateStoreInfo(ext *accountApi.Extension, zipExt extension.Extension, cfg
 	}
 
 	for _, info := range ext.Infos {
-		language := info.Locale.Name[0:2]
+		language := accountApi.LocaleLanguage(info.Locale.Name)
 
 		storeTags := getTranslation(language, cfg.Store.Tags)
 		if storeTags != nil {
@@ -264,6 +272,27 @@ func updateStoreInfo(ext *accountApi.Extension, zipExt extension.Extension, cfg
 	return nil
 }
 
+// updateGeneratedDescription replaces the description of each store language with the one generated by extension docs readme.
+func updateGeneratedDescription(ext *accountApi.Extension, zipExt extension.Extension, changelogVersions int) error {
+	for _, info := range ext.Infos {
+		language := accountApi.LocaleLanguage(info.Locale.Name)
+		if language != "de" && language != "en" {
+			continue
+		}
+
+		markdown, err := extension.BuildStoreDescription(zipExt, language, extension.StoreDescriptionOptions{ChangelogVersions: changelogVersions})
+		if err != nil {
+			return err
+		}
+
+		if info.Description, err = extension.RenderStoreDescriptionHTML(markdown); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getTranslation[T extension.Translatable](language string, config extension.ConfigTranslated[T]) *T {
 	if language == "de" {
 		return config.German
@@ -276,6 +305,8 @@ func getTranslation[T extension.Translatable](language string, config extension.
 
 func init() {
 	accountCompanyProducerExtensionInfoCmd.AddCommand(accountCompanyProducerExtensionInfoPushCmd)
+	accountCompanyProducerExtensionInfoPushCmd.Flags().Bool("generate-description", false, "Replace the description with the one generated by extension docs readme")
+	accountCompanyProducerExtensionInfoPushCmd.Flags().Int("changelog-versions", 3, "Amount of newest changelog versions to include in the generated description")
 }
 
 func parseInlineablePath(path, extensionDir string) (string, error) {
@@ -283,7 +314,7 @@ func parseInlineablePath(path, extensionDir string) (string, error) {
 		return path, nil
 	}
 
-	filePath := fmt.Sprintf("%s/%s", extensionDir, strings.TrimPrefix(path, "file:"))
+	filePath := filepath.Join(extensionDir, strings.TrimPrefix(path, "file:"))
 
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -1,7 +1,10 @@
 package account
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -61,6 +64,8 @@ var accountCompanyProducerExtensionUploadCmd = &cobra.Command{
 			}
 		}
 
+		services.AccountClient.SetUploadProgress(newUploadProgressPrinter(os.Stderr))
+
 		if foundBinary == nil {
 			foundBinary, err = p.CreateExtensionBinaryFile(cmd.Context(), ext.Id, path)
 			if err != nil {
@@ -117,43 +122,14 @@ var accountCompanyProducerExtensionUploadCmd = &cobra.Command{
 		if !skipWaitingForCodereviewResult {
 			logging.FromContext(cmd.Context()).Infof("Waiting for code review result")
 
-			time.Sleep(10 * time.Second)
-
-			maxTries := 10
-			tried := 0
-			for {
-				reviews, err := p.GetBinaryReviewResults(cmd.Context(), ext.Id, foundBinary.Id)
-				if err != nil {
-					return err
-				}
-
-				// Review has been updated
-				if len(reviews) != len(beforeReviews) {
-					lastReview := reviews[len(reviews)-1]
-
-					if !lastReview.IsPending() {
-						if lastReview.HasPassed() {
-							if lastReview.HasWarnings() {
-								logging.FromContext(cmd.Context()).Infof("Code review has been passed but with warnings")
-								logging.FromContext(cmd.Context()).Infof(lastReview.GetSummary())
-							} else {
-								logging.FromContext(cmd.Context()).Infof("Code review has been passed without warnings")
-							}
-
-							break
-						}
-
-						logging.FromContext(cmd.Context()).Fatalln("Code review has not passed", lastReview.GetSummary())
-					}
-				}
-
-				time.Sleep(15 * time.Second)
-				tried++
-
-				if maxTries == tried {
-					logging.FromContext(cmd.Context()).Infof("Skipping waiting for code review result as it took too long")
-				}
+			err = waitForCodeReview(cmd.Context(), p, ext.Id, foundBinary.Id, len(beforeReviews), 15*time.Second, 3*time.Minute)
+			if errors.Is(err, errCodeReviewTimeout) {
+				logging.FromContext(cmd.Context()).Infof("Skipping waiting for code review result as it took too long, use account producer extension wait-review to wait longer")
+
+				return nil
 			}
+
+			return err
 		}
 
 		return nil
@@ -162,7 +138,48 @@ var accountCompanyProducerExtensionUploadCmd = &cobra.Command{
 
 var skipWaitingForCodereviewResult bool
 
+// newUploadProgressPrinter updates the progress in place on terminals and logs every 10 percent otherwise, like in CI logs.
+func newUploadProgressPrinter(out *os.File) account_api.UploadProgress {
+	isTerminal := false
+	if stat, err := out.Stat(); err == nil {
+		isTerminal = stat.Mode()&os.ModeCharDevice != 0
+	}
+
+	step := int64(1)
+	if !isTerminal {
+		step = 10
+	}
+
+	lastStep := int64(-1)
+
+	return func(sent, total int64) {
+		percent := int64(100)
+		if total > 0 {
+			percent = sent * 100 / total
+		}
+
+		// a retry starts again from the beginning
+		if percent/step == lastStep || (percent/step < lastStep && percent != 0) {
+			return
+		}
+
+		lastStep = percent / step
+		line := fmt.Sprintf("Uploading %d%% (%.1f MB / %.1f MB)", percent, float64(sent)/1024/1024, float64(total)/1024/1024)
+
+		if !isTerminal {
+			_, _ = fmt.Fprintln(out, line)
+			return
+		}
+
+		_, _ = io.WriteString(out, "\r"+line)
+
+		if sent == total {
+			_, _ = io.WriteString(out, "\n")
+		}
+	}
+}
+
 func init() {
 	accountCompanyProducerExtensionCmd.AddCommand(accountCompanyProducerExtensionUploadCmd)
-	accountCompanyProducerExtensionCmd.Flags().BoolVar(&skipWaitingForCodereviewResult, "skip-for-review-result", false, "Skips waiting for Code review result")
+	accountCompanyProducerExtensionUploadCmd.Flags().BoolVar(&skipWaitingForCodereviewResult, "skip-for-review-result", false, "Skips waiting for Code review result")
 }
@@ -0,0 +1,92 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	account_api "github.com/FriendsOfShopware/shopware-cli/account-api"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var accountCompanyProducerExtensionWaitReviewCmd = &cobra.Command{
+	Use:   "wait-review [name] [version]",
+	Short: "Waits for the automatic code review of an extension version and fails when it has not passed",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		p, err := services.AccountClient.Producer(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("cannot get producer endpoint: %w", err)
+		}
+
+		ext, err := p.GetExtensionByName(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		binaries, err := p.GetExtensionBinaries(cmd.Context(), ext.Id)
+		if err != nil {
+			return err
+		}
+
+		var foundBinary *account_api.ExtensionBinary
+
+		for _, binary := range binaries {
+			if binary.Version == args[1] {
+				foundBinary = binary
+				break
+			}
+		}
+
+		if foundBinary == nil {
+			return fmt.Errorf("cannot find version %s of extension %s", args[1], args[0])
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Waiting for code review result of %s %s", args[0], args[1])
+
+		return waitForCodeReview(cmd.Context(), p, ext.Id, foundBinary.Id, 0, interval, timeout)
+	},
+}
+
+var errCodeReviewTimeout = errors.New("code review result is not available")
+
+// waitForCodeReview waits for a review result newer than knownReviews, prints it and returns an error when the review has not passed.
+func waitForCodeReview(ctx context.Context, p *account_api.ProducerEndpoint, extensionId, binaryId, knownReviews int, interval, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	review, err := p.WaitForBinaryReview(waitCtx, extensionId, binaryId, knownReviews, interval)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", errCodeReviewTimeout, timeout)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !review.HasPassed() {
+		logging.FromContext(ctx).Errorf("Code review has not passed\n%s", review.GetSummary())
+
+		return fmt.Errorf("code review has not passed")
+	}
+
+	if review.HasWarnings() {
+		logging.FromContext(ctx).Infof("Code review has been passed but with warnings\n%s", review.GetSummary())
+	} else {
+		logging.FromContext(ctx).Infof("Code review has been passed without warnings")
+	}
+
+	return nil
+}
+
+func init() {
+	accountCompanyProducerExtensionCmd.AddCommand(accountCompanyProducerExtensionWaitReviewCmd)
+	accountCompanyProducerExtensionWaitReviewCmd.Flags().Duration("interval", 15*time.Second, "Interval to check for the review result")
+	accountCompanyProducerExtensionWaitReviewCmd.Flags().Duration("timeout", 30*time.Minute, "Maximum time to wait for the review result")
+}
@@ -0,0 +1,99 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	account_api "github.com/FriendsOfShopware/shopware-cli/account-api"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var accountProducerStatisticsCmd = &cobra.Command{
+	Use:   "statistics",
+	Short: "Exports the sales, refunds and downloads of your extensions as CSV or JSON",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		to := time.Now()
+		from := to.AddDate(0, 0, -30)
+
+		var err error
+
+		if fromFlag != "" {
+			if from, err = time.Parse("2006-01-02", fromFlag); err != nil {
+				return fmt.Errorf("invalid --from date, use YYYY-MM-DD: %w", err)
+			}
+		}
+
+		if toFlag != "" {
+			if to, err = time.Parse("2006-01-02", toFlag); err != nil {
+				return fmt.Errorf("invalid --to date, use YYYY-MM-DD: %w", err)
+			}
+		}
+
+		if from.After(to) {
+			return fmt.Errorf("--from %s is after --to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+
+		if format != "csv" && format != "json" {
+			return fmt.Errorf("unknown format %s, use csv or json", format)
+		}
+
+		p, err := services.AccountClient.Producer(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("cannot get producer endpoint: %w", err)
+		}
+
+		statistics, err := p.Statistics(cmd.Context(), from, to)
+		if err != nil {
+			return err
+		}
+
+		var writer io.Writer = os.Stdout
+
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = file.Close()
+			}()
+
+			writer = file
+		}
+
+		if format == "json" {
+			err = json.NewEncoder(writer).Encode(statistics)
+		} else {
+			err = account_api.WriteStatisticsCSV(writer, from, to, statistics)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if output != "" {
+			logging.FromContext(cmd.Context()).Infof("Written the statistics of %d extensions to %s", len(statistics), output)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	accountCompanyProducerCmd.AddCommand(accountProducerStatisticsCmd)
+	accountProducerStatisticsCmd.Flags().String("from", "", "First day of the range (YYYY-MM-DD), defaults to 30 days ago")
+	accountProducerStatisticsCmd.Flags().String("to", "", "Last day of the range (YYYY-MM-DD), defaults to today")
+	accountProducerStatisticsCmd.Flags().String("format", "csv", "Output format: csv or json")
+	accountProducerStatisticsCmd.Flags().String("output", "", "File to write the statistics to instead of stdout")
+}
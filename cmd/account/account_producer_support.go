@@ -0,0 +1,33 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	account_api "github.com/FriendsOfShopware/shopware-cli/account-api"
+)
+
+var accountCompanyProducerSupportCmd = &cobra.Command{
+	Use:   "support",
+	Short: "Manage the support tickets and compatibility inquiries of your extensions",
+}
+
+// supportTicketType maps the --type flag to the list of the account API.
+func supportTicketType(cmd *cobra.Command) (account_api.SupportTicketType, error) {
+	ticketType, _ := cmd.Flags().GetString("type")
+
+	switch ticketType {
+	case "ticket":
+		return account_api.SupportTicketTypeSupport, nil
+	case "compatibility":
+		return account_api.SupportTicketTypeCompatibility, nil
+	}
+
+	return "", fmt.Errorf("unknown type %s, use ticket or compatibility", ticketType)
+}
+
+func init() {
+	accountCompanyProducerCmd.AddCommand(accountCompanyProducerSupportCmd)
+	accountCompanyProducerSupportCmd.PersistentFlags().String("type", "ticket", "Type of the tickets: ticket or compatibility")
+}
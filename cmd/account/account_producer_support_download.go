@@ -0,0 +1,81 @@
+package account
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var accountCompanyProducerSupportDownloadCmd = &cobra.Command{
+	Use:   "download [id]",
+	Short: "Downloads all attachments of a ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		ticketId, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ticket id %s", args[0])
+		}
+
+		ticketType, err := supportTicketType(cmd)
+		if err != nil {
+			return err
+		}
+
+		p, err := services.AccountClient.Producer(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("cannot get producer endpoint: %w", err)
+		}
+
+		ticket, err := p.GetSupportTicket(cmd.Context(), ticketType, ticketId)
+		if err != nil {
+			return err
+		}
+
+		if output == "" {
+			output = strconv.Itoa(ticket.Id)
+		}
+
+		downloaded := 0
+
+		for _, answer := range ticket.Answers {
+			for _, attachment := range answer.Attachments {
+				content, err := p.DownloadSupportTicketAttachment(cmd.Context(), attachment.RemoteLink)
+				if err != nil {
+					return err
+				}
+
+				// The attachment id keeps files with the same name apart, the base name prevents writing outside of the folder
+				file := filepath.Join(output, fmt.Sprintf("%d-%s", attachment.Id, filepath.Base(attachment.Name)))
+
+				if err := os.MkdirAll(output, os.ModePerm); err != nil {
+					return err
+				}
+
+				if err := os.WriteFile(file, content, 0o644); err != nil {
+					return err
+				}
+
+				logging.FromContext(cmd.Context()).Infof("Downloaded %s", file)
+				downloaded++
+			}
+		}
+
+		if downloaded == 0 {
+			logging.FromContext(cmd.Context()).Infof("Ticket %d has no attachments", ticket.Id)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	accountCompanyProducerSupportCmd.AddCommand(accountCompanyProducerSupportDownloadCmd)
+	accountCompanyProducerSupportDownloadCmd.Flags().String("output", "", "Folder for the attachments, defaults to the ticket id")
+}
@@ -0,0 +1,63 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var accountCompanyProducerSupportListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the open support tickets or compatibility inquiries",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		outputJson, _ := cmd.Flags().GetBool("json")
+
+		ticketType, err := supportTicketType(cmd)
+		if err != nil {
+			return err
+		}
+
+		p, err := services.AccountClient.Producer(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("cannot get producer endpoint: %w", err)
+		}
+
+		tickets, err := p.SupportTickets(cmd.Context(), ticketType, !all)
+		if err != nil {
+			return err
+		}
+
+		if outputJson {
+			return json.NewEncoder(os.Stdout).Encode(tickets)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Extension", "Customer", "Title", "Status", "Last change"})
+
+		for _, ticket := range tickets {
+			table.Append([]string{
+				strconv.Itoa(ticket.Id),
+				ticket.Plugin.Name,
+				ticket.Customer.CompanyName,
+				ticket.Title,
+				ticket.Status.Name,
+				ticket.LastChangeDate,
+			})
+		}
+
+		table.Render()
+
+		return nil
+	},
+}
+
+func init() {
+	accountCompanyProducerSupportCmd.AddCommand(accountCompanyProducerSupportListCmd)
+	accountCompanyProducerSupportListCmd.Flags().Bool("all", false, "Include closed and resolved tickets")
+	accountCompanyProducerSupportListCmd.Flags().Bool("json", false, "Output the tickets with all answers as JSON")
+}
@@ -0,0 +1,77 @@
+package account
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var accountCompanyProducerSupportReplyCmd = &cobra.Command{
+	Use:   "reply [id]",
+	Short: "Posts a reply to a ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		message, _ := cmd.Flags().GetString("message")
+		messageFile, _ := cmd.Flags().GetString("message-file")
+
+		ticketId, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid ticket id %s", args[0])
+		}
+
+		ticketType, err := supportTicketType(cmd)
+		if err != nil {
+			return err
+		}
+
+		if message != "" && messageFile != "" {
+			return fmt.Errorf("pass either --message or --message-file")
+		}
+
+		if messageFile != "" {
+			var content []byte
+
+			if messageFile == "-" {
+				content, err = io.ReadAll(os.Stdin)
+			} else {
+				content, err = os.ReadFile(messageFile)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			message = string(content)
+		}
+
+		if strings.TrimSpace(message) == "" {
+			return fmt.Errorf("the reply is empty, pass --message or --message-file")
+		}
+
+		p, err := services.AccountClient.Producer(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("cannot get producer endpoint: %w", err)
+		}
+
+		answer, err := p.ReplyToSupportTicket(cmd.Context(), ticketType, ticketId, message)
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Posted reply %d to ticket %d", answer.Id, ticketId)
+
+		return nil
+	},
+}
+
+func init() {
+	accountCompanyProducerSupportCmd.AddCommand(accountCompanyProducerSupportReplyCmd)
+	accountCompanyProducerSupportReplyCmd.Flags().String("message", "", "Text of the reply")
+	accountCompanyProducerSupportReplyCmd.Flags().String("message-file", "", "File containing the reply, - reads from stdin")
+}
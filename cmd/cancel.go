@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var (
+	commandTimeout time.Duration
+	cancelCommand  context.CancelCauseFunc = func(error) {}
+)
+
+// withCancellation cancels the context on SIGINT and SIGTERM, so running subprocesses are stopped and deferred cleanups
+// like removing temporary folders and partial files are executed. A second signal stops the cli immediately.
+func withCancellation(ctx context.Context) (context.Context, context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		select {
+		case sig := <-signals:
+			logging.FromContext(ctx).Warnf("Received %s, stopping. Send it again to exit immediately", sig)
+			cancel(fmt.Errorf("canceled by %s", sig))
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// startCommandTimeout cancels the command, when it is still running after --timeout.
+func startCommandTimeout() {
+	if commandTimeout <= 0 {
+		return
+	}
+
+	timeout := commandTimeout
+
+	time.AfterFunc(timeout, func() {
+		cancelCommand(fmt.Errorf("the command did not finish within the timeout of %s", timeout))
+	})
+}
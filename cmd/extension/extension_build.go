@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/FriendsOfShopware/shopware-cli/extension"
@@ -50,18 +51,100 @@ var extensionAssetBundleCmd = &cobra.Command{
 		}
 
 		assetCfg.ShopwareVersion = constraint
+		assetCfg.NpmScripts, _ = cmd.Flags().GetString("npm-scripts")
 
-		err = extension.BuildAssetsForExtensions(cmd.Context(), extension.ConvertExtensionsToSources(cmd.Context(), validatedExtensions), assetCfg)
+		if useESBuild, _ := cmd.Flags().GetBool("esbuild"); useESBuild {
+			assetCfg.EnableESBuildForAdmin = true
+			assetCfg.EnableESBuildForStorefront = true
+		}
+
+		sources := extension.ConvertExtensionsToSources(cmd.Context(), validatedExtensions)
+		verify, _ := cmd.Flags().GetBool("verify")
+
+		var committed extension.CompiledAssetSnapshot
+
+		if verify {
+			if committed, err = extension.SnapshotCompiledAssets(sources); err != nil {
+				return err
+			}
+		}
+
+		err = extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg)
 		if err != nil {
 			return fmt.Errorf("cannot build assets: %w", err)
 		}
 
 		logging.FromContext(cmd.Context()).Infof("Assets has been built")
 
-		return nil
+		if verify {
+			built, err := extension.SnapshotCompiledAssets(sources)
+			if err != nil {
+				return err
+			}
+
+			differences := committed.Diff(built)
+
+			for _, difference := range differences {
+				logging.FromContext(cmd.Context()).Errorf("%s", difference)
+			}
+
+			if len(differences) > 0 {
+				return fmt.Errorf("the committed assets do not match the build, commit the freshly built assets")
+			}
+
+			logging.FromContext(cmd.Context()).Infof("The committed assets match the build")
+		}
+
+		return reportAssetBundleSizes(cmd, validatedExtensions)
 	},
 }
 
+func reportAssetBundleSizes(cmd *cobra.Command, extensions []extension.Extension) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Extension", "Component", "File", "Size"})
+	table.SetAutoWrapText(false)
+
+	failed := false
+	violations := make([]string, 0)
+
+	for _, ext := range extensions {
+		sizes, err := extension.GetAssetBundleSizes(extension.ConvertExtensionsToSources(cmd.Context(), []extension.Extension{ext}))
+		if err != nil {
+			return err
+		}
+
+		for _, size := range sizes {
+			table.Append([]string{size.Source, size.Component, size.File, extension.FormatAssetSize(size.Size)})
+		}
+
+		budget := ext.GetExtensionConfig().Build.Zip.Assets.Budget
+		extViolations := extension.CheckAssetBudget(sizes, budget)
+
+		if budget.Fail && len(extViolations) > 0 {
+			failed = true
+		}
+
+		violations = append(violations, extViolations...)
+	}
+
+	if table.NumLines() > 0 {
+		table.Render()
+	}
+
+	for _, violation := range violations {
+		logging.FromContext(cmd.Context()).Warnf("%s", violation)
+	}
+
+	if failed {
+		return fmt.Errorf("asset budget exceeded")
+	}
+
+	return nil
+}
+
 func init() {
 	extensionRootCmd.AddCommand(extensionAssetBundleCmd)
+	extensionAssetBundleCmd.Flags().Bool("esbuild", false, "Build the administration and storefront with esbuild without Shopware sources")
+	extensionAssetBundleCmd.Flags().String("npm-scripts", extension.NpmScriptsRun, "How the lifecycle scripts of the npm packages of the extensions run (run, skip, restricted-env). restricted-env only hides credentials and proxies the network, it does not isolate the scripts")
+	extensionAssetBundleCmd.Flags().Bool("verify", false, "Fails when the committed compiled assets differ from the build")
 }
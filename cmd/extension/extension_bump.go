@@ -0,0 +1,74 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionBumpCmd = &cobra.Command{
+	Use:   "bump [path] [major|minor|patch|version]",
+	Short: "Increases the version of the extension",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		currentVersion, err := ext.GetVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get version: %w", err)
+		}
+
+		bump := "patch"
+		if len(args) == 2 {
+			bump = args[1]
+		}
+
+		newVersion, err := extension.BumpVersion(currentVersion, bump)
+		if err != nil {
+			return err
+		}
+
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		changed, err := extension.SetExtensionVersion(ext, newVersion, fix)
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Bumped version from %s to %s in %s", currentVersion.String(), newVersion, strings.Join(changed, ", "))
+
+		// reload the extension to check against the new version
+		if ext, err = extension.GetExtensionByFolder(path); err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		mismatches, err := extension.CheckVersionConsistency(ext)
+		if err != nil {
+			return err
+		}
+
+		for _, mismatch := range mismatches {
+			logging.FromContext(cmd.Context()).Warnf("%s, use --fix to update it", mismatch.String())
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionBumpCmd)
+	extensionBumpCmd.Flags().Bool("fix", false, "Update the version constants of the plugin class and the changelogs as well")
+}
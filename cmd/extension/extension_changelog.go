@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionChangelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Maintain the changelogs of the extension",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionChangelogCmd)
+}
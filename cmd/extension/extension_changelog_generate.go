@@ -0,0 +1,58 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionChangelogGenerateCmd = &cobra.Command{
+	Use:   "generate [path]",
+	Short: "Generates the changelog of the current version from the conventional commits since the last tag",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		currentVersion, err := ext.GetVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get version: %w", err)
+		}
+
+		entries, err := extension.CollectChangelogEntries(cmd.Context(), ext)
+		if err != nil {
+			return err
+		}
+
+		if entries.IsEmpty() {
+			logging.FromContext(cmd.Context()).Infof("No feat, fix or perf commits found since the last tag")
+			return nil
+		}
+
+		changed, err := extension.WriteGeneratedChangelog(ext, currentVersion.String(), entries, time.Now())
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Generated the changelog of version %s in %s", currentVersion.String(), strings.Join(changed, ", "))
+
+		return nil
+	},
+}
+
+func init() {
+	extensionChangelogCmd.AddCommand(extensionChangelogGenerateCmd)
+}
@@ -0,0 +1,54 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+)
+
+var extensionCIMatrixCmd = &cobra.Command{
+	Use:   "ci-matrix [path]",
+	Short: "Prints a JSON matrix of all supported Shopware versions for CI jobs",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		allPatches, _ := cmd.Flags().GetBool("all-patches")
+		includePrereleases, _ := cmd.Flags().GetBool("include-prereleases")
+
+		matrix, err := extension.BuildCIMatrix(cmd.Context(), ext, extension.CIMatrixOptions{
+			AllPatches:         allPatches,
+			IncludePrereleases: includePrereleases,
+		})
+		if err != nil {
+			return err
+		}
+
+		content, err := json.Marshal(matrix)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(content))
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionCIMatrixCmd)
+	extensionCIMatrixCmd.Flags().Bool("all-patches", false, "Include every patch release instead of only the latest patch of each minor version")
+	extensionCIMatrixCmd.Flags().Bool("include-prereleases", false, "Include release candidates")
+}
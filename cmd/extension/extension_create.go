@@ -1,17 +1,19 @@
 package extension
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
-	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
 
 var extensionCreateCmd = &cobra.Command{
@@ -24,112 +26,126 @@ var extensionCreateCmd = &cobra.Command{
 			return err
 		}
 
-		var extensionConfig config.ExtensionConfig = config.ExtensionConfig{
-			Name:            args[0],
-			Namespace:       args[0],
-			ShopwareVersion: "^6.4",
-			License:         "MIT",
-		}
+		opts := extension.ScaffoldOptions{Name: args[0]}
+		opts.Type, _ = cmd.Flags().GetString("type")
+		opts.Namespace, _ = cmd.Flags().GetString("namespace")
+		opts.ComposerPackage, _ = cmd.Flags().GetString("composer-package")
+		opts.ShopwareVersion, _ = cmd.Flags().GetString("shopware-version")
+		opts.Label, _ = cmd.Flags().GetString("label")
+		opts.Description, _ = cmd.Flags().GetString("description")
+		opts.Author, _ = cmd.Flags().GetString("author")
+		opts.License, _ = cmd.Flags().GetString("license")
+		opts.ManufacturerLink, _ = cmd.Flags().GetString("manufacturer-link")
+		opts.SupportLink, _ = cmd.Flags().GetString("support-link")
+		createInCustomPlugins, _ := cmd.Flags().GetBool("create-in-custom-plugins")
+
+		extensionPath := filepath.Join(rootPath, opts.Name)
 
-		namespace, _ := cmd.PersistentFlags().GetString("namespace")
-		createInCustomPlugins, _ := cmd.PersistentFlags().GetBool("create-in-custom-plugins")
+		if createInCustomPlugins {
+			folder := "plugins"
+			if opts.Type == extension.ScaffoldTypeApp {
+				folder = "apps"
+			}
 
-		if namespace != "" {
-			extensionConfig.Namespace = namespace
+			extensionPath = filepath.Join(rootPath, "custom", folder, opts.Name)
 		}
 
-		fmt.Printf("Using namespace: %s\n", extensionConfig.Namespace)
+		if _, err := os.Stat(extensionPath); err == nil {
+			return fmt.Errorf("the directory '%s' already exists", extensionPath)
+		}
 
-		var pluginPath string
-		if createInCustomPlugins {
-			pluginPath = fmt.Sprintf("%s/custom/plugins/%s", rootPath, extensionConfig.Name)
-		} else {
-			pluginPath = fmt.Sprintf("%s/%s", rootPath, extensionConfig.Name)
+		if interaction.IsInteractive() {
+			if err := askScaffoldOptions(cmd, &opts); err != nil {
+				return err
+			}
 		}
 
-		if _, err := os.Stat(pluginPath); err == nil {
-			return fmt.Errorf("the directory '%s' already exists", pluginPath)
+		files, err := extension.ScaffoldFiles(opts)
+		if err != nil {
+			return err
 		}
 
-		extensionConfig.ComposerPackage = askExtension(promptui.Prompt{
-			Label:    "Composer package",
-			Validate: validComposerPackage,
-		})
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
 
-		extensionConfig.ShopwareVersion = askExtension(promptui.Prompt{
-			Label:    "Required shopware/core version",
-			Validate: emptyValidator,
-			Default:  extensionConfig.ShopwareVersion,
-		})
+		sort.Strings(names)
 
-		extensionConfig.Label = askExtension(promptui.Prompt{
-			Label:    "Plugin label",
-			Validate: emptyValidator,
-		})
+		for _, name := range names {
+			file := filepath.Join(extensionPath, filepath.FromSlash(name))
 
-		extensionConfig.Description = askExtension(promptui.Prompt{
-			Label:    "Plugin description",
-			Validate: emptyValidator,
-		})
+			if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+				return err
+			}
 
-		extensionConfig.License = askExtension(promptui.Prompt{
-			Label:    "License",
-			Validate: emptyValidator,
-			Default:  extensionConfig.License,
-		})
+			if err := os.WriteFile(file, files[name], 0o644); err != nil {
+				return err
+			}
+		}
 
-		extensionConfig.ManufacturerLink = askExtension(promptui.Prompt{
-			Label:    "Manufacturer link",
-			Validate: emptyValidator,
-		})
+		logging.FromContext(cmd.Context()).Infof("Created %s in %s", opts.Name, extensionPath)
+		logging.FromContext(cmd.Context()).Infof("Replace the description placeholders and the icons, f.e. with shopware-cli extension icon generate")
 
-		extensionConfig.SupportLink = askExtension(promptui.Prompt{
-			Label:    "Support link",
-			Validate: emptyValidator,
-			Default:  extensionConfig.ManufacturerLink,
-		})
+		return nil
+	},
+}
 
-		err = os.MkdirAll(fmt.Sprintf("%s/src/Resources/config", pluginPath), 0o755)
-		if err != nil {
-			return err
-		}
+// askScaffoldOptions prompts for all options, which have not been passed as flag. The defaults are derived from the name.
+func askScaffoldOptions(cmd *cobra.Command, opts *extension.ScaffoldOptions) error {
+	defaults := opts.WithDefaults()
+
+	prompts := []struct {
+		flag     string
+		label    string
+		value    *string
+		fallback string
+		validate promptui.ValidateFunc
+	}{
+		{"composer-package", "Composer package", &opts.ComposerPackage, defaults.ComposerPackage, validComposerPackage},
+		{"shopware-version", "Required shopware/core version", &opts.ShopwareVersion, defaults.ShopwareVersion, emptyValidator},
+		{"label", "Label", &opts.Label, defaults.Label, emptyValidator},
+		{"description", "Description (150 to 185 characters, empty for a placeholder)", &opts.Description, defaults.Description, extension.ValidateScaffoldDescription},
+		{"author", "Author", &opts.Author, defaults.Author, emptyValidator},
+		{"license", "License", &opts.License, defaults.License, emptyValidator},
+		{"manufacturer-link", "Manufacturer link", &opts.ManufacturerLink, defaults.ManufacturerLink, nil},
+		{"support-link", "Support link", &opts.SupportLink, defaults.SupportLink, nil},
+	}
 
-		err = createComposerJson(fmt.Sprintf("%s/composer.json", pluginPath), extensionConfig)
-		if err != nil {
-			return err
+	for _, prompt := range prompts {
+		if cmd.Flags().Changed(prompt.flag) {
+			continue
 		}
 
-		err = makeBootstrap(pluginPath, extensionConfig)
-		if err != nil {
-			return err
+		// apps have no composer.json
+		if prompt.flag == "composer-package" && defaults.Type == extension.ScaffoldTypeApp {
+			continue
 		}
 
-		err = makeChangelog(pluginPath)
+		value, err := (&promptui.Prompt{Label: prompt.label, Default: prompt.fallback, Validate: prompt.validate}).Run()
 		if err != nil {
-			return err
+			return fmt.Errorf("prompt failed %w", err)
 		}
 
-		err = makeDefaultServices(pluginPath)
-		if err != nil {
-			return err
-		}
+		*prompt.value = value
+	}
 
-		return nil
-	},
+	return nil
 }
 
 func init() {
 	extensionRootCmd.AddCommand(extensionCreateCmd)
-	extensionCreateCmd.PersistentFlags().String("namespace", "", "Namespace for the plugin")
-	extensionCreateCmd.PersistentFlags().Bool("create-in-custom-plugins", true, "Create the plugin in custom/plugins directory")
-}
-
-func askExtension(inputPrompt promptui.Prompt) string {
-	if id, err := inputPrompt.Run(); err != nil {
-		panic(err)
-	} else {
-		return id
-	}
+	extensionCreateCmd.Flags().String("type", extension.ScaffoldTypePlugin, "Type of the extension (plugin, app, theme)")
+	extensionCreateCmd.Flags().String("namespace", "", "PHP namespace of plugins and themes (default the name)")
+	extensionCreateCmd.Flags().String("composer-package", "", "Composer package like swag/example (default derived from the name)")
+	extensionCreateCmd.Flags().String("shopware-version", "", "Required shopware/core version (default ~6.6.0)")
+	extensionCreateCmd.Flags().String("label", "", "Label shown in the administration and store")
+	extensionCreateCmd.Flags().String("description", "", "Description with 150 to 185 characters (default a placeholder)")
+	extensionCreateCmd.Flags().String("author", "", "Author of the extension")
+	extensionCreateCmd.Flags().String("license", "", "License of the extension (default MIT)")
+	extensionCreateCmd.Flags().String("manufacturer-link", "", "Link to the website of the manufacturer")
+	extensionCreateCmd.Flags().String("support-link", "", "Link to the support of the extension")
+	extensionCreateCmd.Flags().Bool("create-in-custom-plugins", true, "Create the extension in custom/plugins, apps in custom/apps")
 }
 
 func emptyValidator(s string) error {
@@ -147,114 +163,3 @@ func validComposerPackage(s string) error {
 	}
 	return nil
 }
-
-func createComposerJson(composerFile string, extensionConfig config.ExtensionConfig) error {
-	composerData := composerStruct{
-		Name:        extensionConfig.ComposerPackage,
-		Version:     "1.0.0",
-		Description: extensionConfig.Description,
-		Type:        "shopware-platform-plugin",
-		License:     extensionConfig.License,
-		Autoload: map[string]any{
-			"psr-4": map[string]string{
-				fmt.Sprintf("%s\\", extensionConfig.Namespace): "src/",
-			},
-		},
-		Require: map[string]string{
-			"shopware/core": extensionConfig.ShopwareVersion,
-		},
-		Extra: composerExtra{
-			ShopwarePluginClass: fmt.Sprintf("%s\\%s", extensionConfig.Namespace, extensionConfig.Name),
-			Label: map[string]string{
-				"en-GB": extensionConfig.Label,
-			},
-			Description: map[string]string{
-				"en-GB": extensionConfig.Description,
-			},
-			ManufacturerLink: map[string]string{
-				"en-GB": extensionConfig.ManufacturerLink,
-			},
-			SupportLink: map[string]string{
-				"en-GB": extensionConfig.SupportLink,
-			},
-		},
-	}
-
-	jsonContent, err := json.MarshalIndent(composerData, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(composerFile, jsonContent, 0o600)
-}
-
-func makeDefaultServices(pluginPath string) error {
-	xml := `<?xml version="1.0" ?>
-<container xmlns="http://symfony.com/schema/dic/services"
-           xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
-           xsi:schemaLocation="http://symfony.com/schema/dic/services http://symfony.com/schema/dic/services/services-1.0.xsd">
-    <services>
-
-    </services>
-</container>
-`
-
-	servicesFilename := fmt.Sprintf("%s/src/Resources/config/services.xml", pluginPath)
-	return os.WriteFile(servicesFilename, []byte(xml), 0o600)
-}
-
-func makeChangelog(pluginPath string) error {
-	changelogContent := `# Changelog
-
-All notable changes to this project will be documented in this file.
-
-The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/),
-and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
-
-## [Unreleased]
-
-## [1.0.0]
-
-### Added
-
-- Initial version
-`
-
-	changelogFilename := fmt.Sprintf("%s/CHANGELOG_en-GB.md", pluginPath)
-	return os.WriteFile(changelogFilename, []byte(changelogContent), 0o600)
-}
-
-func makeBootstrap(pluginPath string, extensionConfig config.ExtensionConfig) error {
-	fileContentTemplate := `<?php declare(strict_types=1);
-
-namespace %s;
-
-use Shopware\Core\Framework\Plugin;
-
-class %s extends Plugin
-{
-}
-`
-	bootStrapFilename := fmt.Sprintf("%s/src/%s.php", pluginPath, extensionConfig.Name)
-	fileContent := fmt.Sprintf(fileContentTemplate, extensionConfig.Namespace, extensionConfig.Name)
-	return os.WriteFile(bootStrapFilename, []byte(fileContent), 0o600)
-}
-
-type composerExtra struct {
-	ShopwarePluginClass string            `json:"shopware-plugin-class"`
-	Label               map[string]string `json:"label"`
-	Description         map[string]string `json:"description"`
-	ManufacturerLink    map[string]string `json:"manufacturerLink"`
-	SupportLink         map[string]string `json:"supportLink"`
-}
-
-type composerStruct struct {
-	Name        string            `json:"name"`
-	Version     string            `json:"version"`
-	Description string            `json:"description"`
-	Type        string            `json:"type"`
-	License     string            `json:"license"`
-	Autoload    map[string]any    `json:"autoload"`
-	Require     map[string]string `json:"require"`
-	Extra       composerExtra     `json:"extra"`
-}
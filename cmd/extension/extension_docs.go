@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate documentation of the extension",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionDocsCmd)
+}
@@ -0,0 +1,76 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionDocsReadmeCmd = &cobra.Command{
+	Use:   "readme [path]",
+	Short: "Generates a store description with features, requirements, configuration and changelog per language",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		languages, _ := cmd.Flags().GetStringSlice("language")
+		changelogVersions, _ := cmd.Flags().GetInt("changelog-versions")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		asHTML, _ := cmd.Flags().GetBool("html")
+
+		for _, language := range languages {
+			content, err := extension.BuildStoreDescription(ext, language, extension.StoreDescriptionOptions{ChangelogVersions: changelogVersions})
+			if err != nil {
+				return err
+			}
+
+			fileName := fmt.Sprintf("README.store.%s.md", language)
+
+			if asHTML {
+				if content, err = extension.RenderStoreDescriptionHTML(content); err != nil {
+					return err
+				}
+
+				fileName = fmt.Sprintf("README.store.%s.html", language)
+			}
+
+			if outputDir == "" {
+				fmt.Println(content)
+				continue
+			}
+
+			if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(filepath.Join(outputDir, fileName), []byte(content), os.ModePerm); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Written %s description to %s", language, filepath.Join(outputDir, fileName))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionDocsCmd.AddCommand(extensionDocsReadmeCmd)
+	extensionDocsReadmeCmd.Flags().StringSlice("language", []string{"en", "de"}, "Languages to generate (de, en)")
+	extensionDocsReadmeCmd.Flags().Int("changelog-versions", 3, "Amount of newest changelog versions to include")
+	extensionDocsReadmeCmd.Flags().String("output-dir", "", "Write README.store.<language>.md files into this folder instead of stdout")
+	extensionDocsReadmeCmd.Flags().Bool("html", false, "Render the description as HTML")
+}
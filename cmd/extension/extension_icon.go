@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionIconCmd = &cobra.Command{
+	Use:   "icon",
+	Short: "Manage the icons of the extension",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionIconCmd)
+}
@@ -0,0 +1,64 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionIconGenerateCmd = &cobra.Command{
+	Use:   "generate [source] [path]",
+	Short: "Renders the plugin, app and store icons from a SVG or PNG logo",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find source: %w", err)
+		}
+
+		extPath := "."
+		if len(args) == 2 {
+			extPath = args[1]
+		}
+
+		path, err := filepath.Abs(extPath)
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		opts := extension.IconOptions{}
+		opts.Padding, _ = cmd.Flags().GetFloat64("padding")
+
+		if background, _ := cmd.Flags().GetString("background"); background != "" {
+			if opts.Background, err = extension.ParseHexColor(background); err != nil {
+				return err
+			}
+		}
+
+		targets, err := extension.GenerateIcons(cmd.Context(), ext, source, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			logging.FromContext(cmd.Context()).Infof("Generated %s (%dx%d)", target.Path, target.Size, target.Size)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionIconCmd.AddCommand(extensionIconGenerateCmd)
+	extensionIconGenerateCmd.Flags().Float64("padding", 10, "Space around the logo in percent of the icon size")
+	extensionIconGenerateCmd.Flags().String("background", "", "Background color like #ffffff, transparent by default")
+}
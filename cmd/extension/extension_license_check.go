@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionLicenseCheckCmd = &cobra.Command{
+	Use:   "license-check",
+	Short: "Scaffold the store license check of paid plugins",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionLicenseCheckCmd)
+}
@@ -0,0 +1,51 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionLicenseCheckInitCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Generates the license validator for the supported Shopware versions and registers it as service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		written, err := extension.GenerateLicenseCheck(ext)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range written {
+			logging.FromContext(cmd.Context()).Infof("Written %s", file)
+		}
+
+		class, _ := extension.LicenseCheckClass(ext)
+
+		logging.FromContext(cmd.Context()).Infof("Call %s::isValid before executing the paid features", class)
+
+		if !ext.GetExtensionConfig().LicenseCheck.Enabled {
+			logging.FromContext(cmd.Context()).Infof("Enable license_check.enabled in the .shopware-extension.yml to validate the license check on extension validate and zip")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionLicenseCheckCmd.AddCommand(extensionLicenseCheckInitCmd)
+}
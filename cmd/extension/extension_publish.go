@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionPublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish the extension to a package registry",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionPublishCmd)
+}
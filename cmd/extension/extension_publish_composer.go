@@ -0,0 +1,53 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionPublishComposerCmd = &cobra.Command{
+	Use:   "composer [zip]",
+	Short: "Uploads an extension zip to a private composer repository or registers its git tag",
+	Long: `Publishes the package of the composer.json in a zip built by extension zip to a private composer repository.
+
+With --type artifact the zip is uploaded with a PUT request to the --repo url.
+With --type vcs the zip is only read for the package name and version, the registry (GitLab) is asked to build the
+package from an already pushed git tag. The tag is not created or pushed by this command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetString("repo")
+		publishType, _ := cmd.Flags().GetString("type")
+		tag, _ := cmd.Flags().GetString("tag")
+
+		if repo == "" {
+			return fmt.Errorf("--repo is required, pass the upload url of the composer repository")
+		}
+
+		pkg, err := extension.PublishComposerPackage(cmd.Context(), args[0], extension.ComposerPublishOptions{
+			Repository: repo,
+			Type:       publishType,
+			Tag:        tag,
+			Token:      os.Getenv("SHOPWARE_CLI_COMPOSER_TOKEN"),
+			JobToken:   os.Getenv("CI_JOB_TOKEN"),
+		})
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Published %s %s to %s", pkg.Name, pkg.Version, repo)
+
+		return nil
+	},
+}
+
+func init() {
+	extensionPublishCmd.AddCommand(extensionPublishComposerCmd)
+	extensionPublishComposerCmd.Flags().String("repo", "", "Upload url of the repository for artifact, supports the placeholders {name}, {vendor}, {package} and {version}, or the package api url for vcs")
+	extensionPublishComposerCmd.Flags().String("type", extension.ComposerPublishArtifact, "artifact uploads the zip, vcs registers an existing git tag (GitLab)")
+	extensionPublishComposerCmd.Flags().String("tag", "", "Existing git tag to register with --type vcs, defaults to the version")
+}
@@ -0,0 +1,39 @@
+package extension
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionPublishComposerRepositoryCmd = &cobra.Command{
+	Use:   "composer-repository [output] [zip...]",
+	Short: "Generates a static composer repository from built extension zips",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseURL, _ := cmd.Flags().GetString("url")
+
+		if baseURL == "" {
+			return fmt.Errorf("--url is required, pass the url the output folder will be hosted at")
+		}
+
+		added, err := extension.BuildComposerRepository(args[0], baseURL, args[1:])
+		if err != nil {
+			return err
+		}
+
+		for _, pkg := range added {
+			logging.FromContext(cmd.Context()).Infof("Added %s %s", pkg.Name, pkg.Version)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionPublishCmd.AddCommand(extensionPublishComposerRepositoryCmd)
+	extensionPublishComposerRepositoryCmd.Flags().String("url", "", "Url the output folder will be hosted at, used for the dist urls")
+}
@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports about the extension",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionReportCmd)
+}
@@ -0,0 +1,94 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionReportAuthorsCmd = &cobra.Command{
+	Use:   "authors [path]",
+	Short: "Shows the authors of the PHP, Administration and Storefront code based on git blame",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		name, err := ext.GetName()
+		if err != nil {
+			return fmt.Errorf("get name: %w", err)
+		}
+
+		report, err := extension.BuildAuthorsReport(cmd.Context(), ext)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		var content []byte
+
+		switch format {
+		case "table":
+			if output != "" {
+				return fmt.Errorf("the table format cannot be written to a file, use markdown or json")
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Module", "Author", "Email", "Lines", "Share", "Files"})
+
+			for _, module := range report {
+				for _, author := range module.Authors {
+					table.Append([]string{module.Module, author.Name, author.Email, strconv.Itoa(author.Lines), fmt.Sprintf("%.1f%%", module.Share(author)), strconv.Itoa(author.Files)})
+				}
+			}
+
+			table.Render()
+
+			return nil
+		case "markdown":
+			content = []byte(extension.RenderAuthorsReportMarkdown(name, report))
+		case "json":
+			if content, err = json.MarshalIndent(report, "", "  "); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported format %s, use table, markdown or json", format)
+		}
+
+		if output == "" {
+			fmt.Println(string(content))
+			return nil
+		}
+
+		if err := os.WriteFile(output, content, os.ModePerm); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Written authors report to %s", output)
+
+		return nil
+	},
+}
+
+func init() {
+	extensionReportCmd.AddCommand(extensionReportAuthorsCmd)
+	extensionReportAuthorsCmd.Flags().String("format", "table", "Output format (table, markdown, json)")
+	extensionReportAuthorsCmd.Flags().String("output", "", "Write the report into this file instead of stdout")
+}
@@ -0,0 +1,57 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionTestAdminCmd = &cobra.Command{
+	Use:   "admin [path] [-- runner arguments]",
+	Short: "Runs the administration unit tests of the extension with the test setup of Shopware",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		constraint, err := ext.GetShopwareVersionConstraint()
+		if err != nil {
+			return fmt.Errorf("cannot get shopware version constraint: %w", err)
+		}
+
+		runner, _ := cmd.Flags().GetString("runner")
+		npmScripts, _ := cmd.Flags().GetString("npm-scripts")
+
+		if err := extension.RunAdministrationTests(cmd.Context(), ext, extension.AdminTestConfig{
+			ShopwareRoot:    os.Getenv("SHOPWARE_PROJECT_ROOT"),
+			ShopwareVersion: constraint,
+			Runner:          runner,
+			Args:            args[1:],
+			NpmScripts:      npmScripts,
+		}); err != nil {
+			return fmt.Errorf("administration tests failed: %w", err)
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Administration tests have passed")
+
+		return nil
+	},
+}
+
+func init() {
+	extensionTestCmd.AddCommand(extensionTestAdminCmd)
+	extensionTestAdminCmd.Flags().String("runner", "", "Test runner to use (jest, vitest), detected from the Shopware sources by default")
+	extensionTestAdminCmd.Flags().String("npm-scripts", extension.NpmScriptsRun, "How the lifecycle scripts of the npm packages of the extension run (run, skip, restricted-env). restricted-env only hides credentials and proxies the network, it does not isolate the scripts")
+}
@@ -0,0 +1,14 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var extensionTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run the tests of an extension",
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionTestCmd)
+}
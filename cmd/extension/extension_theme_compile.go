@@ -0,0 +1,61 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionThemeCompileCmd = &cobra.Command{
+	Use:   "theme-compile [path]",
+	Short: "Compiles a theme without a installed shop",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		constraint, err := ext.GetShopwareVersionConstraint()
+		if err != nil {
+			return fmt.Errorf("cannot get shopware version constraint: %w", err)
+		}
+
+		outputDir, _ := cmd.Flags().GetString("output-directory")
+		skipJavascript, _ := cmd.Flags().GetBool("skip-js")
+
+		result, err := extension.CompileThemeStandalone(cmd.Context(), ext, extension.ThemeCompileConfig{
+			ShopwareRoot:    os.Getenv("SHOPWARE_PROJECT_ROOT"),
+			ShopwareVersion: constraint,
+			OutputDir:       outputDir,
+			SkipJavascript:  skipJavascript,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot compile theme: %w", err)
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Theme has been compiled to %s", result.CssFile)
+
+		if result.JsFile != "" {
+			logging.FromContext(cmd.Context()).Infof("Theme JavaScript has been compiled to %s", result.JsFile)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionThemeCompileCmd)
+	extensionThemeCompileCmd.Flags().String("output-directory", "", "Output directory for the compiled theme (default: var/theme in the extension)")
+	extensionThemeCompileCmd.Flags().Bool("skip-js", false, "Compile only the SCSS of the theme")
+}
@@ -0,0 +1,75 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionThemeTokensCmd = &cobra.Command{
+	Use:   "theme-tokens [path]",
+	Short: "Generates a reference of all configurable theme tokens",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(path)
+		if err != nil {
+			return fmt.Errorf("cannot open extension: %w", err)
+		}
+
+		name, err := ext.GetName()
+		if err != nil {
+			return fmt.Errorf("get name: %w", err)
+		}
+
+		tokens, err := extension.GetThemeTokens(ext)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		var content []byte
+
+		switch format {
+		case "markdown":
+			content = []byte(extension.RenderThemeTokensMarkdown(name, tokens))
+		case "json":
+			if content, err = json.MarshalIndent(tokens, "", "  "); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported format %s, use markdown or json", format)
+		}
+
+		if output == "" {
+			fmt.Println(string(content))
+			return nil
+		}
+
+		if err := os.WriteFile(output, content, os.ModePerm); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Written %d theme tokens to %s", len(tokens), output)
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionThemeTokensCmd)
+	extensionThemeTokensCmd.Flags().String("format", "markdown", "Output format (markdown, json)")
+	extensionThemeTokensCmd.Flags().String("output", "", "Write the reference into this file instead of stdout")
+}
@@ -0,0 +1,47 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/translation"
+)
+
+var extensionTranslationsCmd = &cobra.Command{
+	Use:   "translations",
+	Short: "Synchronize the snippets with a translation platform",
+}
+
+// getTranslationProvider opens the extension and creates the provider configured in the .shopware-extension.yml.
+func getTranslationProvider(pathArg string) (extension.Extension, translation.Provider, error) {
+	path, err := filepath.Abs(pathArg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot find path: %w", err)
+	}
+
+	ext, err := extension.GetExtensionByFolder(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open extension: %w", err)
+	}
+
+	cfg := ext.GetExtensionConfig().Translations
+
+	provider, err := translation.NewProvider(translation.Options{
+		Provider: cfg.Provider,
+		Project:  cfg.Project,
+		URL:      cfg.URL,
+		Token:    cfg.Token,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ext, provider, nil
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionTranslationsCmd)
+}
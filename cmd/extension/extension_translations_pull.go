@@ -0,0 +1,38 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionTranslationsPullCmd = &cobra.Command{
+	Use:   "pull [path]",
+	Short: "Downloads the translations into the snippet files",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ext, provider, err := getTranslationProvider(args[0])
+		if err != nil {
+			return err
+		}
+
+		locales, _ := cmd.Flags().GetStringSlice("locale")
+
+		written, err := extension.PullSnippets(cmd.Context(), ext, provider, ext.GetExtensionConfig().Translations, locales)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range written {
+			logging.FromContext(cmd.Context()).Infof("Updated %s", file)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionTranslationsCmd.AddCommand(extensionTranslationsPullCmd)
+	extensionTranslationsPullCmd.Flags().StringSlice("locale", []string{}, "Locales to download, defaults to translations.locales or the existing snippet files")
+}
@@ -0,0 +1,35 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var extensionTranslationsPushCmd = &cobra.Command{
+	Use:   "push [path]",
+	Short: "Uploads the snippets of the source locale",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ext, provider, err := getTranslationProvider(args[0])
+		if err != nil {
+			return err
+		}
+
+		pushed, err := extension.PushSnippets(cmd.Context(), ext, provider, ext.GetExtensionConfig().Translations)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range pushed {
+			logging.FromContext(cmd.Context()).Infof("Uploaded %s", file)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionTranslationsCmd.AddCommand(extensionTranslationsPushCmd)
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/ci"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
 
@@ -27,6 +29,12 @@ var extensionValidateCmd = &cobra.Command{
 			return fmt.Errorf("cannot find path: %w", err)
 		}
 
+		storeReview, _ := cmd.Flags().GetBool("store-review")
+
+		if storeReview && stat.IsDir() {
+			return fmt.Errorf("--store-review checks the built zip, create it first with extension zip")
+		}
+
 		var ext extension.Extension
 
 		if stat.IsDir() {
@@ -39,9 +47,53 @@ var extensionValidateCmd = &cobra.Command{
 			return fmt.Errorf("cannot open extension: %w", err)
 		}
 
+		if phpSyntaxMode, _ := cmd.Flags().GetString("php-syntax-check"); phpSyntaxMode != "" && ext.GetExtensionConfig() != nil {
+			ext.GetExtensionConfig().Validation.PHPSyntax.Mode = phpSyntaxMode
+		}
+
+		if eslint, _ := cmd.Flags().GetBool("eslint"); eslint && ext.GetExtensionConfig() != nil {
+			ext.GetExtensionConfig().Validation.ESLint.Enabled = true
+		}
+
+		if npmScripts, _ := cmd.Flags().GetString("npm-scripts"); npmScripts != "" && ext.GetExtensionConfig() != nil {
+			ext.GetExtensionConfig().Validation.ESLint.NpmScripts = npmScripts
+		}
+
 		context := extension.RunValidation(cmd.Context(), ext)
 
-		if context.HasErrors() || context.HasWarnings() {
+		if stat.IsDir() {
+			extension.ValidateLockFiles(context)
+		} else {
+			extension.ValidateArchiveBudget(context, path)
+		}
+
+		if storeReview {
+			extension.ValidateStoreReview(context, path)
+		}
+
+		reporter, _ := cmd.Flags().GetString("reporter")
+
+		switch reporter {
+		case "table":
+		case "checkstyle":
+			content, err := extension.RenderCheckstyle(context)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		case "sarif":
+			content, err := extension.RenderSarif(context)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		default:
+			return fmt.Errorf("unsupported reporter %s, use table, checkstyle or sarif", reporter)
+		}
+
+		if reporter == "table" && (context.HasErrors() || context.HasWarnings()) {
 			table := tablewriter.NewWriter(os.Stdout)
 			table.SetHeader([]string{"Type", "Message"})
 			table.SetAutoWrapText(false)
@@ -57,6 +109,17 @@ var extensionValidateCmd = &cobra.Command{
 			table.Render()
 		}
 
+		validationStatus := "passed"
+		if context.HasErrors() {
+			validationStatus = "failed"
+		}
+
+		ci.SetOutputs(cmd.Context(), map[string]string{
+			"validation-status":   validationStatus,
+			"validation-errors":   strconv.Itoa(len(context.Errors())),
+			"validation-warnings": strconv.Itoa(len(context.Warnings())),
+		})
+
 		if context.HasErrors() {
 			return fmt.Errorf("validation failed")
 		}
@@ -69,4 +132,9 @@ var extensionValidateCmd = &cobra.Command{
 
 func init() {
 	extensionRootCmd.AddCommand(extensionValidateCmd)
+	extensionValidateCmd.Flags().String("reporter", "table", "Output format of the findings (table, checkstyle, sarif)")
+	extensionValidateCmd.Flags().Bool("store-review", false, "Run the checks of the automatic store code review against the zip")
+	extensionValidateCmd.Flags().String("php-syntax-check", "", "How the PHP files are linted (local, remote, skip), overrides validation.php_syntax.mode")
+	extensionValidateCmd.Flags().Bool("eslint", false, "Lint the administration sources with the ESLint config of Shopware, like validation.eslint.enabled")
+	extensionValidateCmd.Flags().String("npm-scripts", "", "How the lifecycle scripts of the npm packages of the extension run for ESLint (run, skip, restricted-env), like validation.eslint.npm_scripts")
 }
@@ -1,16 +1,18 @@
 package extension
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	cp "github.com/otiai10/copy"
 	"github.com/spf13/cobra"
 
 	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/ci"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 )
 
@@ -70,20 +72,22 @@ var extensionZipCmd = &cobra.Command{
 			return fmt.Errorf("get extension name: %w", err)
 		}
 
-		extDir := fmt.Sprintf("%s/%s/", tempDir, extName)
+		extDir := filepath.Join(tempDir, extName) + string(os.PathSeparator)
 
 		err = os.Mkdir(extDir, os.ModePerm)
 		if err != nil {
 			return fmt.Errorf("create temp directory: %w", err)
 		}
 
-		tempDir += "/"
+		tempDir += string(os.PathSeparator)
 
 		defer func(path string) {
 			_ = os.RemoveAll(path)
 		}(tempDir)
 
-		var tag string
+		var tag, gitRef string
+
+		gitCommit, _ := cmd.Flags().GetString("git-commit")
 
 		// Extract files using strategy
 		if disableGit {
@@ -92,14 +96,30 @@ var extensionZipCmd = &cobra.Command{
 				return fmt.Errorf("copy files: %w", err)
 			}
 		} else {
-			gitCommit, _ := cmd.Flags().GetString("git-commit")
-
-			tag, err = extension.GitCopyFolder(extPath, extDir, gitCommit)
+			tag, err = extension.GitCopyFolder(cmd.Context(), extPath, extDir, gitCommit)
 			if err != nil {
 				return fmt.Errorf("copy via git: %w", err)
 			}
 
 			logging.FromContext(cmd.Context()).Infof("Checking out %s using Git", tag)
+
+			gitRef = tag
+		}
+
+		reproducible := extCfg.Build.Zip.Pack.Reproducible
+		if cmd.Flags().Changed("reproducible") {
+			reproducible, _ = cmd.Flags().GetBool("reproducible")
+		}
+
+		// the hooks, build info and zip use the commit date instead of the current time
+		if reproducible && os.Getenv("SOURCE_DATE_EPOCH") == "" {
+			if commitTime, err := extension.GitCommitTime(cmd.Context(), extPath, gitRef); err == nil {
+				if err := os.Setenv("SOURCE_DATE_EPOCH", strconv.FormatInt(commitTime.Unix(), 10)); err != nil {
+					return err
+				}
+			} else {
+				logging.FromContext(cmd.Context()).Warnf("Cannot read the commit date, set SOURCE_DATE_EPOCH for reproducible build dates: %s", err)
+			}
 		}
 
 		// User input wins
@@ -108,7 +128,7 @@ var extensionZipCmd = &cobra.Command{
 		}
 
 		if extCfg.Build.Zip.Composer.Enabled {
-			if err := executeHooks(ext, extCfg.Build.Zip.Composer.BeforeHooks, extDir); err != nil {
+			if err := executeHooks(cmd.Context(), ext, extCfg.Build.Zip.Composer.BeforeHooks, extDir); err != nil {
 				return fmt.Errorf("before hooks composer: %w", err)
 			}
 
@@ -116,13 +136,13 @@ var extensionZipCmd = &cobra.Command{
 				return fmt.Errorf("prepare package: %w", err)
 			}
 
-			if err := executeHooks(ext, extCfg.Build.Zip.Composer.AfterHooks, extDir); err != nil {
+			if err := executeHooks(cmd.Context(), ext, extCfg.Build.Zip.Composer.AfterHooks, extDir); err != nil {
 				return fmt.Errorf("after hooks composer: %w", err)
 			}
 		}
 
 		if extCfg.Build.Zip.Assets.Enabled {
-			if err := executeHooks(ext, extCfg.Build.Zip.Assets.BeforeHooks, extDir); err != nil {
+			if err := executeHooks(cmd.Context(), ext, extCfg.Build.Zip.Assets.BeforeHooks, extDir); err != nil {
 				return fmt.Errorf("before hooks assets: %w", err)
 			}
 
@@ -144,20 +164,77 @@ var extensionZipCmd = &cobra.Command{
 				ShopwareVersion:            shopwareConstraint,
 			}
 
+			assetBuildConfig.NpmScripts, _ = cmd.Flags().GetString("npm-scripts")
+
+			if useESBuild, _ := cmd.Flags().GetBool("esbuild"); useESBuild {
+				assetBuildConfig.EnableESBuildForAdmin = true
+				assetBuildConfig.EnableESBuildForStorefront = true
+			}
+
 			if err := extension.BuildAssetsForExtensions(cmd.Context(), extension.ConvertExtensionsToSources(cmd.Context(), []extension.Extension{tempExt}), assetBuildConfig); err != nil {
 				return fmt.Errorf("building assets: %w", err)
 			}
 
-			if err := executeHooks(ext, extCfg.Build.Zip.Assets.AfterHooks, extDir); err != nil {
+			if err := executeHooks(cmd.Context(), ext, extCfg.Build.Zip.Assets.AfterHooks, extDir); err != nil {
 				return fmt.Errorf("after hooks assets: %w", err)
 			}
 		}
 
+		// the working tree assets belong to another commit, when a specific commit is zipped
+		if theme, ok := ext.(*extension.PlatformTheme); ok && gitCommit == "" {
+			checkedOutExt, err := extension.GetExtensionByFolder(extDir)
+			if err != nil {
+				return err
+			}
+
+			if err := extension.CopyCompiledThemeAssets(theme, checkedOutExt.GetResourcesDir()); err != nil {
+				return err
+			}
+		}
+
 		// Cleanup not wanted files
 		if err := extension.CleanupExtensionFolder(extDir, extCfg.Build.Zip.Pack.Excludes.Paths); err != nil {
 			return fmt.Errorf("cleanup package: %w", err)
 		}
 
+		if extCfg.LicenseHeader.IsEnabled() {
+			if err := applyLicenseHeaders(cmd, extDir, extCfg.LicenseHeader); err != nil {
+				return err
+			}
+		}
+
+		if extCfg.LicenseCheck.Enabled {
+			licenseContext := extension.NewValidationContext(ext)
+			extension.ValidateLicenseCheck(licenseContext)
+
+			for _, msg := range licenseContext.Errors() {
+				logging.FromContext(cmd.Context()).Errorf("%s", msg)
+			}
+
+			if licenseContext.HasErrors() {
+				return fmt.Errorf("the license check of the paid plugin is not wired")
+			}
+		}
+
+		buildInfoFormat := extCfg.Build.Zip.Pack.BuildInfo
+		if cmd.Flags().Changed("build-info") {
+			buildInfoFormat, _ = cmd.Flags().GetString("build-info")
+		}
+
+		if buildInfoFormat != "" {
+			buildInfo, err := extension.NewBuildInfo(cmd.Context(), ext, gitRef, cmd.Root().Version)
+			if err != nil {
+				return fmt.Errorf("build info: %w", err)
+			}
+
+			buildInfoFile, err := extension.WriteBuildInfo(extDir, ext, buildInfoFormat, buildInfo)
+			if err != nil {
+				return fmt.Errorf("write build info: %w", err)
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Written build info of commit %s to %s", buildInfo.GitCommit, strings.TrimPrefix(buildInfoFile, tempDir))
+		}
+
 		if extensionReleaseMode {
 			if err := extension.PrepareExtensionForRelease(cmd.Context(), extPath, extDir, ext); err != nil {
 				return fmt.Errorf("prepare for release: %w", err)
@@ -178,19 +255,59 @@ var extensionZipCmd = &cobra.Command{
 				}
 			}
 
-			fileName = path.Join(outputDir, fileName)
+			fileName = filepath.Join(outputDir, fileName)
 		}
 
-		if err := executeHooks(ext, extCfg.Build.Zip.Pack.BeforeHooks, extDir); err != nil {
+		if err := executeHooks(cmd.Context(), ext, extCfg.Build.Zip.Pack.BeforeHooks, extDir); err != nil {
 			return fmt.Errorf("before hooks pack: %w", err)
 		}
 
-		if err := extension.CreateZip(tempDir, fileName); err != nil {
+		extCfg.Build.Zip.Pack.Reproducible = reproducible
+
+		if err := extension.CreateZip(cmd.Context(), tempDir, fileName, extCfg.Build.Zip.Pack); err != nil {
 			return fmt.Errorf("create zip file: %w", err)
 		}
 
 		logging.FromContext(cmd.Context()).Infof("Created file %s", fileName)
 
+		budgetContext := extension.NewValidationContext(ext)
+		extension.ValidateArchiveBudget(budgetContext, fileName)
+
+		for _, msg := range budgetContext.Warnings() {
+			logging.FromContext(cmd.Context()).Warnf("%s", msg)
+		}
+
+		for _, msg := range budgetContext.Errors() {
+			logging.FromContext(cmd.Context()).Errorf("%s", msg)
+		}
+
+		if budgetContext.HasErrors() {
+			if err := os.Remove(fileName); err != nil {
+				return err
+			}
+
+			return fmt.Errorf("zip file %s exceeds the configured budget and has been removed", fileName)
+		}
+
+		outputs := map[string]string{
+			"artifact-path": fileName,
+			"name":          name,
+		}
+
+		if absoluteFileName, err := filepath.Abs(fileName); err == nil {
+			outputs["artifact-path"] = absoluteFileName
+		}
+
+		if extVersion, err := ext.GetVersion(); err == nil {
+			outputs["version"] = extVersion.String()
+		}
+
+		if constraint, err := ext.GetShopwareVersionConstraint(); err == nil {
+			outputs["shopware-version-constraint"] = constraint.String()
+		}
+
+		ci.SetOutputs(cmd.Context(), outputs)
+
 		return nil
 	},
 }
@@ -201,16 +318,51 @@ func init() {
 	extensionZipCmd.Flags().BoolVar(&extensionReleaseMode, "release", false, "Release mode (remove app secrets)")
 	extensionZipCmd.Flags().String("output-directory", "", "Output directory for the zip file")
 	extensionZipCmd.Flags().String("git-commit", "", "Commit Hash / Tag to use")
+	extensionZipCmd.Flags().Bool("reproducible", false, "Normalizes timestamps and permissions, so the same commit produces a byte-identical zip")
+	extensionZipCmd.Flags().Bool("esbuild", false, "Build the administration and storefront with esbuild without Shopware sources")
+	extensionZipCmd.Flags().String("npm-scripts", extension.NpmScriptsRun, "How the lifecycle scripts of the npm packages of the extension run (run, skip, restricted-env). restricted-env only hides credentials and proxies the network, it does not isolate the scripts")
+	extensionZipCmd.Flags().String("build-info", "", "Writes the build info as json or php into the zip, overrides build.zip.pack.build_info")
+}
+
+// applyLicenseHeaders injects the license header into the packed files or fails when files are missing it.
+func applyLicenseHeaders(cmd *cobra.Command, extDir string, cfg extension.ConfigLicenseHeader) error {
+	if cfg.Inject {
+		injected, err := extension.InjectLicenseHeaders(extDir, cfg)
+		if err != nil {
+			return fmt.Errorf("inject license headers: %w", err)
+		}
+
+		for _, file := range injected {
+			logging.FromContext(cmd.Context()).Infof("Added license header to %s", file)
+		}
+
+		return nil
+	}
+
+	missing, err := extension.FindFilesWithoutLicenseHeader(extDir, cfg)
+	if err != nil {
+		return fmt.Errorf("check license headers: %w", err)
+	}
+
+	for _, file := range missing {
+		logging.FromContext(cmd.Context()).Errorf("file %s is missing the license header", file)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%d files are missing the license header, add it or enable license_header.inject", len(missing))
+	}
+
+	return nil
 }
 
-func executeHooks(ext extension.Extension, hooks []string, extDir string) error {
+func executeHooks(ctx context.Context, ext extension.Extension, hooks []string, extDir string) error {
 	env := []string{
 		fmt.Sprintf("EXTENSION_DIR=%s", extDir),
 		fmt.Sprintf("ORIGINAL_EXTENSION_DIR=%s", ext.GetPath()),
 	}
 
 	for _, hook := range hooks {
-		hookCmd := exec.Command("sh", "-c", hook)
+		hookCmd := extension.ShellCommand(ctx, hook)
 		hookCmd.Stdout = os.Stdout
 		hookCmd.Stderr = os.Stderr
 		hookCmd.Dir = extDir
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/cliplugin"
+	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+// pluginAnnotation marks plugin commands, their names are not sent as telemetry.
+const pluginAnnotation = "plugin"
+
+// registerPlugins adds the external subcommands. Built-in commands cannot be overwritten by a plugin, so the PATH is
+// only searched when the arguments don't select a built-in command, f.e. for plugins, help and completion.
+func registerPlugins(ctx context.Context, args []string) {
+	if existing, _, err := rootCmd.Find(args); err == nil && existing != rootCmd {
+		return
+	}
+
+	plugins, err := cliplugin.Discover(os.Getenv("PATH"), cliplugin.ManifestDirs(cliplugin.ConfigFlag(args)))
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Cannot discover plugins: %v", err)
+		return
+	}
+
+	for _, plugin := range plugins {
+		if existing, _, err := rootCmd.Find([]string{plugin.Name}); err == nil && existing != rootCmd {
+			logging.FromContext(ctx).Debugf("Skipping plugin %s as it conflicts with a built-in command", plugin.Executable)
+			continue
+		}
+
+		rootCmd.AddCommand(newPluginCommand(plugin))
+	}
+}
+
+func newPluginCommand(plugin cliplugin.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                plugin.Name,
+		Short:              plugin.Short,
+		Annotations:        map[string]string{pluginAnnotation: "true"},
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.InitConfig(cfgFile); err != nil {
+				return err
+			}
+
+			binary, err := os.Executable()
+			if err != nil {
+				return err
+			}
+
+			verbose := false
+			for _, arg := range args {
+				if arg == "--verbose" {
+					verbose = true
+				}
+			}
+
+			logging.FromContext(cmd.Context()).Debugf("Running plugin %s", plugin.Executable)
+
+			pluginCmd := process.Command(cmd.Context(), plugin.Executable, args...) //nolint:gosec
+			pluginCmd.Stdin = os.Stdin
+			pluginCmd.Stdout = os.Stdout
+			pluginCmd.Stderr = os.Stderr
+			pluginCmd.Env = cliplugin.Environment(binary, version, config.Config{}.GetPath(), verbose)
+
+			return pluginCmd.Run()
+		},
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"strings"
 
 	"dario.cat/mergo"
 	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/shop"
 	"github.com/spf13/cobra"
@@ -51,11 +51,15 @@ var projectCI = &cobra.Command{
 			return err
 		}
 
+		if shopCfg.Build.Assets.Strategy == shop.AssetStrategySymlink && (shopCfg.Build.RemoveExtensionAssets || shopCfg.Build.DisableAssetCopy) {
+			return fmt.Errorf("the symlink asset strategy cannot be combined with remove_extension_assets or disable_asset_copy")
+		}
+
 		cleanupPaths = append(cleanupPaths, shopCfg.Build.CleanupPaths...)
 
 		logging.FromContext(cmd.Context()).Infof("Installing dependencies using Composer")
 
-		composer := exec.CommandContext(cmd.Context(), "composer", "install", "--no-dev", "--no-interaction", "--no-progress", "--optimize-autoloader", "--classmap-authoritative")
+		composer := process.Command(cmd.Context(), "composer", "install", "--no-dev", "--no-interaction", "--no-progress", "--optimize-autoloader", "--classmap-authoritative")
 		composer.Dir = args[0]
 		composer.Stdin = os.Stdin
 		composer.Stdout = os.Stdout
@@ -65,6 +69,19 @@ var projectCI = &cobra.Command{
 			return err
 		}
 
+		if len(shopCfg.Build.ComposerPatches) > 0 {
+			logging.FromContext(cmd.Context()).Infof("Applying composer patches")
+
+			if err := shop.ApplyComposerPatches(cmd.Context(), args[0], shopCfg.Build.ComposerPatches); err != nil {
+				return err
+			}
+
+			// patches can add new classes, which are missing in the authoritative classmap
+			if err := runTransparentCommand(commandWithRoot(process.Command(cmd.Context(), "composer", "dump-autoload", "--no-dev", "--optimize", "--classmap-authoritative"), args[0])); err != nil {
+				return fmt.Errorf("failed to dump the autoloader after patching: %w", err)
+			}
+		}
+
 		logging.FromContext(cmd.Context()).Infof("Looking for extensions to build assets in project")
 
 		sources := extension.FindAssetSourcesOfProject(cmd.Context(), args[0])
@@ -80,14 +97,15 @@ var projectCI = &cobra.Command{
 			ShopwareRoot:               args[0],
 			ShopwareVersion:            constraint,
 			Browserslist:               shopCfg.Build.Browserslist,
+			Concurrency:                assetBuildConcurrency(cmd, shopCfg),
 		}
 
-		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
+		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, withProjectWebpackConfig(assetCfg, args[0], shopCfg)); err != nil {
 			return err
 		}
 
 		logging.FromContext(cmd.Context()).Infof("Optimizing Administration sources")
-		if err := cleanupAdministrationFiles(cmd.Context(), path.Join(args[0], "vendor", "shopware", "administration")); err != nil {
+		if err := cleanupAdministrationFiles(cmd.Context(), filepath.Join(args[0], "vendor", "shopware", "administration")); err != nil {
 			return err
 		}
 
@@ -102,7 +120,7 @@ var projectCI = &cobra.Command{
 		for _, removePath := range cleanupPaths {
 			logging.FromContext(cmd.Context()).Infof("Removing %s", removePath)
 
-			if err := os.RemoveAll(path.Join(args[0], removePath)); err != nil {
+			if err := os.RemoveAll(filepath.Join(args[0], removePath)); err != nil {
 				return err
 			}
 		}
@@ -113,9 +131,13 @@ var projectCI = &cobra.Command{
 			return err
 		}
 
+		if err := shop.PrepareAssetStrategy(args[0], shopCfg.Build.Assets); err != nil {
+			return err
+		}
+
 		logging.FromContext(cmd.Context()).Infof("Warmup container cache")
 
-		if err := runTransparentCommand(exec.CommandContext(cmd.Context(), "php", path.Join(args[0], "bin", "ci"), "--version")); err != nil { //nolint: gosec
+		if err := runTransparentCommand(process.Command(cmd.Context(), "php", filepath.Join(args[0], "bin", "ci"), "--version")); err != nil { //nolint: gosec
 			return fmt.Errorf("failed to warmup container cache (php bin/ci --version): %w", err)
 		}
 
@@ -123,48 +145,56 @@ var projectCI = &cobra.Command{
 			logging.FromContext(cmd.Context()).Infof("Copying extension assets to final public/bundles folder")
 
 			// Delete asset manifest to force a new build
-			manifestPath := path.Join(args[0], "public", "asset-manifest.json")
+			manifestPath := filepath.Join(args[0], "public", "asset-manifest.json")
 			if _, err := os.Stat(manifestPath); err == nil {
 				if err := os.Remove(manifestPath); err != nil {
 					return err
 				}
 			}
 
-			if err := runTransparentCommand(exec.CommandContext(cmd.Context(), "php", path.Join(args[0], "bin", "ci"), "asset:install")); err != nil { //nolint: gosec
+			if err := runTransparentCommand(process.Command(cmd.Context(), "php", filepath.Join(args[0], "bin", "ci"), "asset:install")); err != nil { //nolint: gosec
 				return fmt.Errorf("failed to install assets (php bin/ci asset:install): %w", err)
 			}
+
+			if shopCfg.Build.Assets.Strategy != "" {
+				logging.FromContext(cmd.Context()).Infof("Applying the %s asset strategy", shopCfg.Build.Assets.Strategy)
+
+				if err := applyAssetStrategy(args[0], shopCfg, sources); err != nil {
+					return err
+				}
+			}
 		}
 
 		if shopCfg.Build.RemoveExtensionAssets {
 			logging.FromContext(cmd.Context()).Infof("Deleting assets of extensions")
 
 			for _, source := range sources {
-				if _, err := os.Stat(path.Join(source.Path, "Resources", "public", "administration", "css")); err == nil {
-					if err := os.WriteFile(path.Join(source.Path, "Resources", ".administration-css"), []byte{}, os.ModePerm); err != nil {
+				if _, err := os.Stat(filepath.Join(source.Path, "Resources", "public", "administration", "css")); err == nil {
+					if err := os.WriteFile(filepath.Join(source.Path, "Resources", ".administration-css"), []byte{}, os.ModePerm); err != nil {
 						return err
 					}
 				}
 
-				if _, err := os.Stat(path.Join(source.Path, "Resources", "public", "administration", "js")); err == nil {
-					if err := os.WriteFile(path.Join(source.Path, "Resources", ".administration-js"), []byte{}, os.ModePerm); err != nil {
+				if _, err := os.Stat(filepath.Join(source.Path, "Resources", "public", "administration", "js")); err == nil {
+					if err := os.WriteFile(filepath.Join(source.Path, "Resources", ".administration-js"), []byte{}, os.ModePerm); err != nil {
 						return err
 					}
 				}
 
-				if err := os.RemoveAll(path.Join(source.Path, "Resources", "public")); err != nil {
+				if err := os.RemoveAll(filepath.Join(source.Path, "Resources", "public")); err != nil {
 					return err
 				}
 			}
 
-			if err := os.RemoveAll(path.Join(args[0], "vendor", "shopware", "administration", "Resources", "public")); err != nil {
+			if err := os.RemoveAll(filepath.Join(args[0], "vendor", "shopware", "administration", "Resources", "public")); err != nil {
 				return err
 			}
 
-			if err := os.WriteFile(path.Join(args[0], "vendor", "shopware", "administration", "Resources", ".administration-js"), []byte{}, os.ModePerm); err != nil {
+			if err := os.WriteFile(filepath.Join(args[0], "vendor", "shopware", "administration", "Resources", ".administration-js"), []byte{}, os.ModePerm); err != nil {
 				return err
 			}
 
-			if err := os.WriteFile(path.Join(args[0], "vendor", "shopware", "administration", "Resources", ".administration-css"), []byte{}, os.ModePerm); err != nil {
+			if err := os.WriteFile(filepath.Join(args[0], "vendor", "shopware", "administration", "Resources", ".administration-css"), []byte{}, os.ModePerm); err != nil {
 				return err
 			}
 		}
@@ -175,6 +205,7 @@ var projectCI = &cobra.Command{
 
 func init() {
 	projectRootCmd.AddCommand(projectCI)
+	projectCI.Flags().Int("concurrency", 0, "Number of extensions built in parallel, defaults to build.asset_concurrency or the CPU count")
 }
 
 func commandWithRoot(cmd *exec.Cmd, root string) *exec.Cmd {
@@ -192,7 +223,7 @@ func runTransparentCommand(cmd *exec.Cmd) error {
 }
 
 func cleanupTcpdf(folder string) error {
-	return filepath.WalkDir(path.Join(folder, "vendor", "tecnickcom/tcpdf/fonts"), func(path string, d os.DirEntry, err error) error {
+	return filepath.WalkDir(filepath.Join(folder, "vendor", "tecnickcom/tcpdf/fonts"), func(path string, d os.DirEntry, err error) error {
 		if d.IsDir() {
 			return nil
 		}
@@ -212,7 +243,7 @@ func cleanupTcpdf(folder string) error {
 }
 
 func cleanupAdministrationFiles(ctx context.Context, folder string) error {
-	adminFolder := path.Join(folder, "Resources", "app", "administration")
+	adminFolder := filepath.Join(folder, "Resources", "app", "administration")
 
 	if _, err := os.Stat(adminFolder); err == nil {
 		logging.FromContext(ctx).Infof("Merging Administration snippet for %s", folder)
@@ -256,7 +287,7 @@ func cleanupAdministrationFiles(ctx context.Context, folder string) error {
 					return err
 				}
 
-				if err := os.WriteFile(path.Join(folder, language), data, os.ModePerm); err != nil {
+				if err := os.WriteFile(filepath.Join(folder, language), data, os.ModePerm); err != nil {
 					return err
 				}
 
@@ -291,7 +322,7 @@ func cleanupAdministrationFiles(ctx context.Context, folder string) error {
 				return err
 			}
 
-			if err := os.WriteFile(path.Join(folder, language), mergedData, os.ModePerm); err != nil {
+			if err := os.WriteFile(filepath.Join(folder, language), mergedData, os.ModePerm); err != nil {
 				return err
 			}
 		}
@@ -304,19 +335,19 @@ func cleanupAdministrationFiles(ctx context.Context, folder string) error {
 
 		logging.FromContext(ctx).Infof("Migrating generated snippet file for %s", folder)
 
-		snippetFolder := path.Join(adminFolder, "src", "app", "snippet")
+		snippetFolder := filepath.Join(adminFolder, "src", "app", "snippet")
 		if err := os.MkdirAll(snippetFolder, os.ModePerm); err != nil {
 			return err
 		}
 
 		for language := range snippetFiles {
-			if err := os.Rename(path.Join(folder, language), path.Join(snippetFolder, language+".json")); err != nil {
+			if err := os.Rename(filepath.Join(folder, language), filepath.Join(snippetFolder, language+".json")); err != nil {
 				return err
 			}
 		}
 
 		logging.FromContext(ctx).Infof("Creating empty main.js for %s", folder)
-		return os.WriteFile(path.Join(adminFolder, "src", "main.js"), []byte(""), os.ModePerm)
+		return os.WriteFile(filepath.Join(adminFolder, "src", "main.js"), []byte(""), os.ModePerm)
 	}
 
 	return nil
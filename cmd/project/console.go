@@ -0,0 +1,73 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var defaultConsolePaths = []string{"bin/console", "vendor/bin/console"}
+
+func addConsoleFlags(flags *pflag.FlagSet) {
+	flags.String("php-binary", "", "PHP binary to run the console with (default php)")
+	flags.String("console-path", "", "Path of the console relative to the project root (default bin/console or vendor/bin/console)")
+	flags.Bool("no-debug", false, "Passes --no-debug to the console")
+}
+
+// newConsoleCommand creates a console command from the flags of cmd, falling back to build.console of the project config.
+func newConsoleCommand(cmd *cobra.Command, projectRoot string, shopCfg *shop.Config, args ...string) (*exec.Cmd, error) {
+	consoleCfg := shopCfg.Build.Console
+
+	if phpBinary, _ := cmd.Flags().GetString("php-binary"); phpBinary != "" {
+		consoleCfg.PHPBinary = phpBinary
+	}
+
+	if consolePath, _ := cmd.Flags().GetString("console-path"); consolePath != "" {
+		consoleCfg.Path = consolePath
+	}
+
+	if noDebug, _ := cmd.Flags().GetBool("no-debug"); noDebug {
+		consoleCfg.NoDebug = true
+	}
+
+	if consoleCfg.PHPBinary == "" {
+		consoleCfg.PHPBinary = "php"
+	}
+
+	consolePath, err := findConsolePath(projectRoot, consoleCfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	consoleArgs := append([]string{consolePath}, args...)
+
+	if consoleCfg.NoDebug {
+		consoleArgs = append(consoleArgs, "--no-debug")
+	}
+
+	return newProjectCommand(cmd.Context(), shopCfg, projectRoot, consoleCfg.PHPBinary, consoleArgs...), nil
+}
+
+func findConsolePath(projectRoot, configured string) (string, error) {
+	if configured != "" {
+		if _, err := os.Stat(filepath.Join(projectRoot, configured)); err != nil {
+			return "", fmt.Errorf("cannot find console at %s: %w", configured, err)
+		}
+
+		return configured, nil
+	}
+
+	for _, consolePath := range defaultConsolePaths {
+		if _, err := os.Stat(filepath.Join(projectRoot, consolePath)); err == nil {
+			return consolePath, nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot find the console in %s, configure it with --console-path or build.console.path", projectRoot)
+}
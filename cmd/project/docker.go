@@ -0,0 +1,44 @@
+package project
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+// newProjectCommand creates a command running in the project root, or inside the docker compose service when configured.
+// A TTY is allocated in the container when the cli itself runs in a terminal.
+func newProjectCommand(ctx context.Context, shopCfg *shop.Config, projectRoot string, name string, args ...string) *exec.Cmd {
+	return projectCommand(ctx, shopCfg, projectRoot, stdinIsTerminal(), name, args...)
+}
+
+// newBackgroundProjectCommand is like newProjectCommand, but never attaches the stdin, f.e. for parallel running workers.
+func newBackgroundProjectCommand(ctx context.Context, shopCfg *shop.Config, projectRoot string, name string, args ...string) *exec.Cmd {
+	return projectCommand(ctx, shopCfg, projectRoot, false, name, args...)
+}
+
+func projectCommand(ctx context.Context, shopCfg *shop.Config, projectRoot string, tty bool, name string, args ...string) *exec.Cmd {
+	if !shopCfg.Docker.IsEnabled() {
+		return commandWithRoot(process.Command(ctx, name, args...), projectRoot)
+	}
+
+	cmd := commandWithRoot(process.Command(ctx, "docker", shopCfg.Docker.ExecArgs(append([]string{name}, args...), tty)...), projectRoot)
+
+	if tty {
+		cmd.Stdin = os.Stdin
+	}
+
+	return cmd
+}
+
+func stdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
@@ -2,6 +2,8 @@ package project
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
 )
 
 var projectConfigPath string
@@ -12,6 +14,12 @@ var projectRootCmd = &cobra.Command{
 }
 
 func Register(rootCmd *cobra.Command) {
+	projectRootCmd.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+			extension.SetRefreshProjectCache(true)
+		}
+	}
 	rootCmd.AddCommand(projectRootCmd)
 	projectRootCmd.PersistentFlags().StringVar(&projectConfigPath, "project-config", ".shopware-project.yml", "Path to .shopware-project.yml")
+	projectRootCmd.PersistentFlags().Bool("refresh", false, "Scan the project for extensions again instead of using the cache")
 }
@@ -1,10 +1,9 @@
 package project
 
 import (
-	"os/exec"
-
 	"github.com/FriendsOfShopware/shopware-cli/extension"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +20,11 @@ var projectAdminBuildCmd = &cobra.Command{
 			return err
 		}
 
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
 		logging.FromContext(cmd.Context()).Infof("Looking for extensions to build assets in project")
 
 		sources := extension.FindAssetSourcesOfProject(cmd.Context(), projectRoot)
@@ -33,16 +37,41 @@ var projectAdminBuildCmd = &cobra.Command{
 			DisableStorefrontBuild: true,
 			ShopwareRoot:           projectRoot,
 			ShopwareVersion:        constraint,
+			Concurrency:            assetBuildConcurrency(cmd, shopCfg),
+		}
+
+		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, withProjectWebpackConfig(assetCfg, projectRoot, shopCfg)); err != nil {
+			return err
+		}
+
+		skipAssetsInstall, _ := cmd.Flags().GetBool("skip-assets-install")
+
+		if skipAssetsInstall || shopCfg.Build.DisableAssetCopy {
+			logging.FromContext(cmd.Context()).Infof("Skipping assets:install")
+			return nil
+		}
+
+		// the cdn config is written by project ci, which builds the container with it
+		if err := shop.ValidateAssetStrategy(shopCfg.Build.Assets); err != nil {
+			return err
+		}
+
+		consoleCmd, err := newConsoleCommand(cmd, projectRoot, shopCfg, "assets:install")
+		if err != nil {
+			return err
 		}
 
-		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
+		if err := runTransparentCommand(consoleCmd); err != nil {
 			return err
 		}
 
-		return runTransparentCommand(commandWithRoot(exec.CommandContext(cmd.Context(), "php", "bin/console", "assets:install"), projectRoot))
+		return applyAssetStrategy(projectRoot, shopCfg, sources)
 	},
 }
 
 func init() {
 	projectRootCmd.AddCommand(projectAdminBuildCmd)
+	projectAdminBuildCmd.Flags().Int("concurrency", 0, "Number of extensions built in parallel, defaults to build.asset_concurrency or the CPU count")
+	projectAdminBuildCmd.Flags().Bool("skip-assets-install", false, "Skips running assets:install after the build")
+	addConsoleFlags(projectAdminBuildCmd.Flags())
 }
@@ -0,0 +1,48 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectAdminWatchCmd = &cobra.Command{
+	Use:   "admin-watch [project-dir]",
+	Short: "Starts the Administration dev server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		buildTool, _ := cmd.Flags().GetString("build-tool")
+		if err := extension.ValidateBuildTool(buildTool); err != nil {
+			return err
+		}
+
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Looking for extensions to watch in project")
+
+		sources := extension.FindAssetSourcesOfProject(cmd.Context(), projectRoot)
+
+		return extension.RunAdministrationDevServer(cmd.Context(), sources, withProjectWebpackConfig(extension.AssetBuildConfig{
+			ShopwareRoot:   projectRoot,
+			AdminBuildTool: buildTool,
+		}, projectRoot, shopCfg))
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectAdminWatchCmd)
+	projectAdminWatchCmd.Flags().String("build-tool", "", "Force the build tool (webpack, vite), detected by default")
+}
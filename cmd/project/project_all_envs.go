@@ -0,0 +1,62 @@
+package project
+
+import (
+	"context"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+func addAllEnvsFlags(flags *pflag.FlagSet) {
+	flags.Bool("all-envs", false, "Runs the command against the default shop and all environments of .shopware-project.yml")
+	flags.Int("concurrency", 4, "How many environments are processed at the same time with --all-envs")
+}
+
+// commandEnvironments returns all environments with --all-envs, otherwise only the default one.
+func commandEnvironments(cmd *cobra.Command, cfg *shop.Config) []string {
+	if allEnvs, _ := cmd.Flags().GetBool("all-envs"); allEnvs {
+		return cfg.EnvironmentNames()
+	}
+
+	return []string{shop.DefaultEnvironment}
+}
+
+// runForCommandEnvironments runs fn for the given environments. With --all-envs they are processed concurrently and a
+// summary of all environments is printed, the error contains all failed environments.
+func runForCommandEnvironments(cmd *cobra.Command, cfg *shop.Config, names []string, fn func(ctx context.Context, name string, cfg *shop.Config) error) error {
+	if allEnvs, _ := cmd.Flags().GetBool("all-envs"); !allEnvs {
+		envCfg, err := cfg.ForEnvironment(names[0])
+		if err != nil {
+			return err
+		}
+
+		return fn(cmd.Context(), names[0], envCfg)
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	results, err := shop.RunForEnvironments(cmd.Context(), cfg, names, concurrency, fn)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Environment", "URL", "Duration", "Result"})
+
+	for _, result := range results {
+		status := "OK"
+		if result.Error != nil {
+			status = result.Error.Error()
+		}
+
+		table.Append([]string{result.Environment, result.URL, result.Duration.Round(1e6).String(), status})
+	}
+
+	table.Render()
+
+	return shop.EnvironmentResultsError(results)
+}
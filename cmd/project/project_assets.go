@@ -0,0 +1,53 @@
+package project
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+// withProjectWebpackConfig adds the webpack configs of the project config to the asset build.
+func withProjectWebpackConfig(assetCfg extension.AssetBuildConfig, projectRoot string, shopCfg *shop.Config) extension.AssetBuildConfig {
+	if shopCfg.Build.Webpack.Administration != "" {
+		assetCfg.AdministrationWebpackConfig = filepath.Join(projectRoot, shopCfg.Build.Webpack.Administration)
+	}
+
+	if shopCfg.Build.Webpack.Storefront != "" {
+		assetCfg.StorefrontWebpackConfig = filepath.Join(projectRoot, shopCfg.Build.Webpack.Storefront)
+	}
+
+	return assetCfg
+}
+
+// assetBuildConcurrency returns the number of extensions built in parallel from the --concurrency flag, the project config or the CPU count.
+func assetBuildConcurrency(cmd *cobra.Command, shopCfg *shop.Config) int {
+	if concurrency, _ := cmd.Flags().GetInt("concurrency"); cmd.Flags().Changed("concurrency") && concurrency > 0 {
+		return concurrency
+	}
+
+	if shopCfg.Build.AssetConcurrency > 0 {
+		return shopCfg.Build.AssetConcurrency
+	}
+
+	return runtime.NumCPU()
+}
+
+// applyAssetStrategy runs the configured asset strategy after assets:install for the platform bundles and the sources.
+func applyAssetStrategy(projectRoot string, shopCfg *shop.Config, sources []asset.Source) error {
+	bundles := make(map[string]string)
+
+	for name, path := range shop.PlatformAssetBundles {
+		bundles[name] = filepath.Join(projectRoot, filepath.FromSlash(path))
+	}
+
+	for _, source := range sources {
+		bundles[source.Name] = filepath.Join(source.Path, "Resources", "public")
+	}
+
+	return shop.ApplyAssetStrategy(projectRoot, shopCfg.Build.Assets, bundles)
+}
@@ -0,0 +1,89 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectAuditCmd = &cobra.Command{
+	Use:   "audit [project-dir]",
+	Short: "Checks the Composer dependencies of the project and its extensions for known vulnerabilities",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+		severity, _ := cmd.Flags().GetString("severity")
+
+		packages, err := shop.CollectAuditPackages(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		if len(packages) == 0 {
+			return fmt.Errorf("cannot find any composer.lock in %s", projectRoot)
+		}
+
+		advisories, err := shop.FetchSecurityAdvisories(cmd.Context(), packages)
+		if err != nil {
+			return err
+		}
+
+		if advisories, err = shop.FilterAdvisoriesBySeverity(advisories, severity); err != nil {
+			return err
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(advisories)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		} else if len(advisories) > 0 {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Package", "Version", "Severity", "Advisory", "Source"})
+			table.SetAutoWrapText(false)
+
+			for _, advisory := range advisories {
+				title := advisory.Title
+				if advisory.CVE != "" {
+					title = fmt.Sprintf("%s (%s)", title, advisory.CVE)
+				}
+
+				table.Append([]string{advisory.Package, advisory.Version, advisory.Severity, title, advisory.Source})
+			}
+
+			table.Render()
+		}
+
+		if len(advisories) > 0 {
+			return fmt.Errorf("found %d security advisories", len(advisories))
+		}
+
+		if !outputAsJson {
+			logging.FromContext(cmd.Context()).Infof("No security advisories found in %d packages", len(packages))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectAuditCmd)
+	projectAuditCmd.Flags().Bool("json", false, "Output as json")
+	projectAuditCmd.Flags().String("severity", "", "Only report advisories with at least this severity (low, medium, high, critical)")
+}
@@ -0,0 +1,177 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+const defaultBenchmarkBaseline = ".shopware-benchmark.json"
+
+var projectBenchmarkCmd = &cobra.Command{
+	Use:   "benchmark [url...]",
+	Short: "Measures the response times of storefront pages and compares them against a baseline",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		requests, _ := cmd.Flags().GetInt("requests")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		saveBaseline, _ := cmd.Flags().GetBool("save-baseline")
+		clearCache, _ := cmd.Flags().GetBool("clear-cache")
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+		baselineFile, _ := cmd.Flags().GetString("baseline")
+
+		urls := args
+		if len(urls) == 0 && cfg.Benchmark != nil {
+			urls = cfg.Benchmark.URLs
+		}
+
+		if len(urls) == 0 {
+			return fmt.Errorf("no urls to benchmark, pass them as arguments or configure benchmark.urls in .shopware-project.yml")
+		}
+
+		if baselineFile == "" && cfg.Benchmark != nil {
+			baselineFile = cfg.Benchmark.Baseline
+		}
+
+		if baselineFile == "" {
+			baselineFile = defaultBenchmarkBaseline
+		}
+
+		if clearCache {
+			client, err := shop.NewShopClient(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.CacheManager.Clear(adminSdk.NewApiContext(cmd.Context())); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Cleared cache for a cold start")
+		}
+
+		results := make([]shop.BenchmarkResult, 0, len(urls))
+
+		for _, u := range urls {
+			absoluteURL, err := resolveShopURL(cfg.URL, u)
+			if err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Benchmarking %s", absoluteURL)
+
+			result, err := shop.RunBenchmark(cmd.Context(), http.DefaultClient, absoluteURL, requests)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, *result)
+		}
+
+		regressions := make([]shop.BenchmarkRegression, 0)
+
+		if !saveBaseline {
+			baseline, err := shop.ReadBenchmarkBaseline(baselineFile)
+			if err == nil {
+				regressions = shop.CompareBenchmark(baseline, results, threshold)
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(map[string]interface{}{"results": results, "regressions": regressions})
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		} else {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"URL", "Cold TTFB", "Cold Total", "TTFB p50", "TTFB p95", "Total p50", "Total p90", "Total p95", "Total p99"})
+
+			for _, result := range results {
+				table.Append([]string{
+					result.URL,
+					result.Cold.TTFB.String(),
+					result.Cold.Total.String(),
+					result.WarmTTFB.P50.String(),
+					result.WarmTTFB.P95.String(),
+					result.WarmTotal.P50.String(),
+					result.WarmTotal.P90.String(),
+					result.WarmTotal.P95.String(),
+					result.WarmTotal.P99.String(),
+				})
+			}
+
+			table.Render()
+		}
+
+		if saveBaseline {
+			if err := shop.WriteBenchmarkBaseline(baselineFile, results); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Saved baseline to %s", baselineFile)
+
+			return nil
+		}
+
+		if len(regressions) > 0 {
+			for _, regression := range regressions {
+				logging.FromContext(cmd.Context()).Warnf("%s", regression)
+			}
+
+			return fmt.Errorf("found %d regressions of more than %.0f%% compared to the baseline", len(regressions), threshold)
+		}
+
+		return nil
+	},
+}
+
+// resolveShopURL resolves relative paths like /account against the shop URL.
+func resolveShopURL(shopURL, target string) (string, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return target, nil
+	}
+
+	if shopURL == "" {
+		return "", fmt.Errorf("cannot resolve %s without url in .shopware-project.yml", target)
+	}
+
+	base, err := url.Parse(shopURL)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectBenchmarkCmd)
+	projectBenchmarkCmd.Flags().Int("requests", 10, "Amount of warm requests per url")
+	projectBenchmarkCmd.Flags().Float64("threshold", 20, "Allowed slowdown in percent compared to the baseline")
+	projectBenchmarkCmd.Flags().String("baseline", "", "Path to the baseline file (default .shopware-benchmark.json)")
+	projectBenchmarkCmd.Flags().Bool("save-baseline", false, "Saves the results as new baseline instead of comparing")
+	projectBenchmarkCmd.Flags().Bool("clear-cache", false, "Clears the shop cache before, so the first request is cold")
+	projectBenchmarkCmd.Flags().Bool("json", false, "Output as json")
+}
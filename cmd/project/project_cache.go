@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the shop caches",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectCacheCmd)
+}
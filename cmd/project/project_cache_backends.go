@@ -0,0 +1,107 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/redis"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectCacheBackendsCmd = &cobra.Command{
+	Use:   "backends",
+	Short: "Shows the state of the configured redis cache backends",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		_, backends, err := readCacheBackends()
+		if err != nil {
+			return err
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "DSN", "Keys", "Used Memory", "Error"})
+
+		for _, name := range sortedCacheBackendNames(backends) {
+			dsn := backends[name]
+			row := []string{name, redis.MaskDSN(dsn), "", "", ""}
+
+			keys, memory, err := inspectCacheBackend(cmd, dsn)
+			if err != nil {
+				row[4] = err.Error()
+			} else {
+				row[2] = strconv.FormatInt(keys, 10)
+				row[3] = memory
+			}
+
+			table.Append(row)
+		}
+
+		table.Render()
+
+		return nil
+	},
+}
+
+func readCacheBackends() (*shop.Config, map[string]string, error) {
+	cfg, err := shop.ReadConfig(projectConfigPath, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(cfg.CacheBackends) == 0 {
+		return nil, nil, fmt.Errorf("no cache backends configured, add them to cache_backends in .shopware-project.yml")
+	}
+
+	return cfg, cfg.CacheBackends, nil
+}
+
+func sortedCacheBackendNames(backends map[string]string) []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func connectCacheBackend(cmd *cobra.Command, dsn string) (*redis.Client, error) {
+	opts, err := redis.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return redis.Dial(cmd.Context(), opts)
+}
+
+func inspectCacheBackend(cmd *cobra.Command, dsn string) (int64, string, error) {
+	client, err := connectCacheBackend(cmd, dsn)
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	keys, err := client.DBSize()
+	if err != nil {
+		return 0, "", err
+	}
+
+	memory, err := client.UsedMemory()
+	if err != nil {
+		return 0, "", err
+	}
+
+	return keys, memory, nil
+}
+
+func init() {
+	projectCacheCmd.AddCommand(projectCacheBackendsCmd)
+}
@@ -0,0 +1,91 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/internal/redis"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var projectCacheBackendsFlushCmd = &cobra.Command{
+	Use:   "flush [name...]",
+	Short: "Flushes the redis databases of the given cache backends",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, backends, err := readCacheBackends()
+		if err != nil {
+			return err
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+		autoApprove, _ := cmd.Flags().GetBool("auto-approve")
+
+		names := args
+
+		if all {
+			names = sortedCacheBackendNames(backends)
+		}
+
+		if len(names) == 0 {
+			return fmt.Errorf("please specify the backends to flush or use --all, configured are: %s", strings.Join(sortedCacheBackendNames(backends), ", "))
+		}
+
+		for _, name := range names {
+			if _, ok := backends[name]; !ok {
+				return fmt.Errorf("cache backend %s is not configured", name)
+			}
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "flush the cache backends"); err != nil {
+			return err
+		}
+
+		if !autoApprove {
+			if err := interaction.Ensure("confirmation", "use --auto-approve to skip it"); err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				logging.FromContext(cmd.Context()).Infof("%s: %s", name, redis.MaskDSN(backends[name]))
+			}
+
+			p := promptui.Prompt{
+				Label:     "You want to delete all keys of these databases",
+				IsConfirm: true,
+			}
+
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range names {
+			client, err := connectCacheBackend(cmd, backends[name])
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			err = client.FlushDB()
+			_ = client.Close()
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Flushed %s", name)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectCacheBackendsCmd.AddCommand(projectCacheBackendsFlushCmd)
+	projectCacheBackendsFlushCmd.Flags().Bool("all", false, "Flushes all configured backends")
+	projectCacheBackendsFlushCmd.Flags().Bool("auto-approve", false, "Skips the confirmation")
+	addProtectedFlags(projectCacheBackendsFlushCmd.Flags())
+}
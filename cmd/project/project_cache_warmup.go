@@ -0,0 +1,111 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectCacheWarmupCmd = &cobra.Command{
+	Use:   "warmup",
+	Short: "Requests all pages of the sitemap to warm up the HTTP cache",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		sitemapURL, _ := cmd.Flags().GetString("sitemap")
+		urlFile, _ := cmd.Flags().GetString("url-file")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		salesChannelName, _ := cmd.Flags().GetString("sales-channel")
+		baseURL := cfg.URL
+
+		var storeApiClient *shop.StoreApiClient
+
+		if salesChannelName != "" {
+			salesChannel, err := cfg.StoreApiSalesChannel(salesChannelName)
+			if err != nil {
+				return err
+			}
+
+			baseURL = salesChannel.URL
+			storeApiClient = shop.NewStoreApiClient(http.DefaultClient, salesChannel.URL, salesChannel.AccessKey)
+		}
+
+		var urls []string
+
+		if urlFile != "" {
+			if urls, err = shop.ReadURLList(urlFile); err != nil {
+				return err
+			}
+		} else {
+			if sitemapURL, err = resolveShopURL(baseURL, sitemapURL); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Reading sitemap %s", sitemapURL)
+
+			if urls, err = shop.FetchSitemapURLs(cmd.Context(), http.DefaultClient, sitemapURL); err != nil {
+				return err
+			}
+		}
+
+		if len(urls) == 0 {
+			return fmt.Errorf("found no urls to warm up")
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Warming up %d urls with concurrency %d", len(urls), concurrency)
+
+		start := time.Now()
+		failed := 0
+
+		total := len(urls)
+
+		onResult := func(result shop.WarmupResult) {
+			if result.Error != nil {
+				failed++
+				logging.FromContext(cmd.Context()).Warnf("%s: %v", result.URL, result.Error)
+
+				return
+			}
+
+			logging.FromContext(cmd.Context()).Debugf("%s: %d in %s", result.URL, result.StatusCode, result.Duration)
+		}
+
+		shop.WarmupURLs(cmd.Context(), http.DefaultClient, urls, concurrency, onResult)
+
+		if storeApiClient != nil {
+			logging.FromContext(cmd.Context()).Infof("Warming up the store-api of sales channel %s", salesChannelName)
+
+			if err := shop.WarmupStoreApi(cmd.Context(), storeApiClient, func(result shop.WarmupResult) {
+				total++
+				onResult(result)
+			}); err != nil {
+				return err
+			}
+		}
+
+		// a shop which answers no request at all is down or the urls are wrong, single failures are only reported
+		if failed == total {
+			return fmt.Errorf("all %d urls failed to warm up", total)
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Warmed up %d urls in %s, %d failed", total-failed, time.Since(start).Round(time.Millisecond), failed)
+
+		return nil
+	},
+}
+
+func init() {
+	projectCacheCmd.AddCommand(projectCacheWarmupCmd)
+	projectCacheWarmupCmd.Flags().String("sitemap", "/sitemap.xml", "Sitemap url, relative urls are resolved against the shop url")
+	projectCacheWarmupCmd.Flags().String("url-file", "", "File with one url per line, used instead of the sitemap")
+	projectCacheWarmupCmd.Flags().Int("concurrency", 5, "Amount of parallel requests")
+	projectCacheWarmupCmd.Flags().String("sales-channel", "", "Name of the sales channel in store_api.sales_channels, its domain and store-api are warmed up")
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -22,30 +23,55 @@ var projectClearCacheCmd = &cobra.Command{
 			return err
 		}
 
-		if cfg.AdminApi == nil {
-			logging.FromContext(cmd.Context()).Infof("Clearing cache localy")
+		environments := commandEnvironments(cmd, cfg)
 
-			projectRoot, err := findClosestShopwareProject()
+		for _, environment := range environments {
+			envCfg, err := cfg.ForEnvironment(environment)
 			if err != nil {
 				return err
 			}
 
-			return os.RemoveAll(fmt.Sprintf("%s/var/cache", projectRoot))
+			if err := ensureUnprotected(cmd, envCfg, "clear the cache"); err != nil {
+				return err
+			}
 		}
 
-		logging.FromContext(cmd.Context()).Infof("Clearing cache using admin-api")
+		return runForCommandEnvironments(cmd, cfg, environments, clearShopCache)
+	},
+}
+
+// clearShopCache clears the cache with the Admin API. Only the default shop without Admin API is the local checkout, the
+// cache of another environment can't be cleared without credentials.
+func clearShopCache(ctx context.Context, environment string, cfg *shop.Config) error {
+	if cfg.AdminApi == nil {
+		if environment != "" && environment != shop.DefaultEnvironment {
+			return fmt.Errorf("environment %s has no admin_api configured", environment)
+		}
 
-		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		logging.FromContext(ctx).Infof("Clearing cache localy")
+
+		projectRoot, err := findClosestShopwareProject()
 		if err != nil {
 			return err
 		}
 
-		_, err = client.CacheManager.Clear(adminSdk.NewApiContext(cmd.Context()))
+		return os.RemoveAll(fmt.Sprintf("%s/var/cache", projectRoot))
+	}
+
+	logging.FromContext(ctx).Infof("Clearing cache using admin-api")
 
+	client, err := shop.NewShopClient(ctx, cfg)
+	if err != nil {
 		return err
-	},
+	}
+
+	_, err = client.CacheManager.Clear(adminSdk.NewApiContext(ctx))
+
+	return err
 }
 
 func init() {
 	projectRootCmd.AddCommand(projectClearCacheCmd)
+	addProtectedFlags(projectClearCacheCmd.Flags())
+	addAllEnvsFlags(projectClearCacheCmd.Flags())
 }
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectCloudCmd = &cobra.Command{
+	Use:   "cloud",
+	Short: "Deploy apps to Shopware SaaS environments",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectCloudCmd)
+}
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+type cloudEnvironment struct {
+	Name            string `json:"name"`
+	URL             string `json:"url"`
+	ShopwareVersion string `json:"shopwareVersion"`
+	Cloud           bool   `json:"cloud"`
+	Error           string `json:"error,omitempty"`
+}
+
+var projectCloudEnvironmentsCmd = &cobra.Command{
+	Use:   "environments",
+	Short: "Lists the configured environments with their Shopware version",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		names := []string{""}
+		for name := range cfg.Environments {
+			names = append(names, name)
+		}
+
+		sort.Strings(names[1:])
+
+		environments := make([]cloudEnvironment, 0, len(names))
+
+		for _, name := range names {
+			envCfg, err := cfg.ForEnvironment(name)
+			if err != nil {
+				return err
+			}
+
+			environment := cloudEnvironment{Name: environmentLabel(name), URL: envCfg.URL}
+
+			if err := fetchCloudEnvironmentInfo(cmd, envCfg, &environment); err != nil {
+				environment.Error = err.Error()
+			}
+
+			environments = append(environments, environment)
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(environments)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "URL", "Shopware Version", "Cloud", "Error"})
+
+		for _, environment := range environments {
+			table.Append([]string{environment.Name, environment.URL, environment.ShopwareVersion, strconv.FormatBool(environment.Cloud), environment.Error})
+		}
+
+		table.Render()
+
+		return nil
+	},
+}
+
+func fetchCloudEnvironmentInfo(cmd *cobra.Command, cfg *shop.Config, environment *cloudEnvironment) error {
+	client, err := shop.NewShopClient(cmd.Context(), cfg)
+	if err != nil {
+		return err
+	}
+
+	info, _, err := client.Info.Info(adminSdk.NewApiContext(cmd.Context()))
+	if err != nil {
+		return err
+	}
+
+	environment.ShopwareVersion = info.Version
+	environment.Cloud = info.IsCloudShop()
+
+	return nil
+}
+
+func init() {
+	projectCloudCmd.AddCommand(projectCloudEnvironmentsCmd)
+	projectCloudEnvironmentsCmd.Flags().Bool("json", false, "Output as json")
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"fmt"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectCloudRolloutCmd = &cobra.Command{
+	Use:   "rollout [path]",
+	Short: "Uploads and updates an app in the given environments one after another",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		environments, _ := cmd.Flags().GetStringSlice("environment")
+		ignoreCompatibility, _ := cmd.Flags().GetBool("ignore-compatibility")
+
+		if len(environments) == 0 {
+			return fmt.Errorf("please specify the environments to rollout with --environment, f.e. --environment staging --environment production")
+		}
+
+		ext, cleanup, err := loadExtensionForUpload(cmd.Context(), args[0], false)
+		defer cleanup()
+
+		if err != nil {
+			return err
+		}
+
+		name, err := ext.GetName()
+		if err != nil {
+			return err
+		}
+
+		extVersion, err := ext.GetVersion()
+		if err != nil {
+			return err
+		}
+
+		// Validate all environments first, so a typo does not stop the rollout halfway
+		envConfigs := make([]*shop.Config, 0, len(environments))
+
+		for _, environment := range environments {
+			envCfg, err := cfg.ForEnvironment(environment)
+			if err != nil {
+				return err
+			}
+
+			if err := ensureUnprotected(cmd, envCfg, "rollout to "+environment); err != nil {
+				return err
+			}
+
+			envConfigs = append(envConfigs, envCfg)
+		}
+
+		for i, envCfg := range envConfigs {
+			logging.FromContext(cmd.Context()).Infof("Rolling out %s %s to %s", name, extVersion.String(), environments[i])
+
+			client, err := shop.NewShopClient(cmd.Context(), envCfg)
+			if err != nil {
+				return fmt.Errorf("%s: %w", environments[i], err)
+			}
+
+			adminCtx := adminSdk.NewApiContext(cmd.Context())
+
+			if err := uploadExtensionToShop(adminCtx, client, ext, true, ignoreCompatibility); err != nil {
+				return fmt.Errorf("%s: %w", environments[i], err)
+			}
+
+			extensions, _, err := client.ExtensionManager.ListAvailableExtensions(adminCtx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", environments[i], err)
+			}
+
+			remoteExtension := extensions.GetByName(name)
+
+			if remoteExtension == nil || remoteExtension.Version != extVersion.String() {
+				return fmt.Errorf("%s: rollout stopped, %s is not running version %s after the update", environments[i], name, extVersion.String())
+			}
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Rolled out %s %s to all environments", name, extVersion.String())
+
+		return nil
+	},
+}
+
+func init() {
+	projectCloudCmd.AddCommand(projectCloudRolloutCmd)
+	projectCloudRolloutCmd.Flags().StringSlice("environment", []string{}, "Environments to rollout to in the given order, use default for the main shop")
+	projectCloudRolloutCmd.Flags().Bool("ignore-compatibility", false, "Uploads the extension even when it requires another Shopware or PHP version than the shop")
+	addProtectedFlags(projectCloudRolloutCmd.Flags())
+}
@@ -0,0 +1,231 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectConfigCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compares the shop configuration of two environments",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		var cfg *shop.Config
+		var err error
+
+		if cfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
+			return err
+		}
+
+		fromName, _ := cmd.Flags().GetString("from")
+		toName, _ := cmd.Flags().GetString("to")
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		if fromName == toName {
+			return fmt.Errorf("--from and --to must be different environments")
+		}
+
+		from, err := pullConfigForCompare(cmd.Context(), cfg, fromName)
+		if err != nil {
+			return fmt.Errorf("cannot pull config of %s: %w", environmentLabel(fromName), err)
+		}
+
+		to, err := pullConfigForCompare(cmd.Context(), cfg, toName)
+		if err != nil {
+			return fmt.Errorf("cannot pull config of %s: %w", environmentLabel(toName), err)
+		}
+
+		differences := shop.CompareSyncConfig(from, to)
+
+		if outputAsJson {
+			content, err := json.Marshal(differences)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+
+			return nil
+		}
+
+		if len(differences) == 0 {
+			logging.FromContext(cmd.Context()).Infof("No differences between %s and %s", environmentLabel(fromName), environmentLabel(toName))
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Key", "Change", environmentLabel(fromName), environmentLabel(toName)})
+		table.SetColWidth(60)
+
+		for _, difference := range differences {
+			table.Append([]string{difference.Key, difference.Type, formatCompareValue(difference.From), formatCompareValue(difference.To)})
+		}
+
+		table.Render()
+
+		return nil
+	},
+}
+
+func environmentLabel(name string) string {
+	if name == "" {
+		return shop.DefaultEnvironment
+	}
+
+	return name
+}
+
+func formatCompareValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(content)
+}
+
+func pullConfigForCompare(ctx context.Context, cfg *shop.Config, environment string) (*shop.ConfigSync, error) {
+	envCfg, err := cfg.ForEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := shop.NewShopClient(ctx, envCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	apiCtx := adminSdk.NewApiContext(ctx)
+
+	// Pull into a copy to keep the local sync config untouched
+	pulled := *envCfg
+	pulled.Sync = &shop.ConfigSync{}
+
+	for _, applyer := range []ConfigSyncApplyer{SystemConfigSync{}, ThemeSync{}} {
+		if err := applyer.Pull(apiCtx, client, &pulled); err != nil {
+			return nil, err
+		}
+	}
+
+	if pulled.Sync.MailTemplate, err = fetchMailTemplatesForCompare(apiCtx, client); err != nil {
+		return nil, err
+	}
+
+	if cfg.Sync != nil {
+		if pulled.Sync.Entity, err = fetchEntitiesForCompare(apiCtx, client, cfg.Sync.Entity); err != nil {
+			return nil, err
+		}
+	}
+
+	return pulled.Sync, nil
+}
+
+// fetchMailTemplatesForCompare returns the mail templates with the content inline instead of writing them into files like config pull.
+func fetchMailTemplatesForCompare(ctx adminSdk.ApiContext, client *adminSdk.Client) ([]shop.MailTemplate, error) {
+	mailTemplates, err := fetchAllMailTemplates(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]shop.MailTemplate, 0)
+
+	for _, row := range mailTemplates.Data {
+		if row.MailTemplateType == nil {
+			continue
+		}
+
+		template := shop.MailTemplate{Id: row.MailTemplateType.TechnicalName}
+
+		for _, translation := range row.Translations {
+			if translation.Language == nil {
+				continue
+			}
+
+			template.Translations = append(template.Translations, shop.MailTemplateTranslation{
+				Language:   translation.Language.Name,
+				SenderName: translation.SenderName,
+				Subject:    translation.Subject,
+				HTML:       translation.ContentHtml,
+				Plain:      translation.ContentPlain,
+			})
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// fetchEntitiesForCompare loads the current values of all entities configured in sync.entity which have an exists filter.
+func fetchEntitiesForCompare(ctx adminSdk.ApiContext, client *adminSdk.Client, entities []shop.EntitySync) ([]shop.EntitySync, error) {
+	result := make([]shop.EntitySync, 0, len(entities))
+
+	for _, entity := range entities {
+		current := shop.EntitySync{Entity: entity.Entity, Payload: map[string]interface{}{}}
+
+		if entity.Exists == nil || len(*entity.Exists) == 0 {
+			result = append(result, current)
+			continue
+		}
+
+		searchPayload, err := json.Marshal(map[string]interface{}{"filter": entity.Exists, "limit": 1})
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := client.NewRequest(ctx, "POST", fmt.Sprintf("/api/search/%s", entity.Entity), bytes.NewReader(searchPayload))
+		if err != nil {
+			return nil, err
+		}
+
+		r.Header.Set("Accept", "application/json")
+		r.Header.Set("Content-Type", "application/json")
+
+		var res struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+
+		resp, err := client.Do(ctx.Context, r, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := resp.Body.Close(); err != nil {
+			return nil, err
+		}
+
+		if len(res.Data) > 0 {
+			for key := range entity.Payload {
+				current.Payload[key] = res.Data[0][key]
+			}
+		}
+
+		result = append(result, current)
+	}
+
+	return result, nil
+}
+
+func init() {
+	projectConfigCmd.AddCommand(projectConfigCompareCmd)
+	projectConfigCompareCmd.Flags().String("from", "", "Environment to compare from, the default shop is used when empty")
+	projectConfigCompareCmd.Flags().String("to", "", "Environment to compare to, the default shop is used when empty")
+	projectConfigCompareCmd.Flags().Bool("json", false, "Output as json")
+}
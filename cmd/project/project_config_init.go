@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/shop"
 )
@@ -20,6 +21,10 @@ var projectConfigInitCmd = &cobra.Command{
 		var content []byte
 		var err error
 
+		if err := interaction.Ensure("Shop-URL", "create the .shopware-project.yml manually or use SHOPWARE_CLI_PROJECT__* environment variables"); err != nil {
+			return err
+		}
+
 		urlPrompt := promptui.Prompt{
 			Label:    "Shop-URL example: http://localhost",
 			Validate: emptyValidator,
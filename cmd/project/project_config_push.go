@@ -1,12 +1,15 @@
 package project
 
 import (
+	"context"
 	"encoding/json"
+	"sync"
 
 	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/shop"
 )
@@ -15,125 +18,197 @@ var projectConfigPushCmd = &cobra.Command{
 	Use:   "push",
 	Short: "Synchronizes your local config to the external shop",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		logFormat := "Payload: %s"
-
 		var cfg *shop.Config
 		var err error
 
-		apiCtx := adminSdk.NewApiContext(cmd.Context())
-
 		autoApprove, _ := cmd.PersistentFlags().GetBool("auto-approve")
 
 		if cfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
 			return err
 		}
 
-		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		environments := commandEnvironments(cmd, cfg)
+
+		for _, environment := range environments {
+			envCfg, err := cfg.ForEnvironment(environment)
+			if err != nil {
+				return err
+			}
+
+			if err := ensureUnprotected(cmd, envCfg, "push the config"); err != nil {
+				return err
+			}
+		}
+
+		var mu sync.Mutex
+		operations := make(map[string]*ConfigSyncOperation)
+
+		err = runForCommandEnvironments(cmd, cfg, environments, func(ctx context.Context, name string, envCfg *shop.Config) error {
+			operation, err := buildConfigSyncOperation(ctx, envCfg)
+			if err != nil {
+				return err
+			}
+
+			if !operation.HasChanges() {
+				logging.FromContext(ctx).Infof("Configuration is up to date")
+				return nil
+			}
+
+			logConfigSyncOperation(ctx, operation)
+
+			mu.Lock()
+			operations[name] = operation
+			mu.Unlock()
+
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		operation := &ConfigSyncOperation{
-			Operations:     map[string]adminSdk.SyncOperation{},
-			SystemSettings: map[*string]map[string]interface{}{},
-			ThemeSettings:  []ThemeSyncOperation{},
+		if len(operations) == 0 {
+			return nil
 		}
 
-		if cfg.Sync != nil {
-			for _, applyer := range NewSyncApplyers() {
-				if err := applyer.Push(apiCtx, client, cfg, operation); err != nil {
-					return err
-				}
+		if !autoApprove {
+			if err := interaction.Ensure("confirmation", "use --auto-approve to skip it"); err != nil {
+				return err
+			}
+
+			p := promptui.Prompt{
+				Label:     "You want to apply these changes to your Shop?",
+				IsConfirm: true,
+			}
+
+			if _, err := p.Run(); err != nil {
+				return err
 			}
 		}
 
-		if !operation.HasChanges() {
-			logging.FromContext(cmd.Context()).Infof("Configuration is up to date")
-			return nil
+		changedEnvironments := make([]string, 0, len(operations))
+		for _, environment := range environments {
+			if _, ok := operations[environment]; ok {
+				changedEnvironments = append(changedEnvironments, environment)
+			}
 		}
 
-		if operation.Operations.HasChanges() {
-			logging.FromContext(cmd.Context()).Infof("Following entities will be written")
+		return runForCommandEnvironments(cmd, cfg, changedEnvironments, func(ctx context.Context, name string, envCfg *shop.Config) error {
+			if err := applyConfigSyncOperation(ctx, envCfg, operations[name]); err != nil {
+				return err
+			}
 
-			for _, values := range operation.Operations {
-				logging.FromContext(cmd.Context()).Infof("Action: %s, Entity: %s", values.Action, values.Entity)
+			logging.FromContext(ctx).Infof("Configuration has been applied to remote")
 
-				content, _ := json.Marshal(values.Payload)
+			return nil
+		})
+	},
+}
 
-				logging.FromContext(cmd.Context()).Infof(logFormat, string(content))
+func buildConfigSyncOperation(ctx context.Context, cfg *shop.Config) (*ConfigSyncOperation, error) {
+	client, err := shop.NewShopClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := &ConfigSyncOperation{
+		Operations:     map[string]adminSdk.SyncOperation{},
+		SystemSettings: map[*string]map[string]interface{}{},
+		ThemeSettings:  []ThemeSyncOperation{},
+	}
+
+	if cfg.Sync != nil {
+		for _, applyer := range NewSyncApplyers() {
+			if err := applyer.Push(adminSdk.NewApiContext(ctx), client, cfg, operation); err != nil {
+				return nil, err
 			}
 		}
+	}
 
-		if operation.SystemSettings.HasChanges() {
-			logging.FromContext(cmd.Context()).Infof("Following system_config changes will be applied")
-
-			for key, values := range operation.SystemSettings {
-				if len(values) == 0 {
-					continue
-				}
+	return operation, nil
+}
 
-				var k string
+func logConfigSyncOperation(ctx context.Context, operation *ConfigSyncOperation) {
+	logFormat := "Payload: %s"
 
-				if key == nil {
-					k = "default"
-				} else {
-					k = *key
-				}
+	if operation.Operations.HasChanges() {
+		logging.FromContext(ctx).Infof("Following entities will be written")
 
-				logging.FromContext(cmd.Context()).Infof("Sales-Channel: %s", k)
+		for _, values := range operation.Operations {
+			logging.FromContext(ctx).Infof("Action: %s, Entity: %s", values.Action, values.Entity)
 
-				content, _ := json.Marshal(values)
+			content, _ := json.Marshal(values.Payload)
 
-				logging.FromContext(cmd.Context()).Infof(logFormat, string(content))
-			}
+			logging.FromContext(ctx).Infof(logFormat, string(content))
 		}
+	}
+
+	if operation.SystemSettings.HasChanges() {
+		logging.FromContext(ctx).Infof("Following system_config changes will be applied")
 
-		if operation.ThemeSettings.HasChanges() {
-			for _, themeOp := range operation.ThemeSettings {
-				logging.FromContext(cmd.Context()).Infof("Updating theme: %s", themeOp.Name)
+		for key, values := range operation.SystemSettings {
+			if len(values) == 0 {
+				continue
+			}
 
-				content, _ := json.Marshal(themeOp.Settings)
+			var k string
 
-				logging.FromContext(cmd.Context()).Infof(logFormat, string(content))
+			if key == nil {
+				k = "default"
+			} else {
+				k = *key
 			}
+
+			logging.FromContext(ctx).Infof("Sales-Channel: %s", k)
+
+			content, _ := json.Marshal(values)
+
+			logging.FromContext(ctx).Infof(logFormat, string(content))
 		}
+	}
 
-		if !autoApprove {
-			p := promptui.Prompt{
-				Label:     "You want to apply these changes to your Shop?",
-				IsConfirm: true,
-			}
+	if operation.ThemeSettings.HasChanges() {
+		for _, themeOp := range operation.ThemeSettings {
+			logging.FromContext(ctx).Infof("Updating theme: %s", themeOp.Name)
 
-			if _, err := p.Run(); err != nil {
-				return err
-			}
+			content, _ := json.Marshal(themeOp.Settings)
+
+			logging.FromContext(ctx).Infof(logFormat, string(content))
 		}
+	}
+}
 
-		if _, err := client.Bulk.Sync(apiCtx, operation.Operations); err != nil {
+func applyConfigSyncOperation(ctx context.Context, cfg *shop.Config, operation *ConfigSyncOperation) error {
+	client, err := shop.NewShopClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	apiCtx := adminSdk.NewApiContext(ctx)
+
+	if _, err := client.Bulk.Sync(apiCtx, operation.Operations); err != nil {
+		return err
+	}
+
+	if operation.SystemSettings.HasChanges() {
+		if _, err := client.SystemConfigManager.UpdateConfig(apiCtx, operation.SystemSettings.ToJson()); err != nil {
 			return err
 		}
+	}
 
-		if operation.SystemSettings.HasChanges() {
-			if _, err := client.SystemConfigManager.UpdateConfig(apiCtx, operation.SystemSettings.ToJson()); err != nil {
+	if operation.ThemeSettings.HasChanges() {
+		for _, themeOp := range operation.ThemeSettings {
+			if _, err := client.ThemeManager.UpdateConfiguration(apiCtx, themeOp.Id, adminSdk.ThemeUpdateRequest{Config: themeOp.Settings}); err != nil {
 				return err
 			}
 		}
+	}
 
-		if operation.ThemeSettings.HasChanges() {
-			for _, themeOp := range operation.ThemeSettings {
-				if _, err := client.ThemeManager.UpdateConfiguration(apiCtx, themeOp.Id, adminSdk.ThemeUpdateRequest{Config: themeOp.Settings}); err != nil {
-					return err
-				}
-			}
-		}
-
-		logging.FromContext(cmd.Context()).Infof("Configuration has been applied to remote")
-
-		return nil
-	},
+	return nil
 }
 
 func init() {
 	projectConfigCmd.AddCommand(projectConfigPushCmd)
 	projectConfigPushCmd.PersistentFlags().Bool("auto-approve", false, "Skips the confirmation")
+	addProtectedFlags(projectConfigPushCmd.Flags())
+	addAllEnvsFlags(projectConfigPushCmd.Flags())
 }
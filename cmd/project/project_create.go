@@ -3,13 +3,9 @@ package project
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
-	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
@@ -17,6 +13,9 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/version"
 )
@@ -45,6 +44,12 @@ var projectCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectFolder := args[0]
 
+		if len(args) < 2 {
+			if err := interaction.Ensure("Select Version", "pass the version as second argument"); err != nil {
+				return err
+			}
+		}
+
 		if _, err := os.Stat(projectFolder); err == nil {
 			return fmt.Errorf("the folder %s exists already", projectFolder)
 		}
@@ -116,13 +121,13 @@ var projectCreateCmd = &cobra.Command{
 			return err
 		}
 
-		if err := os.WriteFile(path.Join(projectFolder, "php.ini"), []byte("memory_limit=512M"), os.ModePerm); err != nil {
+		if err := os.WriteFile(filepath.Join(projectFolder, "php.ini"), []byte("memory_limit=512M"), os.ModePerm); err != nil {
 			return err
 		}
 
 		logging.FromContext(cmd.Context()).Infof("Installing dependencies")
 
-		cmdInstall := exec.Command("composer", "install")
+		cmdInstall := process.Command(cmd.Context(), "composer", "install")
 		cmdInstall.Dir = projectFolder
 		cmdInstall.Stdin = os.Stdin
 		cmdInstall.Stdout = os.Stdout
@@ -133,7 +138,7 @@ var projectCreateCmd = &cobra.Command{
 }
 
 func getFilteredInstallVersions(ctx context.Context) ([]*version.Version, error) {
-	releases, err := fetchAvailableShopwareVersions(ctx)
+	releases, err := staticdata.ShopwareReleases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -158,37 +163,6 @@ func init() {
 	projectRootCmd.AddCommand(projectCreateCmd)
 }
 
-func fetchAvailableShopwareVersions(ctx context.Context) ([]string, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://releases.shopware.com/changelog/index.json", http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logging.FromContext(ctx).Errorf("fetchAvailableShopwareVersions: %v", err)
-		}
-	}()
-
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var releases []string
-
-	if err := json.Unmarshal(content, &releases); err != nil {
-		return nil, err
-	}
-
-	return releases, nil
-}
-
 func generateComposerJson(version string, rc bool) (string, error) {
 	tplContent, err := template.New("composer.json").Parse(`{
     "name": "shopware/production",
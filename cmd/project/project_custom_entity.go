@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectCustomEntityCmd = &cobra.Command{
+	Use:   "custom-entity",
+	Short: "Work with the custom entities of the extensions",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectCustomEntityCmd)
+}
@@ -0,0 +1,157 @@
+package project
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/doutorfinancas/go-mad/database"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+type customEntityDiffResult struct {
+	Extension string `json:"extension"`
+	extension.CustomEntitySchemaDiff
+}
+
+var projectCustomEntityDiffCmd = &cobra.Command{
+	Use:   "diff [database]",
+	Short: "Compares the entities.xml of the extensions with the tables of the database",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetString("port")
+		username, _ := cmd.Flags().GetString("username")
+		password, _ := cmd.Flags().GetString("password")
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		projectRoot, err := findClosestShopwareProject()
+		if err != nil {
+			return err
+		}
+
+		tablesByExtension := make(map[string][]extension.CustomEntityTable)
+		tableNames := make([]string, 0)
+		invalid := false
+
+		for _, ext := range extension.FindExtensionsFromProject(cmd.Context(), projectRoot) {
+			name, err := ext.GetName()
+			if err != nil {
+				return err
+			}
+
+			entities, err := extension.ReadCustomEntities(ext)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			if entities == nil {
+				continue
+			}
+
+			for _, problem := range entities.Validate() {
+				invalid = true
+				logging.FromContext(cmd.Context()).Errorf("%s: %s", name, problem)
+			}
+
+			tablesByExtension[name] = entities.Tables()
+
+			for _, table := range tablesByExtension[name] {
+				tableNames = append(tableNames, table.Name)
+			}
+		}
+
+		if invalid {
+			return fmt.Errorf("the entities.xml of the extensions are invalid")
+		}
+
+		if len(tableNames) == 0 {
+			logging.FromContext(cmd.Context()).Infof("No extension declares custom entities")
+			return nil
+		}
+
+		dbCfg := database.NewConfig(username, password, host, port, args[0])
+
+		db, err := sql.Open("mysql", dbCfg.ConnectionString())
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = db.Close()
+		}()
+
+		columns, err := shop.ReadTableColumns(cmd.Context(), db, tableNames)
+		if err != nil {
+			return err
+		}
+
+		extensionNames := make([]string, 0, len(tablesByExtension))
+		for name := range tablesByExtension {
+			extensionNames = append(extensionNames, name)
+		}
+
+		sort.Strings(extensionNames)
+
+		results := make([]customEntityDiffResult, 0)
+		outdated := false
+
+		for _, name := range extensionNames {
+			for _, diff := range extension.DiffCustomEntitySchema(tablesByExtension[name], columns) {
+				results = append(results, customEntityDiffResult{Extension: name, CustomEntitySchemaDiff: diff})
+
+				if diff.Missing || len(diff.MissingColumns) > 0 {
+					outdated = true
+				}
+			}
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		} else if len(results) > 0 {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Extension", "Table", "Missing Columns", "Undeclared Columns"})
+
+			for _, result := range results {
+				missing := strings.Join(result.MissingColumns, ", ")
+				if result.Missing {
+					missing = "table is missing"
+				}
+
+				table.Append([]string{result.Extension, result.Table, missing, strings.Join(result.Undeclared, ", ")})
+			}
+
+			table.Render()
+		}
+
+		if outdated {
+			return fmt.Errorf("the database schema is behind the entities.xml, update the extensions with bin/console plugin:update or app:refresh")
+		}
+
+		logging.FromContext(cmd.Context()).Infof("The database schema matches the entities.xml of the extensions")
+
+		return nil
+	},
+}
+
+func init() {
+	projectCustomEntityCmd.AddCommand(projectCustomEntityDiffCmd)
+	projectCustomEntityDiffCmd.Flags().String("host", "127.0.0.1", "hostname")
+	projectCustomEntityDiffCmd.Flags().String("username", "root", "mysql user")
+	projectCustomEntityDiffCmd.Flags().String("password", "root", "mysql password")
+	projectCustomEntityDiffCmd.Flags().String("port", "3306", "mysql port")
+	projectCustomEntityDiffCmd.Flags().Bool("json", false, "Output as json")
+}
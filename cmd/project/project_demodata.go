@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectDemodataCmd = &cobra.Command{
+	Use:   "demodata",
+	Short: "Creates test data on a development shop using the Admin API",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectDemodataCmd)
+}
@@ -0,0 +1,66 @@
+package project
+
+import (
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectDemodataCustomersCmd = &cobra.Command{
+	Use:   "customers",
+	Short: "Creates storefront test customers with a known password",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		password, _ := cmd.Flags().GetString("password")
+		domain, _ := cmd.Flags().GetString("email-domain")
+		salesChannel, _ := cmd.Flags().GetString("sales-channel")
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "create demo customers"); err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+		// the registration flows would send mails to the test customers otherwise
+		apiCtx.SkipFlows = true
+
+		customers, err := shop.CreateDemodataCustomers(apiCtx, client, shop.DemodataCustomerOptions{
+			SalesChannelID: salesChannel,
+			EmailDomain:    domain,
+			Password:       password,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, customer := range customers {
+			if customer.Guest {
+				logging.FromContext(cmd.Context()).Infof("Created guest customer %s", customer.Email)
+
+				continue
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Created customer %s with password %s", customer.Email, password)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectDemodataCmd.AddCommand(projectDemodataCustomersCmd)
+	projectDemodataCustomersCmd.Flags().String("password", "shopware", "Password of the customers")
+	projectDemodataCustomersCmd.Flags().String("email-domain", "example.com", "Domain of the customer emails")
+	projectDemodataCustomersCmd.Flags().String("sales-channel", "", "Sales channel id, defaults to the first active storefront")
+	addProtectedFlags(projectDemodataCustomersCmd.Flags())
+}
@@ -0,0 +1,83 @@
+package project
+
+import (
+	"fmt"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectDemodataOrdersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Creates orders in combinations of the order, delivery and transaction states",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		states, _ := cmd.Flags().GetString("states")
+		customer, _ := cmd.Flags().GetString("customer")
+		product, _ := cmd.Flags().GetString("product")
+		salesChannel, _ := cmd.Flags().GetString("sales-channel")
+		withFlows, _ := cmd.Flags().GetBool("with-flows")
+
+		combinations, err := shop.ParseOrderStateCombinations(states)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "create demo orders"); err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+		// the state changes would send mails to the customer otherwise
+		apiCtx.SkipFlows = !withFlows
+
+		opts, err := shop.ResolveDemodataOrderOptions(apiCtx, client, customer, product, salesChannel)
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Creating %d orders", len(combinations))
+
+		failed := 0
+
+		for _, combination := range combinations {
+			order, err := shop.CreateDemodataOrder(apiCtx, client, *opts, combination)
+			if err != nil {
+				failed++
+				logging.FromContext(cmd.Context()).Warnf("%s: %v", combination, err)
+
+				continue
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Created order %s with states %s", order.OrderNumber, combination)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d orders could not be created", failed, len(combinations))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectDemodataCmd.AddCommand(projectDemodataOrdersCmd)
+	projectDemodataOrdersCmd.Flags().String("states", "all", "all or a comma separated list of order:delivery:transaction states like completed:shipped:paid")
+	projectDemodataOrdersCmd.Flags().String("customer", "", "Email of the customer, defaults to the first active customer")
+	projectDemodataOrdersCmd.Flags().String("product", "", "Product number to order, defaults to the first active product")
+	projectDemodataOrdersCmd.Flags().String("sales-channel", "", "Sales channel id, defaults to the first active storefront")
+	projectDemodataOrdersCmd.Flags().Bool("with-flows", false, "Run the flows of the state changes, e.g. to test mails")
+	addProtectedFlags(projectDemodataOrdersCmd.Flags())
+}
@@ -46,117 +46,13 @@ var projectDatabaseDumpCmd = &cobra.Command{
 			return err
 		}
 
-		service := generator.NewService()
-		var opt []database.Option
-		opt = append(opt, database.OptionValue("hex-encode", "1"))
-		opt = append(opt, database.OptionValue("set-charset", "utf8mb4"))
-		opt = append(opt, database.OptionValue("dump-trigger", ""))
-		opt = append(opt, database.OptionValue("skip-definer", ""))
-		opt = append(opt, database.OptionValue("trigger-delimiter", "//"))
-
-		if skipLockTables {
-			opt = append(opt, database.OptionValue("skip-lock-tables", "1"))
-		}
-
-		logger, _ := zap.NewProduction()
-		dumper, err := database.NewMySQLDumper(db, logger, service, opt...)
+		dumper, err := newDatabaseDumper(db, skipLockTables)
 		if err != nil {
 			return err
 		}
 
-		pConf := core.Rules{Ignore: []string{}, NoData: []string{}, Where: map[string]string{}, Rewrite: map[string]core.Rewrite{}}
-
-		if clean {
-			pConf.NoData = append(pConf.NoData, "cart", "customer_recovery", "dead_message", "enqueue", "increment", "elasticsearch_index_task", "log_entry", "message_queue_stats", "notification", "payment_token", "refresh_token", "version", "version_commit", "version_commit_data", "webhook_event_log")
-		}
-
-		if anonymize {
-			pConf.Rewrite = map[string]core.Rewrite{
-				"customer": map[string]string{
-					"first_name":     "faker.Person.FirstName()",
-					"last_name":      "faker.Person.LastName()",
-					"company":        "faker.Person.Name()",
-					"title":          "faker.Person.Name()",
-					"email":          "faker.Internet.Email()",
-					"remote_address": "faker.Internet.Ipv4()",
-				},
-				"customer_address": map[string]string{
-					"first_name":   "faker.Person.FirstName()",
-					"last_name":    "faker.Person.LastName()",
-					"company":      "faker.Person.Name()",
-					"title":        "faker.Person.Name()",
-					"street":       "faker.Address.StreetAddress()",
-					"zipcode":      "faker.Address.PostCode()",
-					"city":         "faker.Address.City()",
-					"phone_number": "faker.Phone.Number()",
-				},
-				"log_entry": map[string]string{
-					"provider": "",
-				},
-				"newsletter_recipient": map[string]string{
-					"email":      "faker.Internet.Email()",
-					"first_name": "faker.Person.FirstName()",
-					"last_name":  "faker.Person.LastName()",
-					"city":       "faker.Address.City()",
-				},
-				"order_address": map[string]string{
-					"first_name":   "faker.Person.FirstName()",
-					"last_name":    "faker.Person.LastName()",
-					"company":      "faker.Person.Name()",
-					"title":        "faker.Person.Name()",
-					"street":       "faker.Address.StreetAddress()",
-					"zipcode":      "faker.Address.PostCode()",
-					"city":         "faker.Address.City()",
-					"phone_number": "faker.Phone.Number()",
-				},
-				"order_customer": map[string]string{
-					"first_name":     "faker.Person.FirstName()",
-					"last_name":      "faker.Person.LastName()",
-					"company":        "faker.Person.Name()",
-					"title":          "faker.Person.Name()",
-					"email":          "faker.Internet.Email()",
-					"remote_address": "faker.Internet.Ipv4()",
-				},
-				"product_review": map[string]string{
-					"email": "faker.Internet.Email()",
-				},
-				"user": map[string]string{
-					"username":   "faker.Person.Name()",
-					"first_name": "faker.Person.FirstName()",
-					"last_name":  "faker.Person.LastName()",
-					"email":      "faker.Internet.Email()",
-				},
-			}
-		}
-
-		var projectCfg *shop.Config
-		if projectCfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
-			if !strings.Contains(err.Error(), "cannot find .shopware-project.yml") {
-				return err
-			}
-		}
-
-		if projectCfg != nil && projectCfg.ConfigDump != nil {
-			pConf.NoData = append(pConf.NoData, projectCfg.ConfigDump.NoData...)
-			pConf.Ignore = append(pConf.Ignore, projectCfg.ConfigDump.Ignore...)
-			for table, rewrites := range projectCfg.ConfigDump.Rewrite {
-				_, ok := pConf.Rewrite[table]
-
-				if !ok {
-					pConf.Rewrite[table] = rewrites
-				} else {
-					for k, v := range rewrites {
-						pConf.Rewrite[table][k] = v
-					}
-				}
-			}
-			pConf.Where = projectCfg.ConfigDump.Where
-		}
-
-		dumper.SetSelectMap(pConf.RewriteToMap())
-		dumper.SetWhereMap(pConf.Where)
-		if dErr := dumper.SetFilterMap(pConf.NoData, pConf.Ignore); dErr != nil {
-			return dErr
+		if err := applyDumpRules(dumper, clean, anonymize); err != nil {
+			return err
 		}
 
 		if gzipEnabled {
@@ -200,6 +96,122 @@ var projectDatabaseDumpCmd = &cobra.Command{
 	},
 }
 
+// newDatabaseDumper creates a dumper writing the triggers and binary data in a format the mysql client can import.
+func newDatabaseDumper(db *sql.DB, skipLockTables bool) (database.MySQL, error) {
+	service := generator.NewService()
+	var opt []database.Option
+	opt = append(opt, database.OptionValue("hex-encode", "1"))
+	opt = append(opt, database.OptionValue("set-charset", "utf8mb4"))
+	opt = append(opt, database.OptionValue("dump-trigger", ""))
+	opt = append(opt, database.OptionValue("skip-definer", ""))
+	opt = append(opt, database.OptionValue("trigger-delimiter", "//"))
+
+	if skipLockTables {
+		opt = append(opt, database.OptionValue("skip-lock-tables", "1"))
+	}
+
+	logger, _ := zap.NewProduction()
+
+	return database.NewMySQLDumper(db, logger, service, opt...)
+}
+
+// applyDumpRules configures the cleaned tables, the anonymization and the dump section of the project config.
+func applyDumpRules(dumper database.MySQL, clean, anonymize bool) error {
+	pConf := core.Rules{Ignore: []string{}, NoData: []string{}, Where: map[string]string{}, Rewrite: map[string]core.Rewrite{}}
+
+	if clean {
+		pConf.NoData = append(pConf.NoData, "cart", "customer_recovery", "dead_message", "enqueue", "increment", "elasticsearch_index_task", "log_entry", "message_queue_stats", "notification", "payment_token", "refresh_token", "version", "version_commit", "version_commit_data", "webhook_event_log")
+	}
+
+	if anonymize {
+		pConf.Rewrite = map[string]core.Rewrite{
+			"customer": map[string]string{
+				"first_name":     "faker.Person.FirstName()",
+				"last_name":      "faker.Person.LastName()",
+				"company":        "faker.Person.Name()",
+				"title":          "faker.Person.Name()",
+				"email":          "faker.Internet.Email()",
+				"remote_address": "faker.Internet.Ipv4()",
+			},
+			"customer_address": map[string]string{
+				"first_name":   "faker.Person.FirstName()",
+				"last_name":    "faker.Person.LastName()",
+				"company":      "faker.Person.Name()",
+				"title":        "faker.Person.Name()",
+				"street":       "faker.Address.StreetAddress()",
+				"zipcode":      "faker.Address.PostCode()",
+				"city":         "faker.Address.City()",
+				"phone_number": "faker.Phone.Number()",
+			},
+			"log_entry": map[string]string{
+				"provider": "",
+			},
+			"newsletter_recipient": map[string]string{
+				"email":      "faker.Internet.Email()",
+				"first_name": "faker.Person.FirstName()",
+				"last_name":  "faker.Person.LastName()",
+				"city":       "faker.Address.City()",
+			},
+			"order_address": map[string]string{
+				"first_name":   "faker.Person.FirstName()",
+				"last_name":    "faker.Person.LastName()",
+				"company":      "faker.Person.Name()",
+				"title":        "faker.Person.Name()",
+				"street":       "faker.Address.StreetAddress()",
+				"zipcode":      "faker.Address.PostCode()",
+				"city":         "faker.Address.City()",
+				"phone_number": "faker.Phone.Number()",
+			},
+			"order_customer": map[string]string{
+				"first_name":     "faker.Person.FirstName()",
+				"last_name":      "faker.Person.LastName()",
+				"company":        "faker.Person.Name()",
+				"title":          "faker.Person.Name()",
+				"email":          "faker.Internet.Email()",
+				"remote_address": "faker.Internet.Ipv4()",
+			},
+			"product_review": map[string]string{
+				"email": "faker.Internet.Email()",
+			},
+			"user": map[string]string{
+				"username":   "faker.Person.Name()",
+				"first_name": "faker.Person.FirstName()",
+				"last_name":  "faker.Person.LastName()",
+				"email":      "faker.Internet.Email()",
+			},
+		}
+	}
+
+	projectCfg, err := shop.ReadConfig(projectConfigPath, false)
+	if err != nil {
+		if !strings.Contains(err.Error(), "cannot find .shopware-project.yml") {
+			return err
+		}
+	}
+
+	if projectCfg != nil && projectCfg.ConfigDump != nil {
+		pConf.NoData = append(pConf.NoData, projectCfg.ConfigDump.NoData...)
+		pConf.Ignore = append(pConf.Ignore, projectCfg.ConfigDump.Ignore...)
+		for table, rewrites := range projectCfg.ConfigDump.Rewrite {
+			_, ok := pConf.Rewrite[table]
+
+			if !ok {
+				pConf.Rewrite[table] = rewrites
+			} else {
+				for k, v := range rewrites {
+					pConf.Rewrite[table][k] = v
+				}
+			}
+		}
+		pConf.Where = projectCfg.ConfigDump.Where
+	}
+
+	dumper.SetSelectMap(pConf.RewriteToMap())
+	dumper.SetWhereMap(pConf.Where)
+
+	return dumper.SetFilterMap(pConf.NoData, pConf.Ignore)
+}
+
 func init() {
 	projectRootCmd.AddCommand(projectDatabaseDumpCmd)
 	projectDatabaseDumpCmd.Flags().String("host", "127.0.0.1", "hostname")
@@ -0,0 +1,30 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectE2ECmd = &cobra.Command{
+	Use:   "e2e",
+	Short: "Set up and run the end-to-end tests of the shop",
+}
+
+// getE2EConfig returns the e2e config of the project, the --framework flag overrides the configured framework.
+func getE2EConfig(cmd *cobra.Command, cfg *shop.Config) shop.ConfigE2E {
+	e2eCfg := shop.ConfigE2E{}
+	if cfg.E2E != nil {
+		e2eCfg = *cfg.E2E
+	}
+
+	if framework, _ := cmd.Flags().GetString("framework"); framework != "" {
+		e2eCfg.Framework = framework
+	}
+
+	return e2eCfg.WithDefaults()
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectE2ECmd)
+}
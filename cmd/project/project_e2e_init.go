@@ -0,0 +1,72 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectE2EInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Creates a Playwright or Cypress test suite for the shop",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		projectRoot, err := findClosestShopwareProject()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		e2eCfg := getE2EConfig(cmd, cfg)
+
+		files, err := shop.E2EScaffoldFiles(e2eCfg.Framework)
+		if err != nil {
+			return err
+		}
+
+		suiteDir := filepath.Join(projectRoot, filepath.FromSlash(e2eCfg.Path))
+
+		if _, err := os.Stat(filepath.Join(suiteDir, "package.json")); err == nil {
+			return fmt.Errorf("%s contains already a test suite", suiteDir)
+		}
+
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			file := filepath.Join(suiteDir, filepath.FromSlash(name))
+
+			if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(file, []byte(files[name]), 0o644); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Written %s", file)
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Run the tests with shopware-cli project e2e run")
+
+		return nil
+	},
+}
+
+func init() {
+	projectE2ECmd.AddCommand(projectE2EInitCmd)
+	projectE2EInitCmd.Flags().String("framework", "", "Test framework (playwright, cypress), defaults to the e2e.framework of the config or playwright")
+}
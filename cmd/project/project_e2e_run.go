@@ -0,0 +1,99 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectE2ERunCmd = &cobra.Command{
+	Use:   "run [-- runner arguments]",
+	Short: "Seeds the test fixtures and runs the end-to-end tests against the shop",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := findClosestShopwareProject()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "create the e2e admin user"); err != nil {
+			return err
+		}
+
+		e2eCfg := getE2EConfig(cmd, cfg)
+		suiteDir := filepath.Join(projectRoot, filepath.FromSlash(e2eCfg.Path))
+
+		if _, err := os.Stat(filepath.Join(suiteDir, "package.json")); os.IsNotExist(err) {
+			return fmt.Errorf("cannot find a test suite in %s, create one with shopware-cli project e2e init", suiteDir)
+		}
+
+		runArgs, err := shop.E2ERunCommand(e2eCfg.Framework, args)
+		if err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+		// the registration flows would send mails to the test customers otherwise
+		apiCtx.SkipFlows = true
+
+		logging.FromContext(cmd.Context()).Infof("Creating the test sales channel, customer and admin user")
+
+		fixtures, err := shop.SeedE2EFixtures(apiCtx, client, cfg.URL, e2eCfg.Password)
+		if err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(filepath.Join(suiteDir, "node_modules")); os.IsNotExist(err) {
+			if err := runE2ECommand(cmd, suiteDir, nil, "npm", "install", "--no-audit", "--no-fund"); err != nil {
+				return err
+			}
+
+			if e2eCfg.Framework == shop.E2EFrameworkPlaywright {
+				if err := runE2ECommand(cmd, suiteDir, nil, "npx", "playwright", "install", "chromium"); err != nil {
+					return err
+				}
+			}
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Running %s tests against %s", e2eCfg.Framework, fixtures.StorefrontURL)
+
+		if err := runE2ECommand(cmd, suiteDir, fixtures.Env(), "npx", runArgs...); err != nil {
+			return fmt.Errorf("e2e tests failed: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func runE2ECommand(cmd *cobra.Command, dir string, env []string, name string, args ...string) error {
+	runCmd := process.Command(cmd.Context(), name, args...)
+	runCmd.Dir = dir
+	runCmd.Env = append(os.Environ(), env...)
+	runCmd.Stdin = os.Stdin
+	runCmd.Stdout = os.Stdout
+	runCmd.Stderr = os.Stderr
+
+	return runCmd.Run()
+}
+
+func init() {
+	projectE2ECmd.AddCommand(projectE2ERunCmd)
+	addProtectedFlags(projectE2ERunCmd.Flags())
+	projectE2ERunCmd.Flags().String("framework", "", "Test framework (playwright, cypress), defaults to the e2e.framework of the config or playwright")
+}
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage the env files of the environments",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectEnvCmd)
+}
@@ -0,0 +1,117 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectEnvInitCmd = &cobra.Command{
+	Use:   "init [project-dir]",
+	Short: "Generates the env file of an environment from the env_template of the project config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		environment, _ := cmd.Flags().GetString("env")
+		force, _ := cmd.Flags().GetBool("force")
+		set, _ := cmd.Flags().GetStringArray("set")
+
+		if environment == "" {
+			return fmt.Errorf("the environment is required, pass it with --env")
+		}
+
+		envFileName, err := shop.EnvFileName(environment)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		// the url of a configured environment is available as {url}, other environments like local use the default shop
+		url := cfg.URL
+		if envCfg, ok := cfg.Environments[environment]; ok {
+			url = envCfg.URL
+		}
+
+		values := make(map[string]string)
+
+		for _, entry := range set {
+			name, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				return fmt.Errorf("invalid --set %s, use NAME=value", entry)
+			}
+
+			values[name] = value
+		}
+
+		envFile := filepath.Join(projectRoot, envFileName)
+
+		if _, err := os.Stat(envFile); err == nil && !force {
+			return fmt.Errorf("%s exists already, use --force to overwrite it", envFile)
+		}
+
+		content, err := shop.RenderEnvFile(cfg.EnvTemplate, environment, url, values, promptEnvVariable)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Written %s", envFile)
+
+		return nil
+	},
+}
+
+func promptEnvVariable(variable shop.ConfigEnvVariable) (string, error) {
+	if err := interaction.Ensure(variable.Name, fmt.Sprintf("pass it with --set %s=value", variable.Name)); err != nil {
+		return "", err
+	}
+
+	label := variable.Name
+	if variable.Description != "" {
+		label = fmt.Sprintf("%s (%s)", variable.Name, variable.Description)
+	}
+
+	prompt := promptui.Prompt{
+		Label: label,
+	}
+
+	if variable.Secret {
+		prompt.Mask = '*'
+	}
+
+	value, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt failed %w", err)
+	}
+
+	return value, nil
+}
+
+func init() {
+	projectEnvCmd.AddCommand(projectEnvInitCmd)
+	projectEnvInitCmd.Flags().String("env", "", "Name of the environment like staging, the file is written to .env.<env>")
+	projectEnvInitCmd.Flags().StringArray("set", []string{}, "Value of a variable as NAME=value instead of prompting, can be passed multiple times")
+	projectEnvInitCmd.Flags().Bool("force", false, "Overwrite an existing env file")
+}
@@ -0,0 +1,37 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectEsCmd = &cobra.Command{
+	Use:   "es",
+	Short: "Manage the Elasticsearch/OpenSearch indices of the shop",
+}
+
+// runEsConsoleCommand runs the given es console command in the closest project.
+func runEsConsoleCommand(cmd *cobra.Command, args ...string) error {
+	projectRoot, err := findClosestShopwareProject()
+	if err != nil {
+		return err
+	}
+
+	shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+	if err != nil {
+		return err
+	}
+
+	consoleCmd, err := newConsoleCommand(cmd, projectRoot, shopCfg, args...)
+	if err != nil {
+		return err
+	}
+
+	return runTransparentCommand(consoleCmd)
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectEsCmd)
+	addConsoleFlags(projectEsCmd.PersistentFlags())
+}
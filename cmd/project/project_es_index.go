@@ -0,0 +1,33 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectEsIndexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Creates new indices for all entities",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		args := []string{"es:index"}
+
+		if noQueue, _ := cmd.Flags().GetBool("no-queue"); noQueue {
+			args = append(args, "--no-queue")
+		}
+
+		if err := runEsConsoleCommand(cmd, args...); err != nil {
+			return err
+		}
+
+		if admin, _ := cmd.Flags().GetBool("admin"); admin {
+			return runEsConsoleCommand(cmd, "es:admin:index")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectEsCmd.AddCommand(projectEsIndexCmd)
+	projectEsIndexCmd.Flags().Bool("no-queue", false, "Indexes synchronously instead of using the message queue")
+	projectEsIndexCmd.Flags().Bool("admin", false, "Also creates the indices of the Administration search")
+}
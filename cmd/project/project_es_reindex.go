@@ -0,0 +1,67 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectEsReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Triggers a full re-indexing using the Admin API and the message queue",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "reindex Elasticsearch"); err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		skip, _ := cmd.Flags().GetStringSlice("skip")
+
+		payload, err := json.Marshal(map[string]interface{}{"skip": skip})
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+
+		r, err := client.NewRequest(apiCtx, "POST", "/api/_action/index", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		r.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(apiCtx.Context, r, nil)
+		if err != nil {
+			return err
+		}
+
+		if err := resp.Body.Close(); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Re-indexing has been queued, make sure a worker is running")
+
+		return nil
+	},
+}
+
+func init() {
+	projectEsCmd.AddCommand(projectEsReindexCmd)
+	projectEsReindexCmd.Flags().StringSlice("skip", []string{}, "Indexers to skip, f.e. category.indexer")
+	addProtectedFlags(projectEsReindexCmd.Flags())
+}
@@ -0,0 +1,49 @@
+package project
+
+import (
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectEsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Deletes all indices and the indexing state",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		autoApprove, _ := cmd.Flags().GetBool("auto-approve")
+
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, cfg, "reset Elasticsearch"); err != nil {
+			return err
+		}
+
+		if !autoApprove {
+			if err := interaction.Ensure("confirmation", "use --auto-approve to skip it"); err != nil {
+				return err
+			}
+
+			p := promptui.Prompt{
+				Label:     "This deletes all Elasticsearch indices of the shop, continue",
+				IsConfirm: true,
+			}
+
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+		}
+
+		return runEsConsoleCommand(cmd, "es:reset", "--no-interaction")
+	},
+}
+
+func init() {
+	projectEsCmd.AddCommand(projectEsResetCmd)
+	projectEsResetCmd.Flags().Bool("auto-approve", false, "Skips the confirmation")
+	addProtectedFlags(projectEsResetCmd.Flags())
+}
@@ -0,0 +1,17 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectEsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the indexing status and the aliases of the indices",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runEsConsoleCommand(cmd, "es:status")
+	},
+}
+
+func init() {
+	projectEsCmd.AddCommand(projectEsStatusCmd)
+}
@@ -0,0 +1,26 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectEsSwitchCmd = &cobra.Command{
+	Use:   "switch",
+	Short: "Switches the aliases to the newest finished indices",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if err := runEsConsoleCommand(cmd, "es:create:alias"); err != nil {
+			return err
+		}
+
+		if cleanup, _ := cmd.Flags().GetBool("cleanup"); cleanup {
+			return runEsConsoleCommand(cmd, "es:index:cleanup", "--force")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectEsCmd.AddCommand(projectEsSwitchCmd)
+	projectEsSwitchCmd.Flags().Bool("cleanup", false, "Deletes the old indices afterwards")
+}
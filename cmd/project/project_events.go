@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Debug the business events of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectEventsCmd)
+}
@@ -0,0 +1,99 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectEventsListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Registers temporary webhooks for business events and prints the incoming payloads",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		events, _ := cmd.Flags().GetStringSlice("event")
+		listen, _ := cmd.Flags().GetString("listen")
+		webhookURL, _ := cmd.Flags().GetString("url")
+
+		if len(events) == 0 {
+			return fmt.Errorf("--event is required, f.e. --event checkout.order.placed")
+		}
+
+		if webhookURL == "" {
+			webhookURL = "http://" + listen
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		server := &http.Server{
+			Addr:              listen,
+			Handler:           shop.NewEventPrintHandler(os.Stdout),
+			ReadHeaderTimeout: time.Second,
+		}
+
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- server.ListenAndServe()
+		}()
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+
+		ids, err := shop.RegisterEventWebhooks(apiCtx, client, events, webhookURL)
+		if err != nil {
+			_ = server.Close()
+
+			return err
+		}
+
+		defer func() {
+			// the command context may be cancelled already, the webhooks need to be removed anyway
+			if err := shop.DeleteEventWebhooks(adminSdk.NewApiContext(context.Background()), client, ids); err != nil {
+				logging.FromContext(cmd.Context()).Errorf("Cannot remove the webhooks, delete the webhooks starting with shopware-cli-listen- manually: %v", err)
+
+				return
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Removed the webhooks")
+		}()
+
+		logging.FromContext(cmd.Context()).Infof("Listening on %s for %d events, the shop sends them to %s. Press Ctrl+C to stop", listen, len(events), webhookURL)
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		select {
+		case <-ctx.Done():
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+		}
+
+		return server.Close()
+	},
+}
+
+func init() {
+	projectEventsCmd.AddCommand(projectEventsListenCmd)
+	projectEventsListenCmd.Flags().StringSlice("event", []string{}, "Business event to listen to, f.e. checkout.order.placed")
+	projectEventsListenCmd.Flags().String("listen", "127.0.0.1:8888", "Address of the local listener")
+	projectEventsListenCmd.Flags().String("url", "", "URL the shop sends the webhooks to, f.e. a tunnel to the listener. Defaults to the listen address")
+}
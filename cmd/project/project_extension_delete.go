@@ -22,6 +22,10 @@ var projectExtensionDeleteCmd = &cobra.Command{
 			return err
 		}
 
+		if err := ensureUnprotected(cmd, cfg, "delete the extensions"); err != nil {
+			return err
+		}
+
 		client, err := shop.NewShopClient(cmd.Context(), cfg)
 		if err != nil {
 			return err
@@ -83,4 +87,5 @@ var projectExtensionDeleteCmd = &cobra.Command{
 
 func init() {
 	projectExtensionCmd.AddCommand(projectExtensionDeleteCmd)
+	addProtectedFlags(projectExtensionDeleteCmd.Flags())
 }
@@ -15,7 +15,7 @@ import (
 
 var projectExtensionOutdatedCmd = &cobra.Command{
 	Use:   "outdated",
-	Short: "List all outdated extensions",
+	Short: "List the installed extensions with their latest version and compatibility",
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		var cfg *shop.Config
 		var err error
@@ -36,14 +36,12 @@ var projectExtensionOutdatedCmd = &cobra.Command{
 		}
 
 		extensions, _, err := client.ExtensionManager.ListAvailableExtensions(adminSdk.NewApiContext(cmd.Context()))
-		extensions = extensions.FilterByUpdateable()
-
 		if err != nil {
 			return err
 		}
 
 		if outputAsJson {
-			content, err := json.Marshal(extensions)
+			content, err := json.Marshal(extensions.FilterByUpdateable())
 			if err != nil {
 				return err
 			}
@@ -53,26 +51,49 @@ var projectExtensionOutdatedCmd = &cobra.Command{
 			return nil
 		}
 
-		if len(extensions) == 0 {
-			logging.FromContext(cmd.Context()).Infof("All extensions are up-to-date")
-			return nil
+		info, _, err := client.Info.Info(adminSdk.NewApiContext(cmd.Context()))
+		if err != nil {
+			return err
 		}
 
+		targetVersion, _ := cmd.Flags().GetString("shopware-version")
+		if targetVersion == "" {
+			targetVersion = info.Version
+		}
+
+		// the versions of the shop are still worth listing, when the store is not reachable
+		report, err := shop.CheckExtensionCompatibility(cmd.Context(), extensions, info.Version, targetVersion)
+		if err != nil {
+			logging.FromContext(cmd.Context()).Warnf("%s", err.Error())
+		}
+
+		outdated := 0
+
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetColWidth(100)
-		table.SetHeader([]string{"Name", "Current Version", "Latest Version", "Update Source"})
+		table.SetHeader([]string{"Name", "Current Version", "Latest Version", "Update Source", fmt.Sprintf("Shopware %s", targetVersion)})
+
+		for _, extension := range report {
+			if extension.Outdated {
+				outdated++
+			}
 
-		for _, extension := range extensions {
-			table.Append([]string{extension.Name, extension.Version, extension.LatestVersion, extension.UpdateSource})
+			table.Append([]string{extension.Name, extension.Version, extension.LatestVersion, extension.UpdateSource, extension.Label()})
 		}
 
 		table.Render()
 
-		return fmt.Errorf("there are %d outdated extensions", len(extensions))
+		if outdated == 0 {
+			logging.FromContext(cmd.Context()).Infof("All extensions are up-to-date")
+			return nil
+		}
+
+		return fmt.Errorf("there are %d outdated extensions", outdated)
 	},
 }
 
 func init() {
 	projectExtensionCmd.AddCommand(projectExtensionOutdatedCmd)
 	projectExtensionOutdatedCmd.PersistentFlags().Bool("json", false, "Output as json")
+	projectExtensionOutdatedCmd.Flags().String("shopware-version", "", "Checks the compatibility with this Shopware version instead of the version of the shop, f.e. 6.6.0.0")
 }
@@ -22,6 +22,10 @@ var projectExtensionUninstallCmd = &cobra.Command{
 			return err
 		}
 
+		if err := ensureUnprotected(cmd, cfg, "uninstall the extensions"); err != nil {
+			return err
+		}
+
 		client, err := shop.NewShopClient(cmd.Context(), cfg)
 		if err != nil {
 			return err
@@ -77,4 +81,5 @@ var projectExtensionUninstallCmd = &cobra.Command{
 
 func init() {
 	projectExtensionCmd.AddCommand(projectExtensionUninstallCmd)
+	addProtectedFlags(projectExtensionUninstallCmd.Flags())
 }
@@ -33,192 +33,234 @@ var projectExtensionUploadCmd = &cobra.Command{
 
 		doLifecycleEvents, _ := cmd.PersistentFlags().GetBool("activate")
 		increaseVersionBeforeUpload, _ := cmd.PersistentFlags().GetBool("increase-version")
+		ignoreCompatibility, _ := cmd.PersistentFlags().GetBool("ignore-compatibility")
 
-		path, err := filepath.Abs(args[0])
-		if err != nil {
-			return fmt.Errorf("cannot find path: %w", err)
-		}
+		ext, cleanup, err := loadExtensionForUpload(cmd.Context(), args[0], increaseVersionBeforeUpload)
+		defer cleanup()
 
-		stat, err := os.Stat(path)
 		if err != nil {
-			return fmt.Errorf("cannot find path: %w", err)
+			return err
 		}
 
-		var ext extension.Extension
+		if cfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
+			return err
+		}
 
-		isFolder := true
+		environment, _ := cmd.PersistentFlags().GetString("environment")
 
-		if stat.IsDir() {
-			ext, err = extension.GetExtensionByFolder(path)
-		} else {
-			ext, err = extension.GetExtensionByZip(path)
-			isFolder = false
+		if cfg, err = cfg.ForEnvironment(environment); err != nil {
+			return err
 		}
 
-		if err != nil {
+		if err := ensureUnprotected(cmd, cfg, "upload the extension"); err != nil {
 			return err
 		}
 
-		extCfg := ext.GetExtensionConfig()
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
 		if err != nil {
-			logging.FromContext(cmd.Context()).Fatalln(fmt.Errorf("update: %v", err))
-		}
-
-		if increaseVersionBeforeUpload {
-			if err := increaseExtensionVersion(cmd.Context(), ext); err != nil {
-				return err
-			}
-
-			ext, err = extension.GetExtensionByFolder(ext.GetPath())
-
-			if err != nil {
-				return err
-			}
+			return err
 		}
 
-		if isFolder {
-			// Create temp dir
-			tempDir, err := os.MkdirTemp("", "extension")
-			if err != nil {
-				return fmt.Errorf("create temp directory: %w", err)
-			}
+		return uploadExtensionToShop(adminCtx, client, ext, doLifecycleEvents, ignoreCompatibility)
+	},
+}
 
-			extName, err := ext.GetName()
-			if err != nil {
-				return fmt.Errorf("get extension name: %w", err)
-			}
+// loadExtensionForUpload returns the extension of a zip or folder. Folders are copied into a temporary folder without
+// the excluded files, the returned cleanup removes it again.
+func loadExtensionForUpload(ctx context.Context, extPath string, increaseVersionBeforeUpload bool) (extension.Extension, func(), error) {
+	cleanup := func() {}
 
-			extDir := fmt.Sprintf("%s/%s/", tempDir, extName)
+	path, err := filepath.Abs(extPath)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("cannot find path: %w", err)
+	}
 
-			err = os.Mkdir(extDir, os.ModePerm)
-			if err != nil {
-				return fmt.Errorf("create temp directory: %w", err)
-			}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("cannot find path: %w", err)
+	}
 
-			tempDir += "/"
+	var ext extension.Extension
 
-			defer func(path string) {
-				_ = os.RemoveAll(path)
-			}(tempDir)
+	isFolder := true
 
-			err = cp.Copy(path, extDir)
-			if err != nil {
-				return fmt.Errorf("copy files: %w", err)
-			}
+	if stat.IsDir() {
+		ext, err = extension.GetExtensionByFolder(path)
+	} else {
+		ext, err = extension.GetExtensionByZip(path)
+		isFolder = false
+	}
 
-			ext, err = extension.GetExtensionByFolder(extDir)
+	if err != nil {
+		return nil, cleanup, err
+	}
 
-			if err != nil {
-				return err
-			}
+	extCfg := ext.GetExtensionConfig()
+	if err != nil {
+		logging.FromContext(ctx).Fatalln(fmt.Errorf("update: %v", err))
+	}
 
-			// Cleanup not wanted files
-			if err := extension.CleanupExtensionFolder(ext.GetPath(), extCfg.Build.Zip.Pack.Excludes.Paths); err != nil {
-				return fmt.Errorf("cleanup package: %w", err)
-			}
+	if increaseVersionBeforeUpload {
+		if err := increaseExtensionVersion(ctx, ext); err != nil {
+			return nil, cleanup, err
 		}
 
-		if cfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
-			return err
-		}
+		ext, err = extension.GetExtensionByFolder(ext.GetPath())
 
-		client, err := shop.NewShopClient(cmd.Context(), cfg)
 		if err != nil {
-			return err
+			return nil, cleanup, err
 		}
+	}
 
-		name, err := ext.GetName()
+	if isFolder {
+		// Create temp dir
+		tempDir, err := os.MkdirTemp("", "extension")
 		if err != nil {
-			return err
+			return nil, cleanup, fmt.Errorf("create temp directory: %w", err)
 		}
 
-		version, err := ext.GetVersion()
+		extName, err := ext.GetName()
 		if err != nil {
-			return err
+			return nil, cleanup, fmt.Errorf("get extension name: %w", err)
 		}
 
-		var buf bytes.Buffer
-		w := zip.NewWriter(&buf)
-		if err := extension.AddZipFiles(w, ext.GetPath()+"/", name+"/"); err != nil {
-			return fmt.Errorf("uploading extension: %w", err)
+		extDir := filepath.Join(tempDir, extName) + string(os.PathSeparator)
+
+		err = os.Mkdir(extDir, os.ModePerm)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("create temp directory: %w", err)
 		}
 
-		if err := w.Close(); err != nil {
-			return err
+		tempDir += string(os.PathSeparator)
+
+		cleanup = func() {
+			_ = os.RemoveAll(tempDir)
 		}
 
-		shopInfo, _, err := client.Info.Info(adminCtx)
+		err = cp.Copy(path, extDir)
 		if err != nil {
-			return fmt.Errorf("cannot get shop info: %w", err)
+			return nil, cleanup, fmt.Errorf("copy files: %w", err)
 		}
 
-		extensions, _, err := client.ExtensionManager.ListAvailableExtensions(adminCtx)
+		ext, err = extension.GetExtensionByFolder(extDir)
+
 		if err != nil {
-			return err
+			return nil, cleanup, err
 		}
 
-		if !shopInfo.IsCloudShop() || extensions.GetByName(name) == nil {
-			if _, err := client.ExtensionManager.UploadExtension(adminCtx, &buf); err != nil {
-				return fmt.Errorf("cannot upload extension: %w", err)
-			}
+		// Cleanup not wanted files
+		if err := extension.CleanupExtensionFolder(ext.GetPath(), extCfg.Build.Zip.Pack.Excludes.Paths); err != nil {
+			return nil, cleanup, fmt.Errorf("cleanup package: %w", err)
+		}
+	}
 
-			extensions, _, err = client.ExtensionManager.ListAvailableExtensions(adminCtx)
+	return ext, cleanup, nil
+}
 
-			if err != nil {
-				return err
-			}
-		} else {
-			if _, err := client.ExtensionManager.UploadExtensionUpdateToCloud(adminCtx, name, &buf); err != nil {
-				return fmt.Errorf("cannot upload extension update: %w", err)
-			}
+// uploadExtensionToShop uploads the extension folder to the shop, in cloud shops an existing extension is uploaded as update.
+// Extensions not matching the Shopware or PHP version of the shop are refused, unless ignoreCompatibility is set.
+func uploadExtensionToShop(adminCtx adminSdk.ApiContext, client *adminSdk.Client, ext extension.Extension, doLifecycleEvents, ignoreCompatibility bool) error {
+	name, err := ext.GetName()
+	if err != nil {
+		return err
+	}
+
+	version, err := ext.GetVersion()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	if err := extension.AddZipFiles(w, ext.GetPath()+"/", name+"/"); err != nil {
+		return fmt.Errorf("uploading extension: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	shopInfo, _, err := client.Info.Info(adminCtx)
+	if err != nil {
+		return fmt.Errorf("cannot get shop info: %w", err)
+	}
+
+	if problems := extension.CheckShopCompatibility(ext, shopInfo.Version, shop.GetPHPVersion(adminCtx, client)); len(problems) > 0 {
+		if !ignoreCompatibility {
+			return fmt.Errorf("%s is not compatible with the shop: %s. Use --ignore-compatibility to upload it anyway", name, strings.Join(problems, ", "))
 		}
 
-		logging.FromContext(cmd.Context()).Infof("Uploaded extension %s with version %s", name, version)
+		for _, problem := range problems {
+			logging.FromContext(adminCtx.Context).Warnf("%s: %s", name, problem)
+		}
+	}
 
-		if _, err := client.ExtensionManager.Refresh(adminCtx); err != nil {
-			return fmt.Errorf("cannot refresh extension list: %w", err)
+	extensions, _, err := client.ExtensionManager.ListAvailableExtensions(adminCtx)
+	if err != nil {
+		return err
+	}
+
+	if !shopInfo.IsCloudShop() || extensions.GetByName(name) == nil {
+		if _, err := client.ExtensionManager.UploadExtension(adminCtx, &buf); err != nil {
+			return fmt.Errorf("cannot upload extension: %w", err)
 		}
 
-		logging.FromContext(cmd.Context()).Infof("Refreshed extension list")
+		extensions, _, err = client.ExtensionManager.ListAvailableExtensions(adminCtx)
+
+		if err != nil {
+			return err
+		}
+	} else {
+		if _, err := client.ExtensionManager.UploadExtensionUpdateToCloud(adminCtx, name, &buf); err != nil {
+			return fmt.Errorf("cannot upload extension update: %w", err)
+		}
+	}
 
-		if doLifecycleEvents {
-			remoteExtension := extensions.GetByName(name)
+	logging.FromContext(adminCtx.Context).Infof("Uploaded extension %s with version %s", name, version)
 
-			if remoteExtension.InstalledAt == nil {
-				if _, err := client.ExtensionManager.InstallExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
-					return fmt.Errorf("cannot install extension: %w", err)
-				}
+	if _, err := client.ExtensionManager.Refresh(adminCtx); err != nil {
+		return fmt.Errorf("cannot refresh extension list: %w", err)
+	}
 
-				logging.FromContext(cmd.Context()).Infof("Installed %s", name)
-			}
+	logging.FromContext(adminCtx.Context).Infof("Refreshed extension list")
 
-			if !remoteExtension.Active {
-				if _, err := client.ExtensionManager.ActivateExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
-					return fmt.Errorf("cannot activate extension: %w", err)
-				}
+	if doLifecycleEvents {
+		remoteExtension := extensions.GetByName(name)
 
-				logging.FromContext(cmd.Context()).Infof("Activated %s", name)
+		if remoteExtension.InstalledAt == nil {
+			if _, err := client.ExtensionManager.InstallExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
+				return fmt.Errorf("cannot install extension: %w", err)
 			}
 
-			if remoteExtension.IsUpdateAble() {
-				if _, err := client.ExtensionManager.UpdateExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
-					return fmt.Errorf("cannot update extension: %w", err)
-				}
+			logging.FromContext(adminCtx.Context).Infof("Installed %s", name)
+		}
 
-				logging.FromContext(cmd.Context()).Infof("Updated %s from %s to %s", name, remoteExtension.Version, remoteExtension.LatestVersion)
+		if !remoteExtension.Active {
+			if _, err := client.ExtensionManager.ActivateExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
+				return fmt.Errorf("cannot activate extension: %w", err)
 			}
+
+			logging.FromContext(adminCtx.Context).Infof("Activated %s", name)
 		}
 
-		if ext.GetType() == "plugin" {
-			if _, err := client.CacheManager.Clear(adminCtx); err != nil {
-				return err
+		if remoteExtension.IsUpdateAble() {
+			if _, err := client.ExtensionManager.UpdateExtension(adminCtx, remoteExtension.Type, remoteExtension.Name); err != nil {
+				return fmt.Errorf("cannot update extension: %w", err)
 			}
 
-			logging.FromContext(cmd.Context()).Infof("Cleared cache")
+			logging.FromContext(adminCtx.Context).Infof("Updated %s from %s to %s", name, remoteExtension.Version, remoteExtension.LatestVersion)
 		}
+	}
 
-		return nil
-	},
+	if ext.GetType() == "plugin" {
+		if _, err := client.CacheManager.Clear(adminCtx); err != nil {
+			return err
+		}
+
+		logging.FromContext(adminCtx.Context).Infof("Cleared cache")
+	}
+
+	return nil
 }
 
 func increaseExtensionVersion(ctx context.Context, ext extension.Extension) error {
@@ -336,4 +378,7 @@ func init() {
 	projectExtensionCmd.AddCommand(projectExtensionUploadCmd)
 	projectExtensionUploadCmd.PersistentFlags().Bool("activate", false, "Installs, Activates, Updates the extension")
 	projectExtensionUploadCmd.PersistentFlags().Bool("increase-version", false, "Increases extension version before uploading")
+	projectExtensionUploadCmd.PersistentFlags().String("environment", "", "Environment of .shopware-project.yml to upload to")
+	projectExtensionUploadCmd.PersistentFlags().Bool("ignore-compatibility", false, "Uploads the extension even when it requires another Shopware or PHP version than the shop")
+	addProtectedFlags(projectExtensionUploadCmd.Flags())
 }
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectFleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Maintain many shops at once",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectFleetCmd)
+}
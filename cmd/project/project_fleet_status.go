@@ -0,0 +1,132 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectFleetStatusCmd = &cobra.Command{
+	Use:   "status [fleet-file]",
+	Short: "Collects the Shopware version, PHP version, extensions and pending updates of many shops",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cfg *shop.Config
+		var names []string
+
+		if len(args) == 1 {
+			fleet, err := shop.ReadFleet(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg, names = fleet.Config()
+		} else {
+			var err error
+
+			if cfg, err = shop.ReadConfig(projectConfigPath, false); err != nil {
+				return err
+			}
+
+			names = cfg.EnvironmentNames()
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		statuses := make([]shop.FleetShopStatus, len(names))
+		indexByName := make(map[string]int, len(names))
+
+		for i, name := range names {
+			indexByName[name] = i
+		}
+
+		results, err := shop.RunForEnvironments(cmd.Context(), cfg, names, concurrency, func(ctx context.Context, name string, envCfg *shop.Config) error {
+			client, err := shop.NewShopClient(ctx, envCfg)
+			if err != nil {
+				return err
+			}
+
+			return shop.CollectShopStatus(adminSdk.NewApiContext(ctx), client, &statuses[indexByName[name]])
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, result := range results {
+			statuses[i].Name = result.Environment
+			statuses[i].URL = result.URL
+
+			if result.Error != nil {
+				statuses[i].Error = result.Error.Error()
+				logging.FromContext(cmd.Context()).Warnf("%s: %s", result.Environment, result.Error)
+			}
+		}
+
+		var content []byte
+
+		switch format {
+		case "table":
+			if output != "" {
+				return fmt.Errorf("the table format cannot be written to a file, use json or csv")
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Shop", "URL", "Shopware", "Shopware Update", "PHP", "Extensions", "Pending Updates", "Error"})
+
+			for _, status := range statuses {
+				table.Append([]string{status.Name, status.URL, status.ShopwareVersion, status.ShopwareUpdate, status.PHPVersion, strconv.Itoa(len(status.Extensions)), strconv.Itoa(status.PendingUpdates), status.Error})
+			}
+
+			table.Render()
+
+			return nil
+		case "json":
+			if content, err = json.MarshalIndent(statuses, "", "  "); err != nil {
+				return err
+			}
+		case "csv":
+			var buf bytes.Buffer
+
+			if err := shop.WriteFleetStatusCSV(&buf, statuses); err != nil {
+				return err
+			}
+
+			content = []byte(strings.TrimSuffix(buf.String(), "\n"))
+		default:
+			return fmt.Errorf("unsupported format %s, use table, json or csv", format)
+		}
+
+		if output == "" {
+			fmt.Println(string(content))
+			return nil
+		}
+
+		if err := os.WriteFile(output, content, 0o644); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Written fleet status to %s", output)
+
+		return nil
+	},
+}
+
+func init() {
+	projectFleetCmd.AddCommand(projectFleetStatusCmd)
+	projectFleetStatusCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+	projectFleetStatusCmd.Flags().String("output", "", "Write the report into this file instead of stdout")
+	projectFleetStatusCmd.Flags().Int("concurrency", 4, "How many shops are queried at the same time")
+}
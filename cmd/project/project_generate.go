@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate configuration files for the project",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectGenerateCmd)
+}
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"math/big"
 	"os"
-	"path"
 	"path/filepath"
 	"time"
 
@@ -40,7 +39,7 @@ var projectNewJWTCmd = &cobra.Command{
 		}
 
 		projectRoot := args[0]
-		jwtFolder := path.Join(projectRoot, "config", "jwt")
+		jwtFolder := filepath.Join(projectRoot, "config", "jwt")
 
 		if _, err := os.Stat(jwtFolder); os.IsNotExist(err) {
 			if err := os.MkdirAll(jwtFolder, os.ModePerm); err != nil {
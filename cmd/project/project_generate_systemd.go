@@ -0,0 +1,91 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectGenerateSystemdCmd = &cobra.Command{
+	Use:   "systemd [project-dir]",
+	Short: "Generates systemd units for the message queue workers and the scheduled tasks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		if projectRoot, err = filepath.Abs(projectRoot); err != nil {
+			return err
+		}
+
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		systemdCfg := shop.ConfigSystemd{}
+		if shopCfg.Systemd != nil {
+			systemdCfg = *shopCfg.Systemd
+		}
+
+		if user, _ := cmd.Flags().GetString("user"); user != "" {
+			systemdCfg.User = user
+		}
+
+		units := shop.GenerateSystemdUnits(projectRoot, systemdCfg, shopCfg.Build.Console)
+		outputDir, _ := cmd.Flags().GetString("output")
+
+		enableNames := make([]string, 0)
+		for _, unit := range units {
+			if strings.HasSuffix(unit.Name, ".service") && unit.Instances == 0 {
+				// Started by its timer
+				continue
+			}
+
+			enableNames = append(enableNames, unit.EnableName()...)
+		}
+
+		if outputDir == "" {
+			for _, unit := range units {
+				fmt.Printf("# %s\n%s\n", unit.Name, unit.Content)
+			}
+
+			fmt.Printf("# Enable with: systemctl enable --now %s\n", strings.Join(enableNames, " "))
+
+			return nil
+		}
+
+		if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+			return err
+		}
+
+		for _, unit := range units {
+			if err := os.WriteFile(filepath.Join(outputDir, unit.Name), []byte(unit.Content), 0o644); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Written %s", filepath.Join(outputDir, unit.Name))
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Enable with: systemctl daemon-reload && systemctl enable --now %s", strings.Join(enableNames, " "))
+
+		return nil
+	},
+}
+
+func init() {
+	projectGenerateCmd.AddCommand(projectGenerateSystemdCmd)
+	projectGenerateSystemdCmd.Flags().String("output", "", "Directory to write the units to, f.e. /etc/systemd/system. Prints them when empty")
+	projectGenerateSystemdCmd.Flags().String("user", "", "User to run the units as (default systemd.user of .shopware-project.yml)")
+}
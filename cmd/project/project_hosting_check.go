@@ -0,0 +1,89 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectHostingCheckCmd = &cobra.Command{
+	Use:   "hosting-check [project-dir]",
+	Short: "Checks the PHP and extension requirements of the Composer dependencies against the hosting profile",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+		phpVersion, _ := cmd.Flags().GetString("php")
+
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		var profile shop.ConfigHosting
+		if cfg.Hosting != nil {
+			profile = *cfg.Hosting
+		}
+
+		if phpVersion != "" {
+			profile.PHP = phpVersion
+		}
+
+		if profile.PHP == "" && len(profile.Extensions) == 0 {
+			return fmt.Errorf("no hosting profile configured, set hosting in %s or pass --php", projectConfigPath)
+		}
+
+		violations, err := shop.CheckHostingProfile(projectRoot, profile)
+		if err != nil {
+			return err
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(violations)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		} else if len(violations) > 0 {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Package", "Requirement", "Constraint", "Source"})
+			table.SetAutoWrapText(false)
+
+			for _, violation := range violations {
+				table.Append([]string{violation.Package, violation.Requirement, violation.Constraint, violation.Source})
+			}
+
+			table.Render()
+		}
+
+		if len(violations) > 0 {
+			return fmt.Errorf("found %d requirements not fulfilled by the hosting", len(violations))
+		}
+
+		if !outputAsJson {
+			logging.FromContext(cmd.Context()).Infof("All platform requirements are fulfilled by the hosting")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectHostingCheckCmd)
+	projectHostingCheckCmd.Flags().Bool("json", false, "Output as json")
+	projectHostingCheckCmd.Flags().String("php", "", "PHP version of the hosting like 8.2, overrides hosting.php of the project config")
+}
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectIntegrationCmd = &cobra.Command{
+	Use:   "integration",
+	Short: "Manage the Admin API integrations of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectIntegrationCmd)
+}
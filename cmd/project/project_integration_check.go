@@ -0,0 +1,78 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectIntegrationCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Checks which CLI features the configured Admin API credentials can use",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		features, _ := cmd.Flags().GetStringSlice("feature")
+
+		if len(features) == 0 {
+			features = shop.FeatureNames()
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		granted, err := shop.FetchGrantedPrivileges(adminSdk.NewApiContext(cmd.Context()), client, cfg.AdminApi)
+		if err != nil {
+			return err
+		}
+
+		if granted.Admin {
+			logging.FromContext(cmd.Context()).Infof("The credentials have admin access and can use all features")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Feature", "Missing Privileges"})
+
+		incomplete := false
+
+		for _, feature := range features {
+			privileges, ok := shop.FeaturePrivileges[feature]
+			if !ok {
+				return fmt.Errorf("unknown feature %s, use one of: %s", feature, strings.Join(shop.FeatureNames(), ", "))
+			}
+
+			missing := granted.Missing(privileges)
+			if len(missing) > 0 {
+				incomplete = true
+			}
+
+			table.Append([]string{feature, strings.Join(missing, ", ")})
+		}
+
+		table.Render()
+
+		if incomplete && cmd.Flags().Changed("feature") {
+			return fmt.Errorf("the credentials are missing privileges for the requested features")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectIntegrationCmd.AddCommand(projectIntegrationCheckCmd)
+	projectIntegrationCheckCmd.Flags().StringSlice("feature", []string{}, "Only checks this CLI feature like config-push, can be passed multiple times (default all features)")
+}
@@ -0,0 +1,71 @@
+package project
+
+import (
+	"fmt"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectIntegrationCreateCmd = &cobra.Command{
+	Use:   "create [label]",
+	Short: "Creates an Admin API integration and prints its credentials",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roles, _ := cmd.Flags().GetStringSlice("role")
+		features, _ := cmd.Flags().GetStringSlice("feature")
+		writeConfig, _ := cmd.Flags().GetBool("write-config")
+
+		var privileges []string
+
+		if len(features) > 0 {
+			var err error
+
+			if privileges, err = shop.PrivilegesForFeatures(features); err != nil {
+				return err
+			}
+		}
+
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		integration, err := shop.CreateIntegration(adminSdk.NewApiContext(cmd.Context()), client, args[0], roles, privileges)
+		if err != nil {
+			return err
+		}
+
+		if writeConfig {
+			if err := shop.WriteAdminApiCredentials(projectConfigPath, integration.AccessKey, integration.SecretAccessKey); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Integration %s has been created and written to %s", integration.Label, projectConfigPath)
+
+			return nil
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Integration %s has been created, the secret cannot be shown again", integration.Label)
+
+		fmt.Printf("Access key ID: %s\n", integration.AccessKey)
+		fmt.Printf("Secret access key: %s\n", integration.SecretAccessKey)
+
+		return nil
+	},
+}
+
+func init() {
+	projectIntegrationCmd.AddCommand(projectIntegrationCreateCmd)
+	projectIntegrationCreateCmd.Flags().StringSlice("role", []string{}, "Name of an ACL role, without roles and features the integration gets admin access")
+	projectIntegrationCreateCmd.Flags().StringSlice("feature", []string{}, "Grants only the privileges needed by this CLI feature like config-push, can be passed multiple times")
+	projectIntegrationCreateCmd.Flags().Bool("write-config", false, "Writes the credentials into the admin_api section of the project config")
+}
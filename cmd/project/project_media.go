@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectMediaCmd = &cobra.Command{
+	Use:   "media",
+	Short: "Work with the media files of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectMediaCmd)
+}
@@ -0,0 +1,95 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectMediaPullCmd = &cobra.Command{
+	Use:   "pull [directory]",
+	Short: "Downloads the media files of the shop with their folder structure and metadata",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		target := "media"
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		folderName, _ := cmd.Flags().GetString("folder")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+
+		folders, err := shop.ListMediaFolders(apiCtx, client)
+		if err != nil {
+			return err
+		}
+
+		var folderIDs []string
+
+		if folderName != "" {
+			if folderIDs = shop.MediaFolderIDsByName(folders, folderName); len(folderIDs) == 0 {
+				return fmt.Errorf("cannot find media folder %s", folderName)
+			}
+		}
+
+		files, err := shop.ListMedia(apiCtx, client, folders, folderIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+
+		downloaded, skipped, failed := 0, 0, 0
+
+		shop.DownloadMedia(cmd.Context(), http.DefaultClient, files, target, concurrency, func(result shop.MediaDownloadResult) {
+			switch {
+			case result.Error != nil:
+				failed++
+				logging.FromContext(cmd.Context()).Errorf("Cannot download %s: %s", result.File.Path, result.Error)
+			case result.Skipped:
+				skipped++
+			default:
+				downloaded++
+				logging.FromContext(cmd.Context()).Debugf("Downloaded %s", result.File.Path)
+			}
+		})
+
+		if err := shop.WriteMediaMetadata(target, files); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Downloaded %d media files to %s, %d were up to date", downloaded, target, skipped)
+
+		if failed > 0 {
+			return fmt.Errorf("%d media files could not be downloaded", failed)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectMediaCmd.AddCommand(projectMediaPullCmd)
+	projectMediaPullCmd.Flags().String("folder", "", "Only download the media of this folder and its sub folders")
+	projectMediaPullCmd.Flags().Int("concurrency", 4, "Number of parallel downloads")
+}
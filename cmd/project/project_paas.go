@@ -0,0 +1,66 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/internal/paas"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+const defaultPaasEnvironment = "main"
+
+var projectPaasCmd = &cobra.Command{
+	Use:   "paas",
+	Short: "Deploy the project to Shopware PaaS",
+}
+
+type paasTarget struct {
+	client      *paas.Client
+	project     string
+	environment string
+}
+
+// newPaasTarget resolves the PaaS project and environment from the flags, falling back to paas of the project config.
+func newPaasTarget(cmd *cobra.Command) (*paasTarget, error) {
+	shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &paasTarget{environment: defaultPaasEnvironment}
+
+	if shopCfg.Paas != nil {
+		target.project = shopCfg.Paas.Project
+
+		if shopCfg.Paas.Environment != "" {
+			target.environment = shopCfg.Paas.Environment
+		}
+	}
+
+	if projectID, _ := cmd.Flags().GetString("project"); projectID != "" {
+		target.project = projectID
+	}
+
+	if environment, _ := cmd.Flags().GetString("environment"); environment != "" {
+		target.environment = environment
+	}
+
+	if target.project == "" {
+		return nil, fmt.Errorf("no PaaS project configured, use --project or paas.project in .shopware-project.yml")
+	}
+
+	if target.client, err = paas.NewClient(cmd.Context(), config.Config{}.GetPaasToken()); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectPaasCmd)
+	projectPaasCmd.PersistentFlags().String("project", "", "PaaS project ID (default paas.project of .shopware-project.yml)")
+	projectPaasCmd.PersistentFlags().String("environment", "", "PaaS environment (default paas.environment of .shopware-project.yml or main)")
+}
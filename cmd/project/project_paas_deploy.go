@@ -0,0 +1,69 @@
+package project
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/paas"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var projectPaasDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Triggers a deployment of the PaaS environment",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		target, err := newPaasTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		redeploy, _ := cmd.Flags().GetBool("redeploy")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+
+		var activity *paas.Activity
+
+		if redeploy {
+			activity, err = target.client.Redeploy(cmd.Context(), target.project, target.environment)
+		} else {
+			activity, err = target.client.Deploy(cmd.Context(), target.project, target.environment)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Started deployment %s of environment %s", activity.ID, target.environment)
+
+		if noWait {
+			return nil
+		}
+
+		return followPaasActivity(cmd, target, activity.ID)
+	},
+}
+
+// followPaasActivity streams the log of the activity and fails when the activity was not successful.
+func followPaasActivity(cmd *cobra.Command, target *paasTarget, activityID string) error {
+	if err := target.client.StreamActivityLog(cmd.Context(), target.project, activityID, os.Stdout); err != nil {
+		return err
+	}
+
+	activity, err := target.client.GetActivity(cmd.Context(), target.project, activityID)
+	if err != nil {
+		return err
+	}
+
+	if activity.IsFailed() {
+		return fmt.Errorf("activity %s failed with result %s", activity.ID, activity.Result)
+	}
+
+	return nil
+}
+
+func init() {
+	projectPaasCmd.AddCommand(projectPaasDeployCmd)
+	projectPaasDeployCmd.Flags().Bool("redeploy", false, "Redeploys the current code without pending changes")
+	projectPaasDeployCmd.Flags().Bool("no-wait", false, "Does not stream the deployment log")
+}
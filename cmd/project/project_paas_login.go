@@ -0,0 +1,89 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/internal/paas"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const paasTokenEnv = "SHOPWARE_CLI_PAAS_TOKEN"
+
+var projectPaasLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Stores the API token of your Shopware PaaS account",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		token, err := readPaasToken(cmd)
+		if err != nil {
+			return err
+		}
+
+		if token == "" {
+			return fmt.Errorf("please provide the API token with --token-stdin or %s", paasTokenEnv)
+		}
+
+		if _, err := paas.NewClient(cmd.Context(), token); err != nil {
+			return err
+		}
+
+		cfg := config.Config{}
+
+		if err := cfg.SetPaasToken(token); err != nil {
+			return err
+		}
+
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Logged in to Shopware PaaS")
+
+		return nil
+	},
+}
+
+// readPaasToken reads the token from stdin, the environment or a prompt. It is never passed as argument, which would
+// leave it in the shell history and the process list.
+func readPaasToken(cmd *cobra.Command) (string, error) {
+	if fromStdin, _ := cmd.Flags().GetBool("token-stdin"); fromStdin {
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	if token := os.Getenv(paasTokenEnv); token != "" {
+		return token, nil
+	}
+
+	if err := interaction.Ensure("API token", "pass it with --token-stdin or set "+paasTokenEnv+" instead"); err != nil {
+		return "", err
+	}
+
+	prompt := promptui.Prompt{
+		Label: "API token",
+		Mask:  '*',
+	}
+
+	token, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt failed %w", err)
+	}
+
+	return strings.TrimSpace(token), nil
+}
+
+func init() {
+	projectPaasCmd.AddCommand(projectPaasLoginCmd)
+	projectPaasLoginCmd.Flags().Bool("token-stdin", false, "Reads the API token of the PaaS account from stdin")
+}
@@ -0,0 +1,32 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectPaasLogsCmd = &cobra.Command{
+	Use:   "logs [activity-id]",
+	Short: "Streams the log of a deployment, by default of the latest activity of the environment",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := newPaasTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			return followPaasActivity(cmd, target, args[0])
+		}
+
+		activity, err := target.client.GetLatestActivity(cmd.Context(), target.project, target.environment)
+		if err != nil {
+			return err
+		}
+
+		return followPaasActivity(cmd, target, activity.ID)
+	},
+}
+
+func init() {
+	projectPaasCmd.AddCommand(projectPaasLogsCmd)
+}
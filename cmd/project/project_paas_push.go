@@ -0,0 +1,101 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/paas"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	paasPushActivityAttempts = 30
+	paasPushActivityInterval = 2 * time.Second
+)
+
+var projectPaasPushCmd = &cobra.Command{
+	Use:   "push [project-dir]",
+	Short: "Pushes the current commit to the PaaS environment, which starts a build and deployment",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		target, err := newPaasTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		project, err := target.client.GetProject(cmd.Context(), target.project)
+		if err != nil {
+			return err
+		}
+
+		if project.Repository.URL == "" {
+			return fmt.Errorf("PaaS project %s has no git repository", target.project)
+		}
+
+		pushArgs := []string{"push", project.Repository.URL, fmt.Sprintf("HEAD:refs/heads/%s", target.environment)}
+
+		if force, _ := cmd.Flags().GetBool("force"); force {
+			pushArgs = append(pushArgs, "--force")
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Pushing to environment %s of project %s", target.environment, target.project)
+
+		// the activity of this push is created after this point, older activities belong to previous pushes or deployments
+		pushedAt := time.Now().Truncate(time.Second)
+
+		if err := runTransparentCommand(commandWithRoot(process.Command(cmd.Context(), "git", pushArgs...), projectRoot)); err != nil {
+			return err
+		}
+
+		if wait, _ := cmd.Flags().GetBool("wait"); !wait {
+			return nil
+		}
+
+		activity, err := waitForPaasPushActivity(cmd.Context(), target, pushedAt)
+		if err != nil {
+			return err
+		}
+
+		return followPaasActivity(cmd, target, activity.ID)
+	},
+}
+
+// waitForPaasPushActivity polls the activities until the one created by the push is registered.
+func waitForPaasPushActivity(ctx context.Context, target *paasTarget, pushedAt time.Time) (*paas.Activity, error) {
+	for attempt := 0; attempt < paasPushActivityAttempts; attempt++ {
+		activity, err := target.client.GetActivityCreatedAfter(ctx, target.project, target.environment, pushedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if activity != nil {
+			return activity, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(paasPushActivityInterval):
+		}
+	}
+
+	return nil, fmt.Errorf("the push did not start an activity in environment %s", target.environment)
+}
+
+func init() {
+	projectPaasCmd.AddCommand(projectPaasPushCmd)
+	projectPaasPushCmd.Flags().Bool("force", false, "Force push to the environment")
+	projectPaasPushCmd.Flags().Bool("wait", false, "Streams the log of the started deployment")
+}
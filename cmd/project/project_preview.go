@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Manage review environments with a copy of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectPreviewCmd)
+}
@@ -0,0 +1,205 @@
+package project
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/doutorfinancas/go-mad/database"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectPreviewCreateCmd = &cobra.Command{
+	Use:   "create [source-database] [target-database]",
+	Short: "Copies the shop database anonymized into a review environment",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetString("port")
+		username, _ := cmd.Flags().GetString("username")
+		password, _ := cmd.Flags().GetString("password")
+		targetHost, _ := cmd.Flags().GetString("target-host")
+		targetPort, _ := cmd.Flags().GetString("target-port")
+		targetUsername, _ := cmd.Flags().GetString("target-username")
+		targetPassword, _ := cmd.Flags().GetString("target-password")
+		previewURL, _ := cmd.Flags().GetString("url")
+		adminUsername, _ := cmd.Flags().GetString("admin-username")
+		adminPassword, _ := cmd.Flags().GetString("admin-password")
+		skipLockTables, _ := cmd.Flags().GetBool("skip-lock-tables")
+
+		if previewURL == "" {
+			return fmt.Errorf("--url is required, f.e. --url https://review-42.example.com")
+		}
+
+		if args[0] == args[1] && (targetHost == "" || targetHost == host) {
+			return fmt.Errorf("the target database must not be the source database")
+		}
+
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnprotected(cmd, shopCfg, "create the preview database "+args[1]); err != nil {
+			return err
+		}
+
+		if targetHost == "" {
+			targetHost = host
+		}
+
+		if targetPort == "" {
+			targetPort = port
+		}
+
+		if targetUsername == "" {
+			targetUsername = username
+		}
+
+		if targetPassword == "" {
+			targetPassword = password
+		}
+
+		generatedAdminPassword := adminPassword == ""
+
+		if generatedAdminPassword {
+			if adminPassword, err = shop.GeneratePassword(); err != nil {
+				return err
+			}
+		}
+
+		// the password is hashed with PHP to get the same bcrypt hash as Shopware
+		passwordHash, err := hashPasswordWithPHP(cmd, adminPassword)
+		if err != nil {
+			return err
+		}
+
+		sourceCfg := database.NewConfig(username, password, host, port, args[0])
+
+		sourceDB, err := sql.Open("mysql", sourceCfg.ConnectionString())
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = sourceDB.Close()
+		}()
+
+		dumper, err := newDatabaseDumper(sourceDB, skipLockTables)
+		if err != nil {
+			return err
+		}
+
+		if err := applyDumpRules(dumper, true, true); err != nil {
+			return err
+		}
+
+		serverCfg := database.NewConfig(targetUsername, targetPassword, targetHost, targetPort, "")
+
+		serverDB, err := sql.Open("mysql", serverCfg.ConnectionString())
+		if err != nil {
+			return err
+		}
+
+		_, err = serverDB.ExecContext(cmd.Context(), fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", strings.ReplaceAll(args[1], "`", "``")))
+		_ = serverDB.Close()
+
+		if err != nil {
+			return fmt.Errorf("cannot create database %s: %w", args[1], err)
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Copying database %s anonymized into %s", args[0], args[1])
+
+		reader, writer := io.Pipe()
+
+		go func() {
+			writer.CloseWithError(dumper.Dump(writer))
+		}()
+
+		importCmd := process.Command(cmd.Context(), "mysql", "-h", targetHost, "-P", targetPort, "-u", targetUsername, args[1])
+		importCmd.Env = append(os.Environ(), fmt.Sprintf("MYSQL_PWD=%s", targetPassword))
+		importCmd.Stdin = reader
+		importCmd.Stdout = os.Stdout
+		importCmd.Stderr = os.Stderr
+
+		if err := importCmd.Run(); err != nil {
+			_ = reader.CloseWithError(err)
+
+			return fmt.Errorf("cannot import the database: %w", err)
+		}
+
+		targetCfg := database.NewConfig(targetUsername, targetPassword, targetHost, targetPort, args[1])
+
+		targetDB, err := sql.Open("mysql", targetCfg.ConnectionString())
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = targetDB.Close()
+		}()
+
+		if err := shop.PreparePreviewDatabase(cmd.Context(), targetDB, shop.PreviewOptions{
+			URL:               previewURL,
+			AdminUsername:     adminUsername,
+			AdminPasswordHash: passwordHash,
+		}); err != nil {
+			return err
+		}
+
+		if generatedAdminPassword {
+			logging.FromContext(cmd.Context()).Infof("Preview database %s is ready for %s, log in to the administration as %s with password %s", args[1], previewURL, adminUsername, adminPassword)
+		} else {
+			logging.FromContext(cmd.Context()).Infof("Preview database %s is ready for %s, log in to the administration as %s", args[1], previewURL, adminUsername)
+		}
+		logging.FromContext(cmd.Context()).Infof("Mails and webhooks are disabled, clear the cache and rebuild the search index of the preview")
+
+		return nil
+	},
+}
+
+func hashPasswordWithPHP(cmd *cobra.Command, password string) (string, error) {
+	shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+	if err != nil {
+		return "", err
+	}
+
+	projectRoot, _ := findClosestShopwareProject()
+
+	var stdout bytes.Buffer
+
+	phpCmd := newBackgroundProjectCommand(cmd.Context(), shopCfg, projectRoot, "php", "-r", "echo password_hash(stream_get_contents(STDIN), PASSWORD_BCRYPT);")
+	phpCmd.Stdin = strings.NewReader(password)
+	phpCmd.Stdout = &stdout
+	phpCmd.Stderr = os.Stderr
+
+	if err := phpCmd.Run(); err != nil {
+		return "", fmt.Errorf("cannot hash the admin password with php: %w", err)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func init() {
+	projectPreviewCmd.AddCommand(projectPreviewCreateCmd)
+	projectPreviewCreateCmd.Flags().String("host", "127.0.0.1", "hostname")
+	projectPreviewCreateCmd.Flags().String("username", "root", "mysql user")
+	projectPreviewCreateCmd.Flags().String("password", "root", "mysql password")
+	projectPreviewCreateCmd.Flags().String("port", "3306", "mysql port")
+	projectPreviewCreateCmd.Flags().String("target-host", "", "hostname of the preview database server (default: --host)")
+	projectPreviewCreateCmd.Flags().String("target-username", "", "mysql user of the preview database server (default: --username)")
+	projectPreviewCreateCmd.Flags().String("target-password", "", "mysql password of the preview database server (default: --password)")
+	projectPreviewCreateCmd.Flags().String("target-port", "", "mysql port of the preview database server (default: --port)")
+	projectPreviewCreateCmd.Flags().String("url", "", "URL of the review environment")
+	projectPreviewCreateCmd.Flags().String("admin-username", "admin", "Username of the admin user")
+	projectPreviewCreateCmd.Flags().String("admin-password", "", "Password of the admin user (default: a random password)")
+	projectPreviewCreateCmd.Flags().Bool("skip-lock-tables", false, "Skips locking the tables")
+	addProtectedFlags(projectPreviewCreateCmd.Flags())
+}
@@ -0,0 +1,49 @@
+package project
+
+import (
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+func addProtectedFlags(flags *pflag.FlagSet) {
+	flags.Bool("force", false, "Runs the command against a protected shop after a typed confirmation")
+}
+
+// ensureUnprotected guards destructive commands. Protected shops require --force and the host of the shop as
+// confirmation, which is prompted or read from SHOPWARE_CLI_CONFIRM_PROTECTED.
+func ensureUnprotected(cmd *cobra.Command, cfg *shop.Config, action string) error {
+	if !cfg.Protected {
+		return nil
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+
+	if !force {
+		return cfg.CheckProtection(action, false, "")
+	}
+
+	confirmation, ok := os.LookupEnv(shop.ProtectedConfirmationEnv)
+
+	if !ok {
+		if err := interaction.Ensure("confirmation", "set "+shop.ProtectedConfirmationEnv+"="+cfg.ProtectionConfirmation()+" to confirm it"); err != nil {
+			return err
+		}
+
+		p := promptui.Prompt{
+			Label: "The shop " + cfg.URL + " is protected, type " + cfg.ProtectionConfirmation() + " to " + action,
+		}
+
+		var err error
+		if confirmation, err = p.Run(); err != nil {
+			return err
+		}
+	}
+
+	return cfg.CheckProtection(action, true, confirmation)
+}
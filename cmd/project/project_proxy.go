@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/shop"
 )
@@ -40,7 +41,7 @@ var projectProxyCmd = &cobra.Command{
 
 		logging.FromContext(cobraCmd.Context()).Infof("Make sure you have set TRUSTED_PROXIES=127.0.0.1,::1 inside your .env file")
 
-		command := exec.Command(cloudflareInstalled, "tunnel", "--url", cfg.URL)
+		command := process.Command(cobraCmd.Context(), cloudflareInstalled, "tunnel", "--url", cfg.URL)
 		command.Stdin = os.Stdin
 		command.Stdout = os.Stdout
 		command.Stderr = os.Stderr
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Manage the message queue of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectQueueCmd)
+}
@@ -0,0 +1,170 @@
+package project
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/doutorfinancas/go-mad/database"
+	"github.com/manifoldco/promptui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+const (
+	failedMessageRetry   = "Retry"
+	failedMessageDiscard = "Discard"
+	failedMessageSkip    = "Skip"
+)
+
+var projectQueueRetryCmd = &cobra.Command{
+	Use:   "retry [database]",
+	Short: "Lists the failed messages and retries or discards them",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetString("port")
+		username, _ := cmd.Flags().GetString("username")
+		password, _ := cmd.Flags().GetString("password")
+		transport, _ := cmd.Flags().GetString("transport")
+		queue, _ := cmd.Flags().GetString("queue")
+		ids, _ := cmd.Flags().GetInt64Slice("id")
+		class, _ := cmd.Flags().GetString("class")
+		errorMessage, _ := cmd.Flags().GetString("error")
+		retryAll, _ := cmd.Flags().GetBool("retry")
+		discardAll, _ := cmd.Flags().GetBool("discard")
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		if retryAll && discardAll {
+			return fmt.Errorf("--retry and --discard cannot be used at same time")
+		}
+
+		cfg := database.NewConfig(username, password, host, port, args[0])
+
+		db, err := sql.Open("mysql", cfg.ConnectionString())
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = db.Close()
+		}()
+
+		messages, err := shop.ListFailedMessages(cmd.Context(), db, transport, shop.FailedMessageFilter{IDs: ids, Class: class, Error: errorMessage})
+		if err != nil {
+			return err
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(messages)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+
+			return nil
+		}
+
+		if len(messages) == 0 {
+			logging.FromContext(cmd.Context()).Infof("There are no failed messages")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Message", "Transport", "Failed At", "Error"})
+
+		for _, message := range messages {
+			table.Append([]string{strconv.FormatInt(message.ID, 10), message.Class, message.OriginalTransport, message.CreatedAt.Format("2006-01-02 15:04:05"), message.Error})
+		}
+
+		table.Render()
+
+		retried, discarded := 0, 0
+		discardConfirmed := false
+
+		for _, message := range messages {
+			action := failedMessageSkip
+
+			switch {
+			case retryAll:
+				action = failedMessageRetry
+			case discardAll:
+				action = failedMessageDiscard
+			default:
+				if err := interaction.Ensure("action", "use --retry or --discard together with the filters"); err != nil {
+					return err
+				}
+
+				prompt := promptui.Select{
+					Label: fmt.Sprintf("%d %s: %s", message.ID, message.Class, message.Error),
+					Items: []string{failedMessageRetry, failedMessageDiscard, failedMessageSkip},
+				}
+
+				if _, action, err = prompt.Run(); err != nil {
+					return err
+				}
+			}
+
+			switch action {
+			case failedMessageRetry:
+				target := queue
+				if target == "" {
+					target = message.RetryQueue()
+				}
+
+				if err := shop.RetryFailedMessage(cmd.Context(), db, message.ID, target); err != nil {
+					return fmt.Errorf("cannot retry message %d: %w", message.ID, err)
+				}
+
+				retried++
+			case failedMessageDiscard:
+				if !discardConfirmed {
+					shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+					if err != nil {
+						return err
+					}
+
+					if err := ensureUnprotected(cmd, shopCfg, "discard failed messages"); err != nil {
+						return err
+					}
+
+					discardConfirmed = true
+				}
+
+				if err := shop.DiscardFailedMessage(cmd.Context(), db, message.ID); err != nil {
+					return fmt.Errorf("cannot discard message %d: %w", message.ID, err)
+				}
+
+				discarded++
+			}
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Retried %d and discarded %d of %d failed messages", retried, discarded, len(messages))
+
+		return nil
+	},
+}
+
+func init() {
+	projectQueueCmd.AddCommand(projectQueueRetryCmd)
+	projectQueueRetryCmd.Flags().String("host", "127.0.0.1", "hostname")
+	projectQueueRetryCmd.Flags().String("username", "root", "mysql user")
+	projectQueueRetryCmd.Flags().String("password", "root", "mysql password")
+	projectQueueRetryCmd.Flags().String("port", "3306", "mysql port")
+	projectQueueRetryCmd.Flags().String("transport", "failed", "Queue name of the failure transport")
+	projectQueueRetryCmd.Flags().String("queue", "", "Queue name the retried messages are moved to, default is the queue of the transport the message failed on")
+	projectQueueRetryCmd.Flags().Int64Slice("id", []int64{}, "Only the messages with these ids")
+	projectQueueRetryCmd.Flags().String("class", "", "Only the messages whose class contains this text")
+	projectQueueRetryCmd.Flags().String("error", "", "Only the messages whose error contains this text")
+	projectQueueRetryCmd.Flags().Bool("retry", false, "Retries all matching messages without asking")
+	projectQueueRetryCmd.Flags().Bool("discard", false, "Discards all matching messages without asking")
+	projectQueueRetryCmd.Flags().Bool("json", false, "Lists the matching messages as json without changing them")
+	addProtectedFlags(projectQueueRetryCmd.Flags())
+}
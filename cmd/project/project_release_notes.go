@@ -0,0 +1,91 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var projectReleaseNotesCmd = &cobra.Command{
+	Use:   "release-notes [project-dir]",
+	Short: "Aggregates the changelogs of all extensions updated since a git ref or date into release notes",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		since, _ := cmd.Flags().GetString("since")
+		languages, _ := cmd.Flags().GetStringSlice("language")
+		output, _ := cmd.Flags().GetString("output")
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		notes, err := extension.CollectReleaseNotes(cmd.Context(), projectRoot, since)
+		if err != nil {
+			return err
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(notes)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+
+			return nil
+		}
+
+		if output == "" {
+			if len(languages) == 0 {
+				languages = []string{"en-GB"}
+			}
+
+			for _, language := range languages {
+				fmt.Print(extension.RenderReleaseNotes(notes, language))
+			}
+
+			return nil
+		}
+
+		if len(languages) == 0 {
+			languages = extension.ReleaseNotesLanguages(notes)
+		}
+
+		if err := os.MkdirAll(output, 0o755); err != nil {
+			return err
+		}
+
+		for _, language := range languages {
+			file := filepath.Join(output, fmt.Sprintf("RELEASE_NOTES_%s.md", language))
+
+			if err := os.WriteFile(file, []byte(extension.RenderReleaseNotes(notes, language)), 0o644); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Written the release notes of %d extensions to %s", len(notes), file)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectReleaseNotesCmd)
+	projectReleaseNotesCmd.Flags().String("since", "", "Git ref like a tag or a date (YYYY-MM-DD) of the previous deployment")
+	projectReleaseNotesCmd.Flags().StringSlice("language", []string{}, "Language of the release notes like en-GB, can be passed multiple times. Defaults to en-GB or all languages of the changelogs with --output")
+	projectReleaseNotesCmd.Flags().String("output", "", "Writes a RELEASE_NOTES_<language>.md per language into this directory instead of printing the english release notes")
+	projectReleaseNotesCmd.Flags().Bool("json", false, "Output the changelog versions as JSON")
+	_ = projectReleaseNotesCmd.MarkFlagRequired("since")
+}
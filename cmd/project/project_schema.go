@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Work with the entity schema of the shop",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectSchemaCmd)
+}
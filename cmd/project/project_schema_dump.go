@@ -0,0 +1,97 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectSchemaDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Generates TypeScript or Go types from the entity schema of the shop",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		entities, _ := cmd.Flags().GetStringSlice("entity")
+		packageName, _ := cmd.Flags().GetString("package")
+		skipCustomFields, _ := cmd.Flags().GetBool("skip-custom-fields")
+
+		if format != "ts" && format != "go" && format != "json" {
+			return fmt.Errorf("unsupported format %s, use ts, go or json", format)
+		}
+
+		client, err := shop.NewShopClient(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		apiCtx := adminSdk.NewApiContext(cmd.Context())
+
+		schema, err := shop.FetchEntitySchema(apiCtx, client)
+		if err != nil {
+			return err
+		}
+
+		if schema, err = schema.Filter(entities); err != nil {
+			return err
+		}
+
+		customFields := map[string][]shop.CustomField{}
+
+		if !skipCustomFields && format != "json" {
+			if customFields, err = shop.FetchCustomFields(apiCtx, client); err != nil {
+				return err
+			}
+		}
+
+		var content []byte
+
+		switch format {
+		case "ts":
+			content = shop.GenerateTypeScript(schema, customFields)
+		case "go":
+			if content, err = shop.GenerateGo(schema, customFields, packageName); err != nil {
+				return err
+			}
+		case "json":
+			if content, err = json.MarshalIndent(schema, "", "  "); err != nil {
+				return err
+			}
+
+			content = append(content, '\n')
+		}
+
+		if output == "" {
+			fmt.Print(string(content))
+			return nil
+		}
+
+		if err := os.WriteFile(output, content, 0o644); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Written the types of %d entities to %s", len(schema), output)
+
+		return nil
+	},
+}
+
+func init() {
+	projectSchemaCmd.AddCommand(projectSchemaDumpCmd)
+	projectSchemaDumpCmd.Flags().String("format", "ts", "Output format (ts, go, json)")
+	projectSchemaDumpCmd.Flags().String("output", "", "Write the types into this file instead of stdout")
+	projectSchemaDumpCmd.Flags().StringSlice("entity", []string{}, "Only generate these entities, can be passed multiple times")
+	projectSchemaDumpCmd.Flags().String("package", "entities", "Package name of the generated Go file")
+	projectSchemaDumpCmd.Flags().Bool("skip-custom-fields", false, "Do not generate types for the custom fields")
+}
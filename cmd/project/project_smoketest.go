@@ -0,0 +1,112 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectSmokeTestCmd = &cobra.Command{
+	Use:   "smoke-test",
+	Short: "Places a guest order with the store-api to verify a deployment",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		smokeTestCfg := shop.ConfigSmokeTest{}
+		if cfg.SmokeTest != nil {
+			smokeTestCfg = *cfg.SmokeTest
+		}
+
+		if accessKey, _ := cmd.Flags().GetString("access-key"); accessKey != "" {
+			smokeTestCfg.AccessKey = accessKey
+		}
+
+		if productNumber, _ := cmd.Flags().GetString("product-number"); productNumber != "" {
+			smokeTestCfg.ProductNumber = productNumber
+		}
+
+		if paymentMethod, _ := cmd.Flags().GetString("payment-method"); paymentMethod != "" {
+			smokeTestCfg.PaymentMethod = paymentMethod
+		}
+
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+		salesChannelName, _ := cmd.Flags().GetString("sales-channel")
+		baseURL := cfg.URL
+
+		if salesChannelName != "" || (smokeTestCfg.AccessKey == "" && cfg.StoreApi != nil) {
+			salesChannel, err := cfg.StoreApiSalesChannel(salesChannelName)
+			if err != nil {
+				return err
+			}
+
+			smokeTestCfg.AccessKey = salesChannel.AccessKey
+			baseURL = salesChannel.URL
+		}
+
+		if smokeTestCfg.AccessKey == "" {
+			return fmt.Errorf("the access key of the sales channel is required, pass --access-key or configure smoke_test.access_key or store_api.sales_channels in .shopware-project.yml")
+		}
+
+		if baseURL == "" {
+			return fmt.Errorf("url is not configured in .shopware-project.yml")
+		}
+
+		steps := shop.RunSmokeTest(cmd.Context(), http.DefaultClient, baseURL, smokeTestCfg)
+
+		if outputAsJson {
+			content, err := json.Marshal(steps)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+		} else {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Step", "Result", "Duration", "Detail"})
+
+			for _, step := range steps {
+				result := "passed"
+				detail := step.Detail
+
+				switch {
+				case step.Skipped:
+					result = "skipped"
+				case !step.Passed:
+					result = "failed"
+					detail = step.Error
+				}
+
+				table.Append([]string{step.Name, result, step.Duration.Round(1e6).String(), detail})
+			}
+
+			table.Render()
+		}
+
+		if !shop.SmokeTestPassed(steps) {
+			return fmt.Errorf("the smoke test failed")
+		}
+
+		logging.FromContext(cmd.Context()).Infof("The smoke test passed")
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectSmokeTestCmd)
+	projectSmokeTestCmd.Flags().String("access-key", "", "Access key of the sales channel (default smoke_test.access_key)")
+	projectSmokeTestCmd.Flags().String("sales-channel", "", "Name of the sales channel in store_api.sales_channels")
+	projectSmokeTestCmd.Flags().String("product-number", "", "Product to add to the cart (default the first available product)")
+	projectSmokeTestCmd.Flags().String("payment-method", "", "Technical name, handler or name of the payment method (default the first available one)")
+	projectSmokeTestCmd.Flags().Bool("json", false, "Output as json")
+}
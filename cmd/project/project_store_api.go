@@ -0,0 +1,74 @@
+package project
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/curl"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+var projectStoreApiCmd = &cobra.Command{
+	Use:   "store-api [method] [path]",
+	Short: "curl interface to the store-api with the access key of a sales channel",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg, err := shop.ReadConfig(projectConfigPath, false)
+		if err != nil {
+			return err
+		}
+
+		salesChannelName, _ := cobraCmd.Flags().GetString("sales-channel")
+		contextToken, _ := cobraCmd.Flags().GetString("context-token")
+		noDefaultHeaders, _ := cobraCmd.Flags().GetBool("no-default-headers")
+
+		salesChannel, err := cfg.StoreApiSalesChannel(salesChannelName)
+		if err != nil {
+			return err
+		}
+
+		shopURL, err := url.Parse(salesChannel.URL)
+		if err != nil {
+			return err
+		}
+
+		apiPath, err := parseStoreApiPath(args[1])
+		if err != nil {
+			return err
+		}
+
+		commandConfig := []curl.Config{
+			curl.Url(shopURL.ResolveReference(apiPath)),
+			curl.Method(args[0]),
+			curl.Header("sw-access-key", salesChannel.AccessKey),
+			curl.Args(args[2:]),
+		}
+
+		if contextToken != "" {
+			commandConfig = append(commandConfig, curl.Header("sw-context-token", contextToken))
+		}
+
+		if !noDefaultHeaders {
+			commandConfig = append(commandConfig, curl.Header("content-type", "application/json"))
+			commandConfig = append(commandConfig, curl.Header("accept", "application/json"))
+		}
+
+		return curl.InitCurlCommand(commandConfig...).Run()
+	},
+}
+
+func parseStoreApiPath(inputPath string) (*url.URL, error) {
+	inputPath = strings.TrimPrefix(inputPath, "/store-api")
+	inputPath = strings.TrimPrefix(inputPath, "store-api")
+	return url.Parse(path.Join("store-api", inputPath))
+}
+
+func init() {
+	projectStoreApiCmd.Flags().String("sales-channel", "", "Name of the sales channel in store_api.sales_channels, required when multiple are configured")
+	projectStoreApiCmd.Flags().String("context-token", "", "Context token of an existing cart or customer session")
+	projectStoreApiCmd.Flags().Bool("no-default-headers", false, "skips setting the content-type and accept headers")
+	projectRootCmd.AddCommand(projectStoreApiCmd)
+}
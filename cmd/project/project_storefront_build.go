@@ -1,10 +1,9 @@
 package project
 
 import (
-	"os/exec"
-
 	"github.com/FriendsOfShopware/shopware-cli/extension"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +20,11 @@ var projectStorefrontBuildCmd = &cobra.Command{
 			return err
 		}
 
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
 		logging.FromContext(cmd.Context()).Infof("Looking for extensions to build assets in project")
 
 		sources := extension.FindAssetSourcesOfProject(cmd.Context(), projectRoot)
@@ -33,16 +37,33 @@ var projectStorefrontBuildCmd = &cobra.Command{
 			DisableAdminBuild: true,
 			ShopwareRoot:      projectRoot,
 			ShopwareVersion:   constraint,
+			Concurrency:       assetBuildConcurrency(cmd, shopCfg),
+		}
+
+		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, withProjectWebpackConfig(assetCfg, projectRoot, shopCfg)); err != nil {
+			return err
 		}
 
-		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
+		consoleCmd, err := newConsoleCommand(cmd, projectRoot, shopCfg, "theme:compile")
+		if err != nil {
 			return err
 		}
 
-		return runTransparentCommand(commandWithRoot(exec.CommandContext(cmd.Context(), "php", "bin/console", "theme:compile"), projectRoot))
+		if err := runTransparentCommand(consoleCmd); err != nil {
+			return err
+		}
+
+		// the compiled theme in public/theme needs the CDN URLs as well, the rewrite skips URLs pointing to the CDN already
+		if shopCfg.Build.Assets.Strategy == shop.AssetStrategyCDN {
+			return applyAssetStrategy(projectRoot, shopCfg, sources)
+		}
+
+		return nil
 	},
 }
 
 func init() {
 	projectRootCmd.AddCommand(projectStorefrontBuildCmd)
+	addConsoleFlags(projectStorefrontBuildCmd.Flags())
+	projectStorefrontBuildCmd.Flags().Int("concurrency", 0, "Number of extensions built in parallel, defaults to build.asset_concurrency or the CPU count")
 }
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var projectTemplateConflictsCmd = &cobra.Command{
+	Use:   "template-conflicts [project-dir]",
+	Short: "Reports storefront blocks which are overridden by multiple extensions",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		outputAsJson, _ := cmd.Flags().GetBool("json")
+
+		conflicts, err := extension.FindTemplateBlockConflicts(cmd.Context(), projectRoot)
+		if err != nil {
+			return err
+		}
+
+		if outputAsJson {
+			content, err := json.Marshal(conflicts)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(content))
+
+			return nil
+		}
+
+		if len(conflicts) == 0 {
+			logging.FromContext(cmd.Context()).Infof("No block is overridden by multiple extensions")
+
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetColWidth(100)
+		table.SetHeader([]string{"Theme", "Template", "Block", "Resolution order", "Not rendered"})
+
+		for _, conflict := range conflicts {
+			hidden := ""
+			if conflict.ReplacedBy != "" {
+				hidden = fmt.Sprintf("%s (replaced by %s)", strings.Join(conflict.Hidden, ", "), conflict.ReplacedBy)
+			}
+
+			table.Append([]string{conflict.Theme, conflict.Template, conflict.Block, strings.Join(conflict.Extensions, " -> "), hidden})
+		}
+
+		table.Render()
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectTemplateConflictsCmd)
+	projectTemplateConflictsCmd.Flags().Bool("json", false, "Output as JSON")
+}
@@ -0,0 +1,12 @@
+package project
+
+import "github.com/spf13/cobra"
+
+var projectThemeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Inspect the themes of the Shopware project",
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectThemeCmd)
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/extension"
+)
+
+var projectThemeTreeCmd = &cobra.Command{
+	Use:   "tree [project-dir]",
+	Short: "Shows the theme inheritance and template overrides",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		inheritance, err := extension.BuildThemeInheritance(cmd.Context(), projectRoot)
+		if err != nil {
+			return err
+		}
+
+		if outputAsDot, _ := cmd.Flags().GetBool("dot"); outputAsDot {
+			fmt.Print(inheritance.RenderDot())
+			return nil
+		}
+
+		printThemeTree(inheritance, "Storefront", "")
+
+		for _, theme := range inheritance.Themes {
+			if len(theme.Templates) == 0 {
+				continue
+			}
+
+			fmt.Printf("\nTemplate overrides of %s (view order: %s)\n", theme.Name, strings.Join(theme.Views, ", "))
+
+			for _, template := range theme.Templates {
+				fmt.Printf("  %s\n    %s\n", template.Template, strings.Join(template.ProvidedBy, " -> "))
+			}
+		}
+
+		return nil
+	},
+}
+
+func printThemeTree(inheritance *extension.ThemeInheritance, name, indent string) {
+	if indent == "" {
+		fmt.Println(name)
+	}
+
+	children := inheritance.Children(name)
+
+	for i, child := range children {
+		prefix, childIndent := "├── ", "│   "
+
+		if i == len(children)-1 {
+			prefix, childIndent = "└── ", "    "
+		}
+
+		fmt.Printf("%s%s%s\n", indent, prefix, child.Name)
+		printThemeTree(inheritance, child.Name, indent+childIndent)
+	}
+}
+
+func init() {
+	projectThemeCmd.AddCommand(projectThemeTreeCmd)
+	projectThemeTreeCmd.Flags().Bool("dot", false, "Output the graph in the Graphviz DOT format")
+}
@@ -0,0 +1,136 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
+)
+
+// upgradeStep is a command of the upgrade, optional steps are reported but do not stop the upgrade.
+type upgradeStep struct {
+	name     string
+	command  func() (*exec.Cmd, error)
+	optional bool
+}
+
+var projectUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [project-dir]",
+	Short: "Upgrades the Shopware version of the project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot = args[0]
+		} else if projectRoot, err = findClosestShopwareProject(); err != nil {
+			return err
+		}
+
+		to, _ := cmd.Flags().GetString("to")
+		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		reportPath, _ := cmd.Flags().GetString("report")
+
+		if to == "" {
+			return fmt.Errorf("please specify the target version with --to, f.e. --to 6.6")
+		}
+
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		plan, err := shop.PlanUpgrade(projectRoot, to)
+		if err != nil {
+			return err
+		}
+
+		report := shop.UpgradeReport{UpgradePlan: *plan, Steps: make([]shop.UpgradeStep, 0)}
+
+		upgradeErr := func() error {
+			if len(plan.Blockers) > 0 && !force {
+				return fmt.Errorf("%d extensions do not allow Shopware %s, update them first or use --force", len(plan.Blockers), to)
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			steps := []upgradeStep{
+				{name: "Prepare the update", command: func() (*exec.Cmd, error) {
+					return newConsoleCommand(cmd, projectRoot, shopCfg, "system:update:prepare", "--no-interaction")
+				}},
+				{name: "Update the composer constraints", command: func() (*exec.Cmd, error) {
+					return newProjectCommand(cmd.Context(), shopCfg, projectRoot, "composer", plan.ComposerRequireArgs()...), nil
+				}},
+				{name: "Update the composer dependencies", command: func() (*exec.Cmd, error) {
+					return newProjectCommand(cmd.Context(), shopCfg, projectRoot, "composer", "update", "--with-all-dependencies", "--no-interaction", "--no-scripts"), nil
+				}},
+			}
+
+			for _, name := range plan.Packages {
+				name := name
+
+				steps = append(steps, upgradeStep{name: "Update the recipe of " + name, optional: true, command: func() (*exec.Cmd, error) {
+					return newProjectCommand(cmd.Context(), shopCfg, projectRoot, "composer", "recipes:update", "--no-interaction", name), nil
+				}})
+			}
+
+			steps = append(steps, upgradeStep{name: "Run the migrations", command: func() (*exec.Cmd, error) {
+				return newConsoleCommand(cmd, projectRoot, shopCfg, "system:update:finish", "--no-interaction")
+			}})
+
+			for _, step := range steps {
+				stepCmd, err := step.command()
+				if err != nil {
+					return err
+				}
+
+				logging.FromContext(cmd.Context()).Infof("%s", step.name)
+
+				result := shop.UpgradeStep{Name: step.name, Command: strings.Join(stepCmd.Args, " "), Optional: step.optional}
+
+				if err := runTransparentCommand(stepCmd); err != nil {
+					result.Error = err.Error()
+					report.Steps = append(report.Steps, result)
+
+					if step.optional {
+						logging.FromContext(cmd.Context()).Warnf("%s failed: %s", step.name, err.Error())
+						continue
+					}
+
+					return fmt.Errorf("upgrade step \"%s\" failed: %w", step.name, err)
+				}
+
+				report.Steps = append(report.Steps, result)
+			}
+
+			return nil
+		}()
+
+		if reportPath == "" {
+			fmt.Print(report.Markdown())
+		} else if err := os.WriteFile(reportPath, []byte(report.Markdown()), 0o644); err != nil {
+			return err
+		} else {
+			logging.FromContext(cmd.Context()).Infof("Wrote the upgrade report to %s", reportPath)
+		}
+
+		return upgradeErr
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectUpgradeCmd)
+	projectUpgradeCmd.Flags().String("to", "", "Target Shopware version, f.e. 6.6 or 6.6.1.0")
+	projectUpgradeCmd.Flags().Bool("force", false, "Upgrade also when installed extensions do not allow the target version")
+	projectUpgradeCmd.Flags().Bool("dry-run", false, "Only report the blocking extensions without changing the project")
+	projectUpgradeCmd.Flags().String("report", "", "Writes the upgrade report as Markdown into this file instead of printing it")
+	addConsoleFlags(projectUpgradeCmd.Flags())
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"strconv"
 	"strings"
@@ -35,6 +34,11 @@ var projectWorkerCmd = &cobra.Command{
 			return err
 		}
 
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
 		if len(args) > 0 {
 			workerAmount, err = strconv.Atoi(args[0])
 
@@ -73,8 +77,7 @@ var projectWorkerCmd = &cobra.Command{
 			wg.Add(1)
 			go func(ctx context.Context) {
 				for {
-					cmd := exec.CommandContext(cancelCtx, "php", consumeArgs...)
-					cmd.Dir = projectRoot
+					cmd := newBackgroundProjectCommand(cancelCtx, shopCfg, projectRoot, "php", consumeArgs...)
 					cmd.Stdout = os.Stdout
 					cmd.Stderr = os.Stderr
 
@@ -2,6 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -9,13 +15,21 @@ import (
 	"github.com/FriendsOfShopware/shopware-cli/cmd/account"
 	"github.com/FriendsOfShopware/shopware-cli/cmd/extension"
 	"github.com/FriendsOfShopware/shopware-cli/cmd/project"
+	"github.com/FriendsOfShopware/shopware-cli/internal/cliplugin"
 	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/internal/httpdump"
+	"github.com/FriendsOfShopware/shopware-cli/internal/interaction"
+	"github.com/FriendsOfShopware/shopware-cli/internal/offline"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/shop"
 )
 
 var (
-	cfgFile string
-	version = "dev"
+	cfgFile       string
+	noInteraction bool
+	dumpHTTP      string
+	offlineMode   bool
+	version       = "dev"
 )
 
 var rootCmd = &cobra.Command{
@@ -26,7 +40,39 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute(ctx context.Context) {
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
+	// the flags of plugin commands are not parsed, so --config is taken from the arguments up front
+	cfgFile = cliplugin.ConfigFlag(os.Args[1:])
+	registerPlugins(ctx, os.Args[1:])
+
+	cmdCtx, cancel := withCancellation(ctx)
+	defer cancel(nil)
+
+	cancelCommand = cancel
+
+	start := time.Now()
+	executedCmd, err := rootCmd.ExecuteContextC(cmdCtx)
+
+	sendTelemetry(ctx, executedCmd, time.Since(start))
+
+	if err != nil {
+		// Report the signal or timeout instead of the errors of the interrupted subprocesses and requests
+		if cmdCtx.Err() != nil {
+			logging.FromContext(ctx).Fatalf("%v: %v", context.Cause(cmdCtx), err)
+		}
+
+		// Pass the exit code of commands like bin/console through to the caller
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			logging.FromContext(ctx).Errorln(err)
+			os.Exit(exitErr.ExitCode())
+		}
+
+		// the error lists the privileges as well, so it is only logged in debug mode
+		if privileges := shop.MissingPrivileges(err); len(privileges) > 0 {
+			logging.FromContext(ctx).Debugln(err)
+			logging.FromContext(ctx).Fatalf("The Admin API credentials are missing the privileges %s, add them to an ACL role of the integration or create one with shopware-cli project integration create --feature", strings.Join(privileges, ", "))
+		}
+
 		logging.FromContext(ctx).Fatalln(err)
 	}
 }
@@ -36,10 +82,31 @@ func init() {
 
 	cobra.OnInitialize(func() {
 		_ = config.InitConfig(cfgFile)
+		interaction.SetNonInteractive(noInteraction)
+
+		if dumpHTTP != "" {
+			if err := httpdump.Enable(dumpHTTP); err != nil {
+				logging.FromContext(rootCmd.Context()).Fatalf("Cannot create HTTP dump directory: %v", err)
+			}
+
+			http.DefaultTransport = httpdump.Wrap(http.DefaultTransport)
+		}
+
+		if offlineMode {
+			offline.Enable()
+		}
+
+		startCommandTimeout()
+
+		http.DefaultTransport = offline.Wrap(http.DefaultTransport)
 	})
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.shopware-cli.yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "show debug output")
+	rootCmd.PersistentFlags().StringVar(&dumpHTTP, "dump-http", "", "record all HTTP requests and responses with redacted secrets into this directory")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "forbid all network access except to the local machine and use bundled data instead")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "cancel the command when it runs longer, f.e. 30m (default no timeout)")
+	rootCmd.PersistentFlags().BoolVar(&noInteraction, "no-interaction", false, "fail instead of prompting for input, enabled automatically in CI")
 
 	project.Register(rootCmd)
 	extension.Register(rootCmd)
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/config"
+	"github.com/FriendsOfShopware/shopware-cli/internal/offline"
+	"github.com/FriendsOfShopware/shopware-cli/internal/telemetry"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Manage the opt-in anonymous usage telemetry",
+	Long: `When enabled, shopware-cli sends the name of the executed command (without arguments),
+its duration, the CLI version and the operating system after each command.
+Nothing else is sent. Telemetry is disabled by default.`,
+}
+
+var telemetryEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enables sending anonymous usage data",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return setTelemetry(cmd.Context(), true)
+	},
+}
+
+var telemetryDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disables sending anonymous usage data",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return setTelemetry(cmd.Context(), false)
+	},
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows whether anonymous usage data is sent",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if err := config.InitConfig(cfgFile); err != nil {
+			return err
+		}
+
+		switch {
+		case telemetry.IsDisabledByEnvironment():
+			fmt.Println("Telemetry is disabled by the DO_NOT_TRACK or SHOPWARE_CLI_DISABLE_TELEMETRY environment variable")
+		case config.Config{}.GetTelemetryEnabled():
+			fmt.Printf("Telemetry is enabled and sent to %s\n", telemetry.Endpoint())
+		default:
+			fmt.Println("Telemetry is disabled")
+		}
+
+		return nil
+	},
+}
+
+func setTelemetry(ctx context.Context, enabled bool) error {
+	if err := config.InitConfig(cfgFile); err != nil {
+		return err
+	}
+
+	conf := config.Config{}
+
+	if err := conf.SetTelemetryEnabled(enabled); err != nil {
+		return err
+	}
+
+	if err := conf.Save(); err != nil {
+		return err
+	}
+
+	if enabled {
+		logging.FromContext(ctx).Infof("Telemetry has been enabled, thank you! Only the command name, duration, CLI version and operating system are sent")
+	} else {
+		logging.FromContext(ctx).Infof("Telemetry has been disabled")
+	}
+
+	return nil
+}
+
+// sendTelemetry reports the executed command, when the user has opted in.
+func sendTelemetry(ctx context.Context, cmd *cobra.Command, duration time.Duration) {
+	if cmd == nil || offline.IsEnabled() || telemetry.IsDisabledByEnvironment() || !(config.Config{}).GetTelemetryEnabled() {
+		return
+	}
+
+	name := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()))
+
+	if name == "" || strings.HasPrefix(name, telemetryCmd.Name()) {
+		return
+	}
+
+	// plugin names can be company specific
+	if _, ok := cmd.Annotations[pluginAnnotation]; ok {
+		name = "plugin"
+	}
+
+	if err := telemetry.Send(ctx, telemetry.Endpoint(), telemetry.NewEvent(name, duration, version)); err != nil {
+		logging.FromContext(ctx).Debugf("Cannot send telemetry: %v", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+	telemetryCmd.AddCommand(telemetryEnableCmd)
+	telemetryCmd.AddCommand(telemetryDisableCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+}
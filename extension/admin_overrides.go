@@ -0,0 +1,251 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+var (
+	adminComponentCallRegex  = regexp.MustCompile(`Component\.(register|override|extend)\(\s*['"]([\w-]+)['"](?:\s*,\s*['"]([\w-]+)['"])?`)
+	adminTemplateImportRegex = regexp.MustCompile(`import\s+\w+\s+from\s+['"](\.[^'"]+\.html\.twig)['"]`)
+	adminTemplateTagRegex    = regexp.MustCompile(`{%-?\s*(block\s+([\w-]+)|endblock)\b`)
+)
+
+// adminOverride is a Component.override or Component.extend of a component, which is not registered by the extension itself.
+type adminOverride struct {
+	File      string
+	Line      int
+	Component string
+	// Template is the file of the imported template relative to the extension, empty without template
+	Template string
+}
+
+// adminComponentIndexLoader returns the blocks by component of a Shopware version.
+type adminComponentIndexLoader func(shopwareVersion string) (map[string][]string, error)
+
+func validateAdminOverrides(c context.Context, ctx *ValidationContext) {
+	plugin, ok := asPlatformPlugin(ctx.Extension)
+	if !ok {
+		return
+	}
+
+	overrides := findAdminOverrides(plugin.GetPath(), filepath.Join(plugin.GetResourcesDir(), "app", "administration", "src"))
+	if len(overrides) == 0 {
+		return
+	}
+
+	constraint, err := ctx.Extension.GetShopwareVersionConstraint()
+	if err != nil {
+		return
+	}
+
+	versions, err := staticdata.ShopwareVersions(c)
+	if err != nil {
+		ctx.AddWarning(fmt.Sprintf("Admin overrides were not checked: %s", err.Error()))
+		return
+	}
+
+	checkAdminOverrides(ctx, overrides, adminOverrideTargetVersions(constraint, versions), func(shopwareVersion string) (map[string][]string, error) {
+		return staticdata.AdminComponents(c, shopwareVersion)
+	})
+}
+
+// adminOverrideTargetVersions returns the lowest and highest release matching the constraint.
+func adminOverrideTargetVersions(constraint *version.Constraints, versions []string) []string {
+	matching := make([]*version.Version, 0)
+
+	for _, raw := range versions {
+		v, err := version.NewVersion(raw)
+		if err != nil || v.IsPrerelease() || !constraint.Check(v) {
+			continue
+		}
+
+		matching = append(matching, v)
+	}
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	sort.Sort(version.Collection(matching))
+
+	lowest, highest := matching[0].Original(), matching[len(matching)-1].Original()
+	if lowest == highest {
+		return []string{lowest}
+	}
+
+	return []string{lowest, highest}
+}
+
+// checkAdminOverrides reports overridden components and blocks missing in the index of the core components as warnings, the
+// index may lag behind a release. Components without the sw- prefix come from other extensions and are not checked.
+func checkAdminOverrides(ctx *ValidationContext, overrides []adminOverride, targetVersions []string, loadIndex adminComponentIndexLoader) {
+	for _, shopwareVersion := range targetVersions {
+		index, err := loadIndex(shopwareVersion)
+		if err != nil {
+			ctx.AddWarning(fmt.Sprintf("Admin overrides were not checked against Shopware %s: %s", shopwareVersion, err.Error()))
+			continue
+		}
+
+		for _, override := range overrides {
+			if !strings.HasPrefix(override.Component, "sw-") {
+				continue
+			}
+
+			blocks, exists := index[override.Component]
+			if !exists {
+				ctx.AddFileWarning(override.File, override.Line, fmt.Sprintf("The admin component %s does not exist in Shopware %s", override.Component, shopwareVersion))
+				continue
+			}
+
+			if override.Template == "" {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(ctx.Extension.GetPath(), override.Template))
+			if err != nil {
+				continue
+			}
+
+			for _, block := range topLevelTwigBlocks(string(content)) {
+				if !containsString(blocks, block.Name) {
+					ctx.AddFileWarning(override.Template, block.Line, fmt.Sprintf("The block %s does not exist in the admin component %s of Shopware %s", block.Name, override.Component, shopwareVersion))
+				}
+			}
+		}
+	}
+}
+
+// findAdminOverrides returns the overridden and extended components of the administration sources, components registered
+// by the extension itself are skipped.
+func findAdminOverrides(extensionRoot, adminRoot string) []adminOverride {
+	overrides := make([]adminOverride, 0)
+	registered := make(map[string]bool)
+
+	_ = filepath.WalkDir(adminRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if ext := filepath.Ext(path); ext != ".js" && ext != ".ts" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(extensionRoot, path)
+
+		for _, match := range adminComponentCallRegex.FindAllStringSubmatchIndex(string(content), -1) {
+			call, name := string(content[match[2]:match[3]]), string(content[match[4]:match[5]])
+
+			override := adminOverride{
+				File:      filepath.ToSlash(relPath),
+				Line:      strings.Count(string(content[:match[0]]), "\n") + 1,
+				Component: name,
+			}
+
+			switch call {
+			case "register":
+				registered[name] = true
+				continue
+			case "extend":
+				registered[name] = true
+
+				if match[6] == -1 {
+					continue
+				}
+
+				override.Component = string(content[match[6]:match[7]])
+			}
+
+			override.Template = adminOverrideTemplate(extensionRoot, path, string(content))
+			overrides = append(overrides, override)
+		}
+
+		return nil
+	})
+
+	filtered := make([]adminOverride, 0, len(overrides))
+
+	for _, override := range overrides {
+		if !registered[override.Component] {
+			filtered = append(filtered, override)
+		}
+	}
+
+	return filtered
+}
+
+// adminOverrideTemplate returns the twig template imported by the component file.
+func adminOverrideTemplate(extensionRoot, file, content string) string {
+	match := adminTemplateImportRegex.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+
+	relPath, err := filepath.Rel(extensionRoot, filepath.Join(filepath.Dir(file), match[1]))
+	if err != nil {
+		return ""
+	}
+
+	return filepath.ToSlash(relPath)
+}
+
+type twigBlock struct {
+	Name string
+	Line int
+}
+
+// topLevelTwigBlocks returns the blocks which are not nested in another block, only those have to exist in the overridden template.
+func topLevelTwigBlocks(content string) []twigBlock {
+	blocks := make([]twigBlock, 0)
+	depth := 0
+
+	for _, match := range adminTemplateTagRegex.FindAllStringSubmatchIndex(content, -1) {
+		if content[match[2]:match[3]] == "endblock" {
+			if depth > 0 {
+				depth--
+			}
+
+			continue
+		}
+
+		if depth == 0 {
+			blocks = append(blocks, twigBlock{Name: content[match[4]:match[5]], Line: strings.Count(content[:match[0]], "\n") + 1})
+		}
+
+		depth++
+	}
+
+	return blocks
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package extension
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+func TestFindAdminOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"src/Resources/app/administration/src/module/sw-product-detail/index.js":                    "import template from './sw-product-detail.html.twig';\n\nShopware.Component.override('sw-product-detail', {\n    template,\n});\n",
+		"src/Resources/app/administration/src/module/sw-product-detail/sw-product-detail.html.twig": "{% block sw_product_detail %}{% endblock %}",
+		"src/Resources/app/administration/src/component/frosh-card/index.ts":                        "const { Component } = Shopware;\n\nComponent.extend('frosh-card', 'sw-card', {});\nComponent.override('frosh-card', {});\n",
+		"src/Resources/app/administration/src/component/frosh-list/index.js":                        "Component.register('frosh-list', {});\nComponent.extend('frosh-list-extended', 'frosh-list', {});\n",
+		"src/Resources/app/administration/node_modules/foo/index.js":                                "Component.override('sw-foo', {});",
+	})
+
+	overrides := findAdminOverrides(dir, filepath.Join(dir, "src", "Resources", "app", "administration", "src"))
+
+	assert.ElementsMatch(t, []adminOverride{
+		{
+			File:      "src/Resources/app/administration/src/module/sw-product-detail/index.js",
+			Line:      3,
+			Component: "sw-product-detail",
+			Template:  "src/Resources/app/administration/src/module/sw-product-detail/sw-product-detail.html.twig",
+		},
+		{
+			File:      "src/Resources/app/administration/src/component/frosh-card/index.ts",
+			Line:      3,
+			Component: "sw-card",
+		},
+	}, overrides)
+}
+
+func TestTopLevelTwigBlocks(t *testing.T) {
+	content := `{% block sw_product_detail_content %}
+    {% parent %}
+    {% block frosh_product_detail_content %}
+        <frosh-card />
+    {% endblock %}
+{% endblock %}
+
+{%- block sw_product_detail_actions -%}
+{% endblock %}`
+
+	assert.Equal(t, []twigBlock{
+		{Name: "sw_product_detail_content", Line: 1},
+		{Name: "sw_product_detail_actions", Line: 8},
+	}, topLevelTwigBlocks(content))
+}
+
+func TestAdminOverrideTargetVersions(t *testing.T) {
+	constraint, _ := version.NewConstraint("~6.5.0")
+
+	assert.Equal(t, []string{"6.5.0.0", "6.5.8.1"}, adminOverrideTargetVersions(&constraint, []string{"6.4.20.2", "6.5.0.0", "6.5.8.1", "6.6.0.0-rc1", "6.6.0.0"}))
+	assert.Equal(t, []string{"6.5.0.0"}, adminOverrideTargetVersions(&constraint, []string{"6.5.0.0"}))
+	assert.Nil(t, adminOverrideTargetVersions(&constraint, []string{"6.4.20.2"}))
+}
+
+func TestCheckAdminOverrides(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"src/detail.html.twig": "{% block sw_product_detail_content %}{% endblock %}\n{% block sw_product_detail_removed %}{% endblock %}",
+	})
+
+	overrides := []adminOverride{
+		{File: "src/detail.js", Line: 3, Component: "sw-product-detail", Template: "src/detail.html.twig"},
+		{File: "src/card.js", Line: 1, Component: "sw-card-removed"},
+		{File: "src/other.js", Line: 1, Component: "frosh-tools-index"},
+	}
+
+	ctx := NewValidationContext(plugin)
+
+	checkAdminOverrides(ctx, overrides, []string{"6.5.0.0", "6.6.0.0"}, func(shopwareVersion string) (map[string][]string, error) {
+		if shopwareVersion == "6.6.0.0" {
+			return nil, fmt.Errorf("not found")
+		}
+
+		return map[string][]string{
+			"sw-product-detail": {"sw_product_detail", "sw_product_detail_content"},
+		}, nil
+	})
+
+	assert.Empty(t, ctx.Errors())
+	assert.Equal(t, []string{
+		"The block sw_product_detail_removed does not exist in the admin component sw-product-detail of Shopware 6.5.0.0",
+		"The admin component sw-card-removed does not exist in Shopware 6.5.0.0",
+		"Admin overrides were not checked against Shopware 6.6.0.0: not found",
+	}, ctx.Warnings())
+
+	issues := ctx.Issues()
+	assert.Equal(t, "src/detail.html.twig", issues[0].File)
+	assert.Equal(t, 2, issues[0].Line)
+}
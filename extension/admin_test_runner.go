@@ -0,0 +1,206 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+const (
+	AdminTestRunnerJest   = "jest"
+	AdminTestRunnerVitest = "vitest"
+
+	// adminTestConfigFile is written next to the config of the administration, so the imports of it resolve
+	adminTestConfigFile = ".shopware-cli-test.config"
+)
+
+var vitestConfigFiles = []string{"vitest.config.js", "vitest.config.mjs", "vitest.config.ts", "vitest.config.mts"}
+
+type AdminTestConfig struct {
+	// ShopwareRoot points to existing Shopware sources, when empty the sources of the lowest supported version are downloaded
+	ShopwareRoot    string
+	ShopwareVersion *version.Constraints
+	// Runner forces jest or vitest, when empty it is detected from the Shopware sources
+	Runner string
+	// Args are passed to the test runner
+	Args []string
+	// NpmScripts controls the lifecycle scripts of the npm packages of the extension: run (default), skip or restricted-env
+	NpmScripts string
+}
+
+// DetectAdminTestRunner returns the test runner used by the given administration folder of Shopware.
+func DetectAdminTestRunner(administrationRoot string) string {
+	for _, file := range vitestConfigFiles {
+		if _, err := os.Stat(filepath.Join(administrationRoot, file)); err == nil {
+			return AdminTestRunnerVitest
+		}
+	}
+
+	return AdminTestRunnerJest
+}
+
+// RunAdministrationTests runs the *.spec.js and *.spec.ts files of the extension administration with the test setup of the
+// Shopware administration, which provides the Shopware globals, the aliases and the mocks.
+func RunAdministrationTests(ctx context.Context, ext Extension, cfg AdminTestConfig) error {
+	extensionAdminRoot := filepath.Join(ext.GetResourcesDir(), "app", "administration")
+
+	if _, err := os.Stat(extensionAdminRoot); os.IsNotExist(err) {
+		return fmt.Errorf("the extension has no administration in %s", extensionAdminRoot)
+	}
+
+	shopwareRoot := cfg.ShopwareRoot
+
+	if shopwareRoot == "" {
+		minVersion, err := lookupForMinMatchingVersion(ctx, cfg.ShopwareVersion)
+		if err != nil {
+			return err
+		}
+
+		shopwareRoot, err = setupShopwareInTemp(ctx, "v"+minVersion)
+		if err != nil {
+			return err
+		}
+
+		defer deletePath(ctx, shopwareRoot)
+	}
+
+	administrationRoot := PlatformPath(shopwareRoot, "Administration", "Resources/app/administration")
+
+	if _, err := os.Stat(filepath.Join(administrationRoot, "node_modules")); os.IsNotExist(err) {
+		logging.FromContext(ctx).Infof("Installing administration dependencies")
+
+		if err := installDependencies(ctx, administrationRoot); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(extensionAdminRoot, "package.json")); err == nil {
+		if err := installExtensionDependencies(ctx, extensionAdminRoot, cfg.NpmScripts, os.Stdout, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	runner := cfg.Runner
+	if runner == "" {
+		runner = DetectAdminTestRunner(administrationRoot)
+	}
+
+	config, fileName, err := renderAdminTestConfig(runner, administrationRoot, extensionAdminRoot)
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(administrationRoot, fileName)
+
+	if err := os.WriteFile(configFile, []byte(config), os.ModePerm); err != nil {
+		return err
+	}
+
+	defer deletePath(ctx, configFile)
+
+	args := []string{"--yes", runner}
+	if runner == AdminTestRunnerVitest {
+		args = append(args, "run")
+	}
+
+	args = append(args, "--config", configFile)
+	args = append(args, cfg.Args...)
+
+	logging.FromContext(ctx).Infof("Running administration tests using %s", runner)
+
+	testCmd := process.Command(ctx, "npx", args...)
+	testCmd.Dir = administrationRoot
+	testCmd.Env = append(os.Environ(),
+		fmt.Sprintf("ADMIN_PATH=%s", administrationRoot),
+		fmt.Sprintf("PROJECT_ROOT=%s", shopwareRoot),
+		"TZ=UTC",
+	)
+	testCmd.Stdin = os.Stdin
+	testCmd.Stdout = os.Stdout
+	testCmd.Stderr = os.Stderr
+
+	return testCmd.Run()
+}
+
+// renderAdminTestConfig extends the test config of the administration to run the tests of the extension. The folder of the
+// extension administration is available with the alias plugin-admin.
+func renderAdminTestConfig(runner, administrationRoot, extensionAdminRoot string) (string, string, error) {
+	// the config is read by node, which expects forward slashes also on Windows
+	extensionRoot := filepath.ToSlash(extensionAdminRoot)
+	nodeModules := filepath.ToSlash(filepath.Join(administrationRoot, "node_modules"))
+
+	encode := func(value interface{}) string {
+		content, _ := json.Marshal(value)
+
+		return string(content)
+	}
+
+	switch runner {
+	case AdminTestRunnerJest:
+		return fmt.Sprintf(`const config = require('./jest.config.js');
+
+module.exports = {
+    ...config,
+    roots: [%[1]s],
+    testMatch: [%[2]s],
+    collectCoverageFrom: [%[3]s],
+    coverageDirectory: %[4]s,
+    moduleDirectories: ['node_modules', %[5]s],
+    moduleNameMapper: {
+        '^plugin-admin(.*)$': %[6]s,
+        ...config.moduleNameMapper,
+    },
+    reporters: ['default'],
+};
+`,
+			encode(extensionRoot),
+			encode(extensionRoot+"/**/*.spec.{js,ts}"),
+			encode(extensionRoot+"/src/**/*.{js,ts}"),
+			encode(extensionRoot+"/coverage"),
+			encode(nodeModules),
+			encode(extensionRoot+"$1"),
+		), adminTestConfigFile + ".js", nil
+	case AdminTestRunnerVitest:
+		configFile := ""
+
+		for _, file := range vitestConfigFiles {
+			if _, err := os.Stat(filepath.Join(administrationRoot, file)); err == nil {
+				configFile = file
+				break
+			}
+		}
+
+		if configFile == "" {
+			return "", "", fmt.Errorf("cannot find a vitest config in %s", administrationRoot)
+		}
+
+		return fmt.Sprintf(`import { mergeConfig } from 'vitest/config';
+import config from './%[1]s';
+
+export default mergeConfig(config, {
+    resolve: {
+        alias: [{ find: /^plugin-admin/, replacement: %[2]s }],
+    },
+    test: {
+        root: %[2]s,
+        dir: %[2]s,
+        include: ['**/*.spec.{js,ts}'],
+        exclude: ['**/node_modules/**'],
+        coverage: { include: ['src/**/*.{js,ts}'], reportsDirectory: %[3]s },
+    },
+});
+`,
+			configFile,
+			encode(extensionRoot),
+			encode(extensionRoot+"/coverage"),
+		), adminTestConfigFile + ".mjs", nil
+	}
+
+	return "", "", fmt.Errorf("unsupported test runner %s, use jest or vitest", runner)
+}
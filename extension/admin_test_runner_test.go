@@ -0,0 +1,44 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectAdminTestRunner(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Equal(t, AdminTestRunnerJest, DetectAdminTestRunner(dir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "vitest.config.ts"), []byte(""), os.ModePerm))
+	assert.Equal(t, AdminTestRunnerVitest, DetectAdminTestRunner(dir))
+}
+
+func TestRenderAdminTestConfig(t *testing.T) {
+	adminRoot := t.TempDir()
+	extensionRoot := filepath.Join(t.TempDir(), "administration")
+
+	config, fileName, err := renderAdminTestConfig(AdminTestRunnerJest, adminRoot, extensionRoot)
+	assert.NoError(t, err)
+	assert.Equal(t, ".shopware-cli-test.config.js", fileName)
+	assert.Contains(t, config, "require('./jest.config.js')")
+	assert.Contains(t, config, `testMatch: ["`+filepath.ToSlash(extensionRoot)+`/**/*.spec.{js,ts}"]`)
+	assert.Contains(t, config, `'^plugin-admin(.*)$': "`+filepath.ToSlash(extensionRoot)+`$1"`)
+
+	_, _, err = renderAdminTestConfig(AdminTestRunnerVitest, adminRoot, extensionRoot)
+	assert.ErrorContains(t, err, "cannot find a vitest config")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(adminRoot, "vitest.config.mts"), []byte(""), os.ModePerm))
+
+	config, fileName, err = renderAdminTestConfig(AdminTestRunnerVitest, adminRoot, extensionRoot)
+	assert.NoError(t, err)
+	assert.Equal(t, ".shopware-cli-test.config.mjs", fileName)
+	assert.Contains(t, config, "import config from './vitest.config.mts';")
+	assert.Contains(t, config, `root: "`+filepath.ToSlash(extensionRoot)+`"`)
+
+	_, _, err = renderAdminTestConfig("mocha", adminRoot, extensionRoot)
+	assert.ErrorContains(t, err, "unsupported test runner mocha")
+}
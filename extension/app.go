@@ -5,7 +5,6 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
-	"path"
 	"path/filepath"
 
 	"github.com/FriendsOfShopware/shopware-cli/version"
@@ -28,15 +27,17 @@ type appManifest struct {
 		Secret          string `xml:"secret"`
 	} `xml:"setup"`
 	Permissions struct {
-		Text   string `xml:",chardata"`
-		Read   string `xml:"read"`
-		Create string `xml:"create"`
-		Update string `xml:"update"`
-		Delete string `xml:"delete"`
+		Text   string   `xml:",chardata"`
+		Read   []string `xml:"read"`
+		Create []string `xml:"create"`
+		Update []string `xml:"update"`
+		Delete []string `xml:"delete"`
+		// Permission are additional privileges like system:cache:info
+		Permission []string `xml:"permission"`
 	} `xml:"permissions"`
 	Webhooks struct {
 		Text    string `xml:",chardata"`
-		Webhook struct {
+		Webhook []struct {
 			Text  string `xml:",chardata"`
 			Name  string `xml:"name,attr"`
 			URL   string `xml:"url,attr"`
@@ -197,7 +198,7 @@ func (a App) GetRootDir() string {
 }
 
 func (a App) GetResourcesDir() string {
-	return path.Join(a.path, "Resources")
+	return filepath.Join(a.path, "Resources")
 }
 
 func newApp(path string) (*App, error) {
@@ -304,16 +305,23 @@ func (a App) GetMetaData() *extensionMetadata {
 	}
 }
 
+// GetIconPath returns the path of the app icon relative to the app root.
+func (a App) GetIconPath() string {
+	if a.manifest.Meta.Icon != "" {
+		return a.manifest.Meta.Icon
+	}
+
+	return "Resources/config/plugin.png"
+}
+
 func (a App) Validate(_ context.Context, ctx *ValidationContext) {
 	validateTheme(ctx)
 
-	appIcon := a.manifest.Meta.Icon
-
-	if appIcon == "" {
-		appIcon = "Resources/config/plugin.png"
-	}
+	appIcon := a.GetIconPath()
 
 	if _, err := os.Stat(filepath.Join(a.GetPath(), appIcon)); os.IsNotExist(err) {
 		ctx.AddError(fmt.Sprintf("Cannot find app icon at %s", appIcon))
 	}
+
+	validateAppManifest(ctx, a.manifest)
 }
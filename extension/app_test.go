@@ -67,7 +67,7 @@ func TestIconNotExists(t *testing.T) {
 	assert.Equal(t, "MyExampleApp", app.manifest.Meta.Name)
 	assert.Equal(t, "", app.manifest.Meta.Icon)
 
-	ctx := newValidationContext(app)
+	ctx := NewValidationContext(app)
 	app.Validate(getTestContext(), ctx)
 
 	assert.Equal(t, 1, len(ctx.errors))
@@ -89,7 +89,7 @@ func TestIconExistsDefaultsPath(t *testing.T) {
 	assert.Equal(t, "MyExampleApp", app.manifest.Meta.Name)
 	assert.Equal(t, "", app.manifest.Meta.Icon)
 
-	ctx := newValidationContext(app)
+	ctx := NewValidationContext(app)
 	app.Validate(getTestContext(), ctx)
 
 	assert.Equal(t, 0, len(ctx.errors))
@@ -108,7 +108,7 @@ func TestIconExistsDifferentPath(t *testing.T) {
 	assert.Equal(t, "MyExampleApp", app.manifest.Meta.Name)
 	assert.Equal(t, "app.png", app.manifest.Meta.Icon)
 
-	ctx := newValidationContext(app)
+	ctx := NewValidationContext(app)
 	app.Validate(getTestContext(), ctx)
 
 	assert.Equal(t, 0, len(ctx.errors))
@@ -143,3 +143,61 @@ func TestCompatibilityGiven(t *testing.T) {
 
 	assert.Equal(t, "~6.5.0", compatibility.String())
 }
+
+func TestAppManifestValidation(t *testing.T) {
+	appPath := t.TempDir()
+
+	manifest := `<?xml version="1.0" encoding="UTF-8"?>
+<manifest>
+	<meta>
+		<name>MyExampleApp</name>
+		<label>Label</label>
+		<description>A description</description>
+		<author>Your Company Ltd.</author>
+		<copyright>(c) by Your Company Ltd.</copyright>
+		<version>1.0.0</version>
+		<license>MIT</license>
+		<icon>manifest.xml</icon>
+	</meta>
+	<permissions>
+		<read>product</read>
+		<update>order</update>
+		<permission>system:cache:info</permission>
+	</permissions>
+	<webhooks>
+		<webhook name="productWritten" url="https://app.example.com/product" event="product.written"/>
+		<webhook name="orderWritten" url="https://app.example.com/order" event="order.written"/>
+		<webhook name="installed" url="/installed" event="app.installed"/>
+	</webhooks>
+	<admin>
+		<module name="dashboard" source="https://app.example.com/dashboard" parent="sw-catalogue">
+			<label>Dashboard</label>
+		</module>
+		<module name="settings" source="settings.html"/>
+		<action-button action="export" entity="order" view="detail" url="https://app.example.com/export">
+			<label>Export</label>
+		</action-button>
+	</admin>
+</manifest>`
+
+	assert.NoError(t, os.WriteFile(path.Join(appPath, "manifest.xml"), []byte(manifest), os.ModePerm))
+
+	app, err := newApp(appPath)
+	assert.NoError(t, err)
+	assert.Len(t, app.manifest.Webhooks.Webhook, 3)
+	assert.Equal(t, []string{"product"}, app.manifest.Permissions.Read)
+
+	ctx := NewValidationContext(app)
+	app.Validate(getTestContext(), ctx)
+
+	assert.Equal(t, []string{
+		"manifest.xml: webhook orderWritten listens to order.written, which requires the read permission for order",
+		`manifest.xml: webhook installed requires an absolute http(s) url, got "/installed"`,
+		`manifest.xml: admin module settings requires an absolute http(s) source, got "settings.html"`,
+		"manifest.xml: admin module settings requires a label",
+	}, ctx.Errors())
+	assert.Equal(t, []string{
+		"manifest.xml: the webhooks cannot be verified without setup, as the payloads are signed with the shop secret of the registration",
+		"manifest.xml: admin module settings has no parent and is not shown in the menu",
+	}, ctx.Warnings())
+}
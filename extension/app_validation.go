@@ -0,0 +1,196 @@
+package extension
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var appNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+var appEntityPermissionRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// appLifecycleEvents are sent to every app without permissions.
+var appLifecycleEvents = map[string]bool{
+	"app.installed":    true,
+	"app.updated":      true,
+	"app.deleted":      true,
+	"app.activated":    true,
+	"app.deactivated":  true,
+	"shopware.updated": true,
+}
+
+// validateAppManifest checks the webhooks, admin modules and permissions of the manifest.xml, which Shopware only rejects on install.
+func validateAppManifest(ctx *ValidationContext, manifest appManifest) {
+	validateAppMeta(ctx, manifest)
+	readPermissions := validateAppPermissions(ctx, manifest)
+	validateAppWebhooks(ctx, manifest, readPermissions)
+	validateAppAdmin(ctx, manifest)
+}
+
+func addManifestError(ctx *ValidationContext, message string) {
+	ctx.AddFileError("manifest.xml", 0, fmt.Sprintf("manifest.xml: %s", message))
+}
+
+func addManifestWarning(ctx *ValidationContext, message string) {
+	ctx.AddFileWarning("manifest.xml", 0, fmt.Sprintf("manifest.xml: %s", message))
+}
+
+func validateAppMeta(ctx *ValidationContext, manifest appManifest) {
+	if manifest.Meta.Name != "" && !appNameRegex.MatchString(manifest.Meta.Name) {
+		addManifestError(ctx, fmt.Sprintf("meta.name %q must only contain letters, digits and underscores", manifest.Meta.Name))
+	}
+
+	if manifest.Meta.Author == "" {
+		addManifestError(ctx, "meta.author is required")
+	}
+
+	if manifest.Meta.Copyright == "" {
+		addManifestError(ctx, "meta.copyright is required")
+	}
+
+	if manifest.Meta.License == "" {
+		addManifestError(ctx, "meta.license is required")
+	}
+
+	if manifest.Setup.RegistrationUrl != "" && !isAbsoluteHTTPURL(manifest.Setup.RegistrationUrl) {
+		addManifestError(ctx, fmt.Sprintf("setup.registrationUrl %q must be an absolute http(s) url", manifest.Setup.RegistrationUrl))
+	}
+}
+
+// validateAppPermissions returns the entities the app has the read permission for.
+func validateAppPermissions(ctx *ValidationContext, manifest appManifest) map[string]bool {
+	readable := make(map[string]bool)
+
+	lists := []struct {
+		name     string
+		entities []string
+	}{
+		{"read", manifest.Permissions.Read},
+		{"create", manifest.Permissions.Create},
+		{"update", manifest.Permissions.Update},
+		{"delete", manifest.Permissions.Delete},
+	}
+
+	for _, list := range lists {
+		seen := make(map[string]bool)
+
+		for _, entity := range list.entities {
+			entity = strings.TrimSpace(entity)
+
+			if !appEntityPermissionRegex.MatchString(entity) {
+				addManifestError(ctx, fmt.Sprintf("permission %s %q is no entity name like product or order_line_item", list.name, entity))
+				continue
+			}
+
+			if seen[entity] {
+				addManifestWarning(ctx, fmt.Sprintf("permission %s %s is listed twice", list.name, entity))
+			}
+
+			seen[entity] = true
+
+			if list.name == "read" {
+				readable[entity] = true
+			}
+		}
+	}
+
+	for _, permission := range manifest.Permissions.Permission {
+		if permission = strings.TrimSpace(permission); permission == "" || strings.ContainsAny(permission, " \t") {
+			addManifestError(ctx, fmt.Sprintf("permission %q must be a privilege like system:cache:info", permission))
+		}
+	}
+
+	return readable
+}
+
+func validateAppWebhooks(ctx *ValidationContext, manifest appManifest, readable map[string]bool) {
+	names := make(map[string]bool)
+
+	for _, webhook := range manifest.Webhooks.Webhook {
+		if webhook.Name == "" {
+			addManifestError(ctx, fmt.Sprintf("webhook for event %s requires a name", webhook.Event))
+		} else if names[webhook.Name] {
+			addManifestError(ctx, fmt.Sprintf("webhook name %s is used twice", webhook.Name))
+		}
+
+		names[webhook.Name] = true
+
+		if webhook.Event == "" {
+			addManifestError(ctx, fmt.Sprintf("webhook %s requires an event", webhook.Name))
+		}
+
+		if !isAbsoluteHTTPURL(webhook.URL) {
+			addManifestError(ctx, fmt.Sprintf("webhook %s requires an absolute http(s) url, got %q", webhook.Name, webhook.URL))
+		}
+
+		if entity, ok := webhookEntity(webhook.Event); ok && !readable[entity] {
+			addManifestError(ctx, fmt.Sprintf("webhook %s listens to %s, which requires the read permission for %s", webhook.Name, webhook.Event, entity))
+		}
+	}
+
+	if len(manifest.Webhooks.Webhook) > 0 && manifest.Setup.RegistrationUrl == "" {
+		addManifestWarning(ctx, "the webhooks cannot be verified without setup, as the payloads are signed with the shop secret of the registration")
+	}
+}
+
+// webhookEntity returns the entity of an entity written or deleted event like product.written.
+func webhookEntity(event string) (string, bool) {
+	if appLifecycleEvents[event] {
+		return "", false
+	}
+
+	for _, suffix := range []string{".written", ".deleted"} {
+		if entity := strings.TrimSuffix(event, suffix); entity != event && appEntityPermissionRegex.MatchString(entity) {
+			return entity, true
+		}
+	}
+
+	return "", false
+}
+
+func validateAppAdmin(ctx *ValidationContext, manifest appManifest) {
+	names := make(map[string]bool)
+
+	for _, module := range manifest.Admin.Module {
+		if module.Name == "" {
+			addManifestError(ctx, "admin module requires a name")
+		} else if names[module.Name] {
+			addManifestError(ctx, fmt.Sprintf("admin module name %s is used twice", module.Name))
+		}
+
+		names[module.Name] = true
+
+		if module.Source != "" && !isAbsoluteHTTPURL(module.Source) {
+			addManifestError(ctx, fmt.Sprintf("admin module %s requires an absolute http(s) source, got %q", module.Name, module.Source))
+		}
+
+		if len(module.Label) == 0 {
+			addManifestError(ctx, fmt.Sprintf("admin module %s requires a label", module.Name))
+		}
+
+		if module.Parent == "" {
+			addManifestWarning(ctx, fmt.Sprintf("admin module %s has no parent and is not shown in the menu", module.Name))
+		}
+	}
+
+	if manifest.Admin.MainModule.Source != "" && !isAbsoluteHTTPURL(manifest.Admin.MainModule.Source) {
+		addManifestError(ctx, fmt.Sprintf("admin main-module requires an absolute http(s) source, got %q", manifest.Admin.MainModule.Source))
+	}
+
+	for _, button := range manifest.Admin.ActionButton {
+		if button.Action == "" || button.Entity == "" || button.View == "" {
+			addManifestError(ctx, fmt.Sprintf("action button %s requires an action, entity and view", button.Action))
+		}
+
+		if !isAbsoluteHTTPURL(button.URL) {
+			addManifestError(ctx, fmt.Sprintf("action button %s requires an absolute http(s) url, got %q", button.Action, button.URL))
+		}
+	}
+}
+
+func isAbsoluteHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -2,7 +2,6 @@ package extension
 
 import (
 	"context"
-	"path"
 	"path/filepath"
 
 	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
@@ -19,13 +18,25 @@ func ConvertExtensionsToSources(ctx context.Context, extensions []Extension) []a
 			continue
 		}
 
-		sources = append(sources, asset.Source{
+		source := asset.Source{
 			Name: name,
 			Path: ext.GetRootDir(),
-		})
+		}
 
 		extConfig := ext.GetExtensionConfig()
 
+		if extConfig != nil {
+			if extConfig.Build.Zip.Assets.AdministrationWebpackConfig != "" {
+				source.AdministrationWebpackConfig = filepath.Join(ext.GetPath(), extConfig.Build.Zip.Assets.AdministrationWebpackConfig)
+			}
+
+			if extConfig.Build.Zip.Assets.StorefrontWebpackConfig != "" {
+				source.StorefrontWebpackConfig = filepath.Join(ext.GetPath(), extConfig.Build.Zip.Assets.StorefrontWebpackConfig)
+			}
+		}
+
+		sources = append(sources, source)
+
 		if extConfig != nil {
 			for _, bundle := range extConfig.Build.ExtraBundles {
 				bundleName := bundle.Name
@@ -36,7 +47,7 @@ func ConvertExtensionsToSources(ctx context.Context, extensions []Extension) []a
 
 				sources = append(sources, asset.Source{
 					Name: bundleName,
-					Path: path.Join(ext.GetRootDir(), bundle.Path),
+					Path: filepath.Join(ext.GetRootDir(), bundle.Path),
 				})
 			}
 		}
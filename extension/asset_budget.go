@@ -0,0 +1,110 @@
+package extension
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+)
+
+const (
+	assetComponentAdministration = "administration"
+	assetComponentStorefront     = "storefront"
+)
+
+type AssetBundleSize struct {
+	Source    string
+	Component string
+	File      string
+	Size      int64
+}
+
+// GetAssetBundleSizes returns the size of all compiled JavaScript and CSS files of the given sources.
+func GetAssetBundleSizes(sources []asset.Source) ([]AssetBundleSize, error) {
+	sizes := make([]AssetBundleSize, 0)
+
+	for _, source := range sources {
+		folders := map[string]string{
+			assetComponentAdministration: filepath.Join(source.Path, "Resources", "public", "administration"),
+			assetComponentStorefront:     filepath.Join(source.Path, "Resources", "app", "storefront", "dist", "storefront"),
+		}
+
+		for component, folder := range folders {
+			if _, err := os.Stat(folder); os.IsNotExist(err) {
+				continue
+			}
+
+			err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				if d.IsDir() || (filepath.Ext(path) != ".js" && filepath.Ext(path) != ".css") {
+					return nil
+				}
+
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+
+				relPath, _ := filepath.Rel(source.Path, path)
+
+				sizes = append(sizes, AssetBundleSize{
+					Source:    source.Name,
+					Component: component,
+					File:      relPath,
+					Size:      info.Size(),
+				})
+
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("GetAssetBundleSizes: %w", err)
+			}
+		}
+	}
+
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].Source != sizes[j].Source {
+			return sizes[i].Source < sizes[j].Source
+		}
+
+		if sizes[i].Component != sizes[j].Component {
+			return sizes[i].Component < sizes[j].Component
+		}
+
+		return sizes[i].File < sizes[j].File
+	})
+
+	return sizes, nil
+}
+
+// CheckAssetBudget returns a message for each file exceeding the budget of its component.
+func CheckAssetBudget(sizes []AssetBundleSize, budget ConfigAssetBudget) []string {
+	violations := make([]string, 0)
+
+	for _, size := range sizes {
+		limit := budget.Storefront
+		if size.Component == assetComponentAdministration {
+			limit = budget.Administration
+		}
+
+		if limit <= 0 || size.Size <= limit*1024 {
+			continue
+		}
+
+		violations = append(violations, fmt.Sprintf("%s of %s has %s and exceeds the %s budget of %d KB", size.File, size.Source, FormatAssetSize(size.Size), size.Component, limit))
+	}
+
+	return violations
+}
+
+// FormatAssetSize formats the size in bytes as kilobytes.
+func FormatAssetSize(size int64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.2f", float64(size)/1024), ".00") + " KB"
+}
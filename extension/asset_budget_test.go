@@ -0,0 +1,38 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+)
+
+func TestGetAssetBundleSizesAndBudget(t *testing.T) {
+	dir := t.TempDir()
+
+	adminDir := filepath.Join(dir, "Resources", "public", "administration", "js")
+	storefrontDir := filepath.Join(dir, "Resources", "app", "storefront", "dist", "storefront", "js")
+
+	assert.NoError(t, os.MkdirAll(adminDir, os.ModePerm))
+	assert.NoError(t, os.MkdirAll(storefrontDir, os.ModePerm))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(adminDir, "frosh-tools.js"), make([]byte, 3*1024), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(adminDir, "frosh-tools.js.map"), make([]byte, 10*1024), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(storefrontDir, "frosh-tools.js"), make([]byte, 1024), os.ModePerm))
+
+	sizes, err := GetAssetBundleSizes([]asset.Source{{Name: "FroshTools", Path: dir}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []AssetBundleSize{
+		{Source: "FroshTools", Component: "administration", File: filepath.Join("Resources", "public", "administration", "js", "frosh-tools.js"), Size: 3 * 1024},
+		{Source: "FroshTools", Component: "storefront", File: filepath.Join("Resources", "app", "storefront", "dist", "storefront", "js", "frosh-tools.js"), Size: 1024},
+	}, sizes)
+
+	assert.Empty(t, CheckAssetBudget(sizes, ConfigAssetBudget{}))
+	assert.Equal(t, []string{
+		"Resources/public/administration/js/frosh-tools.js of FroshTools has 3 KB and exceeds the administration budget of 2 KB",
+	}, CheckAssetBudget(sizes, ConfigAssetBudget{Administration: 2, Storefront: 1}))
+}
@@ -0,0 +1,107 @@
+package extension
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type assetJob struct {
+	// Name prefixes the output of the job, f.e. FroshTools/administration
+	Name string
+	Path string
+}
+
+// runAssetJobs runs the jobs with the given number of workers. With more than one worker the output of each job is
+// prefixed with its name line by line, so the interleaved output stays readable. All errors are returned joined.
+func runAssetJobs(concurrency int, jobs []assetJob, run func(job assetJob, stdout, stderr io.Writer) error) error {
+	if concurrency <= 1 || len(jobs) <= 1 {
+		for _, job := range jobs {
+			if err := run(job, os.Stdout, os.Stderr); err != nil {
+				return fmt.Errorf("%s: %w", job.Name, err)
+			}
+		}
+
+		return nil
+	}
+
+	var outputMu sync.Mutex
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(jobs))
+	workers := make(chan struct{}, concurrency)
+
+	for i, job := range jobs {
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(i int, job assetJob) {
+			defer wg.Done()
+
+			stdout := &prefixWriter{prefix: "[" + job.Name + "] ", out: os.Stdout, mu: &outputMu}
+			stderr := &prefixWriter{prefix: "[" + job.Name + "] ", out: os.Stderr, mu: &outputMu}
+
+			if err := run(job, stdout, stderr); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", job.Name, err)
+			}
+
+			stdout.Flush()
+			stderr.Flush()
+
+			<-workers
+		}(i, job)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// prefixWriter writes complete lines with a prefix, the writers of all jobs share the mutex.
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	mu     *sync.Mutex
+	bufMu  sync.Mutex
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.bufMu.Lock()
+	defer w.bufMu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the last line, when it does not end with a newline.
+func (w *prefixWriter) Flush() {
+	w.bufMu.Lock()
+	defer w.bufMu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, _ = w.out.Write(append([]byte(w.prefix), line...))
+}
@@ -0,0 +1,70 @@
+package extension
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := &prefixWriter{prefix: "[FroshTools] ", out: &out, mu: &sync.Mutex{}}
+
+	_, _ = writer.Write([]byte("added 10 pack"))
+	_, _ = writer.Write([]byte("ages\nup to date\nno newline"))
+	writer.Flush()
+
+	assert.Equal(t, "[FroshTools] added 10 packages\n[FroshTools] up to date\n[FroshTools] no newline\n", out.String())
+}
+
+func TestRunAssetJobs(t *testing.T) {
+	jobs := make([]assetJob, 0)
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, assetJob{Name: fmt.Sprintf("Extension%d", i)})
+	}
+
+	var running, maxRunning, done int32
+
+	err := runAssetJobs(3, jobs, func(job assetJob, _, _ io.Writer) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+
+		atomic.AddInt32(&done, 1)
+
+		if job.Name == "Extension4" || job.Name == "Extension7" {
+			return errors.New("npm install failed")
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, int32(10), done)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	assert.ErrorContains(t, err, "Extension4: npm install failed")
+	assert.ErrorContains(t, err, "Extension7: npm install failed")
+}
+
+func TestRunAssetJobsSequentialStopsOnError(t *testing.T) {
+	calls := 0
+
+	err := runAssetJobs(1, []assetJob{{Name: "A"}, {Name: "B"}}, func(job assetJob, _, _ io.Writer) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	assert.EqualError(t, err, "A: failed")
+	assert.Equal(t, 1, calls)
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
 	"github.com/FriendsOfShopware/shopware-cli/internal/esbuild"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/version"
 )
@@ -24,8 +26,13 @@ const (
 	AdministrationWebpackConfig = "Resources/app/administration/build/webpack.config.js"
 	AdministrationEntrypointJS  = "Resources/app/administration/src/main.js"
 	AdministrationEntrypointTS  = "Resources/app/administration/src/main.ts"
+
+	BuildToolWebpack = "webpack"
+	BuildToolVite    = "vite"
 )
 
+var viteConfigFiles = []string{"vite.config.js", "vite.config.mjs", "vite.config.ts", "vite.config.mts"}
+
 type AssetBuildConfig struct {
 	EnableESBuildForAdmin      bool
 	EnableESBuildForStorefront bool
@@ -35,10 +42,23 @@ type AssetBuildConfig struct {
 	ShopwareRoot               string
 	ShopwareVersion            *version.Constraints
 	Browserslist               string
+	// AdminBuildTool forces the build tool of the administration, when empty it is detected from the Shopware sources
+	AdminBuildTool string
+	// StorefrontBuildTool forces the build tool of the storefront, when empty it is detected from the Shopware sources
+	StorefrontBuildTool string
+	// Concurrency is the number of extensions whose dependencies are installed and which are built with esbuild in parallel
+	Concurrency int
+	// AdministrationWebpackConfig is a webpack config of the project, which is merged into the administration build of every extension
+	AdministrationWebpackConfig string
+	// StorefrontWebpackConfig is a webpack config of the project, which is merged into the storefront build
+	StorefrontWebpackConfig string
+	// NpmScripts controls the lifecycle scripts of the npm packages of the extensions: run (default), skip or restricted-env
+	NpmScripts string
 }
 
 func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, assetConfig AssetBuildConfig) error { // nolint:gocyclo
 	cfgs := buildAssetConfigFromExtensions(sources, assetConfig.ShopwareRoot)
+	cfgs.addProjectWebpackConfig(assetConfig.AdministrationWebpackConfig, assetConfig.StorefrontWebpackConfig)
 
 	if len(cfgs) == 1 {
 		return nil
@@ -49,13 +69,19 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 		return nil
 	}
 
-	buildWithoutShopwareSource := assetConfig.EnableESBuildForStorefront && assetConfig.EnableESBuildForAdmin
+	// the Shopware sources are only needed for the webpack or vite builds, esbuild uses the vendored Shopware externals
+	adminRequiresSource := !assetConfig.EnableESBuildForAdmin && !assetConfig.DisableAdminBuild && cfgs.RequiresAdminBuild()
+	storefrontRequiresSource := !assetConfig.EnableESBuildForStorefront && !assetConfig.DisableStorefrontBuild && cfgs.RequiresStorefrontBuild()
+	buildWithoutShopwareSource := !adminRequiresSource && !storefrontRequiresSource
 
 	shopwareRoot := assetConfig.ShopwareRoot
-	var err error
 	if shopwareRoot == "" && !buildWithoutShopwareSource {
-		shopwareRoot, err = setupShopwareInTemp(ctx, assetConfig.ShopwareVersion)
+		branch, err := assetBuildBranch(ctx, assetConfig.ShopwareVersion)
+		if err != nil {
+			return err
+		}
 
+		shopwareRoot, err = setupShopwareInTemp(ctx, branch)
 		if err != nil {
 			return err
 		}
@@ -70,86 +96,77 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 	}
 
 	// Install shared node_modules between admin and storefront
-	for _, entry := range cfgs {
-		// Install also shared node_modules
-		if _, err := os.Stat(filepath.Join(entry.BasePath, "Resources", "app", "package.json")); err == nil {
-			npmPath := filepath.Join(entry.BasePath, "Resources", "app")
-			if err := installDependencies(npmPath); err != nil {
-				return err
-			}
+	installJobs := make([]assetJob, 0)
 
-			if assetConfig.CleanupNodeModules {
-				defer deletePath(ctx, path.Join(npmPath, "node_modules"))
-			}
-		}
+	for _, name := range cfgs.sortedNames() {
+		entry := cfgs[name]
 
-		if _, err := os.Stat(filepath.Join(entry.BasePath, "Resources", "app", "administration", "package.json")); err == nil {
-			npmPath := filepath.Join(entry.BasePath, "Resources", "app", "administration")
-			if err := installDependencies(npmPath); err != nil {
-				return err
-			}
+		for _, folder := range []string{"", "administration", "storefront"} {
+			npmPath := filepath.Join(entry.BasePath, "Resources", "app", folder)
 
-			if assetConfig.CleanupNodeModules {
-				defer deletePath(ctx, path.Join(npmPath, "node_modules"))
+			if _, err := os.Stat(filepath.Join(npmPath, "package.json")); err == nil {
+				installJobs = append(installJobs, assetJob{Name: strings.TrimSuffix(name+"/"+folder, "/"), Path: npmPath})
 			}
 		}
+	}
 
-		if _, err := os.Stat(filepath.Join(entry.BasePath, "Resources", "app", "storefront", "package.json")); err == nil {
-			npmPath := filepath.Join(entry.BasePath, "Resources", "app", "storefront")
-			err := installDependencies(npmPath)
-			if err != nil {
-				return err
-			}
-
-			if assetConfig.CleanupNodeModules {
-				defer deletePath(ctx, path.Join(npmPath, "node_modules"))
-			}
+	if assetConfig.CleanupNodeModules {
+		for _, job := range installJobs {
+			defer deletePath(ctx, filepath.Join(job.Path, "node_modules"))
 		}
 	}
 
+	if err := runAssetJobs(assetConfig.Concurrency, installJobs, func(job assetJob, stdout, stderr io.Writer) error {
+		return installExtensionDependencies(ctx, job.Path, assetConfig.NpmScripts, stdout, stderr)
+	}); err != nil {
+		return err
+	}
+
 	if !assetConfig.DisableAdminBuild && cfgs.RequiresAdminBuild() {
 		if assetConfig.EnableESBuildForAdmin {
-			for _, source := range sources {
-				if !cfgs.Has(source.Name) {
-					continue
-				}
-
-				options := esbuild.NewAssetCompileOptionsAdmin(source.Name, source.Path)
-
-				if _, err := esbuild.CompileExtensionAsset(ctx, options); err != nil {
-					return err
-				}
+			if err := runAssetJobs(assetConfig.Concurrency, esbuildJobs(sources, cfgs), func(job assetJob, _, _ io.Writer) error {
+				_, err := esbuild.CompileExtensionAsset(ctx, esbuild.NewAssetCompileOptionsAdmin(job.Name, job.Path))
+				return err
+			}); err != nil {
+				return err
 			}
 		} else {
 			administrationRoot := PlatformPath(shopwareRoot, "Administration", "Resources/app/administration")
+			buildTool := resolveBuildTool(assetConfig.AdminBuildTool, administrationRoot)
+
+			logging.FromContext(ctx).Infof("Building administration using %s", buildTool)
+
 			err := npmRunBuild(
+				ctx,
 				administrationRoot,
 				"build",
 				[]string{fmt.Sprintf("PROJECT_ROOT=%s", shopwareRoot), "SHOPWARE_ADMIN_BUILD_ONLY_EXTENSIONS=1"},
 			)
 
 			if assetConfig.CleanupNodeModules {
-				defer deletePath(ctx, path.Join(administrationRoot, "node_modules"))
-				defer deletePath(ctx, path.Join(administrationRoot, "twigVuePlugin"))
+				defer deletePath(ctx, filepath.Join(administrationRoot, "node_modules"))
+				defer deletePath(ctx, filepath.Join(administrationRoot, "twigVuePlugin"))
 			}
 
 			if err != nil {
 				return err
 			}
+
+			if buildTool == BuildToolVite {
+				if err := verifyViteManifests(cfgs, "administration"); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	if !assetConfig.DisableStorefrontBuild && cfgs.RequiresStorefrontBuild() {
 		if assetConfig.EnableESBuildForStorefront {
-			for _, source := range sources {
-				if !cfgs.Has(source.Name) {
-					continue
-				}
-
-				options := esbuild.NewAssetCompileOptionsStorefront(source.Name, source.Path)
-				if _, err := esbuild.CompileExtensionAsset(ctx, options); err != nil {
-					return err
-				}
+			if err := runAssetJobs(assetConfig.Concurrency, esbuildJobs(sources, cfgs), func(job assetJob, _, _ io.Writer) error {
+				_, err := esbuild.CompileExtensionAsset(ctx, esbuild.NewAssetCompileOptionsStorefront(job.Name, job.Path))
+				return err
+			}); err != nil {
+				return err
 			}
 		} else {
 			storefrontRoot := PlatformPath(shopwareRoot, "Storefront", "Resources/app/storefront")
@@ -160,7 +177,7 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 			}
 
 			if assetConfig.Browserslist != "" {
-				npx := exec.CommandContext(ctx, "npx", "--yes", "update-browserslist-db", "--quiet")
+				npx := process.Command(ctx, "npx", "--yes", "update-browserslist-db", "--quiet")
 				npx.Stdout = os.Stdout
 				npx.Stderr = os.Stderr
 				npx.Dir = storefrontRoot
@@ -172,25 +189,136 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 				envList = append(envList, fmt.Sprintf("BROWSERSLIST=%s", assetConfig.Browserslist))
 			}
 
+			buildTool := resolveBuildTool(assetConfig.StorefrontBuildTool, storefrontRoot)
+			buildScript := "production"
+
+			if buildTool == BuildToolVite {
+				buildScript = "build"
+			}
+
+			logging.FromContext(ctx).Infof("Building storefront using %s", buildTool)
+
 			err := npmRunBuild(
+				ctx,
 				storefrontRoot,
-				"production",
+				buildScript,
 				envList,
 			)
 
 			if assetConfig.CleanupNodeModules {
-				defer deletePath(ctx, path.Join(storefrontRoot, "node_modules"))
+				defer deletePath(ctx, filepath.Join(storefrontRoot, "node_modules"))
 			}
 
 			if err != nil {
 				return err
 			}
+
+			if buildTool == BuildToolVite {
+				if err := verifyViteManifests(cfgs, "storefront"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func esbuildJobs(sources []asset.Source, cfgs ExtensionAssetConfig) []assetJob {
+	jobs := make([]assetJob, 0, len(sources))
+
+	for _, source := range sources {
+		if cfgs.Has(source.Name) {
+			jobs = append(jobs, assetJob{Name: source.Name, Path: source.Path})
+		}
+	}
+
+	return jobs
+}
+
+// DetectBuildTool returns the build tool used by the given administration or storefront app folder of Shopware.
+func DetectBuildTool(appRoot string) string {
+	for _, file := range viteConfigFiles {
+		if _, err := os.Stat(filepath.Join(appRoot, file)); err == nil {
+			return BuildToolVite
+		}
+	}
+
+	return BuildToolWebpack
+}
+
+// ValidateBuildTool checks a forced build tool, an empty value lets the build tool be detected.
+func ValidateBuildTool(buildTool string) error {
+	switch buildTool {
+	case "", BuildToolWebpack, BuildToolVite:
+		return nil
+	}
+
+	return fmt.Errorf("unknown build tool %s, use %s or %s", buildTool, BuildToolWebpack, BuildToolVite)
+}
+
+func resolveBuildTool(configured, appRoot string) string {
+	if configured != "" {
+		return configured
+	}
+
+	return DetectBuildTool(appRoot)
+}
+
+// verifyViteManifests checks that Vite has written a manifest for each extension, Shopware needs it to load the built files.
+func verifyViteManifests(cfgs ExtensionAssetConfig, component string) error {
+	for name, entry := range cfgs {
+		if entry.TechnicalName == "storefront" {
+			continue
+		}
+
+		entryFile := entry.Administration.EntryFilePath
+		if component == "storefront" {
+			entryFile = entry.Storefront.EntryFilePath
+		}
+
+		if entryFile == nil {
+			continue
+		}
+
+		manifest := filepath.Join(entry.BasePath, "Resources", "public", component, ".vite", "manifest.json")
+
+		if _, err := os.Stat(manifest); os.IsNotExist(err) {
+			return fmt.Errorf("vite build of %s did not create a manifest at %s", name, manifest)
 		}
 	}
 
 	return nil
 }
 
+// RunAdministrationDevServer starts the development server of the administration with all given extensions.
+func RunAdministrationDevServer(ctx context.Context, sources []asset.Source, assetConfig AssetBuildConfig) error {
+	cfgs := buildAssetConfigFromExtensions(sources, assetConfig.ShopwareRoot)
+	cfgs.addProjectWebpackConfig(assetConfig.AdministrationWebpackConfig, assetConfig.StorefrontWebpackConfig)
+
+	if err := prepareShopwareForAsset(assetConfig.ShopwareRoot, cfgs); err != nil {
+		return err
+	}
+
+	administrationRoot := PlatformPath(assetConfig.ShopwareRoot, "Administration", "Resources/app/administration")
+
+	if err := installDependencies(ctx, administrationRoot); err != nil {
+		return err
+	}
+
+	buildTool := resolveBuildTool(assetConfig.AdminBuildTool, administrationRoot)
+
+	logging.FromContext(ctx).Infof("Starting administration dev server using %s", buildTool)
+
+	devCmd := process.Command(ctx, "npm", "--prefix", administrationRoot, "run", "dev") //nolint:gosec
+	devCmd.Env = append(os.Environ(), fmt.Sprintf("PROJECT_ROOT=%s", assetConfig.ShopwareRoot))
+	devCmd.Stdin = os.Stdin
+	devCmd.Stdout = os.Stdout
+	devCmd.Stderr = os.Stderr
+
+	return devCmd.Run()
+}
+
 func deletePath(ctx context.Context, path string) {
 	if err := os.RemoveAll(path); err != nil {
 		logging.FromContext(ctx).Errorf("Failed to remove path %s: %s", path, err.Error())
@@ -198,12 +326,12 @@ func deletePath(ctx context.Context, path string) {
 	}
 }
 
-func npmRunBuild(path string, buildCmd string, buildEnvVariables []string) error {
-	if err := installDependencies(path); err != nil {
+func npmRunBuild(ctx context.Context, path string, buildCmd string, buildEnvVariables []string) error {
+	if err := installDependencies(ctx, path); err != nil {
 		return err
 	}
 
-	npmBuildCmd := exec.Command("npm", "--prefix", path, "run", buildCmd) //nolint:gosec
+	npmBuildCmd := process.Command(ctx, "npm", "--prefix", path, "run", buildCmd) //nolint:gosec
 	npmBuildCmd.Env = os.Environ()
 	npmBuildCmd.Env = append(npmBuildCmd.Env, buildEnvVariables...)
 	npmBuildCmd.Stdout = os.Stdout
@@ -216,27 +344,31 @@ func npmRunBuild(path string, buildCmd string, buildEnvVariables []string) error
 	return nil
 }
 
-func getInstallCommand(path string) *exec.Cmd {
+func getInstallCommand(ctx context.Context, path string) *exec.Cmd {
 	if _, err := os.Stat(filepath.Join(path, "pnpm-lock.yaml")); err == nil {
-		return exec.Command("pnpm", "install")
+		return process.Command(ctx, "pnpm", "install")
 	}
 
 	if _, err := os.Stat(filepath.Join(path, "yarn.lock")); err == nil {
-		return exec.Command("yarn", "install")
+		return process.Command(ctx, "yarn", "install")
 	}
 
 	if _, err := os.Stat(filepath.Join(path, "bun.lockdb")); err == nil {
-		return exec.Command("bun", "install")
+		return process.Command(ctx, "bun", "install")
 	}
 
-	return exec.Command("npm", "install", "--no-audit", "--no-fund", "--prefer-offline")
+	return process.Command(ctx, "npm", "install", "--no-audit", "--no-fund", "--prefer-offline")
+}
+
+func installDependencies(ctx context.Context, path string) error {
+	return installDependenciesWithOutput(ctx, path, os.Stdout, os.Stderr)
 }
 
-func installDependencies(path string) error {
-	installCmd := getInstallCommand(path)
+func installDependenciesWithOutput(ctx context.Context, path string, stdout, stderr io.Writer) error {
+	installCmd := getInstallCommand(ctx, path)
 	installCmd.Dir = path
-	installCmd.Stdout = os.Stdout
-	installCmd.Stderr = os.Stderr
+	installCmd.Stdout = stdout
+	installCmd.Stderr = stderr
 	installCmd.Env = os.Environ()
 	installCmd.Env = append(installCmd.Env, "PUPPETEER_SKIP_DOWNLOAD=1")
 
@@ -256,6 +388,10 @@ func prepareShopwareForAsset(shopwareRoot string, cfgs map[string]ExtensionAsset
 		}
 	}
 
+	if err := writeWebpackConfigWrappers(shopwareRoot, cfgs); err != nil {
+		return fmt.Errorf("prepareShopwareForAsset: %w", err)
+	}
+
 	pluginJson, err := json.Marshal(cfgs)
 	if err != nil {
 		return fmt.Errorf("prepareShopwareForAsset: %w", err)
@@ -284,21 +420,32 @@ func buildAssetConfigFromExtensions(sources []asset.Source, shopwareRoot string)
 			continue
 		}
 
-		resourcesDir := path.Join(source.Path, "Resources", "app")
+		resourcesDir := filepath.Join(source.Path, "Resources", "app")
 
-		if _, err := os.Stat(resourcesDir); os.IsNotExist(err) {
+		if _, err := os.Stat(resourcesDir); os.IsNotExist(err) && !source.HasCustomWebpackConfig() {
 			continue
 		}
 
-		list[source.Name] = createConfigFromPath(source.Name, source.Path)
+		cfg := createConfigFromPath(source.Name, source.Path)
+
+		if source.AdministrationWebpackConfig != "" {
+			cfg.Administration.additionalWebpack = append(cfg.Administration.additionalWebpack, source.AdministrationWebpackConfig)
+		}
+
+		if source.StorefrontWebpackConfig != "" {
+			cfg.Storefront.additionalWebpack = append(cfg.Storefront.additionalWebpack, source.StorefrontWebpackConfig)
+		}
+
+		list[source.Name] = cfg
 	}
 
 	var basePath string
 	if shopwareRoot == "" {
 		basePath = "src/Storefront/"
 	} else {
+		// the asset config is read by node, which expects forward slashes also on Windows
 		basePath = strings.TrimLeft(
-			strings.Replace(PlatformPath(shopwareRoot, "Storefront", ""), shopwareRoot, "", 1),
+			filepath.ToSlash(strings.Replace(PlatformPath(shopwareRoot, "Storefront", ""), shopwareRoot, "", 1)),
 			"/",
 		) + "/"
 	}
@@ -325,41 +472,41 @@ func createConfigFromPath(entryPointName string, extensionRoot string) Extension
 	var entryFilePathAdmin, entryFilePathStorefront, webpackFileAdmin, webpackFileStorefront *string
 	storefrontStyles := make([]string, 0)
 
-	if _, err := os.Stat(path.Join(extensionRoot, AdministrationEntrypointJS)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, AdministrationEntrypointJS)); err == nil {
 		val := AdministrationEntrypointJS
 		entryFilePathAdmin = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, AdministrationEntrypointTS)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, AdministrationEntrypointTS)); err == nil {
 		val := AdministrationEntrypointTS
 		entryFilePathAdmin = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, AdministrationWebpackConfig)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, AdministrationWebpackConfig)); err == nil {
 		val := AdministrationWebpackConfig
 		webpackFileAdmin = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, StorefrontEntrypointJS)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, StorefrontEntrypointJS)); err == nil {
 		val := StorefrontEntrypointJS
 		entryFilePathStorefront = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, StorefrontEntrypointTS)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, StorefrontEntrypointTS)); err == nil {
 		val := StorefrontEntrypointTS
 		entryFilePathStorefront = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, StorefrontWebpackConfig)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, StorefrontWebpackConfig)); err == nil {
 		val := StorefrontWebpackConfig
 		webpackFileStorefront = &val
 	}
 
-	if _, err := os.Stat(path.Join(extensionRoot, StorefrontBaseCSS)); err == nil {
+	if _, err := os.Stat(filepath.Join(extensionRoot, StorefrontBaseCSS)); err == nil {
 		storefrontStyles = append(storefrontStyles, StorefrontBaseCSS)
 	}
 
-	extensionRoot = strings.TrimRight(extensionRoot, "/") + "/"
+	extensionRoot = strings.TrimRight(filepath.ToSlash(extensionRoot), "/") + "/"
 
 	cfg := ExtensionAssetConfigEntry{
 		BasePath: extensionRoot,
@@ -382,33 +529,37 @@ func createConfigFromPath(entryPointName string, extensionRoot string) Extension
 	return cfg
 }
 
-func setupShopwareInTemp(ctx context.Context, shopwareVersionConstraint *version.Constraints) (string, error) {
+// assetBuildBranch returns the branch of the Shopware repository with the build tooling for the lowest Shopware version
+// matching the constraint.
+func assetBuildBranch(ctx context.Context, shopwareVersionConstraint *version.Constraints) (string, error) {
 	minVersion, err := lookupForMinMatchingVersion(ctx, shopwareVersionConstraint)
 	if err != nil {
 		return "", err
 	}
 
-	dir, err := os.MkdirTemp("", "extension")
-	if err != nil {
-		return "", err
-	}
-
-	cloneBranch := "6.4"
-
 	shopware65Constraint, _ := version.NewConstraint("~6.5.0")
 
 	if shopware65Constraint.Check(version.Must(version.NewVersion(minVersion))) {
-		cloneBranch = "trunk"
+		return "trunk", nil
+	}
+
+	return "6.4", nil
+}
+
+// setupShopwareInTemp clones the Shopware repository at the given branch or tag like v6.5.0.0 into a temporary folder.
+func setupShopwareInTemp(ctx context.Context, gitRef string) (string, error) {
+	dir, err := os.MkdirTemp("", "shopware")
+	if err != nil {
+		return "", err
 	}
 
-	logging.FromContext(ctx).Infof("Cloning shopware with branch: %s into %s", cloneBranch, dir)
+	logging.FromContext(ctx).Infof("Cloning shopware %s into %s", gitRef, dir)
 
-	gitCheckoutCmd := exec.Command("git", "clone", "https://github.com/shopware/platform.git", "--depth=1", "-b", cloneBranch, dir)
+	gitCheckoutCmd := process.Command(ctx, "git", "clone", "https://github.com/shopware/platform.git", "--depth=1", "-b", gitRef, dir)
 	gitCheckoutCmd.Stdout = os.Stdout
 	gitCheckoutCmd.Stderr = os.Stderr
-	err = gitCheckoutCmd.Run()
 
-	if err != nil {
+	if err := gitCheckoutCmd.Run(); err != nil {
 		return "", err
 	}
 
@@ -423,6 +574,17 @@ func (c ExtensionAssetConfig) Has(name string) bool {
 	return ok
 }
 
+func (c ExtensionAssetConfig) sortedNames() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (c ExtensionAssetConfig) RequiresAdminBuild() bool {
 	for _, entry := range c {
 		if entry.Administration.EntryFilePath != nil {
@@ -459,6 +621,8 @@ type ExtensionAssetConfigAdmin struct {
 	Path          string  `json:"path"`
 	EntryFilePath *string `json:"entryFilePath"`
 	Webpack       *string `json:"webpack"`
+	// additionalWebpack are absolute paths of configured webpack configs, which are merged with Webpack
+	additionalWebpack []string
 }
 
 type ExtensionAssetConfigStorefront struct {
@@ -466,4 +630,6 @@ type ExtensionAssetConfigStorefront struct {
 	EntryFilePath *string  `json:"entryFilePath"`
 	Webpack       *string  `json:"webpack"`
 	StyleFiles    []string `json:"styleFiles"`
+	// additionalWebpack are absolute paths of configured webpack configs, which are merged with Webpack
+	additionalWebpack []string
 }
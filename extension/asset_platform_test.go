@@ -92,3 +92,49 @@ func TestGenerateConfigDoesNotAddExtensionWithoutName(t *testing.T) {
 
 	assert.Len(t, config, 1)
 }
+
+func TestGenerateConfigWithCustomWebpackConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	config := buildAssetConfigFromExtensions([]asset.Source{{Name: "Project", Path: dir, StorefrontWebpackConfig: "/project/webpack.storefront.js"}}, "")
+
+	assert.True(t, config.Has("Project"))
+	assert.False(t, config.RequiresStorefrontBuild())
+	assert.Nil(t, config["Project"].Administration.Webpack)
+	assert.Nil(t, config["Project"].Storefront.Webpack)
+	assert.Equal(t, []string{"/project/webpack.storefront.js"}, config["Project"].Storefront.additionalWebpack)
+}
+
+func TestDetectBuildTool(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Equal(t, BuildToolWebpack, DetectBuildTool(dir))
+
+	assert.NoError(t, os.WriteFile(path.Join(dir, "vite.config.mts"), []byte(""), os.ModePerm))
+
+	assert.Equal(t, BuildToolVite, DetectBuildTool(dir))
+	assert.Equal(t, BuildToolWebpack, resolveBuildTool(BuildToolWebpack, dir))
+}
+
+func TestValidateBuildTool(t *testing.T) {
+	assert.NoError(t, ValidateBuildTool(""))
+	assert.NoError(t, ValidateBuildTool(BuildToolVite))
+	assert.EqualError(t, ValidateBuildTool("rollup"), "unknown build tool rollup, use webpack or vite")
+}
+
+func TestVerifyViteManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "Resources", "app", "administration", "src"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "Resources", "app", "administration", "src", "main.js"), []byte("test"), os.ModePerm))
+
+	config := buildAssetConfigFromExtensions([]asset.Source{{Name: "FroshTools", Path: dir}}, "")
+
+	assert.Error(t, verifyViteManifests(config, "administration"))
+	assert.NoError(t, verifyViteManifests(config, "storefront"))
+
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "Resources", "public", "administration", ".vite"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "Resources", "public", "administration", ".vite", "manifest.json"), []byte("{}"), os.ModePerm))
+
+	assert.NoError(t, verifyViteManifests(config, "administration"))
+}
@@ -0,0 +1,90 @@
+package extension
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+)
+
+// compiledAssetFolders are written by the asset build, relative to the extension root.
+var compiledAssetFolders = []string{
+	filepath.Join("Resources", "public", "administration"),
+	filepath.Join("Resources", "app", "storefront", "dist"),
+}
+
+// CompiledAssetSnapshot maps the compiled files of all sources, prefixed with the source name, to their checksum.
+type CompiledAssetSnapshot map[string]string
+
+// SnapshotCompiledAssets hashes the compiled administration and storefront files of the sources.
+func SnapshotCompiledAssets(sources []asset.Source) (CompiledAssetSnapshot, error) {
+	snapshot := make(CompiledAssetSnapshot)
+
+	for _, source := range sources {
+		for _, folder := range compiledAssetFolders {
+			root := filepath.Join(source.Path, folder)
+
+			if _, err := os.Stat(root); os.IsNotExist(err) {
+				continue
+			}
+
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				if d.IsDir() {
+					return nil
+				}
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+
+				relPath, _ := filepath.Rel(source.Path, path)
+				hash := sha256.Sum256(content)
+
+				snapshot[source.Name+"/"+filepath.ToSlash(relPath)] = hex.EncodeToString(hash[:])
+
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("SnapshotCompiledAssets: %w", err)
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Diff returns the files, which are changed, added or removed by the build compared to the committed snapshot.
+func (s CompiledAssetSnapshot) Diff(built CompiledAssetSnapshot) []string {
+	differences := make([]string, 0)
+
+	for file, hash := range s {
+		builtHash, ok := built[file]
+
+		switch {
+		case !ok:
+			differences = append(differences, fmt.Sprintf("%s is committed, but not created by the build", file))
+		case builtHash != hash:
+			differences = append(differences, fmt.Sprintf("%s differs from the build", file))
+		}
+	}
+
+	for file := range built {
+		if _, ok := s[file]; !ok {
+			differences = append(differences, fmt.Sprintf("%s is created by the build, but not committed", file))
+		}
+	}
+
+	sort.Strings(differences)
+
+	return differences
+}
@@ -0,0 +1,45 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+)
+
+func TestCompiledAssetSnapshotDiff(t *testing.T) {
+	dir := t.TempDir()
+	sources := []asset.Source{{Name: "FroshTools", Path: dir}}
+
+	adminJs := filepath.Join(dir, "Resources", "public", "administration", "js", "frosh-tools.js")
+	storefrontJs := filepath.Join(dir, "Resources", "app", "storefront", "dist", "storefront", "js", "frosh-tools.js")
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(adminJs), os.ModePerm))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(storefrontJs), os.ModePerm))
+	assert.NoError(t, os.WriteFile(adminJs, []byte("admin"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(storefrontJs, []byte("storefront"), os.ModePerm))
+
+	committed, err := SnapshotCompiledAssets(sources)
+	assert.NoError(t, err)
+	assert.Len(t, committed, 2)
+
+	built, err := SnapshotCompiledAssets(sources)
+	assert.NoError(t, err)
+	assert.Empty(t, committed.Diff(built))
+
+	assert.NoError(t, os.WriteFile(adminJs, []byte("admin changed"), os.ModePerm))
+	assert.NoError(t, os.Remove(storefrontJs))
+	assert.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(adminJs), "chunk.js"), []byte("chunk"), os.ModePerm))
+
+	built, err = SnapshotCompiledAssets(sources)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"FroshTools/Resources/app/storefront/dist/storefront/js/frosh-tools.js is committed, but not created by the build",
+		"FroshTools/Resources/public/administration/js/chunk.js is created by the build, but not committed",
+		"FroshTools/Resources/public/administration/js/frosh-tools.js differs from the build",
+	}, committed.Diff(built))
+}
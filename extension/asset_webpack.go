@@ -0,0 +1,106 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const webpackConfigWrapperTemplate = `// Generated by shopware-cli, merges the webpack configs of the extension and the project
+const webpackMerge = require(require.resolve('webpack-merge', { paths: [process.cwd()] }));
+const merge = webpackMerge.merge || webpackMerge;
+const configs = %s.map((file) => require(file));
+
+module.exports = (params) => merge(...configs.map((config) => (typeof config === 'function' ? config(params) : config)));
+`
+
+// addProjectWebpackConfig merges the webpack configs of the project into the build. Shopware only loads the webpack
+// config of extensions with an entry file. The administration builds each extension on its own, so the config is added
+// to all of them. The storefront is built as a whole, so the config is added to the first extension only.
+func (c ExtensionAssetConfig) addProjectWebpackConfig(administration, storefront string) {
+	storefrontAdded := storefront == ""
+
+	for _, name := range c.sortedNames() {
+		entry := c[name]
+
+		if administration != "" && entry.Administration.EntryFilePath != nil {
+			entry.Administration.additionalWebpack = append(entry.Administration.additionalWebpack, administration)
+		}
+
+		if !storefrontAdded && entry.TechnicalName != "storefront" && entry.Storefront.EntryFilePath != nil {
+			entry.Storefront.additionalWebpack = append(entry.Storefront.additionalWebpack, storefront)
+			storefrontAdded = true
+		}
+
+		c[name] = entry
+	}
+}
+
+// writeWebpackConfigWrappers points the extensions to their configured webpack configs. When an extension has also
+// its own webpack config, a wrapper is generated in the var folder, which merges both configs.
+func writeWebpackConfigWrappers(shopwareRoot string, cfgs ExtensionAssetConfig) error {
+	for _, name := range cfgs.sortedNames() {
+		entry := cfgs[name]
+
+		admin, err := mergeWebpackConfigs(shopwareRoot, name, "administration", entry.BasePath, entry.Administration.Webpack, entry.Administration.additionalWebpack)
+		if err != nil {
+			return err
+		}
+
+		storefront, err := mergeWebpackConfigs(shopwareRoot, name, "storefront", entry.BasePath, entry.Storefront.Webpack, entry.Storefront.additionalWebpack)
+		if err != nil {
+			return err
+		}
+
+		entry.Administration.Webpack = admin
+		entry.Storefront.Webpack = storefront
+		cfgs[name] = entry
+	}
+
+	return nil
+}
+
+func mergeWebpackConfigs(shopwareRoot, name, component, basePath string, webpack *string, additional []string) (*string, error) {
+	if len(additional) == 0 {
+		return webpack, nil
+	}
+
+	configs := make([]string, 0, len(additional)+1)
+
+	if webpack != nil {
+		own := *webpack
+		if !filepath.IsAbs(own) {
+			own = filepath.Join(basePath, own)
+		}
+
+		configs = append(configs, filepath.ToSlash(own))
+	}
+
+	for _, config := range additional {
+		configs = append(configs, filepath.ToSlash(config))
+	}
+
+	if len(configs) == 1 {
+		return &configs[0], nil
+	}
+
+	files, err := json.Marshal(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := filepath.Join(shopwareRoot, "var", "shopware-cli", "webpack", fmt.Sprintf("%s.%s.js", name, component))
+
+	if err := os.MkdirAll(filepath.Dir(wrapper), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(wrapper, []byte(fmt.Sprintf(webpackConfigWrapperTemplate, files)), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	wrapperPath := filepath.ToSlash(wrapper)
+
+	return &wrapperPath, nil
+}
@@ -0,0 +1,108 @@
+package extension
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+)
+
+func writeWebpackTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+		assert.NoError(t, os.WriteFile(file, []byte(content), os.ModePerm))
+	}
+}
+
+func TestAddProjectWebpackConfigOnlyToExtensionsWithEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	writeWebpackTestFiles(t, dir, map[string]string{
+		"FroshTools/Resources/app/administration/src/main.js": "",
+		"FroshTools/Resources/app/storefront/src/main.js":     "",
+		"FroshCache/Resources/app/administration/src/main.js": "",
+		"FroshViews/Resources/views/base.html.twig":           "",
+		"FroshViews/Resources/app/.gitkeep":                   "",
+	})
+
+	cfgs := buildAssetConfigFromExtensions([]asset.Source{
+		{Name: "FroshTools", Path: filepath.Join(dir, "FroshTools")},
+		{Name: "FroshCache", Path: filepath.Join(dir, "FroshCache")},
+		{Name: "FroshViews", Path: filepath.Join(dir, "FroshViews")},
+	}, "")
+	cfgs.addProjectWebpackConfig("/project/webpack.administration.js", "/project/webpack.storefront.js")
+
+	assert.Equal(t, []string{"/project/webpack.administration.js"}, cfgs["FroshTools"].Administration.additionalWebpack)
+	assert.Equal(t, []string{"/project/webpack.administration.js"}, cfgs["FroshCache"].Administration.additionalWebpack)
+	assert.Empty(t, cfgs["FroshViews"].Administration.additionalWebpack)
+	assert.Equal(t, []string{"/project/webpack.storefront.js"}, cfgs["FroshTools"].Storefront.additionalWebpack)
+	assert.Empty(t, cfgs["FroshCache"].Storefront.additionalWebpack)
+	assert.Empty(t, cfgs["Storefront"].Storefront.additionalWebpack)
+}
+
+func TestWebpackConfigWrapperMergesExtensionAndProjectConfig(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+
+	dir := t.TempDir()
+	shopwareRoot := filepath.Join(dir, "shopware")
+	administrationRoot := filepath.Join(shopwareRoot, "administration")
+
+	writeWebpackTestFiles(t, dir, map[string]string{
+		"FroshTools/Resources/app/administration/src/main.js":             "",
+		"FroshTools/Resources/app/administration/build/webpack.config.js": "module.exports = ({ name }) => ({ resolve: { alias: { '@frosh': name } } });",
+		"FroshTools/webpack.extension.js":                                 "module.exports = { module: { rules: [{ test: /\\.md$/, use: 'extension-loader' }] } };",
+		"webpack.project.js":                                              "module.exports = { module: { rules: [{ test: /\\.yml$/, use: 'yaml-loader' }] } };",
+		"shopware/administration/node_modules/webpack-merge/package.json": `{"name": "webpack-merge", "main": "index.js"}`,
+		"shopware/administration/node_modules/webpack-merge/index.js":     "exports.merge = (...configs) => ({ resolve: { alias: Object.assign({}, ...configs.map((c) => (c.resolve || {}).alias || {})) }, module: { rules: [].concat(...configs.map((c) => (c.module || {}).rules || [])) } });",
+	})
+
+	cfgs := buildAssetConfigFromExtensions([]asset.Source{
+		{Name: "FroshTools", Path: filepath.Join(dir, "FroshTools"), AdministrationWebpackConfig: filepath.Join(dir, "FroshTools", "webpack.extension.js")},
+	}, shopwareRoot)
+	cfgs.addProjectWebpackConfig(filepath.Join(dir, "webpack.project.js"), "")
+
+	assert.NoError(t, prepareShopwareForAsset(shopwareRoot, cfgs))
+
+	content, err := os.ReadFile(filepath.Join(shopwareRoot, "var", "plugins.json"))
+	assert.NoError(t, err)
+
+	var pluginsJson map[string]ExtensionAssetConfigEntry
+	assert.NoError(t, json.Unmarshal(content, &pluginsJson))
+
+	wrapper := *pluginsJson["FroshTools"].Administration.Webpack
+	assert.Equal(t, filepath.ToSlash(filepath.Join(shopwareRoot, "var", "shopware-cli", "webpack", "FroshTools.administration.js")), wrapper)
+
+	// Shopware requires the webpack config of the extension from the administration folder and passes the plugin parameters
+	node := exec.Command("node", "-e", "const config = require(process.argv[1])({ name: 'FroshTools' }); console.log(JSON.stringify({ alias: config.resolve.alias, loaders: config.module.rules.map((r) => r.use) }));", wrapper)
+	node.Dir = administrationRoot
+
+	output, err := node.CombinedOutput()
+	assert.NoError(t, err, string(output))
+	assert.JSONEq(t, `{"alias": {"@frosh": "FroshTools"}, "loaders": ["extension-loader", "yaml-loader"]}`, string(output))
+}
+
+func TestWebpackConfigWithoutOwnConfigIsUsedDirectly(t *testing.T) {
+	dir := t.TempDir()
+
+	writeWebpackTestFiles(t, dir, map[string]string{
+		"FroshTools/Resources/app/storefront/src/main.js": "",
+	})
+
+	cfgs := buildAssetConfigFromExtensions([]asset.Source{{Name: "FroshTools", Path: filepath.Join(dir, "FroshTools")}}, dir)
+	cfgs.addProjectWebpackConfig("", "/project/webpack.storefront.js")
+
+	assert.NoError(t, writeWebpackConfigWrappers(dir, cfgs))
+	assert.Equal(t, "/project/webpack.storefront.js", *cfgs["FroshTools"].Storefront.Webpack)
+	assert.Nil(t, cfgs["FroshTools"].Administration.Webpack)
+	assert.NoDirExists(t, filepath.Join(dir, "var", "shopware-cli"))
+}
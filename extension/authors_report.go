@@ -0,0 +1,201 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	AuthorModulePHP            = "php"
+	AuthorModuleAdministration = "administration"
+	AuthorModuleStorefront     = "storefront"
+)
+
+type AuthorContribution struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Lines int    `json:"lines"`
+	Files int    `json:"files"`
+}
+
+type ModuleAuthors struct {
+	Module  string               `json:"module"`
+	Lines   int                  `json:"lines"`
+	Authors []AuthorContribution `json:"authors"`
+}
+
+type blameAuthor struct {
+	name  string
+	email string
+}
+
+// classifyAuthorModule returns the module of a file or an empty string, when the file does not belong to a module.
+func classifyAuthorModule(file string) string {
+	switch {
+	case strings.Contains(file, "Resources/app/storefront/dist/"), strings.Contains(file, "Resources/public/"):
+		return ""
+	case strings.Contains(file, "Resources/app/administration/"):
+		return AuthorModuleAdministration
+	case strings.Contains(file, "Resources/app/storefront/"), strings.Contains(file, "Resources/views/"):
+		return AuthorModuleStorefront
+	case strings.HasSuffix(file, ".php"):
+		return AuthorModulePHP
+	}
+
+	return ""
+}
+
+// parseBlamePorcelain counts the lines per author of git blame --line-porcelain output.
+func parseBlamePorcelain(output string) map[blameAuthor]int {
+	lines := make(map[blameAuthor]int)
+
+	var current blameAuthor
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "author "):
+			current = blameAuthor{name: strings.TrimPrefix(line, "author ")}
+		case strings.HasPrefix(line, "author-mail "):
+			current.email = strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+		case strings.HasPrefix(line, "\t"):
+			// The content line finishes the entry
+			if current.name != "" && current.name != "Not Committed Yet" {
+				lines[current]++
+			}
+		}
+	}
+
+	return lines
+}
+
+// BuildAuthorsReport aggregates git blame of all tracked files of the extension per module.
+func BuildAuthorsReport(ctx context.Context, ext Extension) ([]ModuleAuthors, error) {
+	filesCmd := process.Command(ctx, "git", "-C", ext.GetPath(), "ls-files", "-z")
+
+	stdout, err := filesCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("BuildAuthorsReport: cannot list git files: %v", err)
+	}
+
+	blames := make(map[string]map[string]map[blameAuthor]int)
+
+	for _, file := range strings.Split(string(stdout), "\x00") {
+		module := classifyAuthorModule(file)
+		if module == "" {
+			continue
+		}
+
+		blameCmd := process.Command(ctx, "git", "-C", ext.GetPath(), "blame", "--line-porcelain", "-w", "--", file)
+
+		var stderr bytes.Buffer
+		blameCmd.Stderr = &stderr
+
+		output, err := blameCmd.Output()
+		if err != nil {
+			logging.FromContext(ctx).Debugf("Cannot blame %s: %s", file, stderr.String())
+			continue
+		}
+
+		if blames[module] == nil {
+			blames[module] = make(map[string]map[blameAuthor]int)
+		}
+
+		blames[module][file] = parseBlamePorcelain(string(output))
+	}
+
+	return aggregateAuthors(blames), nil
+}
+
+// aggregateAuthors sums up the blamed lines by module -> file -> author. Authors are merged by their email and get
+// the name they used for the most lines.
+func aggregateAuthors(blames map[string]map[string]map[blameAuthor]int) []ModuleAuthors {
+	report := make([]ModuleAuthors, 0)
+
+	for module, files := range blames {
+		byEmail := make(map[string]*AuthorContribution)
+		nameLines := make(map[string]map[string]int)
+		moduleAuthors := ModuleAuthors{Module: module}
+
+		for _, authors := range files {
+			for author, lines := range authors {
+				key := strings.ToLower(author.email)
+				if key == "" {
+					key = author.name
+				}
+
+				contribution, ok := byEmail[key]
+				if !ok {
+					contribution = &AuthorContribution{Email: strings.ToLower(author.email)}
+					byEmail[key] = contribution
+					nameLines[key] = make(map[string]int)
+				}
+
+				nameLines[key][author.name] += lines
+				contribution.Lines += lines
+				contribution.Files++
+				moduleAuthors.Lines += lines
+			}
+		}
+
+		for key, contribution := range byEmail {
+			for name, lines := range nameLines[key] {
+				if lines > nameLines[key][contribution.Name] || (lines == nameLines[key][contribution.Name] && name < contribution.Name) {
+					contribution.Name = name
+				}
+			}
+
+			moduleAuthors.Authors = append(moduleAuthors.Authors, *contribution)
+		}
+
+		sort.Slice(moduleAuthors.Authors, func(i, j int) bool {
+			if moduleAuthors.Authors[i].Lines != moduleAuthors.Authors[j].Lines {
+				return moduleAuthors.Authors[i].Lines > moduleAuthors.Authors[j].Lines
+			}
+
+			return moduleAuthors.Authors[i].Name < moduleAuthors.Authors[j].Name
+		})
+
+		report = append(report, moduleAuthors)
+	}
+
+	order := map[string]int{AuthorModulePHP: 0, AuthorModuleAdministration: 1, AuthorModuleStorefront: 2}
+
+	sort.Slice(report, func(i, j int) bool {
+		return order[report[i].Module] < order[report[j].Module]
+	})
+
+	return report
+}
+
+// Share returns the percentage of lines written by the author in the module.
+func (m ModuleAuthors) Share(author AuthorContribution) float64 {
+	if m.Lines == 0 {
+		return 0
+	}
+
+	return float64(author.Lines) * 100 / float64(m.Lines)
+}
+
+func RenderAuthorsReportMarkdown(name string, report []ModuleAuthors) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("# Authors of %s\n", name))
+
+	for _, module := range report {
+		builder.WriteString(fmt.Sprintf("\n## %s\n\n", module.Module))
+		builder.WriteString("| Author | Email | Lines | Share | Files |\n")
+		builder.WriteString("|---|---|---|---|---|\n")
+
+		for _, author := range module.Authors {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %d | %.1f%% | %d |\n", author.Name, author.Email, author.Lines, module.Share(author), author.Files))
+		}
+	}
+
+	return builder.String()
+}
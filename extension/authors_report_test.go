@@ -0,0 +1,79 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyAuthorModule(t *testing.T) {
+	assert.Equal(t, AuthorModulePHP, classifyAuthorModule("src/Subscriber/ProductSubscriber.php"))
+	assert.Equal(t, AuthorModuleAdministration, classifyAuthorModule("src/Resources/app/administration/src/main.js"))
+	assert.Equal(t, AuthorModuleStorefront, classifyAuthorModule("src/Resources/app/storefront/src/main.js"))
+	assert.Equal(t, AuthorModuleStorefront, classifyAuthorModule("src/Resources/views/storefront/base.html.twig"))
+	assert.Equal(t, "", classifyAuthorModule("src/Resources/app/storefront/dist/storefront/js/plugin.js"))
+	assert.Equal(t, "", classifyAuthorModule("src/Resources/public/administration/js/plugin.js"))
+	assert.Equal(t, "", classifyAuthorModule("composer.json"))
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	output := `3f2a1b 1 1 2
+author Jane Doe
+author-mail <jane@example.com>
+author-time 1700000000
+filename src/Foo.php
+	<?php
+3f2a1b 2 2
+author Jane Doe
+author-mail <jane@example.com>
+filename src/Foo.php
+	class Foo {}
+000000 3 3 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+filename src/Foo.php
+	// wip
+4b5c6d 4 4 1
+author John Doe
+author-mail <john@example.com>
+filename src/Foo.php
+	}
+`
+
+	lines := parseBlamePorcelain(output)
+
+	assert.Equal(t, map[blameAuthor]int{
+		{name: "Jane Doe", email: "jane@example.com"}: 2,
+		{name: "John Doe", email: "john@example.com"}: 1,
+	}, lines)
+}
+
+func TestAggregateAuthors(t *testing.T) {
+	jane := blameAuthor{name: "Jane Doe", email: "jane@example.com"}
+	janeUppercase := blameAuthor{name: "Jane", email: "Jane@Example.com"}
+	john := blameAuthor{name: "John Doe", email: "john@example.com"}
+
+	report := aggregateAuthors(map[string]map[string]map[blameAuthor]int{
+		AuthorModuleStorefront: {
+			"src/Resources/app/storefront/src/main.js": {john: 10},
+		},
+		AuthorModulePHP: {
+			"src/Foo.php": {jane: 30, john: 10},
+			"src/Bar.php": {janeUppercase: 20, john: 40},
+		},
+	})
+
+	assert.Len(t, report, 2)
+	assert.Equal(t, AuthorModulePHP, report[0].Module)
+	assert.Equal(t, 100, report[0].Lines)
+	assert.Equal(t, []AuthorContribution{
+		{Name: "Jane Doe", Email: "jane@example.com", Lines: 50, Files: 2},
+		{Name: "John Doe", Email: "john@example.com", Lines: 50, Files: 2},
+	}, report[0].Authors)
+	assert.Equal(t, 50.0, report[0].Share(report[0].Authors[0]))
+	assert.Equal(t, AuthorModuleStorefront, report[1].Module)
+
+	markdown := RenderAuthorsReportMarkdown("FroshTools", report)
+	assert.Contains(t, markdown, "# Authors of FroshTools\n")
+	assert.Contains(t, markdown, "| John Doe | john@example.com | 10 | 100.0% | 1 |\n")
+}
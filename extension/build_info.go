@@ -0,0 +1,141 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+)
+
+const (
+	BuildInfoFormatJSON = "json"
+	BuildInfoFormatPHP  = "php"
+)
+
+// BuildInfo identifies the build of an extension zip, f.e. for support requests.
+type BuildInfo struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	GitCommit  string `json:"gitCommit"`
+	BuildDate  string `json:"buildDate"`
+	CLIVersion string `json:"cliVersion"`
+}
+
+// NewBuildInfo collects the build info of the extension. The commit is resolved from the git ref or HEAD of the extension
+// folder, it stays empty outside of git repositories. SOURCE_DATE_EPOCH overrides the build date.
+func NewBuildInfo(ctx context.Context, ext Extension, gitRef, cliVersion string) (BuildInfo, error) {
+	info := BuildInfo{CLIVersion: cliVersion, BuildDate: time.Now().UTC().Format(time.RFC3339)}
+
+	name, err := ext.GetName()
+	if err != nil {
+		return info, err
+	}
+
+	info.Name = name
+
+	if extVersion, err := ext.GetVersion(); err == nil {
+		info.Version = extVersion.String()
+	}
+
+	if gitRef == "" {
+		gitRef = "HEAD"
+	}
+
+	if commit, err := process.Command(ctx, "git", "-C", ext.GetPath(), "rev-parse", "--verify", "--quiet", gitRef+"^{commit}").Output(); err == nil {
+		info.GitCommit = strings.TrimSpace(string(commit))
+	}
+
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		date, err := parseSourceDateEpoch(epoch)
+		if err != nil {
+			return info, err
+		}
+
+		info.BuildDate = date.Format(time.RFC3339)
+	}
+
+	return info, nil
+}
+
+// WriteBuildInfo writes the build info into the extension folder which gets zipped and returns the written file.
+// The json format writes build-info.json into the extension root, the php format a BuildInfo class next to the plugin class.
+// An existing file of the extension is never overwritten.
+func WriteBuildInfo(extDir string, ext Extension, format string, info BuildInfo) (string, error) {
+	switch format {
+	case BuildInfoFormatJSON:
+		content, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		file := filepath.Join(extDir, "build-info.json")
+
+		return file, writeBuildInfoFile(file, append(content, '\n'))
+	case BuildInfoFormatPHP:
+		plugin, ok := asPlatformPlugin(ext)
+		if !ok {
+			return "", fmt.Errorf("the php build info requires a plugin, use the json format for %s extensions", ext.GetType())
+		}
+
+		classFile := plugin.getPluginClassFile()
+		if classFile == "" {
+			return "", fmt.Errorf("cannot resolve the file of the plugin class %s with the psr-4 autoloading", plugin.composer.Extra.ShopwarePluginClass)
+		}
+
+		pluginClass := plugin.composer.Extra.ShopwarePluginClass
+		namespace := pluginClass[:strings.LastIndex(pluginClass, "\\")+1]
+		file := filepath.Join(extDir, filepath.Dir(classFile), "BuildInfo.php")
+
+		return file, writeBuildInfoFile(file, []byte(renderPHPBuildInfo(strings.TrimSuffix(namespace, "\\"), info)))
+	}
+
+	return "", fmt.Errorf("unknown build info format %s, use json or php", format)
+}
+
+func writeBuildInfoFile(file string, content []byte) error {
+	if _, err := os.Stat(file); err == nil {
+		return fmt.Errorf("the build info file %s already exists in the extension, remove it or disable the build info", filepath.Base(file))
+	}
+
+	return os.WriteFile(file, content, 0o644)
+}
+
+func renderPHPBuildInfo(namespace string, info BuildInfo) string {
+	var buf strings.Builder
+
+	buf.WriteString("<?php declare(strict_types=1);\n\n")
+
+	if namespace != "" {
+		fmt.Fprintf(&buf, "namespace %s;\n\n", namespace)
+	}
+
+	buf.WriteString("/**\n * Generated by shopware-cli extension zip\n */\nfinal class BuildInfo\n{\n")
+
+	for _, constant := range [][2]string{
+		{"VERSION", info.Version},
+		{"GIT_COMMIT", info.GitCommit},
+		{"BUILD_DATE", info.BuildDate},
+		{"CLI_VERSION", info.CLIVersion},
+	} {
+		fmt.Fprintf(&buf, "    public const %s = '%s';\n", constant[0], strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(constant[1]))
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+func parseSourceDateEpoch(epoch string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %s: %w", epoch, err)
+	}
+
+	return time.Unix(seconds, 0).UTC(), nil
+}
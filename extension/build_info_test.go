@@ -0,0 +1,66 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteBuildInfoJSON(t *testing.T) {
+	extDir := t.TempDir()
+	plugin := getTestPlugin(extDir)
+	info := BuildInfo{Name: "FroshTools", Version: "1.0.0", GitCommit: "abc", BuildDate: "2024-01-01T00:00:00Z", CLIVersion: "0.4.0"}
+
+	file, err := WriteBuildInfo(extDir, plugin, BuildInfoFormatJSON, info)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(extDir, "build-info.json"), file)
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+
+	var written BuildInfo
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, info, written)
+}
+
+func TestWriteBuildInfoPHP(t *testing.T) {
+	extDir := t.TempDir()
+	plugin := getTestPlugin(extDir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(extDir, "src"), os.ModePerm))
+
+	file, err := WriteBuildInfo(extDir, plugin, BuildInfoFormatPHP, BuildInfo{Version: "1.0.0", GitCommit: "abc", CLIVersion: "it's dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(extDir, "src", "BuildInfo.php"), file)
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "namespace FroshTools;\n")
+	assert.Contains(t, string(content), "final class BuildInfo\n")
+	assert.Contains(t, string(content), "    public const GIT_COMMIT = 'abc';\n")
+	assert.Contains(t, string(content), "    public const CLI_VERSION = 'it\\'s dev';\n")
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	_, err = WriteBuildInfo(extDir, plugin, BuildInfoFormatPHP, BuildInfo{})
+	assert.ErrorContains(t, err, "the build info file BuildInfo.php already exists in the extension")
+
+	_, err = WriteBuildInfo(extDir, plugin, "yaml", BuildInfo{})
+	assert.ErrorContains(t, err, "unknown build info format yaml")
+}
+
+func TestNewBuildInfoSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+	info, err := NewBuildInfo(context.Background(), getTestPlugin(t.TempDir()), "", "0.4.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "FroshTools", info.Name)
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, "2023-11-14T22:13:20Z", info.BuildDate)
+	assert.Equal(t, "0.4.0", info.CLIVersion)
+}
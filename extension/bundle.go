@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 
 	"github.com/FriendsOfShopware/shopware-cli/version"
 )
@@ -71,12 +71,12 @@ type shopwareBundleComposerJsonExtra struct {
 
 // GetRootDir returns the src directory of the bundle.
 func (p ShopwareBundle) GetRootDir() string {
-	return path.Join(p.path, "src")
+	return filepath.Join(p.path, "src")
 }
 
 // GetResourcesDir returns the resources directory of the shopware bundle.
 func (p ShopwareBundle) GetResourcesDir() string {
-	return path.Join(p.GetRootDir(), "Resources")
+	return filepath.Join(p.GetRootDir(), "Resources")
 }
 
 func (p ShopwareBundle) GetName() (string, error) {
@@ -5,15 +5,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/yuin/goldmark"
 	goldmarkExtension "github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+var (
+	keepAChangelogLinkRegex    = regexp.MustCompile(`^\[[^\]]+\]:\s`)
+	keepAChangelogVersionRegex = regexp.MustCompile(`(?m)^## (\[[^\]]+\]|v?\d+\.\d+)`)
 )
 
-func parseMarkdownChangelogInPath(path string) (map[string]map[string]string, error) {
+func parseMarkdownChangelogInPath(path string, sections map[string]string) (map[string]map[string]string, error) {
 	files, err := filepath.Glob(fmt.Sprintf("%s/CHANGELOG*.md", path))
 	if err != nil {
 		return nil, err
@@ -33,10 +42,10 @@ func parseMarkdownChangelogInPath(path string) (map[string]map[string]string, er
 			return nil, fmt.Errorf("parseMarkdownChangelogInPath: %v", err)
 		}
 
-		changelogs[language], err = parseMarkdownChangelog(string(content))
-
-		if err != nil {
-			return nil, fmt.Errorf("parseMarkdownChangelogInPath: %v", err)
+		if isKeepAChangelog(string(content)) {
+			changelogs[language] = splitKeepAChangelog(string(content), sections)
+		} else {
+			changelogs[language] = splitMarkdownChangelog(string(content))
 		}
 	}
 
@@ -44,12 +53,18 @@ func parseMarkdownChangelogInPath(path string) (map[string]map[string]string, er
 }
 
 func parseMarkdownChangelog(content string) (map[string]string, error) {
+	return renderChangelogVersions(splitMarkdownChangelog(content))
+}
+
+// splitMarkdownChangelog splits the changelog into the markdown of each version, versions are "# 1.0.0" headings.
+func splitMarkdownChangelog(content string) map[string]string {
 	versions := make(map[string]string)
 	currentVersion := ""
 	versionText := ""
 
 	for _, line := range strings.Split(content, "\n") {
-		if strings.HasPrefix(line, "#") {
+		// sub-headings like "### Added" are part of the version
+		if strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "##") {
 			if len(currentVersion) > 0 && len(versionText) > 0 {
 				versions[currentVersion] = versionText
 			}
@@ -63,54 +78,242 @@ func parseMarkdownChangelog(content string) (map[string]string, error) {
 
 	versions[currentVersion] = versionText
 
-	for key, changelog := range versions {
-		var buf bytes.Buffer
+	return versions
+}
+
+// isKeepAChangelog detects the Keep-a-Changelog format by its version headings like "## [1.0.0]" or "## 1.0.0".
+// The Shopware format uses "# 1.0.0" and can contain sub-headings as well.
+func isKeepAChangelog(content string) bool {
+	return keepAChangelogVersionRegex.MatchString(content)
+}
+
+func parseKeepAChangelog(content string, sections map[string]string) (map[string]string, error) {
+	return renderChangelogVersions(splitKeepAChangelog(content, sections))
+}
+
+// splitKeepAChangelog splits a changelog in the Keep-a-Changelog format (https://keepachangelog.com) into the markdown of each version.
+// The sections map allows renaming sections like "Added" for the store, mapping a section to an empty string drops it.
+func splitKeepAChangelog(content string, sections map[string]string) map[string]string {
+	versions := make(map[string]string)
+	currentVersion := ""
+	skipSection := false
 
-		err := GetConfiguredGoldMark().Convert([]byte(changelog), &buf)
+	var versionText strings.Builder
+
+	flush := func() {
+		if len(currentVersion) > 0 {
+			versions[currentVersion] = strings.TrimSpace(versionText.String())
+		}
+		versionText.Reset()
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, "### "):
+			section := strings.TrimSpace(strings.TrimPrefix(line, "### "))
+			label, ok := sections[section]
+			if !ok {
+				label = section
+			}
+
+			skipSection = label == ""
+			if !skipSection {
+				versionText.WriteString(fmt.Sprintf("\n**%s**\n\n", label))
+			}
+		case strings.HasPrefix(line, "## "):
+			flush()
+			currentVersion = parseKeepAChangelogVersion(strings.TrimPrefix(line, "## "))
+			skipSection = false
+		case strings.HasPrefix(line, "#"), keepAChangelogLinkRegex.MatchString(line):
+		default:
+			if len(currentVersion) > 0 && !skipSection {
+				versionText.WriteString(strings.TrimRight(line, " ") + "\n")
+			}
+		}
+	}
+
+	flush()
+
+	return versions
+}
+
+// parseKeepAChangelogVersion extracts the version of headings like "[1.0.0] - 2023-01-01".
+func parseKeepAChangelogVersion(heading string) string {
+	heading, _, _ = strings.Cut(heading, " - ")
+	heading = strings.Trim(strings.TrimSpace(heading), "[]")
+
+	return strings.TrimPrefix(heading, "v")
+}
+
+func renderChangelogVersions(versions map[string]string) (map[string]string, error) {
+	for key, changelog := range versions {
+		rendered, err := renderChangelogMarkdown(changelog)
 		if err != nil {
 			return nil, err
 		}
 
-		versions[key] = buf.String()
+		versions[key] = rendered
 	}
 
 	return versions, nil
 }
 
+func renderChangelogMarkdown(content string) (string, error) {
+	var buf bytes.Buffer
+
+	if err := GetConfiguredGoldMark().Convert([]byte(content), &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 func parseExtensionMarkdownChangelog(ext Extension) (*extensionTranslated, error) {
 	v, err := ext.GetVersion()
 	if err != nil {
 		return nil, err
 	}
 
-	changelogs, err := parseMarkdownChangelogInPath(ext.GetPath())
+	changelog, err := ParseExtensionChangelog(ext)
 	if err != nil {
 		return nil, err
 	}
 
-	changelogEn, ok := changelogs["en-GB"]
-	if !ok {
+	if !changelog.HasLanguage("en-GB") {
 		return nil, fmt.Errorf("english changelog in version %s is missing", v.String())
 	}
 
-	changelogEnVersion, ok := changelogEn[v.String()]
-	if !ok {
+	changelogVersion := changelog.GetVersion(v.String())
+	if changelogVersion == nil {
 		return nil, fmt.Errorf("english changelog is missing")
 	}
 
-	changelogDe, ok := changelogs["de-DE"]
-	if !ok {
-		changelogDe = changelogEn
+	if _, ok := changelogVersion.Entries["en-GB"]; !ok {
+		return nil, fmt.Errorf("english changelog is missing")
+	}
+
+	changelogEnVersion, err := changelogVersion.HTML("en-GB")
+	if err != nil {
+		return nil, err
 	}
 
-	changelogDeVersion, ok := changelogDe[v.String()]
-	if !ok {
+	if _, ok := changelogVersion.Entries["de-DE"]; !ok && changelog.HasLanguage("de-DE") {
 		return nil, fmt.Errorf("german changelog in version %s is missing", v.String())
 	}
 
+	changelogDeVersion, err := changelogVersion.HTML("de-DE")
+	if err != nil {
+		return nil, err
+	}
+
 	return &extensionTranslated{German: changelogDeVersion, English: changelogEnVersion}, nil
 }
 
+// Changelog contains all versions of the markdown changelogs of an extension.
+type Changelog struct {
+	// Versions sorted from the newest to the oldest version
+	Versions  []ChangelogVersion
+	languages map[string]bool
+}
+
+type ChangelogVersion struct {
+	Version string
+	// Entries contains the markdown of this version by language
+	Entries map[string]string
+}
+
+// ParseExtensionChangelog parses the CHANGELOG*.md files of the extension.
+func ParseExtensionChangelog(ext Extension) (*Changelog, error) {
+	return ParseChangelog(ext.GetPath(), ext.GetExtensionConfig().Store.ChangelogSections)
+}
+
+// ParseChangelog parses all CHANGELOG*.md files in the given folder. Both the Shopware format with one file per language and Keep-a-Changelog are supported.
+func ParseChangelog(path string, sections map[string]string) (*Changelog, error) {
+	changelogs, err := parseMarkdownChangelogInPath(path, sections)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog := &Changelog{
+		Versions:  make([]ChangelogVersion, 0),
+		languages: make(map[string]bool),
+	}
+
+	versionIndex := make(map[string]int)
+
+	for language, versions := range changelogs {
+		changelog.languages[language] = true
+
+		for versionName, content := range versions {
+			if versionName == "" {
+				continue
+			}
+
+			index, ok := versionIndex[versionName]
+			if !ok {
+				index = len(changelog.Versions)
+				versionIndex[versionName] = index
+				changelog.Versions = append(changelog.Versions, ChangelogVersion{Version: versionName, Entries: make(map[string]string)})
+			}
+
+			changelog.Versions[index].Entries[language] = strings.TrimSpace(content)
+		}
+	}
+
+	sort.SliceStable(changelog.Versions, func(i, j int) bool {
+		return compareChangelogVersions(changelog.Versions[i].Version, changelog.Versions[j].Version)
+	})
+
+	return changelog, nil
+}
+
+// compareChangelogVersions sorts newer versions first, entries which are no valid version like "Unreleased" are sorted on top.
+func compareChangelogVersions(a, b string) bool {
+	av, aErr := version.NewVersion(a)
+	bv, bErr := version.NewVersion(b)
+
+	switch {
+	case aErr != nil && bErr != nil:
+		return a < b
+	case aErr != nil:
+		return true
+	case bErr != nil:
+		return false
+	}
+
+	return av.GreaterThan(bv)
+}
+
+// HasLanguage returns true when a changelog file exists for the language.
+func (c Changelog) HasLanguage(language string) bool {
+	return c.languages[language]
+}
+
+// GetVersion returns the changelog of the given version or nil when it does not exist.
+func (c Changelog) GetVersion(versionName string) *ChangelogVersion {
+	for i := range c.Versions {
+		if c.Versions[i].Version == versionName {
+			return &c.Versions[i]
+		}
+	}
+
+	return nil
+}
+
+// Markdown returns the markdown of the language and falls back to the english changelog.
+func (v ChangelogVersion) Markdown(language string) string {
+	if content, ok := v.Entries[language]; ok {
+		return content
+	}
+
+	return v.Entries["en-GB"]
+}
+
+// HTML returns the rendered changelog of the language and falls back to the english changelog.
+func (v ChangelogVersion) HTML(language string) (string, error) {
+	return renderChangelogMarkdown(v.Markdown(language))
+}
+
 func GetConfiguredGoldMark() goldmark.Markdown {
 	return goldmark.New(
 		goldmark.WithExtensions(goldmarkExtension.GFM),
@@ -0,0 +1,218 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/git"
+)
+
+var conventionalCommitRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changelogSections maps the conventional commit types to the Keep-a-Changelog sections in their order.
+var changelogSections = []struct {
+	Section string
+	Types   []string
+}{
+	{Section: "Added", Types: []string{"feat"}},
+	{Section: "Changed", Types: []string{"perf", "revert"}},
+	{Section: "Fixed", Types: []string{"fix"}},
+}
+
+// ChangelogEntries contains the generated changelog entries by Keep-a-Changelog section.
+type ChangelogEntries map[string][]string
+
+// IsEmpty returns true, when no commit resulted in a changelog entry.
+func (e ChangelogEntries) IsEmpty() bool {
+	for _, entries := range e {
+		if len(entries) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CollectChangelogEntries collects the conventional commits since the last tag of the extension repository.
+// Commits not matching changelog.pattern of the extension config are skipped.
+func CollectChangelogEntries(ctx context.Context, ext Extension) (ChangelogEntries, error) {
+	commits, err := git.GetCommits(ctx, ext.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := ""
+	if cfg := ext.GetExtensionConfig(); cfg != nil {
+		pattern = cfg.Changelog.Pattern
+	}
+
+	return changelogEntriesFromCommits(commits, pattern)
+}
+
+func changelogEntriesFromCommits(commits []git.GitCommit, pattern string) (ChangelogEntries, error) {
+	var matcher *regexp.Regexp
+
+	if pattern != "" {
+		var err error
+		if matcher, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid changelog pattern: %w", err)
+		}
+	}
+
+	entries := make(ChangelogEntries)
+
+	for _, commit := range commits {
+		if matcher != nil && !matcher.MatchString(commit.Message) {
+			continue
+		}
+
+		match := conventionalCommitRegex.FindStringSubmatch(strings.TrimSpace(commit.Message))
+		if match == nil {
+			continue
+		}
+
+		commitType, scope, breaking, description := strings.ToLower(match[1]), match[2], match[3] == "!", match[4]
+
+		section := ""
+
+		for _, candidate := range changelogSections {
+			for _, t := range candidate.Types {
+				if t == commitType {
+					section = candidate.Section
+				}
+			}
+		}
+
+		// breaking changes are always listed, even for types like refactor
+		if section == "" && breaking {
+			section = "Changed"
+		}
+
+		if section == "" {
+			continue
+		}
+
+		if scope != "" {
+			description = fmt.Sprintf("**%s:** %s", scope, description)
+		}
+
+		if breaking {
+			description = "**BREAKING** " + description
+		}
+
+		entries[section] = append(entries[section], description)
+	}
+
+	return entries, nil
+}
+
+// render renders the entries with Keep-a-Changelog sections or as plain list for the Shopware changelog format.
+func (e ChangelogEntries) render(keepAChangelog bool) string {
+	var buf strings.Builder
+
+	for _, section := range changelogSections {
+		if len(e[section.Section]) == 0 {
+			continue
+		}
+
+		if keepAChangelog {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+
+			fmt.Fprintf(&buf, "### %s\n\n", section.Section)
+		}
+
+		for _, entry := range e[section.Section] {
+			fmt.Fprintf(&buf, "- %s\n", entry)
+		}
+	}
+
+	return buf.String()
+}
+
+// WriteGeneratedChangelog adds the entries as section of the version to all changelog files of the extension. Without
+// changelog files CHANGELOG_en-GB.md and CHANGELOG_de-DE.md are created. All files are rendered before the first one is
+// written, so an error leaves every changelog untouched. The changed files are returned.
+func WriteGeneratedChangelog(ext Extension, newVersion string, entries ChangelogEntries, date time.Time) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(ext.GetPath(), "CHANGELOG*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		files = []string{filepath.Join(ext.GetPath(), "CHANGELOG_en-GB.md"), filepath.Join(ext.GetPath(), "CHANGELOG_de-DE.md")}
+	}
+
+	rendered := make([]string, 0, len(files))
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		updated, err := insertChangelogEntries(string(content), newVersion, entries, date)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+
+		rendered = append(rendered, updated)
+	}
+
+	changed := make([]string, 0, len(files))
+
+	for i, file := range files {
+		if err := os.WriteFile(file, []byte(rendered[i]), 0o644); err != nil {
+			return nil, err
+		}
+
+		changed = append(changed, filepath.Base(file))
+	}
+
+	return changed, nil
+}
+
+// insertChangelogEntries adds the heading of the version when missing and writes the entries below it.
+// A version which has entries already is not touched.
+func insertChangelogEntries(content, newVersion string, entries ChangelogEntries, date time.Time) (string, error) {
+	keepAChangelog := isKeepAChangelog(content)
+
+	content = addChangelogVersion(content, newVersion, date)
+	lines := strings.Split(content, "\n")
+
+	for i, line := range lines {
+		match := changelogVersionHeadRegex.FindStringSubmatch(line)
+		if match == nil || parseKeepAChangelogVersion(match[1]) != newVersion {
+			continue
+		}
+
+		end := len(lines)
+
+		for j := i + 1; j < len(lines); j++ {
+			if changelogVersionHeadRegex.MatchString(lines[j]) {
+				end = j
+				break
+			}
+		}
+
+		if strings.TrimSpace(strings.Join(lines[i+1:end], "\n")) != "" {
+			return "", fmt.Errorf("the changelog of version %s has entries already", newVersion)
+		}
+
+		section := append([]string{line, ""}, strings.Split(entries.render(keepAChangelog), "\n")...)
+
+		if end < len(lines) {
+			return strings.Join(append(append(lines[:i:i], section...), lines[end:]...), "\n"), nil
+		}
+
+		return strings.Join(append(lines[:i:i], section...), "\n"), nil
+	}
+
+	return "", fmt.Errorf("cannot find the heading of version %s", newVersion)
+}
@@ -0,0 +1,112 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/git"
+)
+
+func TestChangelogEntriesFromCommits(t *testing.T) {
+	commits := []git.GitCommit{
+		{Hash: "a", Message: "feat(admin): add the cache module"},
+		{Hash: "b", Message: "fix: reset the queue"},
+		{Hash: "c", Message: "chore: update dependencies"},
+		{Hash: "d", Message: "refactor!: drop the legacy api"},
+		{Hash: "e", Message: "Merge branch 'main'"},
+		{Hash: "f", Message: "perf: cache the indexer"},
+	}
+
+	entries, err := changelogEntriesFromCommits(commits, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, ChangelogEntries{
+		"Added":   {"**admin:** add the cache module"},
+		"Fixed":   {"reset the queue"},
+		"Changed": {"**BREAKING** drop the legacy api", "cache the indexer"},
+	}, entries)
+
+	entries, err = changelogEntriesFromCommits(commits, "^fix")
+	assert.NoError(t, err)
+	assert.Equal(t, ChangelogEntries{"Fixed": {"reset the queue"}}, entries)
+
+	entries, err = changelogEntriesFromCommits(commits[2:3], "")
+	assert.NoError(t, err)
+	assert.True(t, entries.IsEmpty())
+}
+
+func TestWriteGeneratedChangelog(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG_en-GB.md": "# 0.9.0\n\n- First release\n",
+		"CHANGELOG_de-DE.md": "# 0.9.0\n\n- Erste Version\n",
+	})
+
+	entries := ChangelogEntries{"Added": {"add the cache module"}, "Fixed": {"reset the queue"}}
+
+	changed, err := WriteGeneratedChangelog(plugin, "1.0.0", entries, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"CHANGELOG_en-GB.md", "CHANGELOG_de-DE.md"}, changed)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "CHANGELOG_en-GB.md"))
+	assert.Equal(t, "# 1.0.0\n\n- add the cache module\n- reset the queue\n\n# 0.9.0\n\n- First release\n", string(content))
+
+	changelog, err := ParseChangelog(dir, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "- add the cache module\n- reset the queue", changelog.GetVersion("1.0.0").Markdown("de-DE"))
+
+	// the version has entries now
+	_, err = WriteGeneratedChangelog(plugin, "1.0.0", entries, time.Now())
+	assert.ErrorContains(t, err, "has entries already")
+}
+
+func TestWriteGeneratedChangelogKeepsAllFilesOnError(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG_de-DE.md": "# 0.9.0\n\n- Erste Version\n",
+		"CHANGELOG_en-GB.md": "# 1.0.0\n\n- Cache module\n\n# 0.9.0\n\n- First release\n",
+	})
+
+	_, err := WriteGeneratedChangelog(plugin, "1.0.0", ChangelogEntries{"Added": {"add the cache module"}}, time.Now())
+	assert.EqualError(t, err, "CHANGELOG_en-GB.md: the changelog of version 1.0.0 has entries already")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "CHANGELOG_de-DE.md"))
+	assert.Equal(t, "# 0.9.0\n\n- Erste Version\n", string(content))
+}
+
+func TestWriteGeneratedChangelogKeepAChangelog(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG.md": "# Changelog\n\n## [Unreleased]\n\n## [0.9.0] - 2023-12-01\n\n### Added\n\n- First release\n",
+	})
+
+	entries := ChangelogEntries{"Added": {"add the cache module"}, "Fixed": {"reset the queue"}}
+
+	_, err := WriteGeneratedChangelog(plugin, "1.0.0", entries, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	assert.Equal(t, "# Changelog\n\n## [1.0.0] - 2024-01-02\n\n### Added\n\n- add the cache module\n\n### Fixed\n\n- reset the queue\n\n## [0.9.0] - 2023-12-01\n\n### Added\n\n- First release\n", string(content))
+}
+
+func TestWriteGeneratedChangelogCreatesFiles(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	changed, err := WriteGeneratedChangelog(plugin, "1.0.0", ChangelogEntries{"Fixed": {"reset the queue"}}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CHANGELOG_en-GB.md", "CHANGELOG_de-DE.md"}, changed)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "CHANGELOG_de-DE.md"))
+	assert.Equal(t, "# 1.0.0\n\n- reset the queue\n", string(content))
+}
@@ -1,6 +1,8 @@
 package extension
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +23,95 @@ func TestChangelogParsingWhitespaces(t *testing.T) {
 	assert.Equal(t, "<ul>\n<li>Test</li>\n<li>Test2</li>\n</ul>\n", content["1.0.0"])
 	assert.Equal(t, "<ul>\n<li>Test3</li>\n<li>Test4</li>\n</ul>\n", content["2.0.0"])
 }
+
+func TestKeepAChangelogParsing(t *testing.T) {
+	content := `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+### Added
+- Unreleased feature
+
+## [1.1.0] - 2023-05-01
+
+### Added
+- New feature
+
+### Fixed
+- Bug fix
+
+## [1.0.0] - 2023-01-01
+
+### Added
+- First release
+
+[1.1.0]: https://github.com/example/example/compare/v1.0.0...v1.1.0
+`
+
+	assert.True(t, isKeepAChangelog(content))
+
+	versions, err := parseKeepAChangelog(content, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<p><strong>Added</strong></p>\n<ul>\n<li>New feature</li>\n</ul>\n<p><strong>Fixed</strong></p>\n<ul>\n<li>Bug fix</li>\n</ul>\n", versions["1.1.0"])
+	assert.Equal(t, "<p><strong>Added</strong></p>\n<ul>\n<li>First release</li>\n</ul>\n", versions["1.0.0"])
+	assert.Contains(t, versions, "Unreleased")
+}
+
+func TestKeepAChangelogSectionMapping(t *testing.T) {
+	content := "## 1.0.0\n### Added\n- Feature\n### Security\n- Internal\n"
+
+	versions, err := parseKeepAChangelog(content, map[string]string{"Added": "Neu", "Security": ""})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<p><strong>Neu</strong></p>\n<ul>\n<li>Feature</li>\n</ul>\n", versions["1.0.0"])
+}
+
+func TestShopwareChangelogIsNotKeepAChangelog(t *testing.T) {
+	assert.False(t, isKeepAChangelog("# 1.0.0\n\n- Test\n"))
+	assert.False(t, isKeepAChangelog("# 1.0.0\n\n### Added\n- Test\n"))
+	assert.True(t, isKeepAChangelog("# Changelog\n\n## [Unreleased]\n- Test\n"))
+	assert.True(t, isKeepAChangelog("## 1.0.0\n- Test\n"))
+}
+
+func TestShopwareChangelogWithSubHeadings(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("# 1.1.0\n\n### Added\n- Feature\n\n### Fixed\n- Bug\n\n# 1.0.0\n\n### Added\n- First\n"), os.ModePerm))
+
+	changelog, err := ParseChangelog(dir, nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, changelog.Versions, 2)
+	assert.Equal(t, "1.1.0", changelog.Versions[0].Version)
+	assert.Equal(t, "### Added\n- Feature\n\n### Fixed\n- Bug", changelog.Versions[0].Markdown("en-GB"))
+	assert.Equal(t, "### Added\n- First", changelog.GetVersion("1.0.0").Markdown("en-GB"))
+}
+
+func TestParseChangelogVersions(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG_en-GB.md"), []byte("# 1.0.0\n- First\n# 1.1.0\n- Second\n"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG_de-DE.md"), []byte("# 1.0.0\n- Erste\n"), os.ModePerm))
+
+	changelog, err := ParseChangelog(dir, nil)
+	assert.NoError(t, err)
+
+	assert.True(t, changelog.HasLanguage("en-GB"))
+	assert.True(t, changelog.HasLanguage("de-DE"))
+	assert.Len(t, changelog.Versions, 2)
+	assert.Equal(t, "1.1.0", changelog.Versions[0].Version)
+	assert.Equal(t, "1.0.0", changelog.Versions[1].Version)
+
+	first := changelog.GetVersion("1.0.0")
+	assert.NotNil(t, first)
+	assert.Equal(t, "- Erste", first.Markdown("de-DE"))
+
+	html, err := changelog.GetVersion("1.1.0").HTML("de-DE")
+	assert.NoError(t, err)
+	assert.Equal(t, "<ul>\n<li>Second</li>\n</ul>\n", html)
+
+	assert.Nil(t, changelog.GetVersion("2.0.0"))
+}
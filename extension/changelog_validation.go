@@ -0,0 +1,164 @@
+package extension
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultDisallowedChangelogDomains are other shop systems and marketplaces, the store rejects changelogs linking to them.
+var defaultDisallowedChangelogDomains = []string{
+	"shopify.com",
+	"woocommerce.com",
+	"magento.com",
+	"adobe.com",
+	"oxid-esales.com",
+	"plentymarkets.com",
+	"jtl-software.de",
+	"gambio.de",
+	"prestashop.com",
+	"bigcommerce.com",
+}
+
+var (
+	changelogHTMLTagRegex  = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(\s[^<>]*)?/?>`)
+	changelogCodeSpanRegex = regexp.MustCompile("`[^`]*`")
+	changelogEmailRegex    = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+	changelogURLRegex      = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>()\[\]"']+`)
+)
+
+// validateChangelogContent reports content of the changelog entries the store rejects: HTML tags, email addresses,
+// links to other shop systems and entries exceeding the configured maximum length.
+func validateChangelogContent(ctx *ValidationContext) {
+	cfg := ConfigValidationChangelog{}
+	if extCfg := ctx.Extension.GetExtensionConfig(); extCfg != nil {
+		cfg = extCfg.Validation.Changelog
+	}
+
+	disallowedDomains := make(map[string]bool)
+
+	for _, domain := range defaultDisallowedChangelogDomains {
+		disallowedDomains[domain] = true
+	}
+
+	for _, domain := range cfg.DisallowedDomains {
+		disallowedDomains[strings.ToLower(domain)] = true
+	}
+
+	for _, domain := range cfg.AllowedDomains {
+		delete(disallowedDomains, strings.ToLower(domain))
+	}
+
+	files, err := filepath.Glob(filepath.Join(ctx.Extension.GetPath(), "CHANGELOG*.md"))
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			ctx.AddWarning(fmt.Sprintf("Changelog %s was not checked: %s", filepath.Base(file), err.Error()))
+			continue
+		}
+
+		lintChangelogFile(ctx, filepath.Base(file), string(content), cfg.MaxLength, disallowedDomains)
+	}
+}
+
+// lintChangelogFile checks each line of the changelog and the length of each version entry.
+func lintChangelogFile(ctx *ValidationContext, file, content string, maxLength int, disallowedDomains map[string]bool) {
+	keepAChangelog := isKeepAChangelog(content)
+	entryVersion := ""
+	entryLine := 0
+	entryLength := 0
+
+	checkLength := func() {
+		if maxLength > 0 && entryVersion != "" && entryLength > maxLength {
+			ctx.AddFileError(file, entryLine, fmt.Sprintf("the changelog of version %s has %d characters, the maximum is %d", entryVersion, entryLength, maxLength))
+		}
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNumber := i + 1
+
+		// Keep-a-Changelog uses "# Changelog" as title and "## [1.0.0]" for the versions, the Shopware format "# 1.0.0"
+		isVersionHeading := strings.HasPrefix(line, "# ")
+		if keepAChangelog {
+			isVersionHeading = strings.HasPrefix(line, "## ")
+		}
+
+		if isVersionHeading {
+			checkLength()
+
+			entryVersion = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			if keepAChangelog {
+				entryVersion = parseKeepAChangelogVersion(strings.TrimPrefix(line, "## "))
+			}
+
+			entryLine = lineNumber
+			entryLength = 0
+
+			continue
+		}
+
+		// Link references of Keep-a-Changelog are not part of the entries shown in the store
+		if keepAChangelog && keepAChangelogLinkRegex.MatchString(line) {
+			continue
+		}
+
+		if entryVersion != "" && !strings.HasPrefix(line, "#") {
+			entryLength += utf8.RuneCountInString(strings.TrimSpace(line))
+		}
+
+		text := changelogCodeSpanRegex.ReplaceAllString(line, "")
+
+		for _, tag := range changelogHTMLTagRegex.FindAllString(text, -1) {
+			ctx.AddFileError(file, lineNumber, fmt.Sprintf("the HTML tag %s is not allowed in changelogs, use markdown instead", tag))
+		}
+
+		for _, email := range changelogEmailRegex.FindAllString(text, -1) {
+			ctx.AddFileError(file, lineNumber, fmt.Sprintf("the email address %s is not allowed in changelogs, the store provides the support contact", email))
+		}
+
+		for _, link := range changelogURLRegex.FindAllString(text, -1) {
+			if domain := disallowedChangelogDomain(link, disallowedDomains); domain != "" {
+				ctx.AddFileError(file, lineNumber, fmt.Sprintf("the link %s to %s is not allowed in changelogs", link, domain))
+			}
+		}
+	}
+
+	checkLength()
+}
+
+// disallowedChangelogDomain returns the disallowed domain the link points to, subdomains like apps.shopify.com are included.
+func disallowedChangelogDomain(link string, disallowedDomains map[string]bool) string {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+
+	for host != "" {
+		if disallowedDomains[host] {
+			return host
+		}
+
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return ""
+		}
+
+		host = parent
+	}
+
+	return ""
+}
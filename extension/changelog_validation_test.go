@@ -0,0 +1,91 @@
+package extension
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fileIssues formats the issues with their location like an editor would show them.
+func fileIssues(ctx *ValidationContext) []string {
+	formatted := make([]string, 0, len(ctx.Issues()))
+
+	for _, issue := range ctx.Issues() {
+		formatted = append(formatted, fmt.Sprintf("%s:%d: %s", issue.File, issue.Line, issue.Message))
+	}
+
+	return formatted
+}
+
+func TestValidateChangelogContent(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG.md":       "# 1.0.1\n\n* Fixed the <b>checkout</b>, questions to support@example.com\n* Import from https://apps.shopify.com/frosh and `<div>` blocks\n\n# 1.0.0\n\n* Initial release, see https://github.com/FriendsOfShopware\n",
+		"CHANGELOG_de-DE.md": "# 1.0.1\n\n* Checkout korrigiert<br/>\n\n# 1.0.0\n\n* Erste Version\n",
+	})
+
+	ctx := NewValidationContext(&plugin)
+	validateChangelogContent(ctx)
+
+	assert.Equal(t, []string{
+		"CHANGELOG.md:3: the HTML tag <b> is not allowed in changelogs, use markdown instead",
+		"CHANGELOG.md:3: the HTML tag </b> is not allowed in changelogs, use markdown instead",
+		"CHANGELOG.md:3: the email address support@example.com is not allowed in changelogs, the store provides the support contact",
+		"CHANGELOG.md:4: the link https://apps.shopify.com/frosh to shopify.com is not allowed in changelogs",
+		"CHANGELOG_de-DE.md:3: the HTML tag <br/> is not allowed in changelogs, use markdown instead",
+	}, fileIssues(ctx))
+
+	assert.Equal(t, "the HTML tag <b> is not allowed in changelogs, use markdown instead", ctx.Errors()[0])
+}
+
+func TestValidateChangelogContentConfig(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.config = &Config{}
+	plugin.config.Validation.Changelog.MaxLength = 30
+	plugin.config.Validation.Changelog.DisallowedDomains = []string{"competitor.example"}
+	plugin.config.Validation.Changelog.AllowedDomains = []string{"shopify.com"}
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG.md": "# Changelog\n\n## [1.0.1] - 2024-01-01\n\n### Fixed\n\n* Migration from www.shopify.com\n\n## [1.0.0] - 2023-12-01\n\n* www.competitor.example\n\n[1.0.1]: https://shop.competitor.example/compare\n",
+	})
+
+	ctx := NewValidationContext(&plugin)
+	validateChangelogContent(ctx)
+
+	assert.Equal(t, []string{
+		"CHANGELOG.md:3: the changelog of version 1.0.1 has 32 characters, the maximum is 30",
+		"CHANGELOG.md:11: the link www.competitor.example to competitor.example is not allowed in changelogs",
+	}, fileIssues(ctx))
+}
+
+func TestValidateChangelogContentShopwareFormatWithSubHeadings(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.config = &Config{}
+	plugin.config.Validation.Changelog.MaxLength = 20
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"CHANGELOG.md": "# 1.0.1\n\n### Added\n\n* A new feature\n\n### Fixed\n\n* Checkout <br>\n\n# 1.0.0\n\n### Added\n\n* First\n",
+	})
+
+	ctx := NewValidationContext(&plugin)
+	validateChangelogContent(ctx)
+
+	assert.Equal(t, []string{
+		"CHANGELOG.md:9: the HTML tag <br> is not allowed in changelogs, use markdown instead",
+		"CHANGELOG.md:1: the changelog of version 1.0.1 has 30 characters, the maximum is 20",
+	}, fileIssues(ctx))
+}
+
+func TestDisallowedChangelogDomain(t *testing.T) {
+	domains := map[string]bool{"shopify.com": true}
+
+	assert.Equal(t, "shopify.com", disallowedChangelogDomain("https://apps.shopify.com/app", domains))
+	assert.Equal(t, "shopify.com", disallowedChangelogDomain("www.shopify.com", domains))
+	assert.Equal(t, "", disallowedChangelogDomain("https://notshopify.com", domains))
+	assert.Equal(t, "", disallowedChangelogDomain("https://store.shopware.com", domains))
+}
@@ -0,0 +1,73 @@
+package extension
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"sort"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// RenderCheckstyle converts the validation result into the Checkstyle XML format. Issues without file are reported on the
+// composer.json or manifest.xml of the extension.
+func RenderCheckstyle(ctx *ValidationContext) ([]byte, error) {
+	defaultFile := "composer.json"
+	if ctx.Extension.GetType() == TypePlatformApp {
+		defaultFile = "manifest.xml"
+	}
+
+	files := make(map[string]*checkstyleFile)
+
+	for _, issue := range ctx.Issues() {
+		name := issue.File
+		if name == "" {
+			name = defaultFile
+		}
+
+		name = filepath.Join(ctx.Extension.GetPath(), name)
+
+		if _, ok := files[name]; !ok {
+			files[name] = &checkstyleFile{Name: name, Errors: make([]checkstyleError, 0)}
+		}
+
+		files[name].Errors = append(files[name].Errors, checkstyleError{
+			Line:     issue.Line,
+			Severity: issue.Severity,
+			Message:  issue.Message,
+			Source:   "shopware-cli.extension.validate",
+		})
+	}
+
+	report := checkstyleReport{Version: "4.3", Files: make([]checkstyleFile, 0, len(files))}
+
+	for _, file := range files {
+		report.Files = append(report.Files, *file)
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Name < report.Files[j].Name
+	})
+
+	content, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
@@ -0,0 +1,39 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCheckstyle(t *testing.T) {
+	plugin := getTestPlugin("/ext")
+
+	ctx := NewValidationContext(plugin)
+	ctx.AddError("label is not translated in german")
+	ctx.AddFileError("src/Foo.php", 12, "src/Foo.php line 12: nullsafe operator requires PHP 8.0")
+	ctx.AddFileWarning("src/Foo.php", 0, "warning with \"quotes\" & <brackets>")
+
+	content, err := RenderCheckstyle(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="/ext/composer.json">
+    <error severity="error" message="label is not translated in german" source="shopware-cli.extension.validate"></error>
+  </file>
+  <file name="/ext/src/Foo.php">
+    <error line="12" severity="error" message="src/Foo.php line 12: nullsafe operator requires PHP 8.0" source="shopware-cli.extension.validate"></error>
+    <error severity="warning" message="warning with &#34;quotes&#34; &amp; &lt;brackets&gt;" source="shopware-cli.extension.validate"></error>
+  </file>
+</checkstyle>`, string(content))
+
+	assert.Equal(t, []string{"label is not translated in german", "src/Foo.php line 12: nullsafe operator requires PHP 8.0"}, ctx.Errors())
+	assert.Len(t, ctx.Issues(), 3)
+}
+
+func TestRenderCheckstyleEmpty(t *testing.T) {
+	content, err := RenderCheckstyle(NewValidationContext(getTestPlugin("/ext")))
+	assert.NoError(t, err)
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<checkstyle version=\"4.3\"></checkstyle>", string(content))
+}
@@ -0,0 +1,187 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// ciMatrixPHPVersions are the PHP versions the matrix is built from, each Shopware version gets the ones it supports.
+var ciMatrixPHPVersions = []string{"7.2", "7.3", "7.4", "8.0", "8.1", "8.2", "8.3", "8.4"}
+
+type CIMatrixEntry struct {
+	ShopwareVersion string `json:"shopware-version"`
+	PhpVersion      string `json:"php-version,omitempty"`
+}
+
+type CIMatrix struct {
+	Include []CIMatrixEntry `json:"include"`
+}
+
+type CIMatrixOptions struct {
+	// AllPatches includes every patch release instead of only the latest patch of each minor version
+	AllPatches bool
+	// IncludePrereleases includes release candidates and other pre-releases
+	IncludePrereleases bool
+}
+
+// BuildCIMatrix returns all Shopware versions matching the constraint of the extension together with each PHP version
+// supported by Shopware and the composer.json of the extension. When the PHP requirements of Shopware cannot be
+// fetched, only the minimum PHP version of each Shopware version is used.
+func BuildCIMatrix(ctx context.Context, ext Extension, opts CIMatrixOptions) (*CIMatrix, error) {
+	constraint, err := ext.GetShopwareVersionConstraint()
+	if err != nil {
+		return nil, fmt.Errorf("get shopware version constraint: %w", err)
+	}
+
+	versions, err := staticdata.ShopwareVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phpVersions, err := staticdata.PHPVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch php versions: %w", err)
+	}
+
+	shopwarePHPConstraints, err := staticdata.ShopwarePHPConstraints(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Using only the minimum PHP version of each Shopware version: %v", err)
+	}
+
+	var extensionPHPConstraint *version.Constraints
+
+	if phpConstraint := getPHPConstraint(ext); phpConstraint != "" {
+		if parsed, err := version.NewConstraint(phpConstraint); err == nil {
+			extensionPHPConstraint = &parsed
+		}
+	}
+
+	return buildCIMatrix(constraint, versions, phpVersions, shopwarePHPConstraints, extensionPHPConstraint, opts), nil
+}
+
+func buildCIMatrix(constraint *version.Constraints, versions []string, phpVersions, shopwarePHPConstraints map[string]string, extensionPHPConstraint *version.Constraints, opts CIMatrixOptions) *CIMatrix {
+	matching := make([]*version.Version, 0)
+
+	for _, raw := range versions {
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+
+		if v.IsPrerelease() && !opts.IncludePrereleases {
+			continue
+		}
+
+		if !constraint.Check(v) {
+			continue
+		}
+
+		matching = append(matching, v)
+	}
+
+	sort.Sort(version.Collection(matching))
+
+	if !opts.AllPatches {
+		matching = latestPatchPerMinor(matching)
+	}
+
+	matrix := &CIMatrix{Include: make([]CIMatrixEntry, 0, len(matching))}
+
+	for _, v := range matching {
+		for _, phpVersion := range supportedPhpVersions(v, phpVersions, shopwarePHPConstraints, extensionPHPConstraint) {
+			matrix.Include = append(matrix.Include, CIMatrixEntry{
+				ShopwareVersion: v.Original(),
+				PhpVersion:      phpVersion,
+			})
+		}
+	}
+
+	return matrix
+}
+
+// supportedPhpVersions returns the PHP versions allowed by the requirements of Shopware and the extension. Without the
+// requirement of Shopware only its minimum PHP version is known.
+func supportedPhpVersions(shopwareVersion *version.Version, phpVersions, shopwarePHPConstraints map[string]string, extensionPHPConstraint *version.Constraints) []string {
+	shopwareConstraint, err := version.NewConstraint(shopwarePHPConstraints[shopwareVersion.Original()])
+	if err != nil {
+		return []string{lookupPhpVersion(shopwareVersion, phpVersions)}
+	}
+
+	supported := make([]string, 0)
+
+	for _, phpVersion := range ciMatrixPHPVersions {
+		// the latest patch release of the PHP version is checked, so constraints like ~7.4.3 match
+		v, err := version.NewVersion(phpVersion + ".99")
+		if err != nil {
+			continue
+		}
+
+		if !shopwareConstraint.Check(v) {
+			continue
+		}
+
+		if extensionPHPConstraint != nil && !extensionPHPConstraint.Check(v) {
+			continue
+		}
+
+		supported = append(supported, phpVersion)
+	}
+
+	return supported
+}
+
+// latestPatchPerMinor expects a sorted list and keeps only the highest release of each minor version (e.g. 6.5.5.x).
+func latestPatchPerMinor(versions []*version.Version) []*version.Version {
+	filtered := make([]*version.Version, 0)
+
+	for i, v := range versions {
+		if i+1 < len(versions) && sameMinorVersion(v, versions[i+1]) {
+			continue
+		}
+
+		filtered = append(filtered, v)
+	}
+
+	return filtered
+}
+
+func sameMinorVersion(a, b *version.Version) bool {
+	as, bs := a.Segments(), b.Segments()
+
+	for i := 0; i < 3; i++ {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupPhpVersion returns the PHP version of the highest known Shopware version lower or equal than the given one.
+func lookupPhpVersion(shopwareVersion *version.Version, phpVersions map[string]string) string {
+	var best *version.Version
+	phpVersion := ""
+
+	for raw, php := range phpVersions {
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+
+		if v.GreaterThan(shopwareVersion) {
+			continue
+		}
+
+		if best == nil || v.GreaterThan(best) {
+			best = v
+			phpVersion = php
+		}
+	}
+
+	return phpVersion
+}
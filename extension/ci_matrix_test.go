@@ -0,0 +1,61 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+func TestBuildCIMatrix(t *testing.T) {
+	constraint, _ := version.NewConstraint("~6.5.0")
+
+	versions := []string{"6.4.20.0", "6.5.0.0-rc1", "6.5.0.0", "6.5.1.0", "6.5.1.1", "6.5.2.0", "abc"}
+	phpVersions := map[string]string{"6.4.0.0": "7.4", "6.5.0.0": "8.1"}
+
+	matrix := buildCIMatrix(&constraint, versions, phpVersions, nil, nil, CIMatrixOptions{})
+
+	assert.Equal(t, []CIMatrixEntry{
+		{ShopwareVersion: "6.5.0.0", PhpVersion: "8.1"},
+		{ShopwareVersion: "6.5.1.1", PhpVersion: "8.1"},
+		{ShopwareVersion: "6.5.2.0", PhpVersion: "8.1"},
+	}, matrix.Include)
+}
+
+func TestBuildCIMatrixAllPatchesAndPrereleases(t *testing.T) {
+	constraint, _ := version.NewConstraint("~6.5.0")
+
+	versions := []string{"6.5.1.1", "6.5.0.0-rc1", "6.5.1.0"}
+
+	matrix := buildCIMatrix(&constraint, versions, map[string]string{}, nil, nil, CIMatrixOptions{AllPatches: true, IncludePrereleases: true})
+
+	assert.Equal(t, []CIMatrixEntry{
+		{ShopwareVersion: "6.5.0.0-rc1"},
+		{ShopwareVersion: "6.5.1.0"},
+		{ShopwareVersion: "6.5.1.1"},
+	}, matrix.Include)
+}
+
+func TestBuildCIMatrixSupportedPhpVersions(t *testing.T) {
+	constraint, _ := version.NewConstraint(">=6.4.20 <6.6")
+	extensionPHPConstraint, _ := version.NewConstraint(">=8.0")
+
+	versions := []string{"6.4.20.2", "6.5.0.0", "6.5.8.0"}
+	phpVersions := map[string]string{"6.4.0.0": "7.4", "6.5.0.0": "8.1"}
+	shopwarePHPConstraints := map[string]string{
+		"6.4.20.2": "~7.4.3 || ~8.0.0 || ~8.1.0 || ~8.2.0",
+		"6.5.0.0":  "~8.1.0 || ~8.2.0",
+	}
+
+	matrix := buildCIMatrix(&constraint, versions, phpVersions, shopwarePHPConstraints, &extensionPHPConstraint, CIMatrixOptions{AllPatches: true})
+
+	assert.Equal(t, []CIMatrixEntry{
+		{ShopwareVersion: "6.4.20.2", PhpVersion: "8.0"},
+		{ShopwareVersion: "6.4.20.2", PhpVersion: "8.1"},
+		{ShopwareVersion: "6.4.20.2", PhpVersion: "8.2"},
+		{ShopwareVersion: "6.5.0.0", PhpVersion: "8.1"},
+		{ShopwareVersion: "6.5.0.0", PhpVersion: "8.2"},
+		{ShopwareVersion: "6.5.8.0", PhpVersion: "8.1"},
+	}, matrix.Include)
+}
@@ -0,0 +1,210 @@
+package extension
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	// ComposerPublishArtifact uploads the zip file to the repository
+	ComposerPublishArtifact = "artifact"
+	// ComposerPublishVCS lets the repository build the package from a git tag (GitLab package registry)
+	ComposerPublishVCS = "vcs"
+)
+
+type ComposerPublishOptions struct {
+	// Repository is the upload url. For artifacts the placeholders {name}, {vendor}, {package} and {version} are replaced.
+	Repository string
+	Type       string
+	// Tag is published for vcs, defaults to the version of the package
+	Tag string
+	// Token is sent as bearer token. Credentials in the repository url are used for basic authentication.
+	Token string
+	// JobToken is the CI_JOB_TOKEN of GitLab, it's used when no token is given
+	JobToken string
+}
+
+type ComposerPackage struct {
+	Name    string
+	Version string
+}
+
+var (
+	// composerPackageNameRegex is the package name pattern of the composer schema
+	composerPackageNameRegex    = regexp.MustCompile(`^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]|-{1,2})?[a-z0-9]+)*$`)
+	composerPackageVersionRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+)
+
+// ReadComposerPackageFromZip reads the name and version of the composer.json in the zip.
+func ReadComposerPackageFromZip(zipFile string) (*ComposerPackage, error) {
+	composer, err := readComposerJSONFromZip(zipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return composerPackageFromJSON(zipFile, composer)
+}
+
+// composerPackageFromJSON validates the name and version, as they are used in urls and paths of the repository.
+func composerPackageFromJSON(zipFile string, composer map[string]interface{}) (*ComposerPackage, error) {
+	name, _ := composer["name"].(string)
+	packageVersion, _ := composer["version"].(string)
+
+	if name == "" || packageVersion == "" {
+		return nil, fmt.Errorf("the composer.json of %s needs a name and a version to be published", zipFile)
+	}
+
+	if !composerPackageNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("the composer.json of %s has the invalid package name %q, use vendor/package in lowercase", zipFile, name)
+	}
+
+	if !composerPackageVersionRegex.MatchString(packageVersion) || strings.Contains(packageVersion, "..") {
+		return nil, fmt.Errorf("the composer.json of %s has the invalid version %q", zipFile, packageVersion)
+	}
+
+	return &ComposerPackage{Name: name, Version: packageVersion}, nil
+}
+
+// readComposerJSONFromZip reads the composer.json in the root or the first folder of the zip, like composer does for artifacts.
+func readComposerJSONFromZip(zipFile string) (map[string]interface{}, error) {
+	reader, err := zip.OpenReader(zipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if file.Name != "composer.json" && (strings.Count(file.Name, "/") != 1 || !strings.HasSuffix(file.Name, "/composer.json")) {
+			continue
+		}
+
+		handle, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		var composer map[string]interface{}
+
+		err = json.NewDecoder(handle).Decode(&composer)
+		_ = handle.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file.Name, err)
+		}
+
+		return composer, nil
+	}
+
+	return nil, fmt.Errorf("%s contains no composer.json, only plugins can be published as composer package", zipFile)
+}
+
+// PublishComposerPackage pushes the built extension zip to a private composer registry.
+func PublishComposerPackage(ctx context.Context, zipFile string, opts ComposerPublishOptions) (*ComposerPackage, error) {
+	pkg, err := ReadComposerPackageFromZip(zipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var request *http.Request
+
+	switch opts.Type {
+	case ComposerPublishArtifact, "":
+		request, err = newComposerArtifactRequest(ctx, zipFile, pkg, opts)
+	case ComposerPublishVCS:
+		request, err = newComposerVCSRequest(ctx, pkg, opts)
+	default:
+		return nil, fmt.Errorf("unknown publish type %s, use artifact or vcs", opts.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case opts.Token != "":
+		request.Header.Set("Authorization", "Bearer "+opts.Token)
+	case opts.JobToken != "":
+		request.Header.Set("JOB-TOKEN", opts.JobToken)
+	}
+
+	logging.FromContext(ctx).Debugf("%s: %s", request.Method, request.URL.Redacted())
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+
+		return nil, fmt.Errorf("publishing %s %s failed with status %d: %s", pkg.Name, pkg.Version, resp.StatusCode, string(body))
+	}
+
+	return pkg, nil
+}
+
+func newComposerArtifactRequest(ctx context.Context, zipFile string, pkg *ComposerPackage, opts ComposerPublishOptions) (*http.Request, error) {
+	vendor, packageName, _ := strings.Cut(pkg.Name, "/")
+
+	uploadURL := strings.NewReplacer(
+		"{name}", pkg.Name,
+		"{vendor}", url.PathEscape(vendor),
+		"{package}", url.PathEscape(packageName),
+		"{version}", url.PathEscape(pkg.Version),
+	).Replace(opts.Repository)
+
+	file, err := os.Open(zipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	// the http client closes the body
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	request.ContentLength = stat.Size()
+	request.Header.Set("Content-Type", "application/zip")
+
+	return request, nil
+}
+
+func newComposerVCSRequest(ctx context.Context, pkg *ComposerPackage, opts ComposerPublishOptions) (*http.Request, error) {
+	tag := opts.Tag
+	if tag == "" {
+		tag = pkg.Version
+	}
+
+	form := url.Values{}
+	form.Set("tag", tag)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.Repository, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return request, nil
+}
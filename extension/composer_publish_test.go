@@ -0,0 +1,98 @@
+package extension
+
+import (
+	"archive/zip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createComposerTestZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	zipFile := filepath.Join(t.TempDir(), "FroshTools.zip")
+	handle, err := os.Create(zipFile)
+	assert.NoError(t, err)
+
+	writer := zip.NewWriter(handle)
+
+	for name, content := range files {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, _ = w.Write([]byte(content))
+	}
+
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, handle.Close())
+
+	return zipFile
+}
+
+func TestReadComposerPackageFromZip(t *testing.T) {
+	zipFile := createComposerTestZip(t, map[string]string{
+		"FroshTools/vendor/foo/bar/composer.json": `{"name": "foo/bar", "version": "0.1.0"}`,
+		"FroshTools/composer.json":                `{"name": "frosh/tools", "version": "1.2.0"}`,
+	})
+
+	pkg, err := ReadComposerPackageFromZip(zipFile)
+	assert.NoError(t, err)
+	assert.Equal(t, &ComposerPackage{Name: "frosh/tools", Version: "1.2.0"}, pkg)
+
+	_, err = ReadComposerPackageFromZip(createComposerTestZip(t, map[string]string{"MyApp/manifest.xml": "<manifest/>"}))
+	assert.ErrorContains(t, err, "contains no composer.json")
+
+	_, err = ReadComposerPackageFromZip(createComposerTestZip(t, map[string]string{"composer.json": `{"name": "frosh/tools"}`}))
+	assert.ErrorContains(t, err, "needs a name and a version")
+}
+
+func TestPublishComposerPackage(t *testing.T) {
+	zipFile := createComposerTestZip(t, map[string]string{"FroshTools/composer.json": `{"name": "frosh/tools", "version": "1.2.0"}`})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/packages/upload/frosh/tools/1.2.0":
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/zip", r.Header.Get("Content-Type"))
+
+			body, _ := io.ReadAll(r.Body)
+			expected, _ := os.ReadFile(zipFile)
+			assert.Equal(t, expected, body)
+		case "/api/v4/projects/1/packages/composer":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "job", r.Header.Get("JOB-TOKEN"))
+			assert.Equal(t, "v1.2.0", r.FormValue("tag"))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("denied"))
+		}
+	}))
+	defer server.Close()
+
+	pkg, err := PublishComposerPackage(getTestContext(), zipFile, ComposerPublishOptions{
+		Repository: server.URL + "/packages/upload/{vendor}/{package}/{version}",
+		Token:      "secret",
+		JobToken:   "job",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "frosh/tools", pkg.Name)
+
+	_, err = PublishComposerPackage(getTestContext(), zipFile, ComposerPublishOptions{
+		Repository: server.URL + "/api/v4/projects/1/packages/composer",
+		Type:       ComposerPublishVCS,
+		Tag:        "v1.2.0",
+		JobToken:   "job",
+	})
+	assert.NoError(t, err)
+
+	_, err = PublishComposerPackage(getTestContext(), zipFile, ComposerPublishOptions{Repository: server.URL + "/other"})
+	assert.ErrorContains(t, err, "publishing frosh/tools 1.2.0 failed with status 403: denied")
+
+	_, err = PublishComposerPackage(getTestContext(), zipFile, ComposerPublishOptions{Repository: server.URL, Type: "svn"})
+	assert.ErrorContains(t, err, "unknown publish type")
+}
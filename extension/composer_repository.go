@@ -0,0 +1,118 @@
+package extension
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type composerRepository struct {
+	Packages map[string]map[string]map[string]interface{} `json:"packages"`
+}
+
+// BuildComposerRepository generates a static composer repository with a packages.json and the dist archives in the output directory.
+// Packages of an existing packages.json are kept, so new versions can be added to the repository.
+func BuildComposerRepository(outputDir, baseURL string, zipFiles []string) ([]ComposerPackage, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("the url of the repository is required for the dist urls")
+	}
+
+	repository := composerRepository{Packages: map[string]map[string]map[string]interface{}{}}
+	packagesFile := filepath.Join(outputDir, "packages.json")
+
+	if content, err := os.ReadFile(packagesFile); err == nil {
+		if err := json.Unmarshal(content, &repository); err != nil {
+			return nil, fmt.Errorf("cannot parse existing %s: %w", packagesFile, err)
+		}
+
+		if repository.Packages == nil {
+			repository.Packages = map[string]map[string]map[string]interface{}{}
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	added := make([]ComposerPackage, 0, len(zipFiles))
+
+	for _, zipFile := range zipFiles {
+		composer, err := readComposerJSONFromZip(zipFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, err := composerPackageFromJSON(zipFile, composer)
+		if err != nil {
+			return nil, err
+		}
+
+		_, packageName, _ := strings.Cut(pkg.Name, "/")
+		distPath := path.Join("dist", pkg.Name, fmt.Sprintf("%s-%s.zip", packageName, pkg.Version))
+
+		shasum, err := copyComposerDist(zipFile, filepath.Join(outputDir, filepath.FromSlash(distPath)))
+		if err != nil {
+			return nil, err
+		}
+
+		composer["dist"] = map[string]string{
+			"type":   "zip",
+			"url":    strings.TrimSuffix(baseURL, "/") + "/" + distPath,
+			"shasum": shasum,
+		}
+
+		if _, ok := repository.Packages[pkg.Name]; !ok {
+			repository.Packages[pkg.Name] = map[string]map[string]interface{}{}
+		}
+
+		repository.Packages[pkg.Name][pkg.Version] = composer
+		added = append(added, *pkg)
+	}
+
+	content, err := json.MarshalIndent(repository, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(packagesFile, append(content, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// copyComposerDist copies the zip into the repository and returns its sha1 checksum, which composer verifies after the download.
+func copyComposerDist(source, target string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha1.New() //nolint:gosec
+
+	if _, err := io.Copy(io.MultiWriter(out, hash), in); err != nil {
+		_ = out.Close()
+		return "", err
+	}
+
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,65 @@
+package extension
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildComposerRepository(t *testing.T) {
+	output := t.TempDir()
+
+	first := createComposerTestZip(t, map[string]string{"FroshTools/composer.json": `{"name": "frosh/tools", "version": "1.0.0", "type": "shopware-platform-plugin"}`})
+	second := createComposerTestZip(t, map[string]string{"FroshTools/composer.json": `{"name": "frosh/tools", "version": "1.1.0", "type": "shopware-platform-plugin"}`})
+
+	added, err := BuildComposerRepository(output, "https://packages.example.com/", []string{first})
+	assert.NoError(t, err)
+	assert.Equal(t, []ComposerPackage{{Name: "frosh/tools", Version: "1.0.0"}}, added)
+
+	// a second run adds the new version to the existing repository
+	_, err = BuildComposerRepository(output, "https://packages.example.com", []string{second})
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(output, "packages.json"))
+	assert.NoError(t, err)
+
+	var repository composerRepository
+	assert.NoError(t, json.Unmarshal(content, &repository))
+	assert.Len(t, repository.Packages["frosh/tools"], 2)
+
+	release := repository.Packages["frosh/tools"]["1.1.0"]
+	assert.Equal(t, "shopware-platform-plugin", release["type"])
+
+	dist := release["dist"].(map[string]interface{})
+	assert.Equal(t, "zip", dist["type"])
+	assert.Equal(t, "https://packages.example.com/dist/frosh/tools/tools-1.1.0.zip", dist["url"])
+	assert.Len(t, dist["shasum"], 40)
+	assert.FileExists(t, filepath.Join(output, "dist", "frosh", "tools", "tools-1.0.0.zip"))
+	assert.FileExists(t, filepath.Join(output, "dist", "frosh", "tools", "tools-1.1.0.zip"))
+
+	_, err = BuildComposerRepository(output, "", []string{first})
+	assert.Error(t, err)
+}
+
+func TestBuildComposerRepositoryRejectsUnsafeNames(t *testing.T) {
+	output := t.TempDir()
+
+	for _, composerJSON := range []string{
+		`{"name": "../../etc", "version": "1.0.0"}`,
+		`{"name": "frosh/../../tools", "version": "1.0.0"}`,
+		`{"name": "frosh/tools", "version": "../1.0.0"}`,
+		`{"name": "frosh/tools", "version": "1.0.0/x"}`,
+	} {
+		zipFile := createComposerTestZip(t, map[string]string{"FroshTools/composer.json": composerJSON})
+
+		_, err := BuildComposerRepository(output, "https://packages.example.com", []string{zipFile})
+		assert.ErrorContains(t, err, "has the invalid", composerJSON)
+	}
+
+	entries, err := os.ReadDir(output)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
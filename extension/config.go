@@ -5,8 +5,7 @@ import (
 	"os"
 
 	"github.com/FriendsOfShopware/shopware-cli/internal/changelog"
-
-	"gopkg.in/yaml.v3"
+	"github.com/FriendsOfShopware/shopware-cli/internal/envconfig"
 )
 
 type ConfigBuild struct {
@@ -20,21 +19,50 @@ type ConfigBuild struct {
 			ExcludedPackages []string `yaml:"excluded_packages"`
 		} `yaml:"composer"`
 		Assets struct {
-			Enabled                    bool     `yaml:"enabled"`
-			BeforeHooks                []string `yaml:"before_hooks"`
-			AfterHooks                 []string `yaml:"after_hooks"`
-			EnableESBuildForAdmin      bool     `yaml:"enable_es_build_for_admin"`
-			EnableESBuildForStorefront bool     `yaml:"enable_es_build_for_storefront"`
+			Enabled                     bool              `yaml:"enabled"`
+			BeforeHooks                 []string          `yaml:"before_hooks"`
+			AfterHooks                  []string          `yaml:"after_hooks"`
+			EnableESBuildForAdmin       bool              `yaml:"enable_es_build_for_admin"`
+			EnableESBuildForStorefront  bool              `yaml:"enable_es_build_for_storefront"`
+			AdministrationWebpackConfig string            `yaml:"administration_webpack_config"`
+			StorefrontWebpackConfig     string            `yaml:"storefront_webpack_config"`
+			Budget                      ConfigAssetBudget `yaml:"budget"`
 		} `yaml:"assets"`
-		Pack struct {
-			Excludes struct {
-				Paths []string `yaml:"paths"`
-			} `yaml:"excludes"`
-			BeforeHooks []string `yaml:"before_hooks"`
-		} `yaml:"pack"`
+		Pack ConfigZipPack `yaml:"pack"`
 	} `yaml:"zip"`
 }
 
+type ConfigZipPack struct {
+	Excludes struct {
+		Paths []string `yaml:"paths"`
+	} `yaml:"excludes"`
+	BeforeHooks []string             `yaml:"before_hooks"`
+	Compression ConfigZipCompression `yaml:"compression"`
+	// MaxFileSize in megabytes stops the packaging when a single file is bigger, 0 (default) disables the check
+	MaxFileSize int64 `yaml:"max_file_size"`
+	// BuildInfo writes the git commit, build date and CLI version as json file or php class into the zip, empty disables it
+	BuildInfo string `yaml:"build_info"`
+	// Reproducible normalizes the timestamps and permissions of the files, so the same sources produce a byte-identical zip
+	Reproducible bool `yaml:"reproducible"`
+}
+
+type ConfigZipCompression struct {
+	// Level is the deflate level from 0 (store everything) to 9 (smallest), unset uses the default level
+	Level *int `yaml:"level"`
+	// Store are patterns of files which are added without compression, e.g. already compressed images and fonts
+	Store []string `yaml:"store"`
+	// Deflate are patterns of files which are always compressed, they win over store
+	Deflate []string `yaml:"deflate"`
+}
+
+// ConfigAssetBudget limits the size of each compiled JavaScript and CSS file in kilobytes.
+type ConfigAssetBudget struct {
+	Administration int64 `yaml:"administration"`
+	Storefront     int64 `yaml:"storefront"`
+	// Fail lets the build fail instead of printing a warning when a budget is exceeded
+	Fail bool `yaml:"fail"`
+}
+
 type ConfigExtraBundle struct {
 	Path string `yaml:"path"`
 	Name string `yaml:"name"`
@@ -56,6 +84,7 @@ type ConfigStore struct {
 	Features                            ConfigTranslated[[]string]         `yaml:"features"`
 	Faq                                 ConfigTranslated[[]ConfigStoreFaq] `yaml:"faq"`
 	Images                              *[]ConfigStoreImage                `yaml:"images"`
+	ChangelogSections                   map[string]string                  `yaml:"changelog_sections"`
 }
 
 type Translatable interface {
@@ -89,10 +118,107 @@ type ConfigStoreImagePreview struct {
 	English bool `yaml:"en"`
 }
 
+type ConfigValidation struct {
+	Budget    ConfigValidationBudget    `yaml:"budget"`
+	Composer  ConfigValidationComposer  `yaml:"composer"`
+	LockFiles ConfigValidationLockFiles `yaml:"lock_files"`
+	PHPSyntax ConfigValidationPHPSyntax `yaml:"php_syntax"`
+	ESLint    ConfigValidationESLint    `yaml:"eslint"`
+	Changelog ConfigValidationChangelog `yaml:"changelog"`
+}
+
+type ConfigValidationChangelog struct {
+	// MaxLength is the maximum amount of characters of a version entry in one language, 0 disables the check
+	MaxLength int `yaml:"max_length"`
+	// DisallowedDomains are additional domains which should not be linked, subdomains are included
+	DisallowedDomains []string `yaml:"disallowed_domains"`
+	// AllowedDomains removes domains from the default deny-list
+	AllowedDomains []string `yaml:"allowed_domains"`
+}
+
+type ConfigValidationESLint struct {
+	// Enabled lints the administration sources with the ESLint config of the Shopware administration
+	Enabled bool `yaml:"enabled"`
+	// NpmScripts controls the lifecycle scripts of the npm packages of the extension: run (default), skip or restricted-env
+	NpmScripts string `yaml:"npm_scripts"`
+}
+
+type ConfigValidationPHPSyntax struct {
+	// Mode is local (php -l, default), remote (uploads the PHP files to php-syntax-checker.fos.gg) or skip
+	Mode string `yaml:"mode"`
+	// PHPBinary is used by the local mode, defaults to php
+	PHPBinary string `yaml:"php_binary"`
+	// RemoteFallback uses the remote checker, when the local mode finds no PHP binary
+	RemoteFallback bool `yaml:"remote_fallback"`
+}
+
+type ConfigValidationLockFiles struct {
+	// Required fails the validation when composer.json or package.json have no lock file
+	Required bool `yaml:"required"`
+}
+
+type ConfigValidationComposer struct {
+	// DisallowedPackages are additional packages which should not be required, wildcards like symfony/polyfill-* are supported
+	DisallowedPackages []string `yaml:"disallowed_packages"`
+	// AllowedPackages removes packages from the default deny-list
+	AllowedPackages []string `yaml:"allowed_packages"`
+}
+
+type ConfigValidationBudget struct {
+	// MaxZipSize is the maximum size of the zip archive in megabytes
+	MaxZipSize int64 `yaml:"max_zip_size"`
+	// MaxFileSize is the size in megabytes from which a single file is reported
+	MaxFileSize int64 `yaml:"max_file_size"`
+	// MaxFiles is the maximum amount of files in the archive
+	MaxFiles int `yaml:"max_files"`
+	// Fail reports an exceeded max_zip_size or max_files as error instead of a warning
+	Fail bool `yaml:"fail"`
+}
+
+type ConfigTranslations struct {
+	// Provider is crowdin, weblate or phrase
+	Provider string `yaml:"provider"`
+	// Project is the project id (Crowdin, Phrase) or slug (Weblate)
+	Project string `yaml:"project"`
+	// URL of the API, required for Weblate
+	URL string `yaml:"url"`
+	// Token should be passed with SHOPWARE_CLI_EXTENSION__TRANSLATIONS__TOKEN instead of the file
+	Token string `yaml:"token"`
+	// SourceLocale is the locale of the snippets which are uploaded
+	SourceLocale string `yaml:"source_locale"`
+	// Locales are downloaded by pull, defaults to the locales of the existing snippet files
+	Locales []string `yaml:"locales"`
+	// LanguageMapping maps Shopware locales to the language codes of the provider, e.g. de-DE: de
+	LanguageMapping map[string]string `yaml:"language_mapping"`
+}
+
+type ConfigLicenseCheck struct {
+	// Enabled marks the plugin as paid, the validation fails when the license validator is missing, not registered or unused
+	Enabled bool `yaml:"enabled"`
+	// Class of the license validator, defaults to License\LicenseValidator in the namespace of the plugin class
+	Class string `yaml:"class"`
+}
+
+type ConfigLicenseHeader struct {
+	// Header is the text of the header without comment markers
+	Header string `yaml:"header"`
+	// Inject adds the header to files missing it during extension zip, otherwise the zip fails
+	Inject bool `yaml:"inject"`
+	// Excludes are paths relative to the extension root to skip
+	Excludes []string `yaml:"excludes"`
+}
+
 type Config struct {
-	Store     ConfigStore      `yaml:"store"`
-	Build     ConfigBuild      `yaml:"build"`
-	Changelog changelog.Config `yaml:"changelog"`
+	Store      ConfigStore      `yaml:"store"`
+	Build      ConfigBuild      `yaml:"build"`
+	Changelog  changelog.Config `yaml:"changelog"`
+	Validation ConfigValidation `yaml:"validation"`
+	// LicenseHeader is validated and optionally injected into the PHP and JS files
+	LicenseHeader ConfigLicenseHeader `yaml:"license_header"`
+	// Translations configures the translation platform used by extension translations push/pull
+	Translations ConfigTranslations `yaml:"translations"`
+	// LicenseCheck validates that paid plugins check their store license
+	LicenseCheck ConfigLicenseCheck `yaml:"license_check"`
 }
 
 func readExtensionConfig(dir string) (*Config, error) {
@@ -100,24 +226,25 @@ func readExtensionConfig(dir string) (*Config, error) {
 	config := &Config{}
 	config.Build.Zip.Assets.Enabled = true
 	config.Build.Zip.Composer.Enabled = true
+	config.Validation.Budget.MaxZipSize = 20
+	config.Validation.Budget.MaxFileSize = 5
+	config.Validation.Budget.MaxFiles = 10000
+	config.Translations.SourceLocale = "en-GB"
 
 	fileName := fmt.Sprintf("%s/.shopware-extension.yml", dir)
 	_, err := os.Stat(fileName)
 
-	if os.IsNotExist(err) {
-		return config, nil
-	}
+	var fileHandle []byte
 
-	if err != nil {
+	if err == nil {
+		if fileHandle, err = os.ReadFile(fileName); err != nil {
+			return nil, fmt.Errorf(errorFormat, err)
+		}
+	} else if !os.IsNotExist(err) {
 		return nil, err
 	}
 
-	fileHandle, err := os.ReadFile(fileName)
-	if err != nil {
-		return nil, fmt.Errorf(errorFormat, err)
-	}
-
-	err = yaml.Unmarshal(fileHandle, &config)
+	err = envconfig.Decode(fileHandle, envconfig.ExtensionPrefix, os.Environ(), config)
 
 	if err != nil {
 		return nil, fmt.Errorf(errorFormat, err)
@@ -148,5 +275,9 @@ func validateExtensionConfig(config *Config) error {
 		return fmt.Errorf("store.info.videos.de can contain maximal 2 items")
 	}
 
+	if level := config.Build.Zip.Pack.Compression.Level; level != nil && (*level < 0 || *level > 9) {
+		return fmt.Errorf("build.zip.pack.compression.level must be between 0 and 9")
+	}
+
 	return nil
 }
@@ -0,0 +1,270 @@
+package extension
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CustomEntitiesFile declares the custom entities of an extension in its Resources folder, Shopware creates their tables on install and update.
+const CustomEntitiesFile = "entities.xml"
+
+var customEntityNameRegex = regexp.MustCompile(`^(custom_entity|ce)_[a-z0-9_]+$`)
+var customEntityFieldNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var customEntityFieldTypes = map[string]bool{
+	"int": true, "float": true, "string": true, "text": true, "bool": true, "date": true, "json": true, "email": true, "price": true,
+	"many-to-one": true, "one-to-one": true, "one-to-many": true, "many-to-many": true,
+}
+
+var customEntityOnDelete = map[string]bool{"cascade": true, "set-null": true, "restrict": true}
+
+// customEntityReservedFields are added to every custom entity by Shopware.
+var customEntityReservedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+type CustomEntities struct {
+	XMLName  xml.Name       `xml:"entities"`
+	Entities []CustomEntity `xml:"entity"`
+}
+
+type CustomEntity struct {
+	Name   string              `xml:"name,attr"`
+	Fields []CustomEntityField `xml:"-"`
+}
+
+type CustomEntityField struct {
+	XMLName      xml.Name
+	Name         string `xml:"name,attr"`
+	Required     bool   `xml:"required,attr"`
+	Translatable bool   `xml:"translatable,attr"`
+	Reference    string `xml:"reference,attr"`
+	OnDelete     string `xml:"on-delete,attr"`
+}
+
+// Type returns the type of the field like string or many-to-one.
+func (f CustomEntityField) Type() string {
+	return f.XMLName.Local
+}
+
+// UnmarshalXML collects all child elements of fields, as the element name is the type of the field.
+func (e *CustomEntity) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var entity struct {
+		Name   string `xml:"name,attr"`
+		Fields struct {
+			Items []CustomEntityField `xml:",any"`
+		} `xml:"fields"`
+	}
+
+	if err := d.DecodeElement(&entity, &start); err != nil {
+		return err
+	}
+
+	e.Name = entity.Name
+	e.Fields = entity.Fields.Items
+
+	return nil
+}
+
+// ReadCustomEntities reads the entities.xml of the extension, nil is returned when the extension has none.
+func ReadCustomEntities(ext Extension) (*CustomEntities, error) {
+	content, err := os.ReadFile(filepath.Join(ext.GetResourcesDir(), CustomEntitiesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entities CustomEntities
+	if err := xml.Unmarshal(content, &entities); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", CustomEntitiesFile, err)
+	}
+
+	return &entities, nil
+}
+
+func validateCustomEntities(ctx *ValidationContext) {
+	relPath, _ := filepath.Rel(ctx.Extension.GetPath(), filepath.Join(ctx.Extension.GetResourcesDir(), CustomEntitiesFile))
+	relPath = filepath.ToSlash(relPath)
+
+	entities, err := ReadCustomEntities(ctx.Extension)
+	if err != nil {
+		ctx.AddFileError(relPath, 0, fmt.Sprintf("%s: %s", relPath, err.Error()))
+		return
+	}
+
+	if entities == nil {
+		return
+	}
+
+	for _, message := range entities.Validate() {
+		ctx.AddFileError(relPath, 0, fmt.Sprintf("%s: %s", relPath, message))
+	}
+}
+
+// Validate returns the problems Shopware would reject the entities with on install.
+func (c CustomEntities) Validate() []string {
+	problems := make([]string, 0)
+	seenEntities := make(map[string]bool)
+
+	for _, entity := range c.Entities {
+		if !customEntityNameRegex.MatchString(entity.Name) {
+			problems = append(problems, fmt.Sprintf("entity %q must be snake case and start with custom_entity_ or ce_", entity.Name))
+		}
+
+		if seenEntities[entity.Name] {
+			problems = append(problems, fmt.Sprintf("entity %s is declared twice", entity.Name))
+		}
+
+		seenEntities[entity.Name] = true
+
+		if len(entity.Fields) == 0 {
+			problems = append(problems, fmt.Sprintf("entity %s has no fields", entity.Name))
+		}
+
+		seenFields := make(map[string]bool)
+
+		for _, field := range entity.Fields {
+			prefix := fmt.Sprintf("field %s.%s", entity.Name, field.Name)
+
+			if !customEntityFieldTypes[field.Type()] {
+				problems = append(problems, fmt.Sprintf("%s has the unknown type %s", prefix, field.Type()))
+				continue
+			}
+
+			if !customEntityFieldNameRegex.MatchString(field.Name) {
+				problems = append(problems, fmt.Sprintf("%s must be snake case", prefix))
+			}
+
+			if customEntityReservedFields[field.Name] {
+				problems = append(problems, fmt.Sprintf("%s is reserved, Shopware adds it to every entity", prefix))
+			}
+
+			if seenFields[field.Name] {
+				problems = append(problems, fmt.Sprintf("%s is declared twice", prefix))
+			}
+
+			seenFields[field.Name] = true
+
+			if field.isAssociation() {
+				if field.Reference == "" {
+					problems = append(problems, fmt.Sprintf("%s requires a reference", prefix))
+				}
+
+				if field.OnDelete != "" && !customEntityOnDelete[field.OnDelete] {
+					problems = append(problems, fmt.Sprintf("%s has the unknown on-delete %s, use cascade, set-null or restrict", prefix, field.OnDelete))
+				}
+
+				if field.Translatable {
+					problems = append(problems, fmt.Sprintf("%s is an association and cannot be translatable", prefix))
+				}
+			} else if field.Reference != "" || field.OnDelete != "" {
+				problems = append(problems, fmt.Sprintf("%s is no association, reference and on-delete are not allowed", prefix))
+			}
+		}
+	}
+
+	return problems
+}
+
+func (f CustomEntityField) isAssociation() bool {
+	return strings.Contains(f.Type(), "-to-")
+}
+
+// CustomEntityTable is a database table with the columns Shopware creates for a custom entity.
+type CustomEntityTable struct {
+	Name    string
+	Entity  string
+	Columns []string
+}
+
+// Tables returns the tables and columns of the entities. The foreign keys of one-to-many and many-to-many associations
+// live in other tables and are not included.
+func (c CustomEntities) Tables() []CustomEntityTable {
+	tables := make([]CustomEntityTable, 0, len(c.Entities))
+
+	for _, entity := range c.Entities {
+		table := CustomEntityTable{Name: entity.Name, Entity: entity.Name, Columns: []string{"id", "created_at", "updated_at"}}
+		translation := CustomEntityTable{Name: entity.Name + "_translation", Entity: entity.Name, Columns: []string{entity.Name + "_id", "language_id", "created_at", "updated_at"}}
+
+		for _, field := range entity.Fields {
+			switch {
+			case field.Type() == "many-to-one" || field.Type() == "one-to-one":
+				table.Columns = append(table.Columns, field.Name+"_id")
+			case field.isAssociation():
+				continue
+			case field.Translatable:
+				translation.Columns = append(translation.Columns, field.Name)
+			default:
+				table.Columns = append(table.Columns, field.Name)
+			}
+		}
+
+		tables = append(tables, table)
+
+		if len(translation.Columns) > 4 {
+			tables = append(tables, translation)
+		}
+	}
+
+	return tables
+}
+
+// CustomEntitySchemaDiff describes a table which does not match its declaration.
+type CustomEntitySchemaDiff struct {
+	Table          string   `json:"table"`
+	Entity         string   `json:"entity"`
+	Missing        bool     `json:"missing"`
+	MissingColumns []string `json:"missingColumns,omitempty"`
+	// Undeclared are columns of the table which are not declared anymore
+	Undeclared []string `json:"undeclaredColumns,omitempty"`
+}
+
+// DiffCustomEntitySchema compares the declared tables with the columns of the database tables by table name.
+func DiffCustomEntitySchema(tables []CustomEntityTable, actual map[string][]string) []CustomEntitySchemaDiff {
+	diffs := make([]CustomEntitySchemaDiff, 0)
+
+	for _, table := range tables {
+		columns, ok := actual[table.Name]
+		if !ok {
+			diffs = append(diffs, CustomEntitySchemaDiff{Table: table.Name, Entity: table.Entity, Missing: true})
+			continue
+		}
+
+		diff := CustomEntitySchemaDiff{Table: table.Name, Entity: table.Entity}
+		existing := make(map[string]bool, len(columns))
+		declared := make(map[string]bool, len(table.Columns))
+
+		for _, column := range columns {
+			existing[column] = true
+		}
+
+		for _, column := range table.Columns {
+			declared[column] = true
+
+			if !existing[column] {
+				diff.MissingColumns = append(diff.MissingColumns, column)
+			}
+		}
+
+		for _, column := range columns {
+			// version columns are added for associations to versioned entities like product
+			if !declared[column] && !strings.HasSuffix(column, "_version_id") {
+				diff.Undeclared = append(diff.Undeclared, column)
+			}
+		}
+
+		sort.Strings(diff.Undeclared)
+
+		if len(diff.MissingColumns) > 0 || len(diff.Undeclared) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,108 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCustomEntitiesXML = `<?xml version="1.0" encoding="utf-8" ?>
+<entities xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:noNamespaceSchemaLocation="https://raw.githubusercontent.com/shopware/platform/trunk/src/Core/System/CustomEntity/Xml/entity-1.0.xsd">
+    <entity name="custom_entity_blog">
+        <fields>
+            <string name="title" required="true" translatable="true"/>
+            <text name="content" translatable="true"/>
+            <int name="position"/>
+            <many-to-one name="author" reference="user" on-delete="set-null"/>
+            <one-to-many name="comments" reference="custom_entity_blog_comment" on-delete="cascade"/>
+        </fields>
+    </entity>
+</entities>`
+
+func writeTestCustomEntities(t *testing.T, content string) PlatformPlugin {
+	t.Helper()
+
+	plugin := getTestPlugin(t.TempDir())
+
+	assert.NoError(t, os.MkdirAll(plugin.GetResourcesDir(), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(plugin.GetResourcesDir(), CustomEntitiesFile), []byte(content), os.ModePerm))
+
+	return plugin
+}
+
+func TestReadCustomEntities(t *testing.T) {
+	plugin := writeTestCustomEntities(t, testCustomEntitiesXML)
+
+	entities, err := ReadCustomEntities(plugin)
+	assert.NoError(t, err)
+	assert.Len(t, entities.Entities, 1)
+	assert.Len(t, entities.Entities[0].Fields, 5)
+	assert.Equal(t, "many-to-one", entities.Entities[0].Fields[3].Type())
+	assert.Empty(t, entities.Validate())
+
+	assert.Equal(t, []CustomEntityTable{
+		{Name: "custom_entity_blog", Entity: "custom_entity_blog", Columns: []string{"id", "created_at", "updated_at", "position", "author_id"}},
+		{Name: "custom_entity_blog_translation", Entity: "custom_entity_blog", Columns: []string{"custom_entity_blog_id", "language_id", "created_at", "updated_at", "title", "content"}},
+	}, entities.Tables())
+}
+
+func TestReadCustomEntitiesWithoutFile(t *testing.T) {
+	entities, err := ReadCustomEntities(getTestPlugin(t.TempDir()))
+	assert.NoError(t, err)
+	assert.Nil(t, entities)
+}
+
+func TestValidateCustomEntities(t *testing.T) {
+	plugin := writeTestCustomEntities(t, `<entities>
+    <entity name="blog">
+        <fields>
+            <string name="id"/>
+            <string name="title" reference="product"/>
+            <many-to-one name="product" on-delete="nothing"/>
+            <uuid name="external"/>
+        </fields>
+    </entity>
+</entities>`)
+
+	ctx := NewValidationContext(plugin)
+	validateCustomEntities(ctx)
+
+	assert.Equal(t, []string{
+		`src/Resources/entities.xml: entity "blog" must be snake case and start with custom_entity_ or ce_`,
+		"src/Resources/entities.xml: field blog.id is reserved, Shopware adds it to every entity",
+		"src/Resources/entities.xml: field blog.title is no association, reference and on-delete are not allowed",
+		"src/Resources/entities.xml: field blog.product requires a reference",
+		"src/Resources/entities.xml: field blog.product has the unknown on-delete nothing, use cascade, set-null or restrict",
+		"src/Resources/entities.xml: field blog.external has the unknown type uuid",
+	}, ctx.Errors())
+	assert.Equal(t, "src/Resources/entities.xml", ctx.Issues()[0].File)
+}
+
+func TestValidateCustomEntitiesInvalidXML(t *testing.T) {
+	ctx := NewValidationContext(writeTestCustomEntities(t, `<entities><entity>`))
+	validateCustomEntities(ctx)
+
+	assert.Len(t, ctx.Errors(), 1)
+	assert.Contains(t, ctx.Errors()[0], "cannot parse entities.xml")
+}
+
+func TestDiffCustomEntitySchema(t *testing.T) {
+	tables := []CustomEntityTable{
+		{Name: "custom_entity_blog", Entity: "custom_entity_blog", Columns: []string{"id", "title", "author_id"}},
+		{Name: "custom_entity_blog_translation", Entity: "custom_entity_blog", Columns: []string{"custom_entity_blog_id", "content"}},
+		{Name: "custom_entity_tag", Entity: "custom_entity_tag", Columns: []string{"id"}},
+	}
+
+	diffs := DiffCustomEntitySchema(tables, map[string][]string{
+		"custom_entity_blog":             {"id", "title", "author_id", "author_version_id", "legacy"},
+		"custom_entity_blog_translation": {"custom_entity_blog_id"},
+	})
+
+	assert.Equal(t, []CustomEntitySchemaDiff{
+		{Table: "custom_entity_blog", Entity: "custom_entity_blog", Undeclared: []string{"legacy"}},
+		{Table: "custom_entity_blog_translation", Entity: "custom_entity_blog", MissingColumns: []string{"content"}},
+		{Table: "custom_entity_tag", Entity: "custom_entity_tag", Missing: true},
+	}, diffs)
+}
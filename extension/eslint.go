@@ -0,0 +1,183 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	eslintSeverityWarning = 1
+	eslintSeverityError   = 2
+)
+
+var eslintConfigFiles = []string{".eslintrc.js", ".eslintrc.cjs", ".eslintrc.json"}
+
+type eslintFileResult struct {
+	FilePath string          `json:"filePath"`
+	Messages []eslintMessage `json:"messages"`
+}
+
+type eslintMessage struct {
+	RuleID   string `json:"ruleId"`
+	Severity int    `json:"severity"`
+	Message  string `json:"message"`
+	Line     int    `json:"line"`
+}
+
+// validateESLint lints the administration sources of the extension with the ESLint config of the Shopware administration.
+// The check is optional, as it requires node and the Shopware sources with installed dependencies like the asset build.
+func validateESLint(c context.Context, ctx *ValidationContext) {
+	cfg := ctx.Extension.GetExtensionConfig()
+	if cfg == nil || !cfg.Validation.ESLint.Enabled {
+		return
+	}
+
+	extensionAdminRoot := filepath.Join(ctx.Extension.GetResourcesDir(), "app", "administration")
+
+	if _, err := os.Stat(filepath.Join(extensionAdminRoot, "src")); os.IsNotExist(err) {
+		return
+	}
+
+	if _, err := exec.LookPath("npx"); err != nil {
+		ctx.AddWarning("ESLint check skipped, node is not installed")
+		return
+	}
+
+	shopwareRoot := os.Getenv("SHOPWARE_PROJECT_ROOT")
+
+	if shopwareRoot == "" {
+		constraint, err := ctx.Extension.GetShopwareVersionConstraint()
+		if err != nil {
+			return
+		}
+
+		minVersion, err := lookupForMinMatchingVersion(c, constraint)
+		if err != nil {
+			ctx.AddWarning(fmt.Sprintf("ESLint check skipped: %s", err.Error()))
+			return
+		}
+
+		shopwareRoot, err = setupShopwareInTemp(c, "v"+minVersion)
+		if err != nil {
+			ctx.AddWarning(fmt.Sprintf("ESLint check skipped: %s", err.Error()))
+			return
+		}
+
+		defer deletePath(c, shopwareRoot)
+	}
+
+	administrationRoot := PlatformPath(shopwareRoot, "Administration", "Resources/app/administration")
+
+	output, err := runESLint(c, shopwareRoot, administrationRoot, extensionAdminRoot, cfg.Validation.ESLint.NpmScripts)
+	if err != nil {
+		ctx.AddWarning(fmt.Sprintf("ESLint check failed: %s", err.Error()))
+		return
+	}
+
+	if err := addESLintResults(ctx, output); err != nil {
+		ctx.AddWarning(fmt.Sprintf("ESLint check failed: %s", err.Error()))
+	}
+}
+
+// runESLint returns the JSON report of ESLint for the src folder of the extension administration. The npm scripts mode
+// applies to the dependencies of the extension.
+func runESLint(ctx context.Context, shopwareRoot, administrationRoot, extensionAdminRoot, npmScripts string) ([]byte, error) {
+	configFile := ""
+
+	for _, file := range eslintConfigFiles {
+		if _, err := os.Stat(filepath.Join(administrationRoot, file)); err == nil {
+			configFile = filepath.Join(administrationRoot, file)
+			break
+		}
+	}
+
+	if configFile == "" {
+		return nil, fmt.Errorf("cannot find an ESLint config in %s", administrationRoot)
+	}
+
+	if _, err := os.Stat(filepath.Join(administrationRoot, "node_modules")); os.IsNotExist(err) {
+		logging.FromContext(ctx).Infof("Installing administration dependencies")
+
+		if err := installDependencies(ctx, administrationRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(extensionAdminRoot, "package.json")); err == nil {
+		if err := installExtensionDependencies(ctx, extensionAdminRoot, npmScripts, os.Stdout, os.Stderr); err != nil {
+			return nil, err
+		}
+	}
+
+	logging.FromContext(ctx).Infof("Running ESLint with %s", configFile)
+
+	var stdout, stderr bytes.Buffer
+
+	// the local .eslintrc files of the extension are ignored, so the findings match the ruleset of the administration
+	eslintCmd := process.Command(ctx, "npx", "--no-install", "eslint",
+		"--no-eslintrc",
+		"--config", configFile,
+		"--resolve-plugins-relative-to", administrationRoot,
+		"--ext", ".js,.ts",
+		"--format", "json",
+		filepath.Join(extensionAdminRoot, "src"),
+	)
+	eslintCmd.Dir = administrationRoot
+	eslintCmd.Env = append(os.Environ(),
+		fmt.Sprintf("ADMIN_PATH=%s", administrationRoot),
+		fmt.Sprintf("PROJECT_ROOT=%s", shopwareRoot),
+	)
+	eslintCmd.Stdout = &stdout
+	eslintCmd.Stderr = &stderr
+
+	// ESLint exits with 1 when it found errors, other codes mean ESLint itself failed
+	var exitErr *exec.ExitError
+	if err := eslintCmd.Run(); err != nil && (!errors.As(err, &exitErr) || exitErr.ExitCode() != 1) {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// addESLintResults adds the messages of the ESLint JSON report as errors and warnings.
+func addESLintResults(ctx *ValidationContext, output []byte) error {
+	var results []eslintFileResult
+	if err := json.Unmarshal(output, &results); err != nil {
+		return fmt.Errorf("cannot parse the ESLint report: %w", err)
+	}
+
+	for _, result := range results {
+		relPath, err := filepath.Rel(ctx.Extension.GetPath(), result.FilePath)
+		if err != nil {
+			relPath = result.FilePath
+		}
+
+		relPath = filepath.ToSlash(relPath)
+
+		for _, message := range result.Messages {
+			text := fmt.Sprintf("%s: %s", relPath, message.Message)
+			if message.RuleID != "" {
+				text = fmt.Sprintf("%s (%s)", text, message.RuleID)
+			}
+
+			switch message.Severity {
+			case eslintSeverityError:
+				ctx.AddFileError(relPath, message.Line, text)
+			case eslintSeverityWarning:
+				ctx.AddFileWarning(relPath, message.Line, text)
+			}
+		}
+	}
+
+	return nil
+}
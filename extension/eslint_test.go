@@ -0,0 +1,58 @@
+package extension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddESLintResults(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	ctx := NewValidationContext(&plugin)
+
+	file := filepath.ToSlash(filepath.Join(dir, "src", "Resources", "app", "administration", "src", "main.js"))
+
+	output := `[
+		{"filePath": "` + file + `", "messages": [
+			{"ruleId": "no-unused-vars", "severity": 2, "message": "'foo' is defined but never used.", "line": 3},
+			{"ruleId": "vue/require-prop-types", "severity": 1, "message": "Prop \"bar\" should define at least its type.", "line": 12},
+			{"ruleId": null, "severity": 2, "message": "Parsing error: Unexpected token }", "line": 20, "fatal": true}
+		]},
+		{"filePath": "` + filepath.ToSlash(filepath.Join(dir, "src", "Resources", "app", "administration", "src", "other.js")) + `", "messages": []}
+	]`
+
+	assert.NoError(t, addESLintResults(ctx, []byte(output)))
+
+	assert.Equal(t, []string{
+		"src/Resources/app/administration/src/main.js: 'foo' is defined but never used. (no-unused-vars)",
+		"src/Resources/app/administration/src/main.js: Parsing error: Unexpected token }",
+	}, ctx.Errors())
+	assert.Equal(t, []string{"src/Resources/app/administration/src/main.js: Prop \"bar\" should define at least its type. (vue/require-prop-types)"}, ctx.Warnings())
+
+	issues := ctx.Issues()
+	assert.Equal(t, "src/Resources/app/administration/src/main.js", issues[1].File)
+	assert.Equal(t, 12, issues[1].Line)
+
+	assert.Error(t, addESLintResults(ctx, []byte("Oops! Something went wrong!")))
+}
+
+func TestValidateESLintDisabled(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"src/Resources/app/administration/src/main.js": "const foo = 1;",
+	})
+
+	ctx := NewValidationContext(&plugin)
+	validateESLint(getTestContext(), ctx)
+
+	assert.Empty(t, ctx.Issues())
+
+	plugin.config = &Config{}
+	validateESLint(getTestContext(), ctx)
+
+	assert.Empty(t, ctx.Issues())
+}
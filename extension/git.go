@@ -2,14 +2,17 @@ package extension
 
 import (
 	"archive/zip"
-	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
 )
 
-func gitTagOrBranchOfFolder(source string) (string, error) {
-	tagCmd := exec.Command("git", "-C", source, "tag", "--sort=-creatordate")
+func gitTagOrBranchOfFolder(ctx context.Context, source string) (string, error) {
+	tagCmd := process.Command(ctx, "git", "-C", source, "tag", "--sort=-creatordate")
 
 	stdout, err := tagCmd.Output()
 	if err != nil {
@@ -22,7 +25,7 @@ func gitTagOrBranchOfFolder(source string) (string, error) {
 		return versions[0], nil
 	}
 
-	branchCmd := exec.Command("git", "-C", source, "rev-parse", "--abbrev-ref", "HEAD")
+	branchCmd := process.Command(ctx, "git", "-C", source, "rev-parse", "--abbrev-ref", "HEAD")
 
 	stdout, err = branchCmd.Output()
 
@@ -33,32 +36,59 @@ func gitTagOrBranchOfFolder(source string) (string, error) {
 	return strings.Trim(strings.TrimLeft(string(stdout), "* "), "\n"), nil
 }
 
-func GitCopyFolder(source, target, commitHash string) (string, error) {
+func GitCopyFolder(ctx context.Context, source, target, commitHash string) (string, error) {
 	var err error
 	if commitHash == "" {
-		commitHash, err = gitTagOrBranchOfFolder(source)
+		commitHash, err = gitTagOrBranchOfFolder(ctx, source)
 
 		if err != nil {
 			return "", fmt.Errorf("GitCopyFolder: cannot find checkout tag or branch: %v", err)
 		}
 	}
 
-	archiveCmd := exec.Command("git", "-C", source, "archive", commitHash, "--format=zip")
-
-	stdout, err := archiveCmd.Output()
+	// git archive writes into a temporary file instead of memory, big repositories would need their whole size in RAM otherwise
+	archiveFile, err := os.CreateTemp("", "extension-archive-*.zip")
 	if err != nil {
-		return "", fmt.Errorf("GitCopyFolder: cannot archive %s:  %v", commitHash, err)
+		return "", fmt.Errorf("GitCopyFolder: %v", err)
+	}
+
+	_ = archiveFile.Close()
+
+	defer func() {
+		_ = os.Remove(archiveFile.Name())
+	}()
+
+	archiveCmd := process.Command(ctx, "git", "-C", source, "archive", commitHash, "--format=zip", "--output="+archiveFile.Name())
+
+	if out, err := archiveCmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("GitCopyFolder: cannot archive %s:  %v: %s", commitHash, err, string(out))
 	}
 
-	zipReader, err := zip.NewReader(bytes.NewReader(stdout), int64(len(stdout)))
+	zipReader, err := zip.OpenReader(archiveFile.Name())
 	if err != nil {
 		return "", fmt.Errorf("GitCopyFolder: cannot open the zip file produced by git archive: %v", err)
 	}
 
-	err = Unzip(zipReader, target)
+	defer zipReader.Close()
+
+	err = Unzip(&zipReader.Reader, target)
 	if err != nil {
 		return "", fmt.Errorf("GitCopyFolder: cannot unzip the zip archive: %v", err)
 	}
 
 	return commitHash, err
 }
+
+// GitCommitTime returns the committer date of the ref in the repository of the folder.
+func GitCommitTime(ctx context.Context, source, ref string) (time.Time, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	stdout, err := process.Command(ctx, "git", "-C", source, "log", "-1", "--format=%ct", ref).Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GitCommitTime: cannot read the commit date of %s: %v", ref, err)
+	}
+
+	return parseSourceDateEpoch(strings.TrimSpace(string(stdout)))
+}
@@ -0,0 +1,239 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	pluginIconSize = 40
+	storeIconSize  = 256
+	// iconRenderSize is the size the SVG is rasterized in, all icons are scaled down from it
+	iconRenderSize = 1024
+)
+
+type IconOptions struct {
+	// Padding is the space around the logo in percent of the icon size
+	Padding float64
+	// Background is filled behind the logo, nil keeps the icon transparent
+	Background color.Color
+}
+
+type IconTarget struct {
+	// Path is relative to the extension root
+	Path string
+	Size int
+}
+
+type iconPathProvider interface {
+	GetIconPath() string
+}
+
+// GetIconTargets returns all icons required by the extension: the plugin or app icon and the store icon.
+func GetIconTargets(ext Extension) []IconTarget {
+	targets := make([]IconTarget, 0)
+
+	if provider, ok := ext.(iconPathProvider); ok {
+		targets = append(targets, IconTarget{Path: provider.GetIconPath(), Size: pluginIconSize})
+	}
+
+	storeIcon, _ := filepath.Rel(ext.GetPath(), filepath.Join(ext.GetResourcesDir(), "store", "icon.png"))
+
+	if cfg := ext.GetExtensionConfig(); cfg != nil && cfg.Store.Icon != nil {
+		storeIcon = *cfg.Store.Icon
+	}
+
+	return append(targets, IconTarget{Path: storeIcon, Size: storeIconSize})
+}
+
+// GenerateIcons renders the source (SVG or PNG) into all icons of the extension and returns the written targets.
+func GenerateIcons(ctx context.Context, ext Extension, source string, opts IconOptions) ([]IconTarget, error) {
+	logo, err := loadIconSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := GetIconTargets(ext)
+
+	for _, target := range targets {
+		icon := composeIcon(logo, target.Size, opts)
+		file := filepath.Join(ext.GetPath(), target.Path)
+
+		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		if err := writePNG(file, icon); err != nil {
+			return nil, fmt.Errorf("write %s: %w", target.Path, err)
+		}
+	}
+
+	return targets, nil
+}
+
+// loadIconSource decodes PNG files directly and rasterizes SVG files with rsvg-convert, inkscape or ImageMagick.
+func loadIconSource(ctx context.Context, source string) (image.Image, error) {
+	if strings.ToLower(filepath.Ext(source)) != ".svg" {
+		return readPNG(source)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "extension-icon")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logging.FromContext(ctx).Errorf("loadIconSource: %v", err)
+		}
+	}()
+
+	output := filepath.Join(tmpDir, "logo.png")
+	size := strconv.Itoa(iconRenderSize)
+
+	renderers := [][]string{
+		{"rsvg-convert", "--keep-aspect-ratio", "-w", size, "-h", size, "-o", output, source},
+		{"inkscape", source, "--export-type=png", "--export-filename=" + output, "-w", size},
+		{"magick", "-background", "none", "-density", "384", source, "-resize", size + "x" + size, output},
+		{"convert", "-background", "none", "-density", "384", source, "-resize", size + "x" + size, output},
+	}
+
+	for _, renderer := range renderers {
+		if _, err := exec.LookPath(renderer[0]); err != nil {
+			continue
+		}
+
+		logging.FromContext(ctx).Debugf("Rasterizing %s with %s", source, renderer[0])
+
+		cmd := process.Command(ctx, renderer[0], renderer[1:]...) //nolint:gosec
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("%s failed: %w: %s", renderer[0], err, string(out))
+		}
+
+		return readPNG(output)
+	}
+
+	return nil, fmt.Errorf("cannot rasterize %s, install rsvg-convert (librsvg), inkscape or ImageMagick or pass a PNG file", source)
+}
+
+func readPNG(file string) (image.Image, error) {
+	handle, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer handle.Close()
+
+	img, err := png.Decode(handle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", file, err)
+	}
+
+	return img, nil
+}
+
+func writePNG(file string, img image.Image) error {
+	handle, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(handle, img); err != nil {
+		_ = handle.Close()
+		return err
+	}
+
+	return handle.Close()
+}
+
+// composeIcon scales the logo into a square icon of the given size, keeping the aspect ratio and the padding around it.
+func composeIcon(logo image.Image, size int, opts IconOptions) *image.NRGBA {
+	icon := image.NewNRGBA(image.Rect(0, 0, size, size))
+
+	if opts.Background != nil {
+		draw.Draw(icon, icon.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+	}
+
+	inner := float64(size) * (1 - 2*opts.Padding/100)
+	if inner < 1 {
+		inner = 1
+	}
+
+	bounds := logo.Bounds()
+	scale := inner / float64(max(bounds.Dx(), bounds.Dy()))
+	width := max(1, int(float64(bounds.Dx())*scale+0.5))
+	height := max(1, int(float64(bounds.Dy())*scale+0.5))
+
+	offset := image.Pt((size-width)/2, (size-height)/2)
+	scaled := scaleImage(logo, width, height)
+
+	draw.Draw(icon, scaled.Bounds().Add(offset), scaled, image.Point{}, draw.Over)
+
+	return icon
+}
+
+// scaleImage resizes the image by averaging all source pixels covered by a target pixel, which gives smooth results when scaling down.
+func scaleImage(src image.Image, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	bounds := src.Bounds()
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, count uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// RGBA returns premultiplied values, so transparent pixels do not darken the edges
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					count++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{R: uint16(r / count), G: uint16(g / count), B: uint16(b / count), A: uint16(a / count)})
+		}
+	}
+
+	return dst
+}
+
+// ParseHexColor parses colors like #fff or #ffffff.
+func ParseHexColor(value string) (color.Color, error) {
+	hex := strings.TrimPrefix(value, "#")
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("invalid color %s, use the format #rrggbb", value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %s, use the format #rrggbb", value)
+	}
+
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, nil
+}
@@ -0,0 +1,70 @@
+package extension
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#ff8000")
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 255, G: 128, B: 0, A: 255}, c)
+
+	c, err = ParseHexColor("fff")
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, c)
+
+	_, err = ParseHexColor("#ff80")
+	assert.Error(t, err)
+
+	_, err = ParseHexColor("#gggggg")
+	assert.Error(t, err)
+}
+
+func TestComposeIcon(t *testing.T) {
+	// a wide red logo
+	logo := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(logo, logo.Bounds(), image.NewUniform(color.NRGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+
+	icon := composeIcon(logo, 40, IconOptions{Padding: 10})
+
+	assert.Equal(t, image.Rect(0, 0, 40, 40), icon.Bounds())
+	// padding and the space above the wide logo stay transparent
+	assert.Equal(t, uint8(0), icon.NRGBAAt(2, 20).A)
+	assert.Equal(t, uint8(0), icon.NRGBAAt(20, 5).A)
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, icon.NRGBAAt(20, 20))
+
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	icon = composeIcon(logo, 40, IconOptions{Padding: 10, Background: white})
+
+	assert.Equal(t, white, icon.NRGBAAt(2, 2))
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, icon.NRGBAAt(20, 20))
+}
+
+func TestGenerateIcons(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	logo := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	source := filepath.Join(dir, "logo.png")
+	assert.NoError(t, writePNG(source, logo))
+
+	targets, err := GenerateIcons(getTestContext(), plugin, source, IconOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []IconTarget{
+		{Path: "src/Resources/config/plugin.png", Size: 40},
+		{Path: "src/Resources/store/icon.png", Size: 256},
+	}, targets)
+
+	for _, target := range targets {
+		img, err := readPNG(filepath.Join(dir, target.Path))
+		assert.NoError(t, err)
+		assert.Equal(t, target.Size, img.Bounds().Dx())
+		assert.Equal(t, target.Size, img.Bounds().Dy())
+	}
+}
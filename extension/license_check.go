@@ -0,0 +1,290 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+const defaultLicenseCheckClass = "License\\LicenseValidator"
+
+var phpNamespaceRegex = regexp.MustCompile(`(?m)^\s*namespace\s+([^;\s]+)\s*;`)
+
+// licenseCheckPHP74Template is used when the plugin supports Shopware 6.4, which runs on PHP 7.4.
+const licenseCheckPHP74Template = `<?php declare(strict_types=1);
+
+namespace %[1]s;
+
+use Psr\Log\LoggerInterface;
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\Store\Services\StoreClient;
+
+/**
+ * Checks the store license of the plugin. Generated by shopware-cli extension license-check init.
+ */
+class %[2]s
+{
+    private const PLUGIN_NAME = '%[3]s';
+
+    private StoreClient $storeClient;
+
+    private LoggerInterface $logger;
+
+    private ?bool $valid = null;
+
+    public function __construct(StoreClient $storeClient, LoggerInterface $logger)
+    {
+        $this->storeClient = $storeClient;
+        $this->logger = $logger;
+    }
+
+%[4]s}
+`
+
+const licenseCheckPHP81Template = `<?php declare(strict_types=1);
+
+namespace %[1]s;
+
+use Psr\Log\LoggerInterface;
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\Store\Services\StoreClient;
+
+/**
+ * Checks the store license of the plugin. Generated by shopware-cli extension license-check init.
+ */
+class %[2]s
+{
+    private const PLUGIN_NAME = '%[3]s';
+
+    private ?bool $valid = null;
+
+    public function __construct(
+        private readonly StoreClient $storeClient,
+        private readonly LoggerInterface $logger
+    ) {
+    }
+
+%[4]s}
+`
+
+const licenseCheckIsValidMethod = `    public function isValid(Context $context): bool
+    {
+        if ($this->valid !== null) {
+            return $this->valid;
+        }
+
+        try {
+            $licenses = $this->storeClient->getLicenses($context);
+        } catch (\Throwable $e) {
+            // the shop must keep working, when the store is not reachable
+            $this->logger->warning(sprintf('Cannot check the license of %s: %s', self::PLUGIN_NAME, $e->getMessage()));
+
+            return true;
+        }
+
+        foreach ($licenses['data'] ?? [] as $license) {
+            if (($license['licensedExtension']['name'] ?? null) !== self::PLUGIN_NAME) {
+                continue;
+            }
+
+            $expirationDate = $license['expirationDate'] ?? null;
+
+            return $this->valid = $expirationDate === null || new \DateTimeImmutable($expirationDate) > new \DateTimeImmutable();
+        }
+
+        return $this->valid = false;
+    }
+`
+
+// LicenseCheckClass returns the class of the license validator, by default in the namespace of the plugin class.
+func LicenseCheckClass(ext Extension) (string, error) {
+	plugin, ok := asPlatformPlugin(ext)
+	if !ok {
+		return "", fmt.Errorf("the license check is only supported for plugins, apps are licensed by the app system")
+	}
+
+	var class string
+
+	if cfg := ext.GetExtensionConfig(); cfg != nil && cfg.LicenseCheck.Class != "" {
+		class = strings.TrimPrefix(cfg.LicenseCheck.Class, "\\")
+	} else {
+		pluginClass := plugin.composer.Extra.ShopwarePluginClass
+		class = pluginClass[:strings.LastIndex(pluginClass, "\\")+1] + defaultLicenseCheckClass
+	}
+
+	// the generated class and the usage check need the namespace
+	if separator := strings.LastIndex(class, "\\"); separator <= 0 || separator == len(class)-1 {
+		return "", fmt.Errorf("the license check class %s has to be a fully qualified class name with namespace", class)
+	}
+
+	return class, nil
+}
+
+// licenseCheckFile resolves the class with the psr-4 autoloading to the file relative to the plugin root.
+func licenseCheckFile(plugin PlatformPlugin, class string) string {
+	for prefix, dir := range plugin.composer.Autoload.Psr4 {
+		if strings.HasPrefix(class, prefix) {
+			return filepath.Join(dir, strings.ReplaceAll(strings.TrimPrefix(class, prefix), "\\", "/")+".php")
+		}
+	}
+
+	return ""
+}
+
+// GenerateLicenseCheck writes the license validator for the lowest supported Shopware version and registers it in the
+// services.xml. Existing files are not overwritten, the written files are returned relative to the plugin.
+func GenerateLicenseCheck(ext Extension) ([]string, error) {
+	class, err := LicenseCheckClass(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	plugin, _ := asPlatformPlugin(ext)
+
+	classFile := licenseCheckFile(plugin, class)
+	if classFile == "" {
+		return nil, fmt.Errorf("cannot resolve the file of %s with the psr-4 autoloading of the composer.json", class)
+	}
+
+	name, err := ext.GetName()
+	if err != nil {
+		return nil, err
+	}
+
+	constraint, err := ext.GetShopwareVersionConstraint()
+	if err != nil {
+		return nil, err
+	}
+
+	written := make([]string, 0)
+	classPath := filepath.Join(plugin.GetPath(), classFile)
+
+	if _, err := os.Stat(classPath); os.IsNotExist(err) {
+		template := licenseCheckPHP81Template
+
+		// Shopware 6.4 supports PHP 7.4 without constructor promotion and readonly properties
+		if constraint.Check(version.Must(version.NewVersion("6.4.20.2"))) {
+			template = licenseCheckPHP74Template
+		}
+
+		separator := strings.LastIndex(class, "\\")
+		content := fmt.Sprintf(template, class[:separator], class[separator+1:], name, licenseCheckIsValidMethod)
+
+		if err := os.MkdirAll(filepath.Dir(classPath), 0o755); err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(classPath, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+
+		written = append(written, classFile)
+	}
+
+	servicesFile := filepath.Join(plugin.GetResourcesDir(), "config", "services.xml")
+
+	services, err := os.ReadFile(servicesFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the services.xml to register %s: %w", class, err)
+	}
+
+	if !strings.Contains(string(services), class) {
+		if !strings.Contains(string(services), "</services>") {
+			return nil, fmt.Errorf("cannot register %s, the services.xml has no services element", class)
+		}
+
+		service := fmt.Sprintf(`        <service id="%s">
+            <argument type="service" id="Shopware\Core\Framework\Store\Services\StoreClient"/>
+            <argument type="service" id="logger"/>
+        </service>
+`, class)
+
+		// insert the service before the line with the closing services element
+		end := strings.Index(string(services), "</services>")
+		lineStart := strings.LastIndex(string(services[:end]), "\n") + 1
+		content := string(services[:lineStart]) + service + string(services[lineStart:])
+
+		if err := os.WriteFile(servicesFile, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+
+		relPath, _ := filepath.Rel(plugin.GetPath(), servicesFile)
+		written = append(written, relPath)
+	}
+
+	return written, nil
+}
+
+// ValidateLicenseCheck checks for paid plugins, that the license validator exists, is registered as service and used.
+func ValidateLicenseCheck(ctx *ValidationContext) {
+	extCfg := ctx.Extension.GetExtensionConfig()
+	if extCfg == nil || !extCfg.LicenseCheck.Enabled {
+		return
+	}
+
+	class, err := LicenseCheckClass(ctx.Extension)
+	if err != nil {
+		ctx.AddError(fmt.Sprintf("license_check: %s", err.Error()))
+		return
+	}
+
+	plugin, _ := asPlatformPlugin(ctx.Extension)
+	classFile := licenseCheckFile(plugin, class)
+
+	if _, err := os.Stat(filepath.Join(plugin.GetPath(), classFile)); classFile == "" || err != nil {
+		ctx.AddError(fmt.Sprintf("license_check: the license validator %s does not exist, create it with shopware-cli extension license-check init", class))
+		return
+	}
+
+	services, _ := os.ReadFile(filepath.Join(plugin.GetResourcesDir(), "config", "services.xml"))
+	if !strings.Contains(string(services), fmt.Sprintf(`id="%s"`, class)) && !strings.Contains(string(services), fmt.Sprintf(`class="%s"`, class)) {
+		ctx.AddFileError(filepath.ToSlash(classFile), 0, fmt.Sprintf("license_check: %s is not registered in Resources/config/services.xml", class))
+	}
+
+	if !isLicenseCheckUsed(plugin.GetRootDir(), filepath.Join(plugin.GetPath(), classFile), class) {
+		ctx.AddFileError(filepath.ToSlash(classFile), 0, fmt.Sprintf("license_check: %s is not used by any class, call isValid before the paid features are executed", class))
+	}
+}
+
+// isLicenseCheckUsed returns true, when another PHP file references the class by its full name or in the same namespace.
+func isLicenseCheckUsed(rootDir, classPath, class string) bool {
+	separator := strings.LastIndex(class, "\\")
+	namespace, shortName := class[:separator], class[separator+1:]
+	used := false
+
+	_ = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || used {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == "vendor" {
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".php" || path == classPath {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		if strings.Contains(string(content), class) {
+			used = true
+			return nil
+		}
+
+		if match := phpNamespaceRegex.FindSubmatch(content); match != nil && string(match[1]) == namespace && strings.Contains(string(content), shortName) {
+			used = true
+		}
+
+		return nil
+	})
+
+	return used
+}
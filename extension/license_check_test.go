@@ -0,0 +1,108 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const licenseCheckTestServices = `<?xml version="1.0" ?>
+<container xmlns="http://symfony.com/schema/dic/services">
+    <services>
+    </services>
+</container>
+`
+
+func getLicenseCheckTestPlugin(t *testing.T, shopwareConstraint string) PlatformPlugin {
+	t.Helper()
+
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.composer.Require["shopware/core"] = shopwareConstraint
+	plugin.composer.Autoload.Psr4 = map[string]string{"FroshTools\\": "src/"}
+	plugin.config = &Config{LicenseCheck: ConfigLicenseCheck{Enabled: true}}
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "Resources", "config"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Resources", "config", "services.xml"), []byte(licenseCheckTestServices), os.ModePerm))
+
+	return plugin
+}
+
+func TestGenerateLicenseCheck(t *testing.T) {
+	plugin := getLicenseCheckTestPlugin(t, "~6.5.0")
+
+	written, err := GenerateLicenseCheck(plugin)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("src", "License", "LicenseValidator.php"), filepath.Join("src", "Resources", "config", "services.xml")}, written)
+
+	content, err := os.ReadFile(filepath.Join(plugin.GetPath(), "src", "License", "LicenseValidator.php"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "namespace FroshTools\\License;\n")
+	assert.Contains(t, string(content), "private const PLUGIN_NAME = 'FroshTools';")
+	assert.Contains(t, string(content), "private readonly StoreClient $storeClient,")
+
+	services, err := os.ReadFile(filepath.Join(plugin.GetResourcesDir(), "config", "services.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(services), "    <services>\n        <service id=\"FroshTools\\License\\LicenseValidator\">\n")
+
+	// a second run keeps the existing files
+	written, err = GenerateLicenseCheck(plugin)
+	assert.NoError(t, err)
+	assert.Empty(t, written)
+}
+
+func TestLicenseCheckClassWithoutNamespace(t *testing.T) {
+	plugin := getLicenseCheckTestPlugin(t, "~6.5.0")
+	plugin.config.LicenseCheck.Class = "LicenseValidator"
+
+	_, err := GenerateLicenseCheck(plugin)
+	assert.EqualError(t, err, "the license check class LicenseValidator has to be a fully qualified class name with namespace")
+
+	ctx := NewValidationContext(&plugin)
+	ValidateLicenseCheck(ctx)
+	assert.Equal(t, []string{"license_check: the license check class LicenseValidator has to be a fully qualified class name with namespace"}, ctx.Errors())
+}
+
+func TestGenerateLicenseCheckForShopware64(t *testing.T) {
+	plugin := getLicenseCheckTestPlugin(t, "~6.4.0 || ~6.5.0")
+
+	_, err := GenerateLicenseCheck(plugin)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(plugin.GetPath(), "src", "License", "LicenseValidator.php"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "    private StoreClient $storeClient;\n")
+	assert.NotContains(t, string(content), "readonly")
+}
+
+func TestValidateLicenseCheck(t *testing.T) {
+	plugin := getLicenseCheckTestPlugin(t, "~6.5.0")
+
+	ctx := NewValidationContext(&plugin)
+	ValidateLicenseCheck(ctx)
+	assert.Equal(t, []string{"license_check: the license validator FroshTools\\License\\LicenseValidator does not exist, create it with shopware-cli extension license-check init"}, ctx.Errors())
+
+	_, err := GenerateLicenseCheck(plugin)
+	assert.NoError(t, err)
+
+	ctx = NewValidationContext(&plugin)
+	ValidateLicenseCheck(ctx)
+	assert.Equal(t, []string{"license_check: FroshTools\\License\\LicenseValidator is not used by any class, call isValid before the paid features are executed"}, ctx.Errors())
+
+	subscriber := "<?php\n\nnamespace FroshTools\\Subscriber;\n\nuse FroshTools\\License\\LicenseValidator;\n"
+	assert.NoError(t, os.MkdirAll(filepath.Join(plugin.GetRootDir(), "Subscriber"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(plugin.GetRootDir(), "Subscriber", "CheckoutSubscriber.php"), []byte(subscriber), os.ModePerm))
+
+	ctx = NewValidationContext(&plugin)
+	ValidateLicenseCheck(ctx)
+	assert.Empty(t, ctx.Errors())
+
+	plugin.config.LicenseCheck.Enabled = false
+	assert.NoError(t, os.WriteFile(filepath.Join(plugin.GetResourcesDir(), "config", "services.xml"), []byte(licenseCheckTestServices), os.ModePerm))
+
+	ctx = NewValidationContext(&plugin)
+	ValidateLicenseCheck(ctx)
+	assert.Empty(t, ctx.Errors())
+}
@@ -0,0 +1,291 @@
+package extension
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// licenseHeaderFileExtensions are the source files which need the license header.
+var licenseHeaderFileExtensions = []string{".php", ".js", ".ts"}
+
+// licenseHeaderSkippedPaths contain dependencies or compiled files, which are not written by the extension.
+var licenseHeaderSkippedPaths = []string{
+	"vendor",
+	"node_modules",
+	"Resources/public",
+	"Resources/app/storefront/dist",
+}
+
+// licenseHeaderReadLimit is the amount of bytes read to find the header, it's always at the beginning of the file.
+const licenseHeaderReadLimit = 16 * 1024
+
+func (c ConfigLicenseHeader) IsEnabled() bool {
+	return strings.TrimSpace(c.Header) != ""
+}
+
+func licenseHeaderLines(header string) []string {
+	lines := strings.Split(strings.TrimSpace(header), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return lines
+}
+
+// renderLicenseHeader renders the header as doc block comment.
+func renderLicenseHeader(header string) string {
+	var b strings.Builder
+
+	b.WriteString("/**\n")
+
+	for _, line := range licenseHeaderLines(header) {
+		if line == "" {
+			b.WriteString(" *\n")
+			continue
+		}
+
+		b.WriteString(" * " + line + "\n")
+	}
+
+	b.WriteString(" */\n")
+
+	return b.String()
+}
+
+// HasLicenseHeader reports whether the lines of the header are contained in the comments at the beginning of the file.
+func HasLicenseHeader(content []byte, header string) bool {
+	expected := licenseHeaderLines(header)
+	lines := strings.Split(string(content), "\n")
+
+	// The header can follow <?php, declare and blank lines
+	maxStart := 10
+	if len(lines) < maxStart {
+		maxStart = len(lines)
+	}
+
+	for start := 0; start < maxStart; start++ {
+		if licenseHeaderMatches(lines[start:], expected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func licenseHeaderMatches(lines []string, expected []string) bool {
+	if len(lines) < len(expected) {
+		return false
+	}
+
+	for i, want := range expected {
+		if stripCommentMarkers(lines[i]) != want {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stripCommentMarkers(line string) string {
+	line = strings.TrimSpace(line)
+
+	for _, prefix := range []string{"/**", "/*", "//", "*"} {
+		if strings.HasPrefix(line, prefix) && !strings.HasPrefix(line, "*/") {
+			line = strings.TrimPrefix(line, prefix)
+			break
+		}
+	}
+
+	return strings.TrimSpace(line)
+}
+
+// injectLicenseHeader adds the header at the top of the file, for PHP files after the opening tag.
+// injectLicenseHeader copies the content from r to w with the header in front of it, for PHP files after the open tag.
+// Only the first line is buffered, so big files are streamed.
+func injectLicenseHeader(w io.Writer, r io.Reader, header string, isPHP bool) error {
+	rendered := renderLicenseHeader(header)
+	reader := bufio.NewReader(r)
+
+	if isPHP {
+		if openTag, _ := reader.Peek(len("<?php")); string(openTag) == "<?php" {
+			firstLine, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+
+			if !strings.HasSuffix(firstLine, "\n") {
+				_, err := io.WriteString(w, firstLine+"\n\n"+rendered)
+				return err
+			}
+
+			if _, err := io.WriteString(w, firstLine+"\n"+rendered); err != nil {
+				return err
+			}
+
+			_, err = io.Copy(w, reader)
+
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, rendered+"\n"); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(w, reader)
+
+	return err
+}
+
+// injectLicenseHeaderIntoFile streams the file with the header into a temporary file, which replaces the original.
+func injectLicenseHeaderIntoFile(path string, header string, isPHP bool) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	target, err := os.CreateTemp(filepath.Dir(path), ".license-header-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(target.Name())
+
+	if err := injectLicenseHeader(target, source, header, isPHP); err != nil {
+		_ = target.Close()
+		return err
+	}
+
+	if err := target.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(target.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Rename(target.Name(), path)
+}
+
+func findLicenseHeaderFiles(root string, excludes []string) ([]string, error) {
+	skipped := append(append([]string{}, licenseHeaderSkippedPaths...), excludes...)
+	files := make([]string, 0)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, _ := filepath.Rel(root, path)
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			for _, skip := range skipped {
+				if relPath == skip || strings.HasSuffix(relPath, "/"+skip) {
+					return filepath.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		for _, ext := range licenseHeaderFileExtensions {
+			if strings.HasSuffix(relPath, ext) && !strings.HasSuffix(relPath, ".d.ts") {
+				files = append(files, relPath)
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+// FindFilesWithoutLicenseHeader returns the relative paths of all source files missing the configured header.
+func FindFilesWithoutLicenseHeader(root string, cfg ConfigLicenseHeader) ([]string, error) {
+	files, err := findLicenseHeaderFiles(root, cfg.Excludes)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([]string, 0)
+
+	for _, file := range files {
+		content, err := readFileHead(filepath.Join(root, file), licenseHeaderReadLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		if !HasLicenseHeader(content, cfg.Header) {
+			missing = append(missing, file)
+		}
+	}
+
+	return missing, nil
+}
+
+// InjectLicenseHeaders adds the header to all source files missing it and returns the changed files.
+func InjectLicenseHeaders(root string, cfg ConfigLicenseHeader) ([]string, error) {
+	missing, err := FindFilesWithoutLicenseHeader(root, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range missing {
+		if err := injectLicenseHeaderIntoFile(filepath.Join(root, file), cfg.Header, strings.HasSuffix(file, ".php")); err != nil {
+			return nil, fmt.Errorf("InjectLicenseHeaders: %w", err)
+		}
+	}
+
+	return missing, nil
+}
+
+// readFileHead reads up to limit bytes from the beginning of the file.
+func readFileHead(path string, limit int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, limit))
+}
+
+func validateLicenseHeaders(ctx *ValidationContext) {
+	extCfg := ctx.Extension.GetExtensionConfig()
+	if extCfg == nil || !extCfg.LicenseHeader.IsEnabled() {
+		return
+	}
+
+	missing, err := FindFilesWithoutLicenseHeader(ctx.Extension.GetPath(), extCfg.LicenseHeader)
+	if err != nil {
+		ctx.AddError(fmt.Sprintf("cannot check license headers: %v", err))
+		return
+	}
+
+	for _, file := range missing {
+		// extension zip adds the header, so the extension can still be released
+		if extCfg.LicenseHeader.Inject {
+			ctx.AddFileWarning(file, 1, fmt.Sprintf("file %s is missing the license header, it is added by extension zip", file))
+			continue
+		}
+
+		ctx.AddFileError(file, 1, fmt.Sprintf("file %s is missing the license header", file))
+	}
+}
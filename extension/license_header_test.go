@@ -0,0 +1,98 @@
+package extension
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLicenseHeader = `Copyright (c) Example GmbH
+
+For the full license information, please view the LICENSE file.`
+
+func TestHasLicenseHeader(t *testing.T) {
+	assert.True(t, HasLicenseHeader([]byte("<?php\n\n/**\n * Copyright (c) Example GmbH\n *\n * For the full license information, please view the LICENSE file.\n */\n\nclass Foo {}"), testLicenseHeader))
+	assert.True(t, HasLicenseHeader([]byte("// Copyright (c) Example GmbH\n//\n// For the full license information, please view the LICENSE file.\nexport default {};"), testLicenseHeader))
+	assert.False(t, HasLicenseHeader([]byte("<?php\n\nclass Foo {}"), testLicenseHeader))
+	assert.False(t, HasLicenseHeader([]byte("<?php\n/**\n * Copyright (c) Other GmbH\n */"), testLicenseHeader))
+}
+
+func injectLicenseHeaderString(t *testing.T, content string, isPHP bool) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	assert.NoError(t, injectLicenseHeader(&buf, strings.NewReader(content), testLicenseHeader, isPHP))
+
+	return buf.Bytes()
+}
+
+func TestInjectLicenseHeader(t *testing.T) {
+	php := injectLicenseHeaderString(t, "<?php declare(strict_types=1);\n\nclass Foo {}\n", true)
+
+	assert.Equal(t, "<?php declare(strict_types=1);\n\n/**\n * Copyright (c) Example GmbH\n *\n * For the full license information, please view the LICENSE file.\n */\n\nclass Foo {}\n", string(php))
+	assert.True(t, HasLicenseHeader(php, testLicenseHeader))
+
+	js := injectLicenseHeaderString(t, "export default {};\n", false)
+
+	assert.Equal(t, "/**\n * Copyright (c) Example GmbH\n *\n * For the full license information, please view the LICENSE file.\n */\n\nexport default {};\n", string(js))
+	assert.True(t, HasLicenseHeader(js, testLicenseHeader))
+}
+
+func TestInjectLicenseHeaders(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"src/Foo.php": "<?php\n\nclass Foo {}\n",
+		"src/Bar.php": "<?php\n" + renderLicenseHeader(testLicenseHeader) + "\nclass Bar {}\n",
+		"src/Resources/app/administration/src/main.js":    "import './module';\n",
+		"src/Resources/app/administration/src/types.d.ts": "declare module 'foo';\n",
+		"src/Resources/public/administration/js/app.js":   "compiled",
+		"vendor/lib/Lib.php":                              "<?php\n",
+		"src/Legacy/Old.php":                              "<?php\n",
+		"README.md":                                       "# Readme",
+	}
+
+	for file, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), os.ModePerm))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), os.ModePerm))
+	}
+
+	cfg := ConfigLicenseHeader{Header: testLicenseHeader, Excludes: []string{"src/Legacy"}}
+
+	missing, err := FindFilesWithoutLicenseHeader(dir, cfg)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"src/Foo.php", "src/Resources/app/administration/src/main.js"}, missing)
+
+	injected, err := InjectLicenseHeaders(dir, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, missing, injected)
+
+	missing, err = FindFilesWithoutLicenseHeader(dir, cfg)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestValidateLicenseHeaders(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Foo.php"), []byte("<?php\n\nclass Foo {}\n"), os.ModePerm))
+
+	plugin.config = &Config{LicenseHeader: ConfigLicenseHeader{Header: testLicenseHeader}}
+
+	ctx := NewValidationContext(&plugin)
+	validateLicenseHeaders(ctx)
+	assert.Equal(t, []string{"file src/Foo.php is missing the license header"}, ctx.Errors())
+
+	plugin.config.LicenseHeader.Inject = true
+
+	ctx = NewValidationContext(&plugin)
+	validateLicenseHeaders(ctx)
+	assert.Empty(t, ctx.Errors())
+	assert.Equal(t, []string{"file src/Foo.php is missing the license header, it is added by extension zip"}, ctx.Warnings())
+}
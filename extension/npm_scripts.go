@@ -0,0 +1,140 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	NpmScriptsRun           = "run"
+	NpmScriptsSkip          = "skip"
+	NpmScriptsRestrictedEnv = "restricted-env"
+
+	// blockedProxy is the discard port of the local machine, requests of proxy aware tools fail immediately
+	blockedProxy = "http://127.0.0.1:9"
+)
+
+// rootLifecycleScripts are run by the package managers for the package.json of the extension itself after the install.
+var rootLifecycleScripts = []string{"preinstall", "install", "postinstall", "prepare"}
+
+// restrictedPassEnv are the only variables passed from the environment to the scripts, so no tokens or credentials leak.
+var restrictedPassEnv = []string{"PATH", "LANG", "TZ", "SystemRoot", "SYSTEMROOT", "COMSPEC", "PATHEXT", "WINDIR"}
+
+// installExtensionDependencies installs the npm dependencies of an extension. Depending on the mode the lifecycle scripts
+// of the packages run as usual, are skipped or run after the install in a restricted environment.
+func installExtensionDependencies(ctx context.Context, path, mode string, stdout, stderr io.Writer) error {
+	switch mode {
+	case "", NpmScriptsRun:
+		return installDependenciesWithOutput(ctx, path, stdout, stderr)
+	case NpmScriptsSkip:
+		return runNpmCommand(ignoreScripts(getInstallCommand(ctx, path)), path, os.Environ(), stdout, stderr)
+	case NpmScriptsRestrictedEnv:
+		installCmd := getInstallCommand(ctx, path)
+		packageManager := installCmd.Args[0]
+
+		if packageManager != "npm" && packageManager != "pnpm" {
+			return fmt.Errorf("running npm scripts with a restricted environment requires npm or pnpm, but %s uses %s", path, packageManager)
+		}
+
+		if err := runNpmCommand(ignoreScripts(installCmd), path, os.Environ(), stdout, stderr); err != nil {
+			return err
+		}
+
+		home, err := os.MkdirTemp("", "npm-restricted-env")
+		if err != nil {
+			return err
+		}
+
+		defer deletePath(ctx, home)
+
+		env := npmRestrictedEnv(os.Environ(), home)
+
+		// This is no isolation: the scripts can still read files outside of the temporary home and open network connections
+		logging.FromContext(ctx).Infof("Running npm scripts of %s with a restricted environment, the scripts keep access to the file system and network", path)
+
+		if err := runNpmCommand(process.Command(ctx, packageManager, "rebuild"), path, env, stdout, stderr); err != nil {
+			return fmt.Errorf("npm scripts with restricted environment: %w", err)
+		}
+
+		for _, script := range rootLifecycleScripts {
+			if err := runNpmCommand(process.Command(ctx, packageManager, "run", "--if-present", script), path, env, stdout, stderr); err != nil {
+				return fmt.Errorf("npm script %s with restricted environment: %w", script, err)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported npm scripts mode %s, use run, skip or restricted-env", mode)
+}
+
+func runNpmCommand(cmd *exec.Cmd, path string, env []string, stdout, stderr io.Writer) error {
+	cmd.Dir = path
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// the variables of the command itself, like set by ignoreScripts, win
+	cmd.Env = append(append(env, cmd.Env...), "PUPPETEER_SKIP_DOWNLOAD=1")
+
+	return cmd.Run()
+}
+
+// ignoreScripts disables the lifecycle scripts of the install command.
+func ignoreScripts(installCmd *exec.Cmd) *exec.Cmd {
+	// Yarn 2+ fails on unknown flags, both Yarn versions read the setting from the environment
+	if installCmd.Args[0] == "yarn" {
+		installCmd.Env = []string{"YARN_ENABLE_SCRIPTS=false", "YARN_IGNORE_SCRIPTS=true"}
+		return installCmd
+	}
+
+	installCmd.Args = append(installCmd.Args, "--ignore-scripts")
+
+	return installCmd
+}
+
+// npmRestrictedEnv returns the environment for untrusted npm scripts: a temporary home and cache, no variables of the
+// calling environment except the system paths, and unreachable proxies, so downloads of tools respecting them fail.
+func npmRestrictedEnv(environ []string, home string) []string {
+	env := make([]string, 0)
+
+	for _, entry := range environ {
+		for _, name := range restrictedPassEnv {
+			if strings.HasPrefix(entry, name+"=") {
+				env = append(env, entry)
+			}
+		}
+	}
+
+	env = append(env,
+		"HOME="+home,
+		"TMPDIR="+home,
+		"XDG_CONFIG_HOME="+filepath.Join(home, ".config"),
+		"XDG_CACHE_HOME="+filepath.Join(home, ".cache"),
+		"npm_config_cache="+filepath.Join(home, ".npm"),
+		"npm_config_offline=true",
+		"npm_config_proxy="+blockedProxy,
+		"npm_config_https_proxy="+blockedProxy,
+		"HTTP_PROXY="+blockedProxy,
+		"HTTPS_PROXY="+blockedProxy,
+		"ALL_PROXY="+blockedProxy,
+		"http_proxy="+blockedProxy,
+		"https_proxy="+blockedProxy,
+		"all_proxy="+blockedProxy,
+		"NO_PROXY=",
+		"no_proxy=",
+	)
+
+	if runtime.GOOS == "windows" {
+		env = append(env, "USERPROFILE="+home, "TEMP="+home, "TMP="+home)
+	}
+
+	return env
+}
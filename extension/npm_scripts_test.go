@@ -0,0 +1,46 @@
+package extension
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNpmRestrictedEnv(t *testing.T) {
+	env := npmRestrictedEnv([]string{"PATH=/usr/bin", "NPM_TOKEN=secret", "GITHUB_TOKEN=secret", "HOME=/home/user", "PATHEXT=.EXE"}, "/tmp/restricted-env")
+
+	assert.Contains(t, env, "PATH=/usr/bin")
+	assert.Contains(t, env, "PATHEXT=.EXE")
+	assert.Contains(t, env, "HOME=/tmp/restricted-env")
+	assert.Contains(t, env, "npm_config_offline=true")
+	assert.Contains(t, env, "HTTPS_PROXY="+blockedProxy)
+	assert.NotContains(t, env, "NPM_TOKEN=secret")
+	assert.NotContains(t, env, "GITHUB_TOKEN=secret")
+	assert.NotContains(t, env, "HOME=/home/user")
+}
+
+func TestIgnoreScripts(t *testing.T) {
+	dir := t.TempDir()
+
+	installCmd := ignoreScripts(getInstallCommand(getTestContext(), dir))
+	assert.Equal(t, []string{"npm", "install", "--no-audit", "--no-fund", "--prefer-offline", "--ignore-scripts"}, installCmd.Args)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte{}, os.ModePerm))
+
+	installCmd = ignoreScripts(getInstallCommand(getTestContext(), dir))
+	assert.Equal(t, []string{"yarn", "install"}, installCmd.Args)
+	assert.Contains(t, installCmd.Env, "YARN_ENABLE_SCRIPTS=false")
+}
+
+func TestInstallExtensionDependenciesInvalidMode(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.EqualError(t, installExtensionDependencies(getTestContext(), dir, "docker", io.Discard, io.Discard), "unsupported npm scripts mode docker, use run, skip or restricted-env")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte{}, os.ModePerm))
+
+	assert.ErrorContains(t, installExtensionDependencies(getTestContext(), dir, NpmScriptsRestrictedEnv, io.Discard, io.Discard), "requires npm or pnpm")
+}
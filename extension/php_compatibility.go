@@ -0,0 +1,105 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/phplint"
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// phpCompatibilityMaxFileSize is the biggest PHP file which is checked.
+const phpCompatibilityMaxFileSize = 10 * megabyte
+
+// getMinPhpVersion returns the minimum PHP version of the lowest Shopware version matching the constraint. It uses the
+// bundled data only, so the check works without network access.
+func getMinPhpVersion(constraint *version.Constraints) (string, string, bool) {
+	phpVersions, err := staticdata.BundledPHPVersions()
+	if err != nil {
+		return "", "", false
+	}
+
+	shopwareVersions := make([]*version.Version, 0, len(phpVersions))
+
+	for shopwareVersion := range phpVersions {
+		v, err := version.NewVersion(shopwareVersion)
+		if err != nil {
+			continue
+		}
+
+		shopwareVersions = append(shopwareVersions, v)
+	}
+
+	sort.Sort(version.Collection(shopwareVersions))
+
+	for _, shopwareVersion := range shopwareVersions {
+		if constraint.Check(shopwareVersion) {
+			return phpVersions[shopwareVersion.Original()], shopwareVersion.String(), true
+		}
+	}
+
+	return "", "", false
+}
+
+func validatePHPCompatibility(c context.Context, ctx *ValidationContext) {
+	constraint, err := ctx.Extension.GetShopwareVersionConstraint()
+	if err != nil {
+		return
+	}
+
+	phpVersion, shopwareVersion, found := getMinPhpVersion(constraint)
+	if !found {
+		// the bundled data may not know the newest Shopware versions yet
+		logging.FromContext(c).Infof("Skipping the PHP compatibility check, the minimum PHP version for Shopware %s is unknown", constraint.String())
+		return
+	}
+
+	_ = filepath.WalkDir(ctx.Extension.GetPath(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if d.Name() == "vendor" || d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(d.Name(), ".php") {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(ctx.Extension.GetPath(), path)
+
+		// generated PHP files like big data dumps are not loaded into memory
+		if info, err := d.Info(); err == nil && info.Size() > phpCompatibilityMaxFileSize {
+			ctx.AddFileWarning(relPath, 0, fmt.Sprintf("%s is bigger than %d MB and was not checked for PHP compatibility", relPath, phpCompatibilityMaxFileSize/megabyte))
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		issues, err := phplint.Check(string(content), phpVersion)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			ctx.AddFileError(relPath, issue.Line, fmt.Sprintf("%s %s, but Shopware %s supports PHP %s", relPath, issue.String(), shopwareVersion, phpVersion))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+func TestGetMinPhpVersion(t *testing.T) {
+	cases := map[string]string{
+		"~6.4.0":             "7.4",
+		">=6.4.20 <6.6":      "7.4",
+		"~6.5.0":             "8.1",
+		"^6.5.5.0 || ~6.6.0": "8.1",
+		">=6.4.20.1":         "7.4",
+		">=6.4.31":           "8.1",
+	}
+
+	for constraintString, expected := range cases {
+		constraint, err := version.NewConstraint(constraintString)
+		assert.NoError(t, err)
+
+		phpVersion, _, found := getMinPhpVersion(&constraint)
+		assert.True(t, found, constraintString)
+		assert.Equal(t, expected, phpVersion, constraintString)
+	}
+
+	constraint, err := version.NewConstraint("~5.0")
+	assert.NoError(t, err)
+
+	_, _, found := getMinPhpVersion(&constraint)
+	assert.False(t, found)
+}
+
+func TestValidatePHPCompatibility(t *testing.T) {
+	dir := t.TempDir()
+
+	plugin := getTestPlugin(dir)
+	plugin.composer.Require["shopware/core"] = "~6.4.0"
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "vendor"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Status.php"), []byte("<?php\n\nenum Status {\n}\n"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "vendor", "Ignored.php"), []byte("<?php\n\nenum Ignored {\n}\n"), os.ModePerm))
+
+	ctx := NewValidationContext(&plugin)
+	validatePHPCompatibility(context.Background(), ctx)
+
+	assert.Equal(t, []string{"src/Status.php line 3: enumerations requires PHP 8.1, but Shopware 6.4.0.0 supports PHP 7.4"}, ctx.Errors())
+}
@@ -0,0 +1,153 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	PHPSyntaxModeLocal  = "local"
+	PHPSyntaxModeRemote = "remote"
+	PHPSyntaxModeSkip   = "skip"
+)
+
+var phpLintErrorLine = regexp.MustCompile(`^(?:PHP )?((?:Parse|Fatal) error:\s+.*?) in .* on line (\d+)$`)
+
+func validatePHPFiles(c context.Context, ctx *ValidationContext) {
+	syntaxConfig := ConfigValidationPHPSyntax{}
+	if cfg := ctx.Extension.GetExtensionConfig(); cfg != nil {
+		syntaxConfig = cfg.Validation.PHPSyntax
+	}
+
+	switch syntaxConfig.Mode {
+	case "", PHPSyntaxModeLocal:
+		phpBinary := syntaxConfig.PHPBinary
+		if phpBinary == "" {
+			phpBinary = "php"
+		}
+
+		if _, err := exec.LookPath(phpBinary); err != nil {
+			if syntaxConfig.RemoteFallback {
+				logging.FromContext(c).Infof("%s is not installed, falling back to the remote PHP syntax check", phpBinary)
+				validatePHPFilesRemote(c, ctx)
+
+				return
+			}
+
+			ctx.AddWarning(fmt.Sprintf("PHP syntax check skipped, %s is not installed. Install PHP or set validation.php_syntax.mode to remote", phpBinary))
+
+			return
+		}
+
+		validatePHPFilesLocal(c, ctx, phpBinary)
+	case PHPSyntaxModeRemote:
+		validatePHPFilesRemote(c, ctx)
+	case PHPSyntaxModeSkip:
+		return
+	default:
+		ctx.AddError(fmt.Sprintf("unknown validation.php_syntax.mode %s, use local, remote or skip", syntaxConfig.Mode))
+	}
+}
+
+// validatePHPFilesLocal lints all PHP files of the extension with php -l of the installed PHP.
+func validatePHPFilesLocal(c context.Context, ctx *ValidationContext, phpBinary string) {
+	files := make([]string, 0)
+
+	_ = filepath.WalkDir(ctx.Extension.GetPath(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && d.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".php") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	if phpVersion, err := process.Command(c, phpBinary, "-r", "echo PHP_VERSION;").Output(); err == nil {
+		logging.FromContext(c).Infof("Using local php %s for syntax check of %d files", strings.TrimSpace(string(phpVersion)), len(files))
+	}
+
+	type lintResult struct {
+		line    int
+		message string
+	}
+
+	// the results are added in the order of the files to keep the output stable
+	results := make([]*lintResult, len(files))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range queue {
+				output, err := process.Command(c, phpBinary, "-d", "display_errors=1", "-l", files[index]).CombinedOutput() //nolint:gosec
+				if err == nil {
+					continue
+				}
+
+				line, message := parsePHPLintOutput(string(output))
+				if message == "" {
+					message = fmt.Sprintf("php -l failed: %s", err.Error())
+				}
+
+				results[index] = &lintResult{line: line, message: message}
+			}
+		}()
+	}
+
+	for index := range files {
+		queue <- index
+	}
+
+	close(queue)
+	wg.Wait()
+
+	for index, result := range results {
+		if result == nil {
+			continue
+		}
+
+		relPath, _ := filepath.Rel(ctx.Extension.GetPath(), files[index])
+		relPath = filepath.ToSlash(relPath)
+
+		ctx.AddFileError(relPath, result.line, fmt.Sprintf("%s: %s", relPath, result.message))
+	}
+}
+
+// parsePHPLintOutput returns the line and message of the first error reported by php -l.
+func parsePHPLintOutput(output string) (int, string) {
+	for _, outputLine := range strings.Split(output, "\n") {
+		match := phpLintErrorLine.FindStringSubmatch(strings.TrimSpace(outputLine))
+		if match == nil {
+			continue
+		}
+
+		line, _ := strconv.Atoi(match[2])
+
+		return line, match[1]
+	}
+
+	return 0, ""
+}
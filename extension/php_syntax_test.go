@@ -0,0 +1,74 @@
+package extension
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePHPLintOutput(t *testing.T) {
+	line, message := parsePHPLintOutput("PHP Parse error:  syntax error, unexpected token \"}\" in /tmp/plugin/src/Plugin.php on line 12\nErrors parsing /tmp/plugin/src/Plugin.php\n")
+	assert.Equal(t, 12, line)
+	assert.Equal(t, "Parse error:  syntax error, unexpected token \"}\"", message)
+
+	line, message = parsePHPLintOutput("Fatal error: Cannot redeclare foo() in /tmp/a.php on line 3")
+	assert.Equal(t, 3, line)
+	assert.Equal(t, "Fatal error: Cannot redeclare foo()", message)
+
+	line, message = parsePHPLintOutput("Could not open input file: a.php")
+	assert.Equal(t, 0, line)
+	assert.Equal(t, "", message)
+}
+
+func TestValidatePHPFilesSkip(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.config = &Config{}
+	plugin.config.Validation.PHPSyntax.Mode = PHPSyntaxModeSkip
+
+	ctx := NewValidationContext(&plugin)
+	validatePHPFiles(getTestContext(), ctx)
+
+	assert.Empty(t, ctx.Issues())
+
+	plugin.config.Validation.PHPSyntax.Mode = "lambda"
+	validatePHPFiles(getTestContext(), ctx)
+
+	assert.Equal(t, []string{"unknown validation.php_syntax.mode lambda, use local, remote or skip"}, ctx.Errors())
+}
+
+func TestValidatePHPFilesLocalMissingBinary(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.config = &Config{}
+	plugin.config.Validation.PHPSyntax.PHPBinary = "php-does-not-exist"
+
+	ctx := NewValidationContext(&plugin)
+	validatePHPFiles(getTestContext(), ctx)
+
+	assert.Empty(t, ctx.Errors())
+	assert.Equal(t, []string{"PHP syntax check skipped, php-does-not-exist is not installed. Install PHP or set validation.php_syntax.mode to remote"}, ctx.Warnings())
+}
+
+func TestValidatePHPFilesLocal(t *testing.T) {
+	if _, err := exec.LookPath("php"); err != nil {
+		t.Skip("php is not installed")
+	}
+
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Valid.php"), []byte("<?php\necho 'valid';\n"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Invalid.php"), []byte("<?php\n\nfunction foo() {\n"), os.ModePerm))
+
+	ctx := NewValidationContext(&plugin)
+	validatePHPFiles(getTestContext(), ctx)
+
+	issues := ctx.Issues()
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "src/Invalid.php", issues[0].File)
+}
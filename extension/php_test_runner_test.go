@@ -0,0 +1,59 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPHPUnitBootstrap(t *testing.T) {
+	bootstrap := renderPHPUnitBootstrap("/var/www/html", "/var/www/html/custom/plugins/FroshTools", "FroshTools", true)
+
+	assert.Contains(t, bootstrap, "require "+phpString(filepath.Join("/var/www/html", "vendor", "autoload.php"))+";")
+	assert.Contains(t, bootstrap, "->setProjectDir('/var/www/html')")
+	assert.Contains(t, bootstrap, "->setForceInstall(true)")
+	assert.Contains(t, bootstrap, "->addCallingPlugin("+phpString(filepath.Join("/var/www/html/custom/plugins/FroshTools", "composer.json"))+")")
+	assert.Contains(t, bootstrap, "->addActivePlugins('FroshTools')")
+}
+
+func TestRenderPHPUnitConfig(t *testing.T) {
+	config := renderPHPUnitConfig("/tmp/a&b/bootstrap.php", "/plugin/tests")
+
+	assert.Contains(t, config, `bootstrap="/tmp/a&amp;b/bootstrap.php"`)
+	assert.Contains(t, config, "<directory>/plugin/tests</directory>")
+}
+
+func TestPHPString(t *testing.T) {
+	assert.Equal(t, `'C:\\Users\\it\'s'`, phpString(`C:\Users\it's`))
+}
+
+func TestLinkPluginIntoProject(t *testing.T) {
+	project := t.TempDir()
+	plugin := t.TempDir()
+
+	inside := filepath.Join(project, "custom", "plugins", "FroshTools")
+	assert.NoError(t, os.MkdirAll(inside, os.ModePerm))
+
+	path, err := linkPluginIntoProject(context.Background(), project, "FroshTools", inside)
+	assert.NoError(t, err)
+	assert.Equal(t, inside, path)
+
+	_, err = linkPluginIntoProject(context.Background(), project, "FroshTools", plugin)
+	assert.ErrorContains(t, err, "contains already a plugin")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires additional privileges on Windows")
+	}
+
+	path, err = linkPluginIntoProject(context.Background(), project, "FroshAdminer", plugin)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(project, "custom", "plugins", "FroshAdminer"), path)
+
+	target, err := os.Readlink(path)
+	assert.NoError(t, err)
+	assert.Equal(t, plugin, target)
+}
@@ -13,10 +13,10 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/version"
 )
@@ -29,12 +29,12 @@ type PlatformPlugin struct {
 
 // GetRootDir returns the src directory of the plugin.
 func (p PlatformPlugin) GetRootDir() string {
-	return path.Join(p.path, "src")
+	return filepath.Join(p.path, "src")
 }
 
 // GetResourcesDir returns the resources directory of the plugin.
 func (p PlatformPlugin) GetResourcesDir() string {
-	return path.Join(p.GetRootDir(), "Resources")
+	return filepath.Join(p.GetRootDir(), "Resources")
 }
 
 func newPlatformPlugin(path string) (*PlatformPlugin, error) {
@@ -172,6 +172,15 @@ func (p PlatformPlugin) GetMetaData() *extensionMetadata {
 	}
 }
 
+// GetIconPath returns the path of the plugin icon relative to the plugin root.
+func (p PlatformPlugin) GetIconPath() string {
+	if p.composer.Extra.PluginIcon != "" {
+		return p.composer.Extra.PluginIcon
+	}
+
+	return "src/Resources/config/plugin.png"
+}
+
 func (p PlatformPlugin) Validate(c context.Context, ctx *ValidationContext) {
 	if p.composer.Name == "" {
 		ctx.AddError("Key `name` is required")
@@ -207,6 +216,8 @@ func (p PlatformPlugin) Validate(c context.Context, ctx *ValidationContext) {
 		if !exists {
 			ctx.AddError("You need to require \"shopware/core\" package")
 		}
+
+		validateComposerRequirements(ctx, p.composer.Require)
 	}
 
 	requiredKeys := []string{"de-DE", "en-GB"}
@@ -238,11 +249,7 @@ func (p PlatformPlugin) Validate(c context.Context, ctx *ValidationContext) {
 		ctx.AddError("At least one of the properties psr-0 or psr-4 are required in the composer.json")
 	}
 
-	pluginIcon := p.composer.Extra.PluginIcon
-
-	if pluginIcon == "" {
-		pluginIcon = "src/Resources/config/plugin.png"
-	}
+	pluginIcon := p.GetIconPath()
 
 	// check if the plugin icon exists
 	if _, err := os.Stat(filepath.Join(p.GetPath(), pluginIcon)); os.IsNotExist(err) {
@@ -250,14 +257,18 @@ func (p PlatformPlugin) Validate(c context.Context, ctx *ValidationContext) {
 	}
 
 	validateTheme(ctx)
+	validatePHPCompatibility(c, ctx)
 	validatePHPFiles(c, ctx)
+	validateAdminOverrides(c, ctx)
+	validateESLint(c, ctx)
 }
 
 type phpSyntaxCheckerResult struct {
 	Errors []string `json:"errors"`
 }
 
-func validatePHPFiles(c context.Context, ctx *ValidationContext) {
+// validatePHPFilesRemote uploads all PHP files to the syntax checker lambda, which lints them with the min PHP version of the extension.
+func validatePHPFilesRemote(c context.Context, ctx *ValidationContext) {
 	var b bytes.Buffer
 	bufferW := bufio.NewWriter(&b)
 
@@ -364,23 +375,7 @@ func validatePHPFiles(c context.Context, ctx *ValidationContext) {
 }
 
 func getPhpVersion(ctx context.Context, constraint *version.Constraints) (string, error) {
-	r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://raw.githubusercontent.com/FriendsOfShopware/shopware-static-data/main/data/php-version.json", http.NoBody)
-
-	resp, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return "", err
-	}
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logging.FromContext(ctx).Errorf("getPhpVersion: %v", err)
-		}
-	}()
-
-	var shopwareToPHPVersion map[string]string
-
-	err = json.NewDecoder(resp.Body).Decode(&shopwareToPHPVersion)
-
+	shopwareToPHPVersion, err := staticdata.PHPVersions(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path"
 	"path/filepath"
 
 	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
@@ -14,7 +13,7 @@ import (
 )
 
 func GetShopwareProjectConstraint(project string) (*version.Constraints, error) {
-	composerJson, err := os.ReadFile(path.Join(project, "composer.json"))
+	composerJson, err := os.ReadFile(filepath.Join(project, "composer.json"))
 	if err != nil {
 		return nil, err
 	}
@@ -40,11 +39,27 @@ func GetShopwareProjectConstraint(project string) (*version.Constraints, error)
 	return &c, nil
 }
 
+// FindAssetSourcesOfProject returns the extensions and bundles of the project. The result is cached until a
+// composer file or an extension in custom/plugins or custom/apps changes.
 func FindAssetSourcesOfProject(ctx context.Context, project string) []asset.Source {
+	if sources, ok := readProjectSourceCache(project); ok {
+		logging.FromContext(ctx).Infof("Using cached list of %d extensions and bundles, pass --refresh to scan the project again", len(sources))
+
+		return sources
+	}
+
 	extensions := FindExtensionsFromProject(ctx, project)
+	sources := findAssetSourcesOfProject(ctx, project, extensions)
+
+	writeProjectSourceCache(ctx, project, extensions, sources)
+
+	return sources
+}
+
+func findAssetSourcesOfProject(ctx context.Context, project string, extensions []Extension) []asset.Source {
 	sources := ConvertExtensionsToSources(ctx, extensions)
 
-	composerJson, err := os.ReadFile(path.Join(project, "composer.json"))
+	composerJson, err := os.ReadFile(filepath.Join(project, "composer.json"))
 	if err != nil {
 		logging.FromContext(ctx).Errorf("Cannot read composer.json: %s", err.Error())
 	}
@@ -68,7 +83,7 @@ func FindAssetSourcesOfProject(ctx context.Context, project string) []asset.Sour
 
 		sources = append(sources, asset.Source{
 			Name: name,
-			Path: path.Join(project, bundlePath),
+			Path: filepath.Join(project, bundlePath),
 		})
 	}
 
@@ -91,7 +106,7 @@ func FindExtensionsFromProject(ctx context.Context, project string) []Extension
 		extensions[name] = ext
 	}
 
-	for _, ext := range addExtensionsByWildcard(path.Join(project, "custom", "plugins")) {
+	for _, ext := range addExtensionsByWildcard(filepath.Join(project, "custom", "plugins")) {
 		name, err := ext.GetName()
 		if err != nil {
 			continue
@@ -110,7 +125,7 @@ func FindExtensionsFromProject(ctx context.Context, project string) []Extension
 		extensions[name] = ext
 	}
 
-	for _, ext := range addExtensionsByWildcard(path.Join(project, "custom", "apps")) {
+	for _, ext := range addExtensionsByWildcard(filepath.Join(project, "custom", "apps")) {
 		name, err := ext.GetName()
 		if err != nil {
 			continue
@@ -134,7 +149,7 @@ func FindExtensionsFromProject(ctx context.Context, project string) []Extension
 func addExtensionsByComposer(project string) []Extension {
 	var list []Extension
 
-	lock, err := os.ReadFile(path.Join(project, "composer.lock"))
+	lock, err := os.ReadFile(filepath.Join(project, "composer.lock"))
 	if err != nil {
 		return list
 	}
@@ -146,19 +161,21 @@ func addExtensionsByComposer(project string) []Extension {
 
 	for _, pkg := range composer.Packages {
 		if pkg.PackageType == ComposerTypePlugin || pkg.PackageType == ComposerTypeBundle || pkg.PackageType == ComposerTypeApp {
-			ext, err := GetExtensionByFolder(path.Join(project, "vendor", pkg.Name))
+			ext, err := GetExtensionByFolder(filepath.Join(project, "vendor", pkg.Name))
 			if err != nil {
 				continue
 			}
 
 			// The extension in the vendor folder has maybe not filled the version in this composer.json. Let's overwrite it with the version from composer.lock
-			switch pkg.PackageType {
-			case ComposerTypePlugin:
-				ext.(*PlatformPlugin).composer.Version = pkg.Version
-			case ComposerTypeApp:
-				ext.(*App).manifest.Meta.Version = pkg.Version
-			case ComposerTypeBundle:
-				ext.(*ShopwareBundle).composer.Version = pkg.Version
+			switch typedExt := ext.(type) {
+			case *PlatformPlugin:
+				typedExt.composer.Version = pkg.Version
+			case *PlatformTheme:
+				typedExt.composer.Version = pkg.Version
+			case *App:
+				typedExt.manifest.Meta.Version = pkg.Version
+			case *ShopwareBundle:
+				typedExt.composer.Version = pkg.Version
 			}
 
 			list = append(list, ext)
@@ -178,7 +195,7 @@ func addExtensionsByWildcard(extensionDir string) []Extension {
 
 	for _, file := range extensions {
 		if file.IsDir() {
-			ext, err := GetExtensionByFolder(path.Join(extensionDir, file.Name()))
+			ext, err := GetExtensionByFolder(filepath.Join(extensionDir, file.Name()))
 			if err != nil {
 				continue
 			}
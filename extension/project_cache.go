@@ -0,0 +1,147 @@
+package extension
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/asset"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+// projectCacheVersion is increased when the format of the cached data changes.
+const projectCacheVersion = 1
+
+// refreshProjectCache ignores the cached sources and scans the project again.
+var refreshProjectCache = false
+
+// projectExtensionFiles define an extension, changes on them invalidate the cache.
+var projectExtensionFiles = []string{"composer.json", "manifest.xml", ".shopware-extension.yml"}
+
+type projectSourceCache struct {
+	Version int `json:"version"`
+	// Files maps the tracked files and folders to their modification time in nanoseconds, missing files are 0
+	Files   map[string]int64 `json:"files"`
+	Sources []asset.Source   `json:"sources"`
+}
+
+// SetRefreshProjectCache forces FindAssetSourcesOfProject to scan the project instead of using the cache.
+func SetRefreshProjectCache(refresh bool) {
+	refreshProjectCache = refresh
+}
+
+func getProjectCacheFile(project string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(project))
+
+	return filepath.Join(cacheDir, "shopware-cli", "project-sources", hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// readProjectSourceCache returns the cached sources when none of the tracked files have been modified.
+func readProjectSourceCache(project string) ([]asset.Source, bool) {
+	if refreshProjectCache || os.Getenv("SHOPWARE_CLI_DISABLE_PROJECT_CACHE") == "1" {
+		return nil, false
+	}
+
+	cacheFile, err := getProjectCacheFile(project)
+	if err != nil {
+		return nil, false
+	}
+
+	content, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return nil, false
+	}
+
+	var cache projectSourceCache
+	if err := json.Unmarshal(content, &cache); err != nil || cache.Version != projectCacheVersion {
+		return nil, false
+	}
+
+	for file, modTime := range cache.Files {
+		if getModTime(file) != modTime {
+			return nil, false
+		}
+	}
+
+	return cache.Sources, true
+}
+
+func writeProjectSourceCache(ctx context.Context, project string, extensions []Extension, sources []asset.Source) {
+	cacheFile, err := getProjectCacheFile(project)
+	if err != nil {
+		return
+	}
+
+	cache := projectSourceCache{Version: projectCacheVersion, Files: getProjectCacheFiles(project, extensions), Sources: sources}
+
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o700); err != nil {
+		logging.FromContext(ctx).Debugf("Cannot create cache directory: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(cacheFile, content, 0o600); err != nil {
+		logging.FromContext(ctx).Debugf("Cannot write project cache: %v", err)
+	}
+}
+
+// getProjectCacheFiles collects the files which decide which extensions are found. Adding or removing a folder in
+// custom/plugins or custom/apps changes the modification time of the parent folder, composer changes the composer.lock and
+// rewrites vendor/composer/installed.json on every install or update.
+func getProjectCacheFiles(project string, extensions []Extension) map[string]int64 {
+	files := make(map[string]int64)
+
+	track := func(file string) {
+		files[file] = getModTime(file)
+	}
+
+	track(filepath.Join(project, "composer.json"))
+	track(filepath.Join(project, "composer.lock"))
+	track(filepath.Join(project, "vendor", "composer", "installed.json"))
+
+	for _, folder := range []string{filepath.Join(project, "custom", "plugins"), filepath.Join(project, "custom", "apps")} {
+		track(folder)
+
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			track(filepath.Join(folder, entry.Name()))
+
+			for _, file := range projectExtensionFiles {
+				track(filepath.Join(folder, entry.Name(), file))
+			}
+		}
+	}
+
+	for _, ext := range extensions {
+		for _, file := range projectExtensionFiles {
+			track(filepath.Join(ext.GetPath(), file))
+		}
+	}
+
+	return files
+}
+
+func getModTime(file string) int64 {
+	stat, err := os.Stat(file)
+	if err != nil {
+		return 0
+	}
+
+	return stat.ModTime().UnixNano()
+}
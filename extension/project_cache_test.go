@@ -0,0 +1,71 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProjectTestPlugin(t *testing.T, project, name string) {
+	t.Helper()
+
+	writeVersionTestFiles(t, project, map[string]string{
+		filepath.Join("custom", "plugins", name, "composer.json"): `{"name": "frosh/` + name + `", "type": "shopware-platform-plugin", "extra": {"shopware-plugin-class": "Frosh\\` + name + `\\` + name + `"}}`,
+	})
+}
+
+func TestFindAssetSourcesOfProjectCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	project := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(project, "composer.json"), []byte(`{"require": {"shopware/core": "~6.5.0"}}`), os.ModePerm))
+	writeProjectTestPlugin(t, project, "FroshTools")
+
+	sources := FindAssetSourcesOfProject(getTestContext(), project)
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "FroshTools", sources[0].Name)
+
+	cached, ok := readProjectSourceCache(project)
+	assert.True(t, ok)
+	assert.Equal(t, sources, cached)
+
+	// a new plugin folder changes the modification time of custom/plugins
+	writeProjectTestPlugin(t, project, "FroshDevelopmentHelper")
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(filepath.Join(project, "custom", "plugins"), future, future))
+
+	_, ok = readProjectSourceCache(project)
+	assert.False(t, ok)
+
+	sources = FindAssetSourcesOfProject(getTestContext(), project)
+	assert.Len(t, sources, 2)
+
+	// changing the composer.json of an extension invalidates the cache as well
+	future = future.Add(time.Minute)
+	assert.NoError(t, os.Chtimes(filepath.Join(project, "custom", "plugins", "FroshTools", "composer.json"), future, future))
+
+	_, ok = readProjectSourceCache(project)
+	assert.False(t, ok)
+
+	FindAssetSourcesOfProject(getTestContext(), project)
+
+	// a composer install writes the installed packages
+	writeVersionTestFiles(t, project, map[string]string{
+		filepath.Join("vendor", "composer", "installed.json"): `{"packages": []}`,
+	})
+
+	_, ok = readProjectSourceCache(project)
+	assert.False(t, ok)
+
+	FindAssetSourcesOfProject(getTestContext(), project)
+
+	SetRefreshProjectCache(true)
+	defer SetRefreshProjectCache(false)
+
+	_, ok = readProjectSourceCache(project)
+	assert.False(t, ok)
+}
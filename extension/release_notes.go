@@ -0,0 +1,233 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// ReleaseNote contains the changelog versions of an extension, which were released between two states of a project.
+type ReleaseNote struct {
+	Name string `json:"name"`
+	// FromVersion is empty, when the extension was added to the project
+	FromVersion string             `json:"fromVersion"`
+	ToVersion   string             `json:"toVersion"`
+	Versions    []ChangelogVersion `json:"versions"`
+}
+
+type releaseNotesComposerJson struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// CollectReleaseNotes collects the changelogs of all extensions of the project, which have been updated since the git ref
+// or date (YYYY-MM-DD). The previous version is read from the extension itself or the composer.lock at that state.
+func CollectReleaseNotes(ctx context.Context, projectRoot, since string) ([]ReleaseNote, error) {
+	ref, err := resolveReleaseNotesRef(ctx, projectRoot, since)
+	if err != nil {
+		return nil, err
+	}
+
+	lockVersions := make(map[string]string)
+
+	if content, err := gitShowFile(ctx, projectRoot, ref, "composer.lock"); err == nil {
+		var lock struct {
+			Packages []releaseNotesComposerJson `json:"packages"`
+		}
+
+		if err := json.Unmarshal(content, &lock); err != nil {
+			return nil, fmt.Errorf("cannot parse the composer.lock of %s: %w", since, err)
+		}
+
+		for _, pkg := range lock.Packages {
+			lockVersions[pkg.Name] = strings.TrimPrefix(pkg.Version, "v")
+		}
+	}
+
+	notes := make([]ReleaseNote, 0)
+
+	for _, ext := range FindExtensionsFromProject(ctx, projectRoot) {
+		name, err := ext.GetName()
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := ext.GetVersion()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the version of %s: %w", name, err)
+		}
+
+		note := ReleaseNote{Name: name, ToVersion: current.String()}
+		note.FromVersion = extensionVersionAtRef(ctx, projectRoot, ref, ext, lockVersions)
+
+		var previous *version.Version
+
+		if note.FromVersion != "" {
+			if previous, err = version.NewVersion(note.FromVersion); err != nil {
+				logging.FromContext(ctx).Warnf("Ignoring the invalid previous version %s of %s", note.FromVersion, name)
+				previous = nil
+			} else if !current.GreaterThan(previous) {
+				continue
+			}
+		}
+
+		changelog, err := ParseExtensionChangelog(ext)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the changelog of %s: %w", name, err)
+		}
+
+		note.Versions = changelog.versionsBetween(previous, current)
+		notes = append(notes, note)
+	}
+
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].Name < notes[j].Name
+	})
+
+	return notes, nil
+}
+
+// versionsBetween returns the versions newer than from and up to to, all versions up to to without from.
+func (c Changelog) versionsBetween(from, to *version.Version) []ChangelogVersion {
+	versions := make([]ChangelogVersion, 0)
+
+	for _, changelogVersion := range c.Versions {
+		v, err := version.NewVersion(changelogVersion.Version)
+		if err != nil {
+			continue
+		}
+
+		if v.LessThanOrEqual(to) && (from == nil || v.GreaterThan(from)) {
+			versions = append(versions, changelogVersion)
+		}
+	}
+
+	return versions
+}
+
+// RenderReleaseNotes renders the release notes as markdown document of the language, missing translations fall back to english.
+func RenderReleaseNotes(notes []ReleaseNote, language string) string {
+	var buf strings.Builder
+
+	buf.WriteString("# Release notes\n")
+
+	for _, note := range notes {
+		if note.FromVersion == "" {
+			fmt.Fprintf(&buf, "\n## %s %s (new)\n", note.Name, note.ToVersion)
+		} else {
+			fmt.Fprintf(&buf, "\n## %s %s → %s\n", note.Name, note.FromVersion, note.ToVersion)
+		}
+
+		if len(note.Versions) == 0 {
+			buf.WriteString("\nNo changelog available.\n")
+			continue
+		}
+
+		for _, v := range note.Versions {
+			fmt.Fprintf(&buf, "\n### %s\n\n%s\n", v.Version, v.Markdown(language))
+		}
+	}
+
+	return buf.String()
+}
+
+// ReleaseNotesLanguages returns all languages of the changelogs in the release notes.
+func ReleaseNotesLanguages(notes []ReleaseNote) []string {
+	unique := map[string]bool{"en-GB": true}
+
+	for _, note := range notes {
+		for _, v := range note.Versions {
+			for language := range v.Entries {
+				unique[language] = true
+			}
+		}
+	}
+
+	languages := make([]string, 0, len(unique))
+	for language := range unique {
+		languages = append(languages, language)
+	}
+
+	sort.Strings(languages)
+
+	return languages
+}
+
+func resolveReleaseNotesRef(ctx context.Context, projectRoot, since string) (string, error) {
+	if _, err := time.Parse("2006-01-02", since); err == nil {
+		ref, err := gitOutput(ctx, projectRoot, "rev-list", "-1", "--before="+since, "HEAD")
+		if err != nil {
+			return "", err
+		}
+
+		if ref == "" {
+			return "", fmt.Errorf("there is no commit before %s", since)
+		}
+
+		return ref, nil
+	}
+
+	ref, err := gitOutput(ctx, projectRoot, "rev-parse", "--verify", since+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve git ref %s: %w", since, err)
+	}
+
+	return ref, nil
+}
+
+// extensionVersionAtRef returns the version of the extension at the ref or an empty string, when it did not exist.
+func extensionVersionAtRef(ctx context.Context, projectRoot, ref string, ext Extension, lockVersions map[string]string) string {
+	relPath, err := filepath.Rel(projectRoot, ext.GetPath())
+	if err != nil {
+		return ""
+	}
+
+	if ext.GetType() == TypePlatformApp {
+		var manifest appManifest
+
+		if content, err := gitShowFile(ctx, projectRoot, ref, filepath.Join(relPath, "manifest.xml")); err == nil && xml.Unmarshal(content, &manifest) == nil {
+			return manifest.Meta.Version
+		}
+	}
+
+	var composer releaseNotesComposerJson
+
+	if content, err := gitShowFile(ctx, projectRoot, ref, filepath.Join(relPath, "composer.json")); err == nil && json.Unmarshal(content, &composer) == nil && composer.Version != "" {
+		return strings.TrimPrefix(composer.Version, "v")
+	}
+
+	// extensions installed by composer are not committed, their version is in the composer.lock
+	content, err := os.ReadFile(filepath.Join(ext.GetPath(), "composer.json"))
+	if err != nil || json.Unmarshal(content, &composer) != nil {
+		return ""
+	}
+
+	return lockVersions[composer.Name]
+}
+
+func gitShowFile(ctx context.Context, projectRoot, ref, file string) ([]byte, error) {
+	cmd := process.Command(ctx, "git", "-C", projectRoot, "show", ref+":./"+filepath.ToSlash(file))
+
+	return cmd.Output()
+}
+
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := process.Command(ctx, "git", append([]string{"-C", dir}, args...)...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
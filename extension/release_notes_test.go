@@ -0,0 +1,81 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeReleaseNotesPlugin(t *testing.T, pluginDir, pluginVersion, changelog string) {
+	t.Helper()
+
+	composer := `{"name": "frosh/tools", "type": "shopware-platform-plugin", "version": "` + pluginVersion + `", "license": "MIT",
+		"require": {"shopware/core": "~6.5.0"}, "autoload": {"psr-4": {"FroshTools\\": "src/"}},
+		"extra": {"shopware-plugin-class": "FroshTools\\FroshTools", "label": {"en-GB": "Frosh Tools"}}}`
+
+	assert.NoError(t, os.MkdirAll(pluginDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "composer.json"), []byte(composer), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "CHANGELOG_en-GB.md"), []byte(changelog), os.ModePerm))
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
+
+func TestCollectReleaseNotes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	projectRoot := t.TempDir()
+	pluginDir := filepath.Join(projectRoot, "custom", "plugins", "FroshTools")
+
+	writeReleaseNotesPlugin(t, pluginDir, "1.0.0", "# 1.0.0\n- Initial release\n")
+	runTestGit(t, projectRoot, "init", "-q")
+	runTestGit(t, projectRoot, "add", "-A")
+	runTestGit(t, projectRoot, "commit", "-q", "-m", "initial")
+	runTestGit(t, projectRoot, "tag", "release-1")
+
+	writeReleaseNotesPlugin(t, pluginDir, "1.2.0", "# 1.2.0\n- Feature B\n\n# 1.1.0\n- Feature A\n\n# 1.0.0\n- Initial release\n")
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "CHANGELOG_de-DE.md"), []byte("# 1.2.0\n- Funktion B\n"), os.ModePerm))
+	runTestGit(t, projectRoot, "add", "-A")
+	runTestGit(t, projectRoot, "commit", "-q", "-m", "update")
+
+	notes, err := CollectReleaseNotes(context.Background(), projectRoot, "release-1")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, "FroshTools", notes[0].Name)
+	assert.Equal(t, "1.0.0", notes[0].FromVersion)
+	assert.Equal(t, "1.2.0", notes[0].ToVersion)
+	assert.Len(t, notes[0].Versions, 2)
+	assert.Equal(t, []string{"de-DE", "en-GB"}, ReleaseNotesLanguages(notes))
+
+	german := RenderReleaseNotes(notes, "de-DE")
+	assert.Contains(t, german, "## FroshTools 1.0.0 → 1.2.0")
+	assert.Contains(t, german, "### 1.2.0\n\n- Funktion B")
+	assert.Contains(t, german, "### 1.1.0\n\n- Feature A")
+	assert.NotContains(t, german, "Initial release")
+
+	notes, err = CollectReleaseNotes(context.Background(), projectRoot, "HEAD")
+	assert.NoError(t, err)
+	assert.Empty(t, notes)
+
+	_, err = CollectReleaseNotes(context.Background(), projectRoot, "unknown-tag")
+	assert.ErrorContains(t, err, "cannot resolve git ref unknown-tag")
+}
+
+func TestReleaseNotesOfNewExtension(t *testing.T) {
+	notes := []ReleaseNote{{Name: "FroshTools", ToVersion: "1.0.0"}}
+
+	assert.Contains(t, RenderReleaseNotes(notes, "en-GB"), "## FroshTools 1.0.0 (new)\n\nNo changelog available.\n")
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/FriendsOfShopware/shopware-cli/version"
@@ -34,14 +35,19 @@ func GetExtensionByFolder(path string) (Extension, error) {
 		return nil, fmt.Errorf("unknown extension type")
 	}
 
-	var ext Extension
-
-	ext, err := newPlatformPlugin(path)
+	plugin, err := newPlatformPlugin(path)
 	if err != nil {
-		ext, err = newShopwareBundle(path)
+		return newShopwareBundle(path)
+	}
+
+	if _, err := os.Stat(filepath.Join(plugin.GetResourcesDir(), "theme.json")); err == nil {
+		// an invalid theme.json is reported by the validation of the plugin
+		if theme, err := newPlatformTheme(plugin); err == nil {
+			return theme, nil
+		}
 	}
 
-	return ext, err
+	return plugin, nil
 }
 
 func GetExtensionByZip(filePath string) (Extension, error) {
@@ -73,7 +79,7 @@ func GetExtensionByZip(filePath string) (Extension, error) {
 	}
 
 	extName := strings.Split(fileName, "/")[0]
-	return GetExtensionByFolder(fmt.Sprintf("%s/%s", dir, extName))
+	return GetExtensionByFolder(filepath.Join(dir, extName))
 }
 
 type extensionTranslated struct {
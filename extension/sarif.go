@@ -0,0 +1,107 @@
+package extension
+
+import (
+	"encoding/json"
+)
+
+const sarifRuleID = "shopware-cli.extension.validate"
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// RenderSarif converts the validation result into SARIF 2.1.0, f.e. for GitHub code scanning. The file paths are relative
+// to the extension root and issues without file are reported on the composer.json or manifest.xml of the extension.
+func RenderSarif(ctx *ValidationContext) ([]byte, error) {
+	defaultFile := "composer.json"
+	if ctx.Extension.GetType() == TypePlatformApp {
+		defaultFile = "manifest.xml"
+	}
+
+	results := make([]sarifResult, 0, len(ctx.Issues()))
+
+	for _, issue := range ctx.Issues() {
+		file := issue.File
+		if file == "" {
+			file = defaultFile
+		}
+
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: file}}
+
+		if issue.Line > 0 {
+			location.Region = &sarifRegion{StartLine: issue.Line}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    sarifRuleID,
+			Level:     issue.Severity,
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	report := sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "shopware-cli",
+				InformationURI: "https://sw-cli.fos.gg",
+				Rules:          []sarifRule{{ID: sarifRuleID, ShortDescription: sarifMessage{Text: "Shopware extension validation"}}},
+			}},
+			Results: results,
+		}},
+	}
+
+	return json.MarshalIndent(report, "", "  ")
+}
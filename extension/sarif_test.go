@@ -0,0 +1,47 @@
+package extension
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSarif(t *testing.T) {
+	plugin := getTestPlugin("/ext")
+
+	ctx := NewValidationContext(plugin)
+	ctx.AddError("label is not translated in german")
+	ctx.AddFileError("src/Foo.php", 12, "src/Foo.php line 12: nullsafe operator requires PHP 8.0")
+	ctx.AddFileWarning("src/Foo.php", 0, "print_r is used")
+
+	content, err := RenderSarif(ctx)
+	assert.NoError(t, err)
+
+	var report sarifReport
+	assert.NoError(t, json.Unmarshal(content, &report))
+
+	assert.Equal(t, "2.1.0", report.Version)
+	assert.Len(t, report.Runs, 1)
+	assert.Equal(t, "shopware-cli", report.Runs[0].Tool.Driver.Name)
+
+	results := report.Runs[0].Results
+	assert.Len(t, results, 3)
+
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, "composer.json", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Nil(t, results[0].Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, "src/Foo.php", results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, results[1].Locations[0].PhysicalLocation.Region.StartLine)
+
+	assert.Equal(t, "warning", results[2].Level)
+	assert.Equal(t, "print_r is used", results[2].Message.Text)
+	assert.Nil(t, results[2].Locations[0].PhysicalLocation.Region)
+}
+
+func TestRenderSarifEmpty(t *testing.T) {
+	content, err := RenderSarif(NewValidationContext(getTestPlugin("/ext")))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"results": []`)
+}
@@ -0,0 +1,384 @@
+package extension
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"regexp"
+	"strings"
+)
+
+const (
+	ScaffoldTypePlugin = "plugin"
+	ScaffoldTypeApp    = "app"
+	ScaffoldTypeTheme  = "theme"
+
+	scaffoldVersion = "1.0.0"
+)
+
+var (
+	scaffoldNameRegex      = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	scaffoldNamespaceRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\\[A-Za-z_][A-Za-z0-9_]*)*$`)
+	scaffoldComposerRegex  = regexp.MustCompile(`^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]?|-{0,2})[a-z0-9]+)*$`)
+	scaffoldWordRegex      = regexp.MustCompile(`[A-Z][a-z0-9]*|[a-z0-9]+`)
+
+	// scaffoldIconColor is the blue of the Shopware administration
+	scaffoldIconColor = color.NRGBA{R: 0x18, G: 0x9e, B: 0xff, A: 0xff}
+)
+
+// The store requires descriptions with 150 to 185 characters, the placeholders have a valid length until they are replaced.
+const (
+	scaffoldDescriptionEnglish = "Replace this placeholder with a short description of the extension. It is shown in the store and in the administration and needs 150 to 185 characters."
+	scaffoldDescriptionGerman  = "Ersetze diesen Platzhalter durch eine kurze Beschreibung der Erweiterung. Sie wird im Store und in der Administration angezeigt und braucht 150 bis 185 Zeichen."
+)
+
+// ScaffoldOptions describes the extension created by extension create, empty values are filled by WithDefaults.
+type ScaffoldOptions struct {
+	Type string
+	// Name is the technical name like SwagExample, it is the name of the plugin class or app
+	Name string
+	// Namespace is the PHP namespace of plugins and themes
+	Namespace        string
+	ComposerPackage  string
+	ShopwareVersion  string
+	Label            string
+	Description      string
+	Author           string
+	License          string
+	ManufacturerLink string
+	SupportLink      string
+}
+
+// WithDefaults derives the missing options from the name, f.e. SwagExample gets the composer package swag/example and the
+// label Swag Example.
+func (o ScaffoldOptions) WithDefaults() ScaffoldOptions {
+	words := scaffoldWordRegex.FindAllString(o.Name, -1)
+
+	if o.Type == "" {
+		o.Type = ScaffoldTypePlugin
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = o.Name
+	}
+
+	if o.ComposerPackage == "" && len(words) > 0 {
+		vendor := strings.ToLower(words[0])
+		name := vendor
+
+		if len(words) > 1 {
+			name = strings.ToLower(strings.Join(words[1:], "-"))
+		}
+
+		o.ComposerPackage = vendor + "/" + name
+	}
+
+	if o.ShopwareVersion == "" {
+		o.ShopwareVersion = "~6.6.0"
+	}
+
+	if o.Label == "" {
+		o.Label = strings.Join(words, " ")
+	}
+
+	if o.Author == "" && len(words) > 0 {
+		o.Author = words[0]
+	}
+
+	if o.License == "" {
+		o.License = "MIT"
+	}
+
+	return o
+}
+
+func (o ScaffoldOptions) validate() error {
+	switch o.Type {
+	case ScaffoldTypePlugin, ScaffoldTypeApp, ScaffoldTypeTheme:
+	default:
+		return fmt.Errorf("unsupported extension type %s, use plugin, app or theme", o.Type)
+	}
+
+	if !scaffoldNameRegex.MatchString(o.Name) {
+		return fmt.Errorf("the name %q must start with an uppercase letter and contain only letters and digits, like SwagExample", o.Name)
+	}
+
+	if o.Type != ScaffoldTypeApp {
+		if !scaffoldNamespaceRegex.MatchString(o.Namespace) {
+			return fmt.Errorf("%q is not a valid PHP namespace", o.Namespace)
+		}
+
+		if !scaffoldComposerRegex.MatchString(o.ComposerPackage) {
+			return fmt.Errorf("%q is not a valid composer package", o.ComposerPackage)
+		}
+	}
+
+	return ValidateScaffoldDescription(o.Description)
+}
+
+// ValidateScaffoldDescription allows an empty description, which is replaced with a placeholder.
+func ValidateScaffoldDescription(description string) error {
+	if description != "" && (len(description) < 150 || len(description) > 185) {
+		return fmt.Errorf("the description has %d characters, the store requires 150 to 185 characters", len(description))
+	}
+
+	return nil
+}
+
+// descriptions returns the german and english description, a given description is used for both languages.
+func (o ScaffoldOptions) descriptions() (string, string) {
+	if o.Description != "" {
+		return o.Description, o.Description
+	}
+
+	return scaffoldDescriptionGerman, scaffoldDescriptionEnglish
+}
+
+// ScaffoldFiles returns the files of a new extension by their path relative to the extension root. The extension passes
+// extension validate without changes.
+func ScaffoldFiles(opts ScaffoldOptions) (map[string][]byte, error) {
+	opts = opts.WithDefaults()
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	icon, err := scaffoldIcon(pluginIconSize, pluginIconSize)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		"CHANGELOG.md":            []byte(fmt.Sprintf("# %s\n\n* Initial release\n", scaffoldVersion)),
+		"CHANGELOG_de-DE.md":      []byte(fmt.Sprintf("# %s\n\n* Erste Veröffentlichung\n", scaffoldVersion)),
+		".shopware-extension.yml": []byte(scaffoldExtensionConfig(opts)),
+	}
+
+	if opts.Type == ScaffoldTypeApp {
+		files["manifest.xml"] = []byte(scaffoldManifest(opts))
+		files["Resources/config/plugin.png"] = icon
+
+		return files, nil
+	}
+
+	composerJson, err := scaffoldComposerJson(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files["composer.json"] = composerJson
+	files[fmt.Sprintf("src/%s.php", opts.Name)] = []byte(scaffoldPluginClass(opts))
+	files["src/Resources/config/services.xml"] = []byte(scaffoldServicesXml)
+	files["src/Resources/config/plugin.png"] = icon
+
+	if opts.Type == ScaffoldTypeTheme {
+		preview, err := scaffoldIcon(360, 240)
+		if err != nil {
+			return nil, err
+		}
+
+		themeJson, err := scaffoldThemeJson(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		files["src/Resources/theme.json"] = themeJson
+		files["src/Resources/app/storefront/src/assets/preview.png"] = preview
+		files["src/Resources/app/storefront/src/scss/base.scss"] = []byte("/*\nStyles of the theme, they are compiled after the styles of the Storefront.\n*/\n")
+		files["src/Resources/app/storefront/src/scss/overrides.scss"] = []byte("/*\nOverride the Bootstrap and Storefront variables here, they are compiled before the styles of the Storefront.\n*/\n")
+	}
+
+	return files, nil
+}
+
+func scaffoldComposerJson(opts ScaffoldOptions) ([]byte, error) {
+	german, english := opts.descriptions()
+
+	require := map[string]string{"shopware/core": opts.ShopwareVersion}
+	if opts.Type == ScaffoldTypeTheme {
+		require["shopware/storefront"] = opts.ShopwareVersion
+	}
+
+	composerJson := struct {
+		Name        string              `json:"name"`
+		Description string              `json:"description"`
+		Version     string              `json:"version"`
+		Type        string              `json:"type"`
+		License     string              `json:"license"`
+		Authors     []map[string]string `json:"authors"`
+		Require     map[string]string   `json:"require"`
+		Autoload    map[string]any      `json:"autoload"`
+		Extra       map[string]any      `json:"extra"`
+	}{
+		Name:        opts.ComposerPackage,
+		Description: english,
+		Version:     scaffoldVersion,
+		Type:        ComposerTypePlugin,
+		License:     opts.License,
+		Authors:     []map[string]string{{"name": opts.Author}},
+		Require:     require,
+		Autoload: map[string]any{
+			"psr-4": map[string]string{opts.Namespace + "\\": "src/"},
+		},
+		Extra: map[string]any{
+			"shopware-plugin-class": opts.Namespace + "\\" + opts.Name,
+			"label":                 map[string]string{"de-DE": opts.Label, "en-GB": opts.Label},
+			"description":           map[string]string{"de-DE": german, "en-GB": english},
+			"manufacturerLink":      map[string]string{"de-DE": opts.ManufacturerLink, "en-GB": opts.ManufacturerLink},
+			"supportLink":           map[string]string{"de-DE": opts.SupportLink, "en-GB": opts.SupportLink},
+		},
+	}
+
+	return marshalScaffoldJson(composerJson)
+}
+
+func scaffoldThemeJson(opts ScaffoldOptions) ([]byte, error) {
+	themeJson := struct {
+		Name              string   `json:"name"`
+		Author            string   `json:"author"`
+		Views             []string `json:"views"`
+		Style             []string `json:"style"`
+		Script            []string `json:"script"`
+		Asset             []string `json:"asset"`
+		PreviewMedia      string   `json:"previewMedia"`
+		ConfigInheritance []string `json:"configInheritance"`
+	}{
+		Name:              opts.Name,
+		Author:            opts.Author,
+		Views:             []string{"@Storefront", "@Plugins", "@" + opts.Name},
+		Style:             []string{"app/storefront/src/scss/overrides.scss", "@Storefront", "app/storefront/src/scss/base.scss"},
+		Script:            []string{"@Storefront"},
+		Asset:             []string{"@Storefront", "app/storefront/src/assets"},
+		PreviewMedia:      "app/storefront/src/assets/preview.png",
+		ConfigInheritance: []string{"@Storefront"},
+	}
+
+	return marshalScaffoldJson(themeJson)
+}
+
+func marshalScaffoldJson(value any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func scaffoldPluginClass(opts ScaffoldOptions) string {
+	if opts.Type == ScaffoldTypeTheme {
+		return fmt.Sprintf(`<?php declare(strict_types=1);
+
+namespace %s;
+
+use Shopware\Core\Framework\Plugin;
+use Shopware\Storefront\Framework\ThemeInterface;
+
+class %s extends Plugin implements ThemeInterface
+{
+}
+`, opts.Namespace, opts.Name)
+	}
+
+	return fmt.Sprintf(`<?php declare(strict_types=1);
+
+namespace %s;
+
+use Shopware\Core\Framework\Plugin;
+
+class %s extends Plugin
+{
+}
+`, opts.Namespace, opts.Name)
+}
+
+const scaffoldServicesXml = `<?xml version="1.0" ?>
+<container xmlns="http://symfony.com/schema/dic/services"
+           xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+           xsi:schemaLocation="http://symfony.com/schema/dic/services http://symfony.com/schema/dic/services/services-1.0.xsd">
+    <services>
+    </services>
+</container>
+`
+
+func scaffoldManifest(opts ScaffoldOptions) string {
+	german, english := opts.descriptions()
+
+	escape := func(value string) string {
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(value))
+
+		return buf.String()
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<manifest xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:noNamespaceSchemaLocation="https://raw.githubusercontent.com/shopware/shopware/trunk/src/Core/Framework/App/Manifest/Schema/manifest-2.0.xsd">
+    <meta>
+        <name>%s</name>
+        <label>%s</label>
+        <label lang="de-DE">%s</label>
+        <description>%s</description>
+        <description lang="de-DE">%s</description>
+        <author>%s</author>
+        <copyright>(c) by %s</copyright>
+        <version>%s</version>
+        <icon>Resources/config/plugin.png</icon>
+        <license>%s</license>
+        <compatibility>%s</compatibility>
+    </meta>
+</manifest>
+`,
+		escape(opts.Name),
+		escape(opts.Label),
+		escape(opts.Label),
+		escape(english),
+		escape(german),
+		escape(opts.Author),
+		escape(opts.Author),
+		scaffoldVersion,
+		escape(opts.License),
+		escape(opts.ShopwareVersion),
+	)
+}
+
+func scaffoldExtensionConfig(opts ScaffoldOptions) string {
+	storeType := "extension"
+	if opts.Type == ScaffoldTypeTheme {
+		storeType = "theme"
+	}
+
+	return fmt.Sprintf(`# yaml-language-server: $schema=https://raw.githubusercontent.com/FriendsOfShopware/shopware-cli/main/extension/shopware-extension-schema.json
+store:
+  type: %s
+  default_locale: en_GB
+  localizations:
+    - de_DE
+    - en_GB
+  availabilities:
+    - German
+    - International
+`, storeType)
+}
+
+// scaffoldIcon returns a placeholder PNG filled with the Shopware blue, it is replaced by extension icon generate.
+func scaffoldIcon(width, height int) ([]byte, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(scaffoldIconColor), image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
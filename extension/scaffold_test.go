@@ -0,0 +1,91 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeScaffold(t *testing.T, opts ScaffoldOptions) string {
+	t.Helper()
+
+	files, err := ScaffoldFiles(opts)
+	assert.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), opts.Name)
+
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+		assert.NoError(t, os.WriteFile(file, content, os.ModePerm))
+	}
+
+	return dir
+}
+
+func TestScaffoldPassesValidation(t *testing.T) {
+	for _, extensionType := range []string{ScaffoldTypePlugin, ScaffoldTypeApp, ScaffoldTypeTheme} {
+		t.Run(extensionType, func(t *testing.T) {
+			dir := writeScaffold(t, ScaffoldOptions{Type: extensionType, Name: "SwagExample", ManufacturerLink: "https://example.com"})
+
+			ext, err := GetExtensionByFolder(dir)
+			assert.NoError(t, err)
+
+			cfg := ext.GetExtensionConfig()
+			cfg.Validation.PHPSyntax.Mode = PHPSyntaxModeSkip
+
+			ctx := RunValidation(getTestContext(), ext)
+			assert.Empty(t, ctx.Errors())
+			assert.Empty(t, ctx.Warnings())
+
+			name, err := ext.GetName()
+			assert.NoError(t, err)
+			assert.Equal(t, "SwagExample", name)
+
+			changelog, err := ext.GetChangelog()
+			assert.NoError(t, err)
+			assert.Contains(t, changelog.German, "Erste Veröffentlichung")
+		})
+	}
+}
+
+func TestScaffoldTypes(t *testing.T) {
+	dir := writeScaffold(t, ScaffoldOptions{Type: ScaffoldTypeTheme, Name: "SwagTheme"})
+
+	ext, err := GetExtensionByFolder(dir)
+	assert.NoError(t, err)
+	assert.IsType(t, &PlatformTheme{}, ext)
+
+	dir = writeScaffold(t, ScaffoldOptions{Type: ScaffoldTypeApp, Name: "SwagApp"})
+
+	ext, err = GetExtensionByFolder(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, TypePlatformApp, ext.GetType())
+}
+
+func TestScaffoldOptionsDefaults(t *testing.T) {
+	opts := ScaffoldOptions{Name: "SwagPayPalCheckout"}.WithDefaults()
+
+	assert.Equal(t, ScaffoldTypePlugin, opts.Type)
+	assert.Equal(t, "SwagPayPalCheckout", opts.Namespace)
+	assert.Equal(t, "swag/pay-pal-checkout", opts.ComposerPackage)
+	assert.Equal(t, "Swag Pay Pal Checkout", opts.Label)
+	assert.Equal(t, "Swag", opts.Author)
+	assert.Equal(t, "MIT", opts.License)
+
+	assert.Equal(t, "example/example", ScaffoldOptions{Name: "Example"}.WithDefaults().ComposerPackage)
+}
+
+func TestScaffoldInvalidOptions(t *testing.T) {
+	_, err := ScaffoldFiles(ScaffoldOptions{Name: "swag-example"})
+	assert.ErrorContains(t, err, "must start with an uppercase letter")
+
+	_, err = ScaffoldFiles(ScaffoldOptions{Name: "SwagExample", Type: "bundle"})
+	assert.EqualError(t, err, "unsupported extension type bundle, use plugin, app or theme")
+
+	_, err = ScaffoldFiles(ScaffoldOptions{Name: "SwagExample", Description: "Too short"})
+	assert.EqualError(t, err, "the description has 9 characters, the store requires 150 to 185 characters")
+}
@@ -0,0 +1,20 @@
+package extension
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+)
+
+// ShellCommand runs the command line of a hook with sh. On Windows cmd is used, when no sh (f.e. of Git for Windows) is installed.
+func ShellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		if _, err := exec.LookPath("sh"); err != nil {
+			return process.Command(ctx, "cmd", "/C", command)
+		}
+	}
+
+	return process.Command(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,55 @@
+package extension
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// phpVersionRegex strips suffixes like -1ubuntu or +deb from PHP versions.
+var phpVersionRegex = regexp.MustCompile(`^\d+\.\d+(\.\d+)?`)
+
+// getPHPConstraint returns the php requirement of the composer.json, apps have none.
+func getPHPConstraint(ext Extension) string {
+	if plugin, ok := asPlatformPlugin(ext); ok {
+		return plugin.composer.Require["php"]
+	}
+
+	switch e := ext.(type) {
+	case ShopwareBundle:
+		return e.composer.Require["php"]
+	case *ShopwareBundle:
+		return e.composer.Require["php"]
+	}
+
+	return ""
+}
+
+// CheckShopCompatibility returns the reasons why the extension can't be installed into a shop with the given Shopware and
+// PHP version. Versions which are empty or can't be parsed, like the PHP version of shops not exposing it, are skipped.
+func CheckShopCompatibility(ext Extension, shopwareVersion, phpVersion string) []string {
+	problems := make([]string, 0)
+
+	if constraint, err := ext.GetShopwareVersionConstraint(); err == nil && shopwareVersion != "" {
+		if v, err := version.NewVersion(shopwareVersion); err == nil && !constraint.Check(v) {
+			problems = append(problems, fmt.Sprintf("the extension requires Shopware %s, but the shop runs %s", constraint.String(), shopwareVersion))
+		}
+	}
+
+	phpConstraintString := getPHPConstraint(ext)
+	phpVersion = phpVersionRegex.FindString(phpVersion)
+
+	if phpConstraintString != "" && phpVersion != "" {
+		constraint, err := version.NewConstraint(phpConstraintString)
+		if err != nil {
+			return problems
+		}
+
+		if v, err := version.NewVersion(phpVersion); err == nil && !constraint.Check(v) {
+			problems = append(problems, fmt.Sprintf("the extension requires PHP %s, but the shop runs %s", phpConstraintString, phpVersion))
+		}
+	}
+
+	return problems
+}
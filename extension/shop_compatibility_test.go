@@ -0,0 +1,24 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckShopCompatibility(t *testing.T) {
+	plugin := getTestPlugin(t.TempDir())
+	plugin.composer.Require = map[string]string{"shopware/core": "~6.5.0", "php": ">=8.1"}
+
+	assert.Empty(t, CheckShopCompatibility(plugin, "6.5.6.1", "8.2.10-1ubuntu"))
+	assert.Empty(t, CheckShopCompatibility(plugin, "6.5.6.1", ""))
+
+	assert.Equal(t, []string{
+		"the extension requires Shopware ~6.5.0, but the shop runs 6.4.20.2",
+		"the extension requires PHP >=8.1, but the shop runs 7.4.33",
+	}, CheckShopCompatibility(plugin, "6.4.20.2", "7.4.33"))
+
+	// without a php requirement only the Shopware version is checked
+	plugin.composer.Require = map[string]string{"shopware/core": "~6.5.0"}
+	assert.Empty(t, CheckShopCompatibility(&plugin, "6.5.0.0", "7.4.33"))
+}
@@ -0,0 +1,101 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// snippetRequiredLocales are the languages the store requires translations for.
+var snippetRequiredLocales = []string{"de-DE", "en-GB"}
+
+// validateSnippetCompleteness reports snippet files and keys existing in one of the required locales, but not in the other.
+func validateSnippetCompleteness(ctx *ValidationContext) {
+	sets, err := FindSnippetSets(ctx.Extension)
+	if err != nil {
+		ctx.AddWarning(fmt.Sprintf("Snippets were not checked: %s", err.Error()))
+		return
+	}
+
+	for _, set := range sets {
+		keys := make(map[string]map[string]bool)
+
+		for _, locale := range snippetRequiredLocales {
+			if !containsString(set.Locales, locale) {
+				continue
+			}
+
+			file := set.Path(locale)
+
+			content, err := os.ReadFile(filepath.Join(ctx.Extension.GetPath(), file))
+			if err != nil {
+				ctx.AddFileError(file, 0, fmt.Sprintf("cannot read snippet file %s: %s", file, err.Error()))
+				continue
+			}
+
+			var snippets map[string]interface{}
+			if err := json.Unmarshal(content, &snippets); err != nil {
+				ctx.AddFileError(file, 0, fmt.Sprintf("snippet file %s is not valid JSON: %s", file, err.Error()))
+				continue
+			}
+
+			keys[locale] = make(map[string]bool)
+			flattenSnippetKeys(snippets, "", keys[locale])
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		for _, locale := range snippetRequiredLocales {
+			for _, other := range snippetRequiredLocales {
+				if locale == other || keys[other] == nil {
+					continue
+				}
+
+				file := set.Path(locale)
+
+				if !containsString(set.Locales, locale) {
+					ctx.AddFileError(file, 0, fmt.Sprintf("snippet file %s is missing, it exists for %s", file, other))
+					continue
+				}
+
+				if keys[locale] == nil {
+					continue
+				}
+
+				for _, key := range missingSnippetKeys(keys[other], keys[locale]) {
+					ctx.AddFileError(file, 0, fmt.Sprintf("snippet %s is missing in %s, it exists in %s", key, file, other))
+				}
+			}
+		}
+	}
+}
+
+// flattenSnippetKeys adds the keys of all translations of the nested snippets joined with dots, like frosh.title.
+func flattenSnippetKeys(snippets map[string]interface{}, prefix string, keys map[string]bool) {
+	for key, value := range snippets {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenSnippetKeys(nested, prefix+key+".", keys)
+			continue
+		}
+
+		keys[prefix+key] = true
+	}
+}
+
+func missingSnippetKeys(source, target map[string]bool) []string {
+	missing := make([]string, 0)
+
+	for key := range source {
+		if !target[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
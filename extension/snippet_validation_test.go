@@ -0,0 +1,36 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSnippetCompleteness(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"src/Resources/snippet/storefront.en-GB.json":                                "{\"frosh\": {\"title\": \"Tools\", \"save\": \"Save\"}}",
+		"src/Resources/snippet/storefront.de-DE.json":                                "{\"frosh\": {\"title\": \"Werkzeuge\", \"cancel\": \"Abbrechen\"}}",
+		"src/Resources/app/administration/src/module/frosh-tools/snippet/en-GB.json": "{\"frosh-tools\": {\"title\": \"Tools\"}}",
+		"src/Resources/app/administration/src/module/frosh-cache/snippet/de-DE.json": "{\"frosh-cache\": {\"title\": \"Cache\"}}",
+		"src/Resources/app/administration/src/module/frosh-cache/snippet/en-GB.json": "{\"frosh-cache\": {\"title\": \"Cache\"}}",
+		"src/Resources/app/administration/src/module/frosh-queue/snippet/nl-NL.json": "{\"frosh-queue\": {\"title\": \"Wachtrij\"}}",
+		"src/Resources/app/administration/src/module/frosh-logs/snippet/de-DE.json":  "{\"frosh-logs\": ",
+		"src/Resources/app/administration/src/module/frosh-logs/snippet/en-GB.json":  "{\"frosh-logs\": {\"title\": \"Logs\"}}",
+	})
+
+	ctx := NewValidationContext(&plugin)
+	validateSnippetCompleteness(ctx)
+
+	assert.Equal(t, []string{
+		"snippet file src/Resources/app/administration/src/module/frosh-logs/snippet/de-DE.json is not valid JSON: unexpected end of JSON input",
+		"snippet file src/Resources/app/administration/src/module/frosh-tools/snippet/de-DE.json is missing, it exists for en-GB",
+		"snippet frosh.save is missing in src/Resources/snippet/storefront.de-DE.json, it exists in en-GB",
+		"snippet frosh.cancel is missing in src/Resources/snippet/storefront.en-GB.json, it exists in de-DE",
+	}, ctx.Errors())
+
+	issues := ctx.Issues()
+	assert.Equal(t, "src/Resources/snippet/storefront.de-DE.json", issues[2].File)
+}
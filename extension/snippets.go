@@ -0,0 +1,243 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/translation"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const snippetLocalePlaceholder = "{locale}"
+
+// snippetFileRegex matches storefront snippets like storefront.en-GB.json and administration snippets like en-GB.json.
+var snippetFileRegex = regexp.MustCompile(`^(.*?)([a-z]{2}-[A-Z]{2})(\.?[^/]*\.json)$`)
+
+// snippetSkippedDirs contain dependencies or compiled files.
+var snippetSkippedDirs = []string{"node_modules", "vendor", "public", "dist"}
+
+// SnippetSet is a snippet file with all its translations.
+type SnippetSet struct {
+	// Name identifies the file on the translation platform
+	Name string
+	// Pattern is the path relative to the extension root with {locale} as placeholder
+	Pattern string
+	// Locales of the existing files
+	Locales []string
+}
+
+// Path returns the file of the locale relative to the extension root.
+func (s SnippetSet) Path(locale string) string {
+	return strings.ReplaceAll(s.Pattern, snippetLocalePlaceholder, locale)
+}
+
+// FindSnippetSets searches the snippet directories of the storefront and the administration.
+func FindSnippetSets(ext Extension) ([]SnippetSet, error) {
+	sets := make(map[string]*SnippetSet)
+
+	err := filepath.WalkDir(ext.GetResourcesDir(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			for _, skipped := range snippetSkippedDirs {
+				if d.Name() == skipped {
+					return filepath.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		if filepath.Base(filepath.Dir(path)) != "snippet" {
+			return nil
+		}
+
+		match := snippetFileRegex.FindStringSubmatch(d.Name())
+		if match == nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(ext.GetPath(), path)
+		if err != nil {
+			return err
+		}
+
+		pattern := filepath.ToSlash(filepath.Join(filepath.Dir(rel), match[1]+snippetLocalePlaceholder+match[3]))
+
+		if _, ok := sets[pattern]; !ok {
+			resourcesRel, _ := filepath.Rel(ext.GetResourcesDir(), filepath.Dir(path))
+			sets[pattern] = &SnippetSet{Name: snippetSetName(filepath.ToSlash(resourcesRel), match[1]+match[3]), Pattern: pattern}
+		}
+
+		sets[pattern].Locales = append(sets[pattern].Locales, match[2])
+
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	result := make([]SnippetSet, 0, len(sets))
+	for _, set := range sets {
+		result = append(result, *set)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Pattern < result[j].Pattern
+	})
+
+	return result, nil
+}
+
+// snippetSetName builds a readable name from the directory and file name without the locale,
+// e.g. storefront for snippet/storefront.en-GB.json and administration-module-foo for app/administration/src/module/foo/snippet/en-GB.json.
+func snippetSetName(dir, file string) string {
+	parts := make([]string, 0)
+
+	for _, part := range strings.FieldsFunc(dir+"/"+file, func(r rune) bool { return r == '/' || r == '.' }) {
+		switch part {
+		case "app", "src", "snippet", "json":
+			continue
+		}
+
+		parts = append(parts, strings.ToLower(part))
+	}
+
+	if len(parts) == 0 {
+		return "messages"
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// PushSnippets uploads the snippet files of the source locale and returns the uploaded files.
+func PushSnippets(ctx context.Context, ext Extension, provider translation.Provider, cfg ConfigTranslations) ([]string, error) {
+	sets, err := FindSnippetSets(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	pushed := make([]string, 0)
+
+	for _, set := range sets {
+		file := set.Path(cfg.SourceLocale)
+
+		content, err := os.ReadFile(filepath.Join(ext.GetPath(), file))
+		if os.IsNotExist(err) {
+			logging.FromContext(ctx).Warnf("Skipping %s, it has no %s file", set.Pattern, cfg.SourceLocale)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := provider.Push(ctx, set.Name, cfg.providerLocale(cfg.SourceLocale), content); err != nil {
+			return nil, err
+		}
+
+		pushed = append(pushed, file)
+	}
+
+	return pushed, nil
+}
+
+// PullSnippets downloads the translations into the snippet files and returns the written files.
+// Without locales the configured locales or the locales of the existing files are downloaded.
+func PullSnippets(ctx context.Context, ext Extension, provider translation.Provider, cfg ConfigTranslations, locales []string) ([]string, error) {
+	sets, err := FindSnippetSets(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(locales) == 0 {
+		locales = cfg.Locales
+	}
+
+	written := make([]string, 0)
+
+	for _, set := range sets {
+		source, err := os.ReadFile(filepath.Join(ext.GetPath(), set.Path(cfg.SourceLocale)))
+		if os.IsNotExist(err) {
+			logging.FromContext(ctx).Warnf("Skipping %s, it has no %s file", set.Pattern, cfg.SourceLocale)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		setLocales := locales
+		if len(setLocales) == 0 {
+			setLocales = set.Locales
+		}
+
+		for _, locale := range setLocales {
+			if locale == cfg.SourceLocale {
+				continue
+			}
+
+			content, err := provider.Pull(ctx, set.Name, cfg.providerLocale(locale))
+			if err != nil {
+				return nil, err
+			}
+
+			formatted, err := formatSnippetJSON(content, detectJSONIndent(source))
+			if err != nil {
+				return nil, fmt.Errorf("invalid snippet file %s for %s: %w", set.Name, locale, err)
+			}
+
+			file := set.Path(locale)
+
+			if err := os.WriteFile(filepath.Join(ext.GetPath(), file), formatted, os.ModePerm); err != nil {
+				return nil, err
+			}
+
+			written = append(written, file)
+		}
+	}
+
+	return written, nil
+}
+
+func (c ConfigTranslations) providerLocale(locale string) string {
+	if mapped, ok := c.LanguageMapping[locale]; ok {
+		return mapped
+	}
+
+	return locale
+}
+
+// detectJSONIndent returns the indentation of the first indented line, so downloaded files look like the source file.
+func detectJSONIndent(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) != len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+
+	return "    "
+}
+
+func formatSnippetJSON(content []byte, indent string) ([]byte, error) {
+	var formatted bytes.Buffer
+
+	if err := json.Indent(&formatted, bytes.TrimSpace(content), "", indent); err != nil {
+		return nil, err
+	}
+
+	formatted.WriteString("\n")
+
+	return formatted.Bytes(), nil
+}
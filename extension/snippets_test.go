@@ -0,0 +1,86 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTranslationProvider struct {
+	pushed map[string]string
+	pulled map[string]string
+}
+
+func (f *fakeTranslationProvider) Push(_ context.Context, name, locale string, content []byte) error {
+	f.pushed[name+"/"+locale] = string(content)
+	return nil
+}
+
+func (f *fakeTranslationProvider) Pull(_ context.Context, name, locale string) ([]byte, error) {
+	return []byte(f.pulled[name+"/"+locale]), nil
+}
+
+func writeSnippetTestFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"src/Resources/snippet/storefront.en-GB.json":                                "{\n  \"frosh\": {\n    \"title\": \"Tools\"\n  }\n}\n",
+		"src/Resources/snippet/storefront.de-DE.json":                                "{}\n",
+		"src/Resources/app/administration/src/module/frosh-tools/snippet/en-GB.json": "{\"title\": \"Tools\"}",
+		"src/Resources/app/administration/node_modules/foo/snippet/en-GB.json":       "{}",
+		"src/Resources/config/services.json":                                         "{}",
+	})
+}
+
+func TestFindSnippetSets(t *testing.T) {
+	dir := t.TempDir()
+	writeSnippetTestFiles(t, dir)
+
+	sets, err := FindSnippetSets(getTestPlugin(dir))
+	assert.NoError(t, err)
+	assert.Equal(t, []SnippetSet{
+		{Name: "administration-module-frosh-tools", Pattern: "src/Resources/app/administration/src/module/frosh-tools/snippet/{locale}.json", Locales: []string{"en-GB"}},
+		{Name: "storefront", Pattern: "src/Resources/snippet/storefront.{locale}.json", Locales: []string{"de-DE", "en-GB"}},
+	}, sets)
+	assert.Equal(t, "src/Resources/snippet/storefront.fr-FR.json", sets[1].Path("fr-FR"))
+}
+
+func TestPushPullSnippets(t *testing.T) {
+	dir := t.TempDir()
+	writeSnippetTestFiles(t, dir)
+	plugin := getTestPlugin(dir)
+
+	cfg := ConfigTranslations{SourceLocale: "en-GB", LanguageMapping: map[string]string{"de-DE": "de"}}
+	provider := &fakeTranslationProvider{
+		pushed: map[string]string{},
+		pulled: map[string]string{
+			"storefront/de":                           "{\"frosh\":{\"title\":\"Werkzeuge\"}}",
+			"administration-module-frosh-tools/de":    "{\"title\":\"Werkzeuge\"}",
+			"administration-module-frosh-tools/fr-FR": "{\"title\":\"Outils\"}",
+			"storefront/fr-FR":                        "{\"frosh\":{\"title\":\"Outils\"}}",
+		},
+	}
+
+	pushed, err := PushSnippets(getTestContext(), plugin, provider, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/Resources/app/administration/src/module/frosh-tools/snippet/en-GB.json", "src/Resources/snippet/storefront.en-GB.json"}, pushed)
+	assert.Equal(t, "{\"title\": \"Tools\"}", provider.pushed["administration-module-frosh-tools/en-GB"])
+
+	// without locales only the existing translations are updated
+	written, err := PullSnippets(getTestContext(), plugin, provider, cfg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/Resources/snippet/storefront.de-DE.json"}, written)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "src/Resources/snippet/storefront.de-DE.json"))
+	assert.Equal(t, "{\n  \"frosh\": {\n    \"title\": \"Werkzeuge\"\n  }\n}\n", string(content))
+
+	written, err = PullSnippets(getTestContext(), plugin, provider, cfg, []string{"fr-FR"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/Resources/app/administration/src/module/frosh-tools/snippet/fr-FR.json", "src/Resources/snippet/storefront.fr-FR.json"}, written)
+
+	content, _ = os.ReadFile(filepath.Join(dir, "src/Resources/app/administration/src/module/frosh-tools/snippet/fr-FR.json"))
+	assert.Equal(t, "{\n    \"title\": \"Outils\"\n}\n", string(content))
+}
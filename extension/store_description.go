@@ -0,0 +1,289 @@
+package extension
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// storeDescriptionLocales maps the store languages used in the extension config to the locales of composer.json, config.xml and the changelog.
+var storeDescriptionLocales = map[string]string{
+	"de": "de-DE",
+	"en": "en-GB",
+}
+
+var storeDescriptionHeadings = map[string]map[string]string{
+	"en": {
+		"features":      "Features",
+		"requirements":  "Requirements",
+		"configuration": "Configuration",
+		"changelog":     "Changelog",
+		"setting":       "Setting",
+		"type":          "Type",
+		"default":       "Default",
+		"description":   "Description",
+		"php":           "PHP %s or newer",
+	},
+	"de": {
+		"features":      "Funktionen",
+		"requirements":  "Voraussetzungen",
+		"configuration": "Konfiguration",
+		"changelog":     "Änderungen",
+		"setting":       "Einstellung",
+		"type":          "Typ",
+		"default":       "Standard",
+		"description":   "Beschreibung",
+		"php":           "PHP %s oder neuer",
+	},
+}
+
+type StoreDescriptionOptions struct {
+	// ChangelogVersions is the amount of the newest changelog versions to include, 0 skips the changelog
+	ChangelogVersions int
+}
+
+type PluginConfigCard struct {
+	// Title contains the title by locale
+	Title  map[string]string
+	Fields []PluginConfigField
+}
+
+type PluginConfigField struct {
+	Name string
+	// Type is the input-field type or the component name
+	Type     string
+	Label    map[string]string
+	HelpText map[string]string
+	Default  string
+}
+
+type xmlTranslatedText struct {
+	Lang  string `xml:"lang,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlPluginConfigField struct {
+	XMLName       xml.Name
+	Type          string              `xml:"type,attr"`
+	ComponentName string              `xml:"name,attr"`
+	Name          string              `xml:"name"`
+	Label         []xmlTranslatedText `xml:"label"`
+	HelpText      []xmlTranslatedText `xml:"helpText"`
+	DefaultValue  string              `xml:"defaultValue"`
+}
+
+type xmlPluginConfig struct {
+	Cards []struct {
+		Title  []xmlTranslatedText    `xml:"title"`
+		Fields []xmlPluginConfigField `xml:",any"`
+	} `xml:"card"`
+}
+
+// ParsePluginConfig reads the cards and fields of Resources/config/config.xml. Extensions without configuration return no cards.
+func ParsePluginConfig(ext Extension) ([]PluginConfigCard, error) {
+	content, err := os.ReadFile(filepath.Join(ext.GetResourcesDir(), "config", "config.xml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginConfig: %w", err)
+	}
+
+	var config xmlPluginConfig
+	if err := xml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse config.xml: %w", err)
+	}
+
+	cards := make([]PluginConfigCard, 0, len(config.Cards))
+
+	for _, xmlCard := range config.Cards {
+		card := PluginConfigCard{Title: translatedTextMap(xmlCard.Title), Fields: make([]PluginConfigField, 0)}
+
+		for _, xmlField := range xmlCard.Fields {
+			field := PluginConfigField{
+				Name:     xmlField.Name,
+				Type:     xmlField.Type,
+				Label:    translatedTextMap(xmlField.Label),
+				HelpText: translatedTextMap(xmlField.HelpText),
+				Default:  strings.TrimSpace(xmlField.DefaultValue),
+			}
+
+			if xmlField.XMLName.Local == "component" {
+				field.Type = xmlField.ComponentName
+			}
+
+			if field.Type == "" {
+				field.Type = "text"
+			}
+
+			card.Fields = append(card.Fields, field)
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+// translatedTextMap converts the texts to a map by locale, texts without lang attribute are english.
+func translatedTextMap(texts []xmlTranslatedText) map[string]string {
+	translated := make(map[string]string)
+
+	for _, text := range texts {
+		lang := text.Lang
+		if lang == "" {
+			lang = "en-GB"
+		}
+
+		translated[lang] = strings.TrimSpace(text.Value)
+	}
+
+	return translated
+}
+
+// translatedValue returns the text of the locale and falls back to english.
+func translatedValue(texts map[string]string, locale string) string {
+	if text, ok := texts[locale]; ok && text != "" {
+		return text
+	}
+
+	return texts["en-GB"]
+}
+
+// BuildStoreDescription assembles a markdown description of the extension for the store in the given language (de or en).
+// It contains the features of the extension config, the requirements, a configuration reference of the config.xml and the newest changelog entries.
+func BuildStoreDescription(ext Extension, language string, opts StoreDescriptionOptions) (string, error) {
+	locale, ok := storeDescriptionLocales[language]
+	if !ok {
+		return "", fmt.Errorf("unsupported language %s, use de or en", language)
+	}
+
+	headings := storeDescriptionHeadings[language]
+
+	var sb strings.Builder
+
+	metadata := ext.GetMetaData()
+	label, description := metadata.Label.English, metadata.Description.English
+
+	if language == "de" && metadata.Label.German != "" {
+		label, description = metadata.Label.German, metadata.Description.German
+	}
+
+	if label == "" {
+		label, _ = ext.GetName()
+	}
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", label))
+
+	if description != "" {
+		sb.WriteString(description + "\n\n")
+	}
+
+	if cfg := ext.GetExtensionConfig(); cfg != nil {
+		if features := getStoreTranslation(language, cfg.Store.Features); features != nil && len(*features) > 0 {
+			sb.WriteString(fmt.Sprintf("## %s\n\n", headings["features"]))
+
+			for _, feature := range *features {
+				sb.WriteString(fmt.Sprintf("- %s\n", feature))
+			}
+
+			sb.WriteString("\n")
+		}
+	}
+
+	if constraint, err := ext.GetShopwareVersionConstraint(); err == nil {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", headings["requirements"]))
+		sb.WriteString(fmt.Sprintf("- Shopware %s\n", constraint.String()))
+
+		if phpVersion, _, found := getMinPhpVersion(constraint); found {
+			sb.WriteString("- " + fmt.Sprintf(headings["php"], phpVersion) + "\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	cards, err := ParsePluginConfig(ext)
+	if err != nil {
+		return "", err
+	}
+
+	if len(cards) > 0 {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", headings["configuration"]))
+
+		for _, card := range cards {
+			if title := translatedValue(card.Title, locale); title != "" {
+				sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+			}
+
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", headings["setting"], headings["type"], headings["default"], headings["description"]))
+			sb.WriteString("| --- | --- | --- | --- |\n")
+
+			for _, field := range card.Fields {
+				fieldLabel := translatedValue(field.Label, locale)
+				if fieldLabel == "" {
+					fieldLabel = field.Name
+				}
+
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", escapeMarkdownCell(fieldLabel), field.Type, escapeMarkdownCell(field.Default), escapeMarkdownCell(translatedValue(field.HelpText, locale))))
+			}
+
+			sb.WriteString("\n")
+		}
+	}
+
+	if opts.ChangelogVersions > 0 {
+		changelog, err := ParseExtensionChangelog(ext)
+		if err != nil {
+			return "", err
+		}
+
+		versions := make([]ChangelogVersion, 0)
+
+		for _, changelogVersion := range changelog.Versions {
+			if len(versions) == opts.ChangelogVersions {
+				break
+			}
+
+			if changelogVersion.Markdown(locale) != "" {
+				versions = append(versions, changelogVersion)
+			}
+		}
+
+		if len(versions) > 0 {
+			sb.WriteString(fmt.Sprintf("## %s\n\n", headings["changelog"]))
+
+			for _, changelogVersion := range versions {
+				sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", changelogVersion.Version, changelogVersion.Markdown(locale)))
+			}
+		}
+	}
+
+	return strings.TrimSpace(sb.String()) + "\n", nil
+}
+
+// RenderStoreDescriptionHTML converts the markdown description into the HTML expected by the store.
+func RenderStoreDescriptionHTML(markdown string) (string, error) {
+	var buf bytes.Buffer
+
+	if err := GetConfiguredGoldMark().Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func getStoreTranslation[T Translatable](language string, config ConfigTranslated[T]) *T {
+	if language == "de" && config.German != nil {
+		return config.German
+	}
+
+	return config.English
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
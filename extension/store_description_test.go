@@ -0,0 +1,120 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPluginConfigXML = `<?xml version="1.0" encoding="UTF-8"?>
+<config xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+    <card>
+        <title>Basic configuration</title>
+        <title lang="de-DE">Grundeinstellungen</title>
+        <input-field type="bool">
+            <name>active</name>
+            <label>Active</label>
+            <label lang="de-DE">Aktiv</label>
+            <helpText>Enables the | feature</helpText>
+            <defaultValue>true</defaultValue>
+        </input-field>
+        <component name="sw-entity-single-select">
+            <name>category</name>
+            <entity>category</entity>
+            <label>Category</label>
+        </component>
+        <input-field>
+            <name>apiKey</name>
+        </input-field>
+    </card>
+</config>`
+
+func TestParsePluginConfig(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	cards, err := ParsePluginConfig(plugin)
+	assert.NoError(t, err)
+	assert.Len(t, cards, 0)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "Resources", "config"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Resources", "config", "config.xml"), []byte(testPluginConfigXML), os.ModePerm))
+
+	cards, err = ParsePluginConfig(plugin)
+	assert.NoError(t, err)
+	assert.Len(t, cards, 1)
+	assert.Equal(t, map[string]string{"en-GB": "Basic configuration", "de-DE": "Grundeinstellungen"}, cards[0].Title)
+	assert.Len(t, cards[0].Fields, 3)
+
+	assert.Equal(t, PluginConfigField{
+		Name:     "active",
+		Type:     "bool",
+		Label:    map[string]string{"en-GB": "Active", "de-DE": "Aktiv"},
+		HelpText: map[string]string{"en-GB": "Enables the | feature"},
+		Default:  "true",
+	}, cards[0].Fields[0])
+	assert.Equal(t, "sw-entity-single-select", cards[0].Fields[1].Type)
+	assert.Equal(t, "text", cards[0].Fields[2].Type)
+}
+
+func TestBuildStoreDescription(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	features := []string{"Fast", "Simple"}
+	germanFeatures := []string{"Schnell", "Einfach"}
+	plugin.config = &Config{Store: ConfigStore{Features: ConfigTranslated[[]string]{English: &features, German: &germanFeatures}}}
+	plugin.composer.Extra.Label["de-DE"] = "Frosh Werkzeuge"
+	plugin.composer.Require["shopware/core"] = "~6.5.0"
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "Resources", "config"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "Resources", "config", "config.xml"), []byte(testPluginConfigXML), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG_en-GB.md"), []byte("# 1.1.0\n\n- Second\n\n# 1.0.0\n\n- First\n"), os.ModePerm))
+
+	description, err := BuildStoreDescription(plugin, "en", StoreDescriptionOptions{ChangelogVersions: 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `# Frosh Tools
+
+Frosh Tools
+
+## Features
+
+- Fast
+- Simple
+
+## Requirements
+
+- Shopware ~6.5.0
+- PHP 8.1 or newer
+
+## Configuration
+
+### Basic configuration
+
+| Setting | Type | Default | Description |
+| --- | --- | --- | --- |
+| Active | bool | true | Enables the \| feature |
+| Category | sw-entity-single-select |  |  |
+| apiKey | text |  |  |
+
+## Changelog
+
+### 1.1.0
+
+- Second
+`, description)
+
+	german, err := BuildStoreDescription(plugin, "de", StoreDescriptionOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, german, "# Frosh Werkzeuge")
+	assert.Contains(t, german, "- Schnell")
+	assert.Contains(t, german, "### Grundeinstellungen")
+	assert.Contains(t, german, "| Aktiv | bool | true | Enables the \\| feature |")
+	assert.NotContains(t, german, "1.1.0")
+
+	_, err = BuildStoreDescription(plugin, "fr", StoreDescriptionOptions{})
+	assert.Error(t, err)
+}
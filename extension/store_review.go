@@ -0,0 +1,218 @@
+package extension
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// storeReviewCodeRule is a code check of the automatic store review, matched line by line against the shipped files.
+type storeReviewCodeRule struct {
+	extensions []string
+	pattern    *regexp.Regexp
+	message    string
+	warning    bool
+}
+
+// phpFunctionCall matches the start of a global function call, method and static calls are ignored.
+const phpFunctionCall = `(?:^|[^>:$\w])\s*`
+
+// storeReviewCodeRules reject debug leftovers and functions executing code or shell commands.
+var storeReviewCodeRules = []storeReviewCodeRule{
+	{extensions: []string{".php"}, pattern: regexp.MustCompile(phpFunctionCall + `(var_dump|dd|dump)\s*\(`), message: "debug function %s must be removed"},
+	{extensions: []string{".php"}, pattern: regexp.MustCompile(phpFunctionCall + `(phpinfo|eval|exec|shell_exec|system|passthru|proc_open|popen)\s*\(`), message: "function %s is not allowed"},
+	{extensions: []string{".php"}, pattern: regexp.MustCompile(phpFunctionCall + `(print_r|error_log)\s*\(`), message: "debug function %s should be removed", warning: true},
+	{extensions: []string{".twig"}, pattern: regexp.MustCompile(`\{\{-?\s*(dump)\s*\(`), message: "debug function %s must be removed"},
+	{extensions: []string{".js", ".ts"}, pattern: regexp.MustCompile(`(^|[^.\w])(debugger)\s*;`), message: "debug statement %s must be removed"},
+	{extensions: []string{".js", ".ts"}, pattern: regexp.MustCompile(`\b(console\.log)\s*\(`), message: "debug statement %s should be removed", warning: true},
+}
+
+// storeReviewIgnoredCodePaths contain compiled or third party code which is not checked.
+var storeReviewIgnoredCodePaths = []string{
+	"vendor/",
+	"Resources/public/",
+	"Resources/app/storefront/dist/",
+}
+
+// ValidateStoreReview runs the checks of the automatic store code review against the built zip: the archive structure,
+// the file blacklist, the code checks and the metadata required for a release.
+func ValidateStoreReview(context *ValidationContext, zipPath string) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		context.AddError(fmt.Sprintf("cannot open zip file: %v", err))
+		return
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	name, err := context.Extension.GetName()
+	if err != nil {
+		return
+	}
+
+	validateStoreArchiveStructure(context, name, reader.File)
+	validateStoreCode(context, name, reader.File)
+	validateStoreMetadata(context)
+}
+
+func validateStoreArchiveStructure(context *ValidationContext, name string, files []*zip.File) {
+	notAllowedErrorFormat := "file %s is not allowed in the zip file"
+
+	for _, file := range files {
+		if strings.Contains(file.Name, "..") || strings.HasPrefix(file.Name, "/") || strings.Contains(file.Name, "\\") {
+			context.AddError(fmt.Sprintf("invalid path %s in zip file", file.Name))
+			continue
+		}
+
+		if file.Name != name+"/" && !strings.HasPrefix(file.Name, name+"/") {
+			context.AddError(fmt.Sprintf("file %s must be inside the folder %s, the zip needs the technical name as root folder", file.Name, name))
+			continue
+		}
+
+		relPath := strings.TrimSuffix(strings.TrimPrefix(file.Name, name+"/"), "/")
+		if relPath == "" {
+			continue
+		}
+
+		for _, notAllowed := range defaultNotAllowedPaths {
+			if relPath == notAllowed || strings.HasPrefix(relPath, notAllowed+"/") {
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, file.Name))
+			}
+		}
+
+		baseName := path.Base(relPath)
+
+		for _, notAllowed := range defaultNotAllowedFiles {
+			if baseName == notAllowed {
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, file.Name))
+			}
+		}
+
+		for _, extension := range defaultNotAllowedExtensions {
+			if strings.HasSuffix(baseName, extension) {
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, file.Name))
+			}
+		}
+	}
+}
+
+func validateStoreCode(context *ValidationContext, name string, files []*zip.File) {
+	for _, file := range files {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		relPath := strings.TrimPrefix(file.Name, name+"/")
+
+		if isStoreReviewIgnoredPath(relPath) {
+			continue
+		}
+
+		rules := make([]storeReviewCodeRule, 0)
+
+		for _, rule := range storeReviewCodeRules {
+			for _, extension := range rule.extensions {
+				if strings.HasSuffix(relPath, extension) {
+					rules = append(rules, rule)
+				}
+			}
+		}
+
+		if len(rules) == 0 {
+			continue
+		}
+
+		if err := checkStoreCodeFile(context, relPath, file, rules); err != nil {
+			context.AddFileError(relPath, 0, fmt.Sprintf("cannot read file %s: %v", relPath, err))
+		}
+	}
+}
+
+func isStoreReviewIgnoredPath(relPath string) bool {
+	for _, ignored := range storeReviewIgnoredCodePaths {
+		if strings.HasPrefix(relPath, ignored) || strings.Contains(relPath, "/"+ignored) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkStoreCodeFile reads the file line by line, so only the longest line is kept in memory.
+func checkStoreCodeFile(context *ValidationContext, relPath string, file *zip.File, rules []storeReviewCodeRule) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return checkStoreCodeRules(context, relPath, reader, rules)
+}
+
+func checkStoreCodeRules(context *ValidationContext, relPath string, content io.Reader, rules []storeReviewCodeRule) error {
+	reader := bufio.NewReader(content)
+
+	for i := 0; ; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		checkStoreCodeLine(context, relPath, i+1, strings.TrimSuffix(line, "\n"), rules)
+
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+func checkStoreCodeLine(context *ValidationContext, relPath string, lineNumber int, line string, rules []storeReviewCodeRule) {
+	trimmed := strings.TrimSpace(line)
+
+	// commented out code is not executed
+	if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "*") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "{#") {
+		return
+	}
+
+	for _, rule := range rules {
+		match := rule.pattern.FindStringSubmatch(line)
+		// declaring a method with the same name is fine
+		if match == nil || strings.Contains(line, "function "+match[len(match)-1]) {
+			continue
+		}
+
+		message := fmt.Sprintf(rule.message, match[len(match)-1])
+
+		if rule.warning {
+			context.AddFileWarning(relPath, lineNumber, message)
+		} else {
+			context.AddFileError(relPath, lineNumber, message)
+		}
+	}
+}
+
+func validateStoreMetadata(context *ValidationContext) {
+	changelog, err := context.Extension.GetChangelog()
+	if err != nil {
+		context.AddError(fmt.Sprintf("changelog: %v", err))
+		return
+	}
+
+	if changelog.English == "" {
+		context.AddError("the changelog of the current version is missing in english")
+	}
+
+	if changelog.German == "" {
+		context.AddError("the changelog of the current version is missing in german")
+	}
+}
@@ -0,0 +1,61 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStoreArchiveStructure(t *testing.T) {
+	reader := createTestZip(t, map[string][]byte{
+		"FroshTools/composer.json":     []byte("{}"),
+		"FroshTools/.gitlab-ci.yml":    []byte(""),
+		"FroshTools/tests/FooTest.php": []byte(""),
+		"FroshTools/src/.DS_Store":     []byte(""),
+		"FroshTools/src/backup.zip":    []byte(""),
+		"Other/composer.json":          []byte("{}"),
+	})
+
+	ctx := &ValidationContext{}
+	validateStoreArchiveStructure(ctx, "FroshTools", reader.File)
+
+	assert.ElementsMatch(t, []string{
+		"file FroshTools/.gitlab-ci.yml is not allowed in the zip file",
+		"file FroshTools/tests/FooTest.php is not allowed in the zip file",
+		"file FroshTools/src/.DS_Store is not allowed in the zip file",
+		"file FroshTools/src/backup.zip is not allowed in the zip file",
+		"file Other/composer.json must be inside the folder FroshTools, the zip needs the technical name as root folder",
+	}, ctx.Errors())
+}
+
+func TestValidateStoreCode(t *testing.T) {
+	reader := createTestZip(t, map[string][]byte{
+		"FroshTools/src/Service.php": []byte(`<?php
+class Service {
+    public function dump(): void {
+        dump($this);
+        // var_dump($this);
+        $this->exec('a');
+        return \shell_exec('ls');
+    }
+}`),
+		"FroshTools/src/Resources/views/base.html.twig":           []byte("{{ dump(page) }}\n{# {{ dump() }} #}"),
+		"FroshTools/src/Resources/app/administration/src/main.js": []byte("console.log('a');\ndebugger;"),
+		"FroshTools/src/Resources/public/administration/js/a.js":  []byte("debugger;"),
+		"FroshTools/vendor/foo/bar.php":                           []byte("<?php eval('1');"),
+	})
+
+	ctx := &ValidationContext{}
+	validateStoreCode(ctx, "FroshTools", reader.File)
+
+	assert.ElementsMatch(t, []string{
+		"src/Service.php:4: debug function dump must be removed",
+		"src/Service.php:7: function shell_exec is not allowed",
+		"src/Resources/views/base.html.twig:1: debug function dump must be removed",
+		"src/Resources/app/administration/src/main.js:2: debug statement debugger must be removed",
+		"src/Resources/app/administration/src/main.js:1: debug statement console.log should be removed",
+	}, fileIssues(ctx))
+
+	assert.Equal(t, []string{"debug statement console.log should be removed"}, ctx.Warnings())
+	assert.Contains(t, ctx.Issues(), ValidationIssue{Severity: ValidationSeverityError, Message: "debug function dump must be removed", File: "src/Service.php", Line: 4})
+}
@@ -0,0 +1,132 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+var storefrontTemplateTagRegex = regexp.MustCompile(`{%-?\s*(block\s+([\w-]+)|endblock)\b|{{-?\s*(parent)\(\)`)
+
+// TemplateBlockConflict is a block of a storefront template, which is overridden by multiple extensions in a theme.
+type TemplateBlockConflict struct {
+	Theme    string `json:"theme"`
+	Template string `json:"template"`
+	Block    string `json:"block"`
+	// Extensions are in resolution order, the last one is rendered first and renders the others with parent()
+	Extensions []string `json:"extensions"`
+	// ReplacedBy is the extension with the highest priority, which overrides the block without calling parent()
+	ReplacedBy string `json:"replacedBy,omitempty"`
+	// Hidden contains the extensions whose changes are not rendered, because of ReplacedBy
+	Hidden []string `json:"hidden,omitempty"`
+}
+
+// FindTemplateBlockConflicts reports the storefront blocks, which are overridden by more than one extension of the project.
+func FindTemplateBlockConflicts(ctx context.Context, project string) ([]TemplateBlockConflict, error) {
+	sources, err := findThemeInheritanceSources(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return findTemplateBlockConflicts(sources)
+}
+
+func findTemplateBlockConflicts(sources []themeInheritanceSource) ([]TemplateBlockConflict, error) {
+	inheritance, err := resolveThemeInheritance(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	viewsDirs := make(map[string]string)
+	for _, source := range sources {
+		viewsDirs[source.name] = source.viewsDir
+	}
+
+	conflicts := make([]TemplateBlockConflict, 0)
+
+	for _, theme := range inheritance.Themes {
+		for _, template := range theme.Templates {
+			blocks := make(map[string][]string)
+			callsParent := make(map[string]map[string]bool)
+
+			for _, provider := range template.ProvidedBy {
+				if provider == storefrontThemeName {
+					continue
+				}
+
+				content, err := os.ReadFile(filepath.Join(viewsDirs[provider], filepath.FromSlash(template.Template)))
+				if err != nil {
+					return nil, err
+				}
+
+				callsParent[provider] = parseStorefrontTemplateBlocks(string(content))
+
+				for block := range callsParent[provider] {
+					blocks[block] = append(blocks[block], provider)
+				}
+			}
+
+			for block, extensions := range blocks {
+				if len(extensions) < 2 {
+					continue
+				}
+
+				conflict := TemplateBlockConflict{Theme: theme.Name, Template: template.Template, Block: block, Extensions: extensions}
+
+				for i := len(extensions) - 1; i > 0; i-- {
+					if !callsParent[extensions[i]][block] {
+						conflict.ReplacedBy = extensions[i]
+						conflict.Hidden = extensions[:i]
+
+						break
+					}
+				}
+
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Theme != conflicts[j].Theme {
+			return conflicts[i].Theme < conflicts[j].Theme
+		}
+
+		if conflicts[i].Template != conflicts[j].Template {
+			return conflicts[i].Template < conflicts[j].Template
+		}
+
+		return conflicts[i].Block < conflicts[j].Block
+	})
+
+	return conflicts, nil
+}
+
+// parseStorefrontTemplateBlocks returns all blocks of the template and whether they call parent().
+func parseStorefrontTemplateBlocks(content string) map[string]bool {
+	blocks := make(map[string]bool)
+	stack := make([]string, 0)
+
+	for _, match := range storefrontTemplateTagRegex.FindAllStringSubmatch(content, -1) {
+		switch {
+		case match[3] == "parent":
+			if len(stack) > 0 {
+				blocks[stack[len(stack)-1]] = true
+			}
+		case match[1] == "endblock":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			stack = append(stack, match[2])
+
+			if _, ok := blocks[match[2]]; !ok {
+				blocks[match[2]] = false
+			}
+		}
+	}
+
+	return blocks
+}
@@ -0,0 +1,55 @@
+package extension
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestTemplate(t *testing.T, viewsDir, template, content string) {
+	t.Helper()
+
+	assert.NoError(t, os.MkdirAll(path.Dir(path.Join(viewsDir, template)), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(viewsDir, template), []byte(content), os.ModePerm))
+}
+
+func TestParseStorefrontTemplateBlocks(t *testing.T) {
+	blocks := parseStorefrontTemplateBlocks(`{% sw_extends '@Storefront/storefront/base.html.twig' %}
+{% block base_header %}
+    {{ parent() }}
+    {% block my_plugin_banner %}Banner{% endblock %}
+{% endblock %}
+{%- block base_footer -%}Footer{%- endblock -%}`)
+
+	assert.Equal(t, map[string]bool{"base_header": true, "my_plugin_banner": false, "base_footer": false}, blocks)
+}
+
+func TestFindTemplateBlockConflicts(t *testing.T) {
+	storefrontViews := t.TempDir()
+	pluginAViews := t.TempDir()
+	pluginBViews := t.TempDir()
+	pluginCViews := t.TempDir()
+
+	template := "storefront/base.html.twig"
+
+	writeTestTemplate(t, storefrontViews, template, "{% block base_header %}{% endblock %}{% block base_footer %}{% endblock %}")
+	writeTestTemplate(t, pluginAViews, template, "{% block base_header %}A{{ parent() }}{% endblock %}{% block base_footer %}A{% endblock %}")
+	writeTestTemplate(t, pluginBViews, template, "{% block base_header %}{{ parent() }}B{% endblock %}{% block base_main %}B{% endblock %}")
+	writeTestTemplate(t, pluginCViews, template, "{% block base_footer %}C{% endblock %}{% block base_main %}{{ parent() }}C{% endblock %}")
+
+	conflicts, err := findTemplateBlockConflicts([]themeInheritanceSource{
+		{name: "Storefront", viewsDir: storefrontViews, theme: &themeJSON{}},
+		{name: "PluginA", viewsDir: pluginAViews},
+		{name: "PluginB", viewsDir: pluginBViews},
+		{name: "PluginC", viewsDir: pluginCViews},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []TemplateBlockConflict{
+		{Theme: "Storefront", Template: template, Block: "base_footer", Extensions: []string{"PluginA", "PluginC"}, ReplacedBy: "PluginC", Hidden: []string{"PluginA"}},
+		{Theme: "Storefront", Template: template, Block: "base_header", Extensions: []string{"PluginA", "PluginB"}},
+		{Theme: "Storefront", Template: template, Block: "base_main", Extensions: []string{"PluginB", "PluginC"}},
+	}, conflicts)
+}
@@ -38,5 +38,15 @@ func validateTheme(ctx *ValidationContext) {
 }
 
 type themeJSON struct {
-	PreviewMedia string `json:"previewMedia"`
+	Name         string   `json:"name"`
+	Author       string   `json:"author"`
+	PreviewMedia string   `json:"previewMedia"`
+	Style        []string `json:"style"`
+	Script       []string `json:"script"`
+	Views        []string `json:"views"`
+	Asset        []string `json:"asset"`
+	Inheritance  []string `json:"configInheritance"`
+	Config       struct {
+		Fields map[string]themeJSONField `json:"fields"`
+	} `json:"config"`
 }
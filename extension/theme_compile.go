@@ -0,0 +1,236 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/esbuild"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+type ThemeCompileConfig struct {
+	// ShopwareRoot points to existing Shopware sources, when empty the matching storefront sources are downloaded
+	ShopwareRoot    string
+	ShopwareVersion *version.Constraints
+	OutputDir       string
+	SkipJavascript  bool
+}
+
+type ThemeCompileResult struct {
+	CssFile string
+	JsFile  string
+}
+
+// CompileThemeStandalone compiles the SCSS and JS of a theme against the storefront sources without an installed shop.
+func CompileThemeStandalone(ctx context.Context, ext Extension, cfg ThemeCompileConfig) (*ThemeCompileResult, error) {
+	name, err := ext.GetName()
+	if err != nil {
+		return nil, err
+	}
+
+	theme, err := readThemeJSON(filepath.Join(ext.GetResourcesDir(), "theme.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	shopwareRoot := cfg.ShopwareRoot
+
+	if shopwareRoot == "" {
+		minVersion, err := lookupForMinMatchingVersion(ctx, cfg.ShopwareVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		shopwareRoot, err = setupShopwareInTemp(ctx, "v"+minVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		defer deletePath(ctx, shopwareRoot)
+	}
+
+	storefrontResources := PlatformPath(shopwareRoot, "Storefront", "Resources")
+	storefrontApp := filepath.Join(storefrontResources, "app", "storefront")
+
+	if _, err := os.Stat(filepath.Join(storefrontApp, "node_modules")); os.IsNotExist(err) {
+		logging.FromContext(ctx).Infof("Installing storefront dependencies")
+
+		if err := installDependencies(ctx, storefrontApp); err != nil {
+			return nil, err
+		}
+	}
+
+	storefrontTheme, err := readThemeJSON(filepath.Join(storefrontResources, "theme.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	source := buildThemeScssSource(theme, ext.GetResourcesDir(), storefrontTheme, storefrontResources)
+
+	logging.FromContext(ctx).Infof("Compiling SCSS of theme %s", name)
+
+	css, err := esbuild.CompileScss(ctx, source, "file://"+filepath.Join(ext.GetResourcesDir(), "theme.scss"), []string{
+		filepath.Join(ext.GetResourcesDir(), "app", "storefront"),
+		storefrontApp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("compile scss: %w", err)
+	}
+
+	outputDir := cfg.OutputDir
+	if outputDir == "" {
+		outputDir = filepath.Join(ext.GetPath(), "var", "theme")
+	}
+
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	result := &ThemeCompileResult{CssFile: filepath.Join(outputDir, "all.css")}
+
+	if err := os.WriteFile(result.CssFile, []byte(css), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	if cfg.SkipJavascript {
+		return result, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(ext.GetRootDir(), StorefrontEntrypointJS)); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(ext.GetRootDir(), StorefrontEntrypointTS)); os.IsNotExist(err) {
+			return result, nil
+		}
+	}
+
+	logging.FromContext(ctx).Infof("Compiling JavaScript of theme %s", name)
+
+	options := esbuild.NewAssetCompileOptionsStorefront(name, ext.GetRootDir())
+	options.OutputDir, _ = filepath.Rel(ext.GetRootDir(), outputDir)
+
+	compiled, err := esbuild.CompileExtensionAsset(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result.JsFile = compiled.JsFile
+
+	return result, nil
+}
+
+func readThemeJSON(file string) (*themeJSON, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read theme.json: %w", err)
+	}
+
+	var theme themeJSON
+	if err := json.Unmarshal(content, &theme); err != nil {
+		return nil, fmt.Errorf("cannot decode theme.json: %w", err)
+	}
+
+	return &theme, nil
+}
+
+// buildThemeScssSource creates the entry SCSS like the Shopware ThemeCompiler, the theme config is dumped as variables followed by the imports of all style files.
+func buildThemeScssSource(theme *themeJSON, themeResources string, storefrontTheme *themeJSON, storefrontResources string) string {
+	var builder strings.Builder
+
+	fields := make(map[string]themeJSONField)
+	for name, field := range storefrontTheme.Config.Fields {
+		fields[name] = field
+	}
+
+	for name, field := range theme.Config.Fields {
+		if existing, ok := fields[name]; ok && field.Value == nil {
+			field.Value = existing.Value
+		}
+
+		fields[name] = field
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if value := formatThemeScssValue(fields[name]); value != "" {
+			builder.WriteString(fmt.Sprintf("$%s: %s;\n", name, value))
+		}
+	}
+
+	for _, style := range theme.Style {
+		switch style {
+		case "@Storefront":
+			for _, storefrontStyle := range storefrontTheme.Style {
+				if strings.HasPrefix(storefrontStyle, "@") {
+					continue
+				}
+
+				builder.WriteString(fmt.Sprintf("@import %q;\n", path.Join(filepath.ToSlash(storefrontResources), storefrontStyle)))
+			}
+		case "@Plugins":
+			continue
+		default:
+			if strings.HasPrefix(style, "@") {
+				continue
+			}
+
+			builder.WriteString(fmt.Sprintf("@import %q;\n", path.Join(filepath.ToSlash(themeResources), style)))
+		}
+	}
+
+	return builder.String()
+}
+
+// formatThemeScssValue returns the SCSS value of a theme config field. Switches and checkboxes are always SCSS booleans,
+// as 0 and "0" are truthy in SCSS.
+func formatThemeScssValue(field themeJSONField) string {
+	if field.Value != nil && (field.Type == "switch" || field.Type == "checkbox") {
+		switch value := field.Value.(type) {
+		case bool:
+			return strconv.FormatBool(value)
+		case float64:
+			return strconv.FormatBool(value != 0)
+		case string:
+			return strconv.FormatBool(value == "1" || value == "true")
+		}
+
+		return "false"
+	}
+
+	switch value := field.Value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if value {
+			return "true"
+		}
+
+		return "false"
+	case float64:
+		return fmt.Sprintf("%v", value)
+	case string:
+		if value == "" {
+			return ""
+		}
+
+		if field.Type == "media" || field.Type == "text" {
+			return fmt.Sprintf("%q", value)
+		}
+
+		return value
+	}
+
+	return ""
+}
@@ -0,0 +1,36 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildThemeScssSource(t *testing.T) {
+	storefront := &themeJSON{Style: []string{"app/storefront/src/scss/base.scss", "@Plugins"}}
+	storefront.Config.Fields = map[string]themeJSONField{
+		"sw-color-brand-primary": {Type: "color", Value: "#008490"},
+		"sw-logo-desktop":        {Type: "media", Value: "logo.png"},
+	}
+
+	theme := &themeJSON{Style: []string{"app/storefront/src/scss/overrides.scss", "@Storefront", "@Plugins", "app/storefront/src/scss/base.scss"}}
+	theme.Config.Fields = map[string]themeJSONField{
+		"sw-color-brand-primary": {Type: "color", Value: "#ff0000"},
+		"sw-border-radius":       {Type: "text", Value: nil},
+		"my-feature-enabled":     {Type: "switch", Value: true},
+		"my-feature-disabled":    {Type: "checkbox", Value: "0"},
+		"my-sticky-header":       {Type: "switch", Value: float64(0)},
+	}
+
+	source := buildThemeScssSource(theme, "/theme/Resources", storefront, "/storefront/Resources")
+
+	assert.Equal(t, `$my-feature-disabled: false;
+$my-feature-enabled: true;
+$my-sticky-header: false;
+$sw-color-brand-primary: #ff0000;
+$sw-logo-desktop: "logo.png";
+@import "/theme/Resources/app/storefront/src/scss/overrides.scss";
+@import "/storefront/Resources/app/storefront/src/scss/base.scss";
+@import "/theme/Resources/app/storefront/src/scss/base.scss";
+`, source)
+}
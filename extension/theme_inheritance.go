@@ -0,0 +1,253 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const storefrontThemeName = "Storefront"
+
+type ThemeInheritance struct {
+	Themes []ThemeInheritanceNode
+}
+
+type ThemeInheritanceNode struct {
+	Name   string
+	Parent string
+	// Views contains the resolved view order, the last entry has the highest priority
+	Views     []string
+	Templates []ThemeTemplateOverride
+}
+
+type ThemeTemplateOverride struct {
+	Template   string
+	ProvidedBy []string
+	ResolvedBy string
+}
+
+type themeInheritanceSource struct {
+	name      string
+	viewsDir  string
+	theme     *themeJSON
+	templates []string
+}
+
+// BuildThemeInheritance reads the themes of the project and resolves the template inheritance for each of them.
+func BuildThemeInheritance(ctx context.Context, project string) (*ThemeInheritance, error) {
+	sources, err := findThemeInheritanceSources(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveThemeInheritance(sources)
+}
+
+// findThemeInheritanceSources returns the Storefront and all extensions of the project with their views folder.
+func findThemeInheritanceSources(ctx context.Context, project string) ([]themeInheritanceSource, error) {
+	sources := []themeInheritanceSource{
+		{
+			name:     storefrontThemeName,
+			viewsDir: PlatformPath(project, "Storefront", "Resources/views"),
+			theme:    &themeJSON{},
+		},
+	}
+
+	for _, ext := range FindExtensionsFromProject(ctx, project) {
+		name, err := ext.GetName()
+		if err != nil {
+			continue
+		}
+
+		source := themeInheritanceSource{
+			name:     name,
+			viewsDir: filepath.Join(ext.GetResourcesDir(), "views"),
+		}
+
+		themeFile := filepath.Join(ext.GetResourcesDir(), "theme.json")
+		if _, err := os.Stat(themeFile); err == nil {
+			if source.theme, err = readThemeJSON(themeFile); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+func resolveThemeInheritance(sources []themeInheritanceSource) (*ThemeInheritance, error) {
+	byName := make(map[string]*themeInheritanceSource)
+	plugins := make([]string, 0)
+
+	for i := range sources {
+		templates, err := findStorefrontTemplates(sources[i].viewsDir)
+		if err != nil {
+			return nil, err
+		}
+
+		sources[i].templates = templates
+		byName[sources[i].name] = &sources[i]
+
+		if sources[i].theme == nil {
+			plugins = append(plugins, sources[i].name)
+		}
+	}
+
+	sort.Strings(plugins)
+
+	inheritance := &ThemeInheritance{}
+
+	for _, source := range sources {
+		if source.theme == nil {
+			continue
+		}
+
+		node := ThemeInheritanceNode{Name: source.name}
+
+		if source.name != storefrontThemeName {
+			node.Parent = storefrontThemeName
+
+			for _, parent := range source.theme.Inheritance {
+				if parent = strings.TrimPrefix(parent, "@"); parent != source.name {
+					node.Parent = parent
+				}
+			}
+		}
+
+		views := source.theme.Views
+		if len(views) == 0 {
+			views = []string{"@" + storefrontThemeName, "@Plugins", "@" + source.name}
+
+			// the Storefront is the first view already, listing it again would report all its templates as overridden
+			if source.name == storefrontThemeName {
+				views = []string{"@" + storefrontThemeName, "@Plugins"}
+			}
+		}
+
+		for _, view := range views {
+			if view == "@Plugins" {
+				node.Views = append(node.Views, plugins...)
+				continue
+			}
+
+			node.Views = append(node.Views, strings.TrimPrefix(view, "@"))
+		}
+
+		providers := make(map[string][]string)
+
+		for _, view := range node.Views {
+			viewSource, ok := byName[view]
+			if !ok {
+				continue
+			}
+
+			for _, template := range viewSource.templates {
+				providers[template] = append(providers[template], view)
+			}
+		}
+
+		for template, providedBy := range providers {
+			if len(providedBy) < 2 {
+				continue
+			}
+
+			node.Templates = append(node.Templates, ThemeTemplateOverride{
+				Template:   template,
+				ProvidedBy: providedBy,
+				ResolvedBy: providedBy[len(providedBy)-1],
+			})
+		}
+
+		sort.Slice(node.Templates, func(i, j int) bool {
+			return node.Templates[i].Template < node.Templates[j].Template
+		})
+
+		inheritance.Themes = append(inheritance.Themes, node)
+	}
+
+	return inheritance, nil
+}
+
+func findStorefrontTemplates(viewsDir string) ([]string, error) {
+	templates := make([]string, 0)
+
+	if _, err := os.Stat(viewsDir); os.IsNotExist(err) {
+		return templates, nil
+	}
+
+	err := filepath.WalkDir(viewsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".twig") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(viewsDir, path)
+		if err != nil {
+			return err
+		}
+
+		templates = append(templates, filepath.ToSlash(relPath))
+
+		return nil
+	})
+
+	return templates, err
+}
+
+// Children returns the themes which inherit directly from the given theme.
+func (t ThemeInheritance) Children(name string) []ThemeInheritanceNode {
+	children := make([]ThemeInheritanceNode, 0)
+
+	for _, theme := range t.Themes {
+		if theme.Parent == name {
+			children = append(children, theme)
+		}
+	}
+
+	return children
+}
+
+// RenderDot renders the inheritance chain and the template overrides in the Graphviz DOT format.
+func (t ThemeInheritance) RenderDot() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph themes {\n")
+	builder.WriteString("  rankdir=LR;\n")
+
+	for _, theme := range t.Themes {
+		builder.WriteString(fmt.Sprintf("  %q [shape=box];\n", theme.Name))
+
+		if theme.Parent != "" {
+			builder.WriteString(fmt.Sprintf("  %q -> %q [label=\"inherits\"];\n", theme.Parent, theme.Name))
+		}
+
+		overrides := make(map[string]int)
+		for _, template := range theme.Templates {
+			overrides[template.ResolvedBy]++
+		}
+
+		extensions := make([]string, 0, len(overrides))
+		for name := range overrides {
+			extensions = append(extensions, name)
+		}
+
+		sort.Strings(extensions)
+
+		for _, name := range extensions {
+			builder.WriteString(fmt.Sprintf("  %q -> %q [style=dashed, label=\"%d templates\"];\n", name, theme.Name, overrides[name]))
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
@@ -0,0 +1,81 @@
+package extension
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTemplate(t *testing.T, viewsDir, template string) {
+	t.Helper()
+
+	assert.NoError(t, os.MkdirAll(path.Dir(path.Join(viewsDir, template)), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(viewsDir, template), []byte("{% sw_extends '@Storefront/"+template+"' %}"), os.ModePerm))
+}
+
+func TestResolveThemeInheritance(t *testing.T) {
+	storefrontViews := t.TempDir()
+	pluginViews := t.TempDir()
+	themeViews := t.TempDir()
+
+	createTestTemplate(t, storefrontViews, "storefront/base.html.twig")
+	createTestTemplate(t, storefrontViews, "storefront/page/product-detail/index.html.twig")
+	createTestTemplate(t, pluginViews, "storefront/base.html.twig")
+	createTestTemplate(t, themeViews, "storefront/base.html.twig")
+	createTestTemplate(t, themeViews, "storefront/layout/header.html.twig")
+
+	inheritance, err := resolveThemeInheritance([]themeInheritanceSource{
+		{name: "Storefront", viewsDir: storefrontViews, theme: &themeJSON{}},
+		{name: "MyPlugin", viewsDir: pluginViews},
+		{name: "MyTheme", viewsDir: themeViews, theme: &themeJSON{}},
+		{name: "ChildTheme", viewsDir: t.TempDir(), theme: &themeJSON{Inheritance: []string{"@Storefront", "@MyTheme"}, Views: []string{"@Storefront", "@MyTheme", "@ChildTheme"}}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, inheritance.Themes, 3)
+
+	myTheme := inheritance.Themes[1]
+	assert.Equal(t, "Storefront", myTheme.Parent)
+	assert.Equal(t, []string{"Storefront", "MyPlugin", "MyTheme"}, myTheme.Views)
+	assert.Len(t, myTheme.Templates, 1)
+	assert.Equal(t, "storefront/base.html.twig", myTheme.Templates[0].Template)
+	assert.Equal(t, []string{"Storefront", "MyPlugin", "MyTheme"}, myTheme.Templates[0].ProvidedBy)
+	assert.Equal(t, "MyTheme", myTheme.Templates[0].ResolvedBy)
+
+	childTheme := inheritance.Themes[2]
+	assert.Equal(t, "MyTheme", childTheme.Parent)
+	assert.Equal(t, []string{"Storefront", "MyTheme"}, childTheme.Templates[0].ProvidedBy)
+
+	assert.Len(t, inheritance.Children("Storefront"), 1)
+	assert.Contains(t, inheritance.RenderDot(), "\"MyTheme\" -> \"ChildTheme\" [label=\"inherits\"];")
+}
+
+func TestResolveThemeInheritancePlainChildTheme(t *testing.T) {
+	storefrontViews := t.TempDir()
+	themeViews := t.TempDir()
+
+	createTestTemplate(t, storefrontViews, "storefront/base.html.twig")
+	createTestTemplate(t, storefrontViews, "storefront/layout/header.html.twig")
+	createTestTemplate(t, themeViews, "storefront/layout/header.html.twig")
+
+	inheritance, err := resolveThemeInheritance([]themeInheritanceSource{
+		{name: "Storefront", viewsDir: storefrontViews, theme: &themeJSON{}},
+		{name: "PlainTheme", viewsDir: themeViews, theme: &themeJSON{}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, inheritance.Themes, 2)
+
+	storefront := inheritance.Themes[0]
+	assert.Equal(t, []string{"Storefront"}, storefront.Views)
+	assert.Empty(t, storefront.Templates)
+
+	plainTheme := inheritance.Themes[1]
+	assert.Equal(t, "Storefront", plainTheme.Parent)
+	assert.Equal(t, []string{"Storefront", "PlainTheme"}, plainTheme.Views)
+	assert.Len(t, plainTheme.Templates, 1)
+	assert.Equal(t, "storefront/layout/header.html.twig", plainTheme.Templates[0].Template)
+	assert.Equal(t, []string{"Storefront", "PlainTheme"}, plainTheme.Templates[0].ProvidedBy)
+}
@@ -0,0 +1,124 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cp "github.com/otiai10/copy"
+)
+
+// themeCompiledAssetsDir contains the compiled storefront javascript of the theme, which is often ignored in git.
+const themeCompiledAssetsDir = "app/storefront/dist"
+
+// PlatformTheme is a plugin with a theme.json, Shopware installs it like a plugin and registers the theme on activation.
+type PlatformTheme struct {
+	*PlatformPlugin
+	theme *themeJSON
+}
+
+func newPlatformTheme(plugin *PlatformPlugin) (*PlatformTheme, error) {
+	theme, err := readThemeJSON(filepath.Join(plugin.GetResourcesDir(), "theme.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlatformTheme{PlatformPlugin: plugin, theme: theme}, nil
+}
+
+func (t PlatformTheme) Validate(c context.Context, ctx *ValidationContext) {
+	t.PlatformPlugin.Validate(c, ctx)
+
+	validateThemeReferences(ctx, t.GetResourcesDir(), t.theme)
+}
+
+// GetThemeName returns the name of the theme in the theme.json.
+func (t PlatformTheme) GetThemeName() string {
+	return t.theme.Name
+}
+
+// CompiledAssetPaths returns the paths of the compiled assets referenced by the theme.json relative to the resources dir.
+func (t PlatformTheme) CompiledAssetPaths() []string {
+	paths := []string{themeCompiledAssetsDir}
+
+	for _, script := range t.theme.Script {
+		if !isThemeBundleReference(script) && !strings.HasPrefix(script, themeCompiledAssetsDir+"/") {
+			paths = append(paths, script)
+		}
+	}
+
+	return paths
+}
+
+// CopyCompiledThemeAssets copies the compiled assets of the theme into the resources dir of the target, which are missing
+// there. The zip is created from a git checkout, which does not contain ignored build output.
+func CopyCompiledThemeAssets(theme *PlatformTheme, targetResourcesDir string) error {
+	for _, assetPath := range theme.CompiledAssetPaths() {
+		source := filepath.Join(theme.GetResourcesDir(), filepath.FromSlash(assetPath))
+		target := filepath.Join(targetResourcesDir, filepath.FromSlash(assetPath))
+
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			continue
+		}
+
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+
+		if err := cp.Copy(source, target); err != nil {
+			return fmt.Errorf("cannot copy the compiled theme assets %s: %w", assetPath, err)
+		}
+	}
+
+	return nil
+}
+
+// isThemeBundleReference returns true for entries like @Storefront or @Plugins, which reference other bundles.
+func isThemeBundleReference(entry string) bool {
+	return strings.HasPrefix(entry, "@")
+}
+
+// validateThemeReferences checks that the styles, scripts, asset folders and media config values of the theme.json exist.
+func validateThemeReferences(ctx *ValidationContext, resourcesDir string, theme *themeJSON) {
+	relResources, _ := filepath.Rel(ctx.Extension.GetPath(), resourcesDir)
+	themeFile := filepath.ToSlash(filepath.Join(relResources, "theme.json"))
+
+	check := func(kind, entry, hint string) {
+		if entry == "" || isThemeBundleReference(entry) {
+			return
+		}
+
+		if _, err := os.Stat(filepath.Join(resourcesDir, filepath.FromSlash(entry))); os.IsNotExist(err) {
+			ctx.AddFileError(themeFile, 0, fmt.Sprintf("%s: %s %s does not exist%s", themeFile, kind, entry, hint))
+		}
+	}
+
+	for _, style := range theme.Style {
+		check("style", style, "")
+	}
+
+	for _, script := range theme.Script {
+		hint := ""
+		if strings.HasPrefix(script, themeCompiledAssetsDir+"/") {
+			hint = ", build the storefront assets first"
+		}
+
+		check("script", script, hint)
+	}
+
+	for _, asset := range theme.Asset {
+		check("asset", asset, "")
+	}
+
+	for name, field := range theme.Config.Fields {
+		value, ok := field.Value.(string)
+
+		if field.Type != "media" || !ok || strings.Contains(value, "://") || strings.HasPrefix(value, "/") {
+			continue
+		}
+
+		check(fmt.Sprintf("media of config field %s", name), value, "")
+	}
+}
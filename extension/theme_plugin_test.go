@@ -0,0 +1,94 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testThemeJSON = `{
+	"name": "SwagTheme",
+	"previewMedia": "app/storefront/src/assets/preview.jpg",
+	"style": ["app/storefront/src/scss/overrides.scss", "@Storefront", "app/storefront/src/scss/base.scss"],
+	"script": ["@Storefront", "app/storefront/dist/storefront/js/swag-theme/swag-theme.js"],
+	"asset": ["@Storefront", "app/storefront/src/assets"],
+	"config": {
+		"fields": {
+			"sw-logo-desktop": {"type": "media", "value": "app/storefront/src/assets/logo.png"},
+			"sw-logo-external": {"type": "media", "value": "https://cdn.example.com/logo.png"},
+			"sw-color-brand-primary": {"type": "color", "value": "#008490"}
+		}
+	}
+}`
+
+func writeTestTheme(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	resources := filepath.Join(dir, "src", "Resources")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(resources, "app", "storefront", "src", "scss"), os.ModePerm))
+	assert.NoError(t, os.MkdirAll(filepath.Join(resources, "app", "storefront", "src", "assets"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(resources, "app", "storefront", "src", "scss", "base.scss"), []byte{}, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(resources, "theme.json"), []byte(testThemeJSON), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "composer.json"), []byte(`{"name": "swag/theme", "type": "shopware-platform-plugin", "version": "1.0.0", "extra": {"shopware-plugin-class": "Swag\\Theme\\SwagTheme"}}`), os.ModePerm))
+
+	return dir
+}
+
+func TestGetExtensionByFolderDetectsTheme(t *testing.T) {
+	ext, err := GetExtensionByFolder(writeTestTheme(t))
+	assert.NoError(t, err)
+
+	theme, ok := ext.(*PlatformTheme)
+	assert.True(t, ok)
+	assert.Equal(t, TypePlatformPlugin, theme.GetType())
+	assert.Equal(t, "SwagTheme", theme.GetThemeName())
+	assert.Equal(t, []string{"app/storefront/dist"}, theme.CompiledAssetPaths())
+}
+
+func TestValidateThemeReferences(t *testing.T) {
+	ext, err := GetExtensionByFolder(writeTestTheme(t))
+	assert.NoError(t, err)
+
+	theme := ext.(*PlatformTheme)
+	ctx := NewValidationContext(theme)
+	validateThemeReferences(ctx, theme.GetResourcesDir(), theme.theme)
+
+	assert.ElementsMatch(t, []string{
+		"src/Resources/theme.json: style app/storefront/src/scss/overrides.scss does not exist",
+		"src/Resources/theme.json: script app/storefront/dist/storefront/js/swag-theme/swag-theme.js does not exist, build the storefront assets first",
+		"src/Resources/theme.json: media of config field sw-logo-desktop app/storefront/src/assets/logo.png does not exist",
+	}, ctx.Errors())
+}
+
+func TestCopyCompiledThemeAssets(t *testing.T) {
+	dir := writeTestTheme(t)
+	compiled := filepath.Join(dir, "src", "Resources", "app", "storefront", "dist", "storefront", "js", "swag-theme")
+
+	assert.NoError(t, os.MkdirAll(compiled, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(compiled, "swag-theme.js"), []byte("js"), os.ModePerm))
+
+	ext, err := GetExtensionByFolder(dir)
+	assert.NoError(t, err)
+
+	target := t.TempDir()
+	assert.NoError(t, CopyCompiledThemeAssets(ext.(*PlatformTheme), target))
+
+	content, err := os.ReadFile(filepath.Join(target, "app", "storefront", "dist", "storefront", "js", "swag-theme", "swag-theme.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "js", string(content))
+}
+
+func TestCheckShopCompatibilityOfTheme(t *testing.T) {
+	dir := writeTestTheme(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "composer.json"), []byte(`{"name": "swag/theme", "type": "shopware-platform-plugin", "version": "1.0.0", "require": {"php": ">=8.1"}, "extra": {"shopware-plugin-class": "Swag\\Theme\\SwagTheme"}}`), os.ModePerm))
+
+	ext, err := GetExtensionByFolder(dir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ">=8.1", getPHPConstraint(ext))
+	assert.Equal(t, []string{"the extension requires PHP >=8.1, but the shop runs 7.4.33"}, CheckShopCompatibility(ext, "", "7.4.33"))
+}
@@ -0,0 +1,229 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	ThemeTokenSourceConfig = "theme.json"
+	ThemeTokenSourceScss   = "scss"
+)
+
+var (
+	scssVariableRegex = regexp.MustCompile(`^\s*\$([\w-]+)\s*:\s*(.+?)\s*(!default)?\s*;`)
+	scssSizeRegex     = regexp.MustCompile(`^-?[\d.]+(px|rem|em|%|vh|vw)$`)
+	scssNumberRegex   = regexp.MustCompile(`^-?[\d.]+$`)
+)
+
+type ThemeToken struct {
+	Name        string            `json:"name"`
+	Source      string            `json:"source"`
+	Type        string            `json:"type"`
+	Default     string            `json:"default"`
+	Label       map[string]string `json:"label,omitempty"`
+	HelpText    map[string]string `json:"helpText,omitempty"`
+	Block       string            `json:"block,omitempty"`
+	Section     string            `json:"section,omitempty"`
+	Editable    bool              `json:"editable"`
+	File        string            `json:"file,omitempty"`
+	Overridable bool              `json:"overridable"`
+}
+
+type themeJSONField struct {
+	Label    map[string]string `json:"label"`
+	HelpText map[string]string `json:"helpText"`
+	Type     string            `json:"type"`
+	Value    interface{}       `json:"value"`
+	Editable *bool             `json:"editable"`
+	Block    string            `json:"block"`
+	Section  string            `json:"section"`
+}
+
+// GetThemeTokens collects all configurable tokens of a theme from the theme.json config fields and the SCSS variables of the storefront sources.
+func GetThemeTokens(ext Extension) ([]ThemeToken, error) {
+	themeJSONPath := filepath.Join(ext.GetResourcesDir(), "theme.json")
+
+	if _, err := os.Stat(themeJSONPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("cannot find theme.json at %s", themeJSONPath)
+	}
+
+	content, err := os.ReadFile(themeJSONPath)
+	if err != nil {
+		return nil, fmt.Errorf("GetThemeTokens: %w", err)
+	}
+
+	var theme themeJSON
+	if err := json.Unmarshal(content, &theme); err != nil {
+		return nil, fmt.Errorf("cannot decode theme.json: %w", err)
+	}
+
+	tokens := themeTokensFromConfig(theme)
+
+	scssTokens, err := themeTokensFromScssFolder(filepath.Join(ext.GetResourcesDir(), "app", "storefront", "src", "scss"))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokens, scssTokens...), nil
+}
+
+func themeTokensFromConfig(theme themeJSON) []ThemeToken {
+	names := make([]string, 0, len(theme.Config.Fields))
+	for name := range theme.Config.Fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tokens := make([]ThemeToken, 0, len(names))
+
+	for _, name := range names {
+		field := theme.Config.Fields[name]
+
+		token := ThemeToken{
+			Name:     name,
+			Source:   ThemeTokenSourceConfig,
+			Type:     field.Type,
+			Label:    field.Label,
+			HelpText: field.HelpText,
+			Block:    field.Block,
+			Section:  field.Section,
+			Editable: field.Editable == nil || *field.Editable,
+		}
+
+		switch value := field.Value.(type) {
+		case nil:
+		case string:
+			token.Default = value
+		default:
+			encoded, _ := json.Marshal(value)
+			token.Default = string(encoded)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+func themeTokensFromScssFolder(folder string) ([]ThemeToken, error) {
+	tokens := make([]ThemeToken, 0)
+
+	if _, err := os.Stat(folder); os.IsNotExist(err) {
+		return tokens, nil
+	}
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".scss" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		relPath, _ := filepath.Rel(folder, path)
+
+		for _, token := range parseScssVariables(string(content)) {
+			token.File = relPath
+			tokens = append(tokens, token)
+		}
+
+		return nil
+	})
+
+	return tokens, err
+}
+
+func parseScssVariables(content string) []ThemeToken {
+	tokens := make([]ThemeToken, 0)
+
+	for _, line := range strings.Split(content, "\n") {
+		matches := scssVariableRegex.FindStringSubmatch(line)
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		tokens = append(tokens, ThemeToken{
+			Name:        matches[1],
+			Source:      ThemeTokenSourceScss,
+			Type:        guessScssValueType(matches[2]),
+			Default:     matches[2],
+			Overridable: matches[3] != "",
+		})
+	}
+
+	return tokens
+}
+
+func guessScssValueType(value string) string {
+	lower := strings.ToLower(value)
+
+	switch {
+	case strings.HasPrefix(lower, "#"), strings.HasPrefix(lower, "rgb"), strings.HasPrefix(lower, "hsl"):
+		return "color"
+	case strings.HasPrefix(lower, "$"):
+		return "reference"
+	case scssSizeRegex.MatchString(lower):
+		return "size"
+	case scssNumberRegex.MatchString(lower):
+		return "number"
+	case lower == "true" || lower == "false":
+		return "boolean"
+	}
+
+	return "string"
+}
+
+// RenderThemeTokensMarkdown renders the given tokens as a designer facing Markdown reference.
+func RenderThemeTokensMarkdown(name string, tokens []ThemeToken) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("# Theme tokens of %s\n", name))
+
+	sections := []struct {
+		source string
+		title  string
+	}{
+		{ThemeTokenSourceConfig, "Theme configuration"},
+		{ThemeTokenSourceScss, "SCSS variables"},
+	}
+
+	for _, section := range sections {
+		builder.WriteString(fmt.Sprintf("\n## %s\n\n", section.title))
+		builder.WriteString("| Name | Type | Default | Description |\n")
+		builder.WriteString("|---|---|---|---|\n")
+
+		for _, token := range tokens {
+			if token.Source != section.source {
+				continue
+			}
+
+			description := token.Label["en-GB"]
+			if help := token.HelpText["en-GB"]; help != "" {
+				description = strings.TrimSpace(description + " " + help)
+			}
+
+			if token.Source == ThemeTokenSourceScss {
+				description = token.File
+			}
+
+			builder.WriteString(fmt.Sprintf("| `%s` | %s | `%s` | %s |\n", token.Name, token.Type, strings.ReplaceAll(token.Default, "|", "\\|"), description))
+		}
+	}
+
+	return builder.String()
+}
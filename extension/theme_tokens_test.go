@@ -0,0 +1,48 @@
+package extension
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScssVariables(t *testing.T) {
+	tokens := parseScssVariables("$sw-color-brand: #008490 !default;\n// comment\n$spacer: 1.5rem;\n$border: $sw-color-brand;\n.foo { color: red; }\n")
+
+	assert.Len(t, tokens, 3)
+
+	assert.Equal(t, "sw-color-brand", tokens[0].Name)
+	assert.Equal(t, "#008490", tokens[0].Default)
+	assert.Equal(t, "color", tokens[0].Type)
+	assert.True(t, tokens[0].Overridable)
+
+	assert.Equal(t, "spacer", tokens[1].Name)
+	assert.Equal(t, "size", tokens[1].Type)
+	assert.False(t, tokens[1].Overridable)
+
+	assert.Equal(t, "reference", tokens[2].Type)
+}
+
+func TestGetThemeTokens(t *testing.T) {
+	appPath := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(path.Join(appPath, "Resources", "app", "storefront", "src", "scss"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(appPath, "Resources", "theme.json"), []byte(`{"config": {"fields": {"sw-font-family-base": {"label": {"en-GB": "Font family"}, "type": "text", "value": "Inter"}, "sw-logo-desktop": {"type": "media", "value": null, "editable": false}}}}`), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(appPath, "Resources", "app", "storefront", "src", "scss", "base.scss"), []byte("$my-radius: 4px !default;\n"), os.ModePerm))
+
+	tokens, err := GetThemeTokens(App{path: appPath})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 3)
+
+	assert.Equal(t, "sw-font-family-base", tokens[0].Name)
+	assert.Equal(t, "Inter", tokens[0].Default)
+	assert.True(t, tokens[0].Editable)
+	assert.False(t, tokens[1].Editable)
+	assert.Equal(t, "base.scss", tokens[2].File)
+
+	markdown := RenderThemeTokensMarkdown("MyTheme", tokens)
+	assert.Contains(t, markdown, "| `sw-font-family-base` | text | `Inter` | Font family |")
+	assert.Contains(t, markdown, "| `my-radius` | size | `4px` | base.scss |")
+}
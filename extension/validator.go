@@ -9,18 +9,40 @@ import (
 	"golang.org/x/net/context"
 )
 
+const (
+	ValidationSeverityError   = "error"
+	ValidationSeverityWarning = "warning"
+)
+
 type ValidationContext struct {
 	Extension Extension
 	errors    []string
 	warnings  []string
+	issues    []ValidationIssue
 }
 
-func newValidationContext(ext Extension) *ValidationContext {
+// ValidationIssue is an error or warning together with the file it was found in, used by reporters like Checkstyle.
+type ValidationIssue struct {
+	Severity string
+	Message  string
+	// File is relative to the extension root, empty when the issue is not related to a single file
+	File string
+	// Line is 0 when unknown
+	Line int
+}
+
+func NewValidationContext(ext Extension) *ValidationContext {
 	return &ValidationContext{Extension: ext}
 }
 
 func (c *ValidationContext) AddError(message string) {
+	c.AddFileError("", 0, message)
+}
+
+// AddFileError adds an error found in the given file, the path is relative to the extension root.
+func (c *ValidationContext) AddFileError(file string, line int, message string) {
 	c.errors = append(c.errors, message)
+	c.issues = append(c.issues, ValidationIssue{Severity: ValidationSeverityError, Message: message, File: file, Line: line})
 }
 
 func (c *ValidationContext) HasErrors() bool {
@@ -32,7 +54,13 @@ func (c *ValidationContext) Errors() []string {
 }
 
 func (c *ValidationContext) AddWarning(message string) {
+	c.AddFileWarning("", 0, message)
+}
+
+// AddFileWarning adds a warning found in the given file, the path is relative to the extension root.
+func (c *ValidationContext) AddFileWarning(file string, line int, message string) {
 	c.warnings = append(c.warnings, message)
+	c.issues = append(c.issues, ValidationIssue{Severity: ValidationSeverityWarning, Message: message, File: file, Line: line})
 }
 
 func (c *ValidationContext) HasWarnings() bool {
@@ -43,10 +71,21 @@ func (c *ValidationContext) Warnings() []string {
 	return c.warnings
 }
 
+// Issues returns all errors and warnings in the order they were added.
+func (c *ValidationContext) Issues() []ValidationIssue {
+	return c.issues
+}
+
 func RunValidation(ctx context.Context, ext Extension) *ValidationContext {
-	context := newValidationContext(ext)
+	context := NewValidationContext(ext)
 
 	runDefaultValidate(context)
+	validateLicenseHeaders(context)
+	validateVersionConsistency(context)
+	validateChangelogContent(context)
+	validateCustomEntities(context)
+	validateSnippetCompleteness(context)
+	ValidateLicenseCheck(context)
 	ext.Validate(ctx, context)
 
 	return context
@@ -76,6 +115,7 @@ func runDefaultValidate(context *ValidationContext) {
 	notAllowedErrorFormat := "file %s is not allowed in the zip file"
 	_ = filepath.Walk(context.Extension.GetPath(), func(path string, info fs.FileInfo, err error) error {
 		name := filepath.Base(path)
+		relPath, _ := filepath.Rel(context.Extension.GetPath(), path)
 
 		if name == ".." {
 			context.AddError("Path travel detected in zip file")
@@ -83,19 +123,19 @@ func runDefaultValidate(context *ValidationContext) {
 
 		for _, file := range defaultNotAllowedPaths {
 			if strings.HasPrefix(path, file) {
-				context.AddError(fmt.Sprintf(notAllowedErrorFormat, path))
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, path))
 			}
 		}
 
 		for _, file := range defaultNotAllowedFiles {
 			if file == name {
-				context.AddError(fmt.Sprintf(notAllowedErrorFormat, path))
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, path))
 			}
 		}
 
 		for _, ext := range defaultNotAllowedExtensions {
 			if strings.HasSuffix(name, ext) {
-				context.AddError(fmt.Sprintf(notAllowedErrorFormat, path))
+				context.AddFileError(relPath, 0, fmt.Sprintf(notAllowedErrorFormat, path))
 			}
 		}
 
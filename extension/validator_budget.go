@@ -0,0 +1,93 @@
+package extension
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const megabyte = 1024 * 1024
+
+// suspiciousArchivePaths are leftovers of the development which should never be shipped.
+var suspiciousArchivePaths = []string{
+	"node_modules/",
+	".git/",
+}
+
+// ValidateArchiveBudget checks the built zip archive against the size and file limits of the extension config.
+func ValidateArchiveBudget(context *ValidationContext, zipPath string) {
+	budget := context.Extension.GetExtensionConfig().Validation.Budget
+
+	stat, err := os.Stat(zipPath)
+	if err != nil {
+		context.AddError(fmt.Sprintf("cannot read zip file: %v", err))
+		return
+	}
+
+	if budget.MaxZipSize > 0 && stat.Size() > budget.MaxZipSize*megabyte {
+		addBudgetViolation(context, budget, fmt.Sprintf("the zip file has a size of %.2f MB and exceeds the limit of %d MB", float64(stat.Size())/megabyte, budget.MaxZipSize))
+	}
+
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		context.AddError(fmt.Sprintf("cannot open zip file: %v", err))
+		return
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	validateArchiveFiles(context, budget, reader.File)
+}
+
+func validateArchiveFiles(context *ValidationContext, budget ConfigValidationBudget, files []*zip.File) {
+	fileCount := 0
+	reportedPaths := make(map[string]bool)
+
+	for _, file := range files {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		fileCount++
+
+		if budget.MaxFileSize > 0 && file.UncompressedSize64 > uint64(budget.MaxFileSize*megabyte) {
+			context.AddWarning(fmt.Sprintf("file %s has a size of %.2f MB", file.Name, float64(file.UncompressedSize64)/megabyte))
+		}
+
+		if strings.HasSuffix(file.Name, ".map") {
+			context.AddWarning(fmt.Sprintf("file %s is a source map and should not be shipped", file.Name))
+		}
+
+		for _, suspicious := range suspiciousArchivePaths {
+			index := strings.Index("/"+file.Name, "/"+suspicious)
+			if index == -1 {
+				continue
+			}
+
+			folder := file.Name[:index+len(suspicious)]
+			if reportedPaths[folder] {
+				continue
+			}
+
+			reportedPaths[folder] = true
+			context.AddWarning(fmt.Sprintf("folder %s should not be shipped in the zip file", folder))
+		}
+	}
+
+	if budget.MaxFiles > 0 && fileCount > budget.MaxFiles {
+		addBudgetViolation(context, budget, fmt.Sprintf("the zip file contains %d files and exceeds the limit of %d files", fileCount, budget.MaxFiles))
+	}
+}
+
+// addBudgetViolation reports an exceeded limit as warning, unless the budget is configured to fail.
+func addBudgetViolation(context *ValidationContext, budget ConfigValidationBudget, message string) {
+	if budget.Fail {
+		context.AddError(message)
+		return
+	}
+
+	context.AddWarning(message)
+}
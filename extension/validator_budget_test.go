@@ -0,0 +1,79 @@
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestZip(t *testing.T, files map[string][]byte) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		f, err := writer.Create(name)
+		assert.NoError(t, err)
+
+		_, err = f.Write(content)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	return reader
+}
+
+func TestValidateArchiveFilesSuspiciousPaths(t *testing.T) {
+	reader := createTestZip(t, map[string][]byte{
+		"FroshTools/composer.json":                       []byte("{}"),
+		"FroshTools/src/Resources/app/node_modules/a.js": []byte(""),
+		"FroshTools/src/Resources/app/node_modules/b.js": []byte(""),
+		"FroshTools/.git/HEAD":                           []byte(""),
+		"FroshTools/src/Resources/public/main.js.map":    []byte(""),
+	})
+
+	ctx := &ValidationContext{}
+	validateArchiveFiles(ctx, ConfigValidationBudget{MaxFiles: 10}, reader.File)
+
+	assert.False(t, ctx.HasErrors())
+	assert.ElementsMatch(t, []string{
+		"folder FroshTools/src/Resources/app/node_modules/ should not be shipped in the zip file",
+		"folder FroshTools/.git/ should not be shipped in the zip file",
+		"file FroshTools/src/Resources/public/main.js.map is a source map and should not be shipped",
+	}, ctx.Warnings())
+}
+
+func TestValidateArchiveFilesLimits(t *testing.T) {
+	reader := createTestZip(t, map[string][]byte{
+		"FroshTools/a.php":   []byte(""),
+		"FroshTools/b.php":   []byte(""),
+		"FroshTools/big.bin": make([]byte, 2*megabyte),
+	})
+
+	ctx := &ValidationContext{}
+	validateArchiveFiles(ctx, ConfigValidationBudget{MaxFiles: 2, MaxFileSize: 1, Fail: true}, reader.File)
+
+	assert.Equal(t, []string{"the zip file contains 3 files and exceeds the limit of 2 files"}, ctx.Errors())
+	assert.Equal(t, []string{"file FroshTools/big.bin has a size of 2.00 MB"}, ctx.Warnings())
+}
+
+func TestValidateArchiveFilesLimitsWarnByDefault(t *testing.T) {
+	reader := createTestZip(t, map[string][]byte{
+		"FroshTools/a.php": []byte(""),
+		"FroshTools/b.php": []byte(""),
+		"FroshTools/c.php": []byte(""),
+	})
+
+	ctx := &ValidationContext{}
+	validateArchiveFiles(ctx, ConfigValidationBudget{MaxFiles: 2}, reader.File)
+
+	assert.False(t, ctx.HasErrors())
+	assert.Equal(t, []string{"the zip file contains 3 files and exceeds the limit of 2 files"}, ctx.Warnings())
+}
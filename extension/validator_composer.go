@@ -0,0 +1,89 @@
+package extension
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultDisallowedComposerPackages are already provided by Shopware or are forbidden in the store.
+var defaultDisallowedComposerPackages = map[string]string{
+	"shopware/platform":  "the platform package cannot be required, require shopware/core instead",
+	"symfony/polyfill-*": "the polyfills are already shipped by Shopware",
+	"composer/composer":  "composer is already provided by Shopware",
+}
+
+// warnedComposerPackages are only reported as warning, requiring them is unnecessary, but does not break the shop.
+var warnedComposerPackages = map[string]bool{
+	"symfony/polyfill-*": true,
+}
+
+// shopwarePackagesRequiringVersion are packages of the platform which need an explicit version constraint.
+var shopwarePackagesRequiringVersion = []string{
+	"shopware/core",
+	"shopware/administration",
+	"shopware/storefront",
+	"shopware/elasticsearch",
+}
+
+func validateComposerRequirements(ctx *ValidationContext, require map[string]string) {
+	cfg := ConfigValidationComposer{}
+	if extCfg := ctx.Extension.GetExtensionConfig(); extCfg != nil {
+		cfg = extCfg.Validation.Composer
+	}
+
+	disallowed := make(map[string]string)
+
+	for pattern, reason := range defaultDisallowedComposerPackages {
+		disallowed[pattern] = reason
+	}
+
+	warned := make(map[string]bool)
+
+	for pattern := range warnedComposerPackages {
+		warned[pattern] = true
+	}
+
+	for _, pattern := range cfg.DisallowedPackages {
+		disallowed[pattern] = "the package is disallowed by the extension config"
+		delete(warned, pattern)
+	}
+
+	for _, pattern := range cfg.AllowedPackages {
+		delete(disallowed, pattern)
+	}
+
+	packages := make([]string, 0, len(require))
+	for pkg := range require {
+		packages = append(packages, pkg)
+	}
+
+	sort.Strings(packages)
+
+	for _, pkg := range packages {
+		for pattern, reason := range disallowed {
+			if matched, _ := path.Match(pattern, pkg); matched {
+				if warned[pattern] {
+					ctx.AddFileWarning("composer.json", 0, fmt.Sprintf("The package %s should not be required: %s", pkg, reason))
+				} else {
+					ctx.AddFileError("composer.json", 0, fmt.Sprintf("The package %s is not allowed to be required: %s", pkg, reason))
+				}
+
+				break
+			}
+		}
+
+		for _, shopwarePackage := range shopwarePackagesRequiringVersion {
+			if pkg == shopwarePackage && isUnboundComposerConstraint(require[pkg]) {
+				ctx.AddFileError("composer.json", 0, fmt.Sprintf("The package %s needs a version constraint", pkg))
+			}
+		}
+	}
+}
+
+func isUnboundComposerConstraint(constraint string) bool {
+	constraint = strings.TrimSpace(constraint)
+
+	return constraint == "" || constraint == "*" || constraint == "dev-master" || constraint == "dev-trunk"
+}
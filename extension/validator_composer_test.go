@@ -0,0 +1,46 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposerRequirementsDefaultDenyList(t *testing.T) {
+	plugin := getTestPlugin(t.TempDir())
+	ctx := NewValidationContext(&plugin)
+
+	validateComposerRequirements(ctx, map[string]string{
+		"shopware/core":           "~6.5.0",
+		"shopware/storefront":     "*",
+		"symfony/polyfill-php80":  "^1.0",
+		"guzzlehttp/guzzle":       "^7.0",
+		"shopware/administration": "~6.5.0",
+	})
+
+	assert.Equal(t, []string{
+		"The package shopware/storefront needs a version constraint",
+	}, ctx.Errors())
+
+	assert.Equal(t, []string{
+		"The package symfony/polyfill-php80 should not be required: the polyfills are already shipped by Shopware",
+	}, ctx.Warnings())
+}
+
+func TestComposerRequirementsConfiguredDenyList(t *testing.T) {
+	plugin := getTestPlugin(t.TempDir())
+	plugin.config = &Config{}
+	plugin.config.Validation.Composer.DisallowedPackages = []string{"guzzlehttp/*"}
+	plugin.config.Validation.Composer.AllowedPackages = []string{"symfony/polyfill-*"}
+
+	ctx := NewValidationContext(&plugin)
+
+	validateComposerRequirements(ctx, map[string]string{
+		"symfony/polyfill-php80": "^1.0",
+		"guzzlehttp/guzzle":      "^7.0",
+	})
+
+	assert.Equal(t, []string{
+		"The package guzzlehttp/guzzle is not allowed to be required: the package is disallowed by the extension config",
+	}, ctx.Errors())
+}
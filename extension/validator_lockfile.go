@@ -0,0 +1,365 @@
+package extension
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// composerContentHashKeys are the keys of the composer.json, which are part of the content-hash in the composer.lock.
+var composerContentHashKeys = []string{"name", "version", "require", "require-dev", "conflict", "replace", "provide", "minimum-stability", "prefer-stable", "repositories", "extra"}
+
+// npmLockFiles are the lock files of the supported package managers, only the package-lock.json is checked for drift.
+var npmLockFiles = []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockdb"}
+
+// npmDependencyKeys are compared between the package.json and the root package of the package-lock.json.
+var npmDependencyKeys = []string{"dependencies", "devDependencies", "optionalDependencies", "peerDependencies"}
+
+// ValidateLockFiles checks that the composer.lock and package-lock.json files are up to date with their manifests. It is
+// only run for source folders, as the zip contains a composer.lock created for the store build.
+func ValidateLockFiles(ctx *ValidationContext) {
+	cfg := ConfigValidationLockFiles{}
+	if extCfg := ctx.Extension.GetExtensionConfig(); extCfg != nil {
+		cfg = extCfg.Validation.LockFiles
+	}
+
+	validateComposerLock(ctx, cfg)
+
+	root := ctx.Extension.GetPath()
+
+	for _, folder := range []string{root, filepath.Join(ctx.Extension.GetResourcesDir(), "app", "administration"), filepath.Join(ctx.Extension.GetResourcesDir(), "app", "storefront")} {
+		validateNpmLock(ctx, cfg, folder)
+	}
+}
+
+func validateComposerLock(ctx *ValidationContext, cfg ConfigValidationLockFiles) {
+	composerJson, err := os.ReadFile(filepath.Join(ctx.Extension.GetPath(), "composer.json"))
+	if err != nil {
+		return
+	}
+
+	composerLock, err := os.ReadFile(filepath.Join(ctx.Extension.GetPath(), "composer.lock"))
+	if os.IsNotExist(err) {
+		if cfg.Required {
+			ctx.AddFileError("composer.json", 0, "composer.lock is missing, commit it to make the installed dependencies reproducible")
+		}
+
+		return
+	}
+
+	if err != nil {
+		ctx.AddFileError("composer.lock", 0, fmt.Sprintf("cannot read composer.lock: %s", err.Error()))
+		return
+	}
+
+	var lock struct {
+		ContentHash string `json:"content-hash"`
+	}
+
+	if err := json.Unmarshal(composerLock, &lock); err != nil {
+		ctx.AddFileError("composer.lock", 0, fmt.Sprintf("cannot parse composer.lock: %s", err.Error()))
+		return
+	}
+
+	hash, err := composerContentHash(composerJson)
+	if err != nil {
+		ctx.AddFileError("composer.json", 0, fmt.Sprintf("cannot parse composer.json: %s", err.Error()))
+		return
+	}
+
+	if hash != lock.ContentHash {
+		ctx.AddFileError("composer.lock", 0, "composer.lock is not up to date with composer.json, run composer update --lock")
+	}
+}
+
+func validateNpmLock(ctx *ValidationContext, cfg ConfigValidationLockFiles, folder string) {
+	packageJson, err := os.ReadFile(filepath.Join(folder, "package.json"))
+	if err != nil {
+		return
+	}
+
+	relFolder, _ := filepath.Rel(ctx.Extension.GetPath(), folder)
+	relPath := func(file string) string {
+		return filepath.ToSlash(filepath.Join(relFolder, file))
+	}
+
+	// other keys like name or scripts are no dependency maps, so they are decoded one by one
+	var rawManifest map[string]json.RawMessage
+	if err := json.Unmarshal(packageJson, &rawManifest); err != nil {
+		ctx.AddFileError(relPath("package.json"), 0, fmt.Sprintf("cannot parse package.json: %s", err.Error()))
+		return
+	}
+
+	manifest := make(map[string]map[string]string)
+
+	for _, key := range npmDependencyKeys {
+		dependencies := make(map[string]string)
+
+		if raw, ok := rawManifest[key]; ok {
+			if err := json.Unmarshal(raw, &dependencies); err != nil {
+				ctx.AddFileError(relPath("package.json"), 0, fmt.Sprintf("cannot parse %s of package.json: %s", key, err.Error()))
+				return
+			}
+		}
+
+		manifest[key] = dependencies
+	}
+
+	lockFile := ""
+
+	for _, file := range npmLockFiles {
+		if _, err := os.Stat(filepath.Join(folder, file)); err == nil {
+			lockFile = file
+			break
+		}
+	}
+
+	if lockFile == "" {
+		if cfg.Required && (len(manifest["dependencies"]) > 0 || len(manifest["devDependencies"]) > 0) {
+			ctx.AddFileError(relPath("package.json"), 0, fmt.Sprintf("%s has dependencies, but no lock file like package-lock.json", relPath("package.json")))
+		}
+
+		return
+	}
+
+	if lockFile != "package-lock.json" {
+		return
+	}
+
+	content, err := os.ReadFile(filepath.Join(folder, lockFile))
+	if err != nil {
+		ctx.AddFileError(relPath(lockFile), 0, fmt.Sprintf("cannot read %s: %s", lockFile, err.Error()))
+		return
+	}
+
+	var lock struct {
+		Packages     map[string]map[string]any `json:"packages"`
+		Dependencies map[string]map[string]any `json:"dependencies"`
+	}
+
+	if err := json.Unmarshal(content, &lock); err != nil {
+		ctx.AddFileError(relPath(lockFile), 0, fmt.Sprintf("cannot parse %s: %s", lockFile, err.Error()))
+		return
+	}
+
+	outdated := false
+
+	if root, ok := lock.Packages[""]; ok {
+		for _, key := range npmDependencyKeys {
+			locked := make(map[string]string)
+
+			if dependencies, ok := root[key].(map[string]any); ok {
+				for name, constraint := range dependencies {
+					locked[name], _ = constraint.(string)
+				}
+			}
+
+			if !equalStringMaps(manifest[key], locked) {
+				outdated = true
+			}
+		}
+	} else {
+		// lockfileVersion 1 only lists the resolved packages without the constraints of the package.json
+		for _, key := range []string{"dependencies", "devDependencies"} {
+			for name := range manifest[key] {
+				if _, ok := lock.Dependencies[name]; !ok {
+					outdated = true
+				}
+			}
+		}
+	}
+
+	if outdated {
+		ctx.AddFileError(relPath(lockFile), 0, fmt.Sprintf("%s is not up to date with package.json, run npm install", relPath(lockFile)))
+	}
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// composerContentHash calculates the content-hash of the composer.lock like Composer does: the relevant keys sorted by
+// name and encoded with the default flags of json_encode in PHP.
+func composerContentHash(composerJson []byte) (string, error) {
+	var content map[string]json.RawMessage
+	if err := json.Unmarshal(composerJson, &content); err != nil {
+		return "", err
+	}
+
+	relevant := make(map[string]json.RawMessage)
+
+	for _, key := range composerContentHashKeys {
+		if value, ok := content[key]; ok {
+			relevant[key] = value
+		}
+	}
+
+	if rawConfig, ok := content["config"]; ok {
+		var config map[string]json.RawMessage
+		if err := json.Unmarshal(rawConfig, &config); err == nil {
+			if platform, ok := config["platform"]; ok {
+				relevant["config"] = json.RawMessage(`{"platform":` + string(platform) + `}`)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(relevant))
+	for key := range relevant {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var sb strings.Builder
+
+	if len(keys) == 0 {
+		sb.WriteString("[]")
+	} else {
+		sb.WriteString("{")
+
+		for i, key := range keys {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+
+			writePHPJsonString(&sb, key)
+			sb.WriteString(":")
+
+			decoder := json.NewDecoder(bytes.NewReader(relevant[key]))
+			decoder.UseNumber()
+
+			if err := writePHPJsonValue(decoder, &sb); err != nil {
+				return "", err
+			}
+		}
+
+		sb.WriteString("}")
+	}
+
+	hash := md5.Sum([]byte(sb.String())) //nolint:gosec
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// writePHPJsonValue re-encodes the next value keeping the order of the keys. PHP decodes empty objects into empty arrays.
+func writePHPJsonValue(decoder *json.Decoder, sb *strings.Builder) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		isObject := value == '{'
+
+		if isObject && !decoder.More() {
+			sb.WriteString("[]")
+			_, err := decoder.Token()
+
+			return err
+		}
+
+		if isObject {
+			sb.WriteString("{")
+		} else {
+			sb.WriteString("[")
+		}
+
+		for first := true; decoder.More(); first = false {
+			if !first {
+				sb.WriteString(",")
+			}
+
+			if isObject {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+
+				writePHPJsonString(sb, fmt.Sprint(key))
+				sb.WriteString(":")
+			}
+
+			if err := writePHPJsonValue(decoder, sb); err != nil {
+				return err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+
+		if isObject {
+			sb.WriteString("}")
+		} else {
+			sb.WriteString("]")
+		}
+	case string:
+		writePHPJsonString(sb, value)
+	case json.Number:
+		sb.WriteString(value.String())
+	case bool:
+		sb.WriteString(fmt.Sprint(value))
+	case nil:
+		sb.WriteString("null")
+	}
+
+	return nil
+}
+
+// writePHPJsonString escapes like json_encode without flags: slashes and all non-ASCII characters are escaped.
+func writePHPJsonString(sb *strings.Builder, value string) {
+	sb.WriteString(`"`)
+
+	for _, r := range value {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '/':
+			sb.WriteString(`\/`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			switch {
+			case r < 0x20:
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			case r < 0x80:
+				sb.WriteRune(r)
+			case r > 0xffff:
+				high, low := utf16.EncodeRune(r)
+				sb.WriteString(fmt.Sprintf(`\u%04x\u%04x`, high, low))
+			default:
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			}
+		}
+	}
+
+	sb.WriteString(`"`)
+}
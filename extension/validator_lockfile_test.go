@@ -0,0 +1,118 @@
+package extension
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLockComposerJson = `{
+    "name": "frosh/tools",
+    "type": "shopware-platform-plugin",
+    "require": {
+        "shopware/core": "~6.5.0"
+    },
+    "extra": {
+        "label": {"de-DE": "Tools für Shopware"},
+        "plugin-icon": "src/Resources/config/plugin.png"
+    },
+    "autoload": {},
+    "config": {
+        "platform": {"php": "8.1"},
+        "sort-packages": true
+    }
+}`
+
+func writeLockTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+}
+
+func TestComposerContentHash(t *testing.T) {
+	// json_encode of PHP escapes slashes and unicode, only the relevant keys are sorted
+	expected := md5.Sum([]byte(`{"config":{"platform":{"php":"8.1"}},"extra":{"label":{"de-DE":"Tools f\u00fcr Shopware"},"plugin-icon":"src\/Resources\/config\/plugin.png"},"name":"frosh\/tools","require":{"shopware\/core":"~6.5.0"}}`)) //nolint:gosec
+
+	hash, err := composerContentHash([]byte(testLockComposerJson))
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expected[:]), hash)
+}
+
+func TestComposerContentHashEmptyObject(t *testing.T) {
+	expected := md5.Sum([]byte(`{"extra":[],"name":"frosh\/tools"}`)) //nolint:gosec
+
+	hash, err := composerContentHash([]byte(`{"name": "frosh/tools", "extra": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expected[:]), hash)
+}
+
+func TestValidateLockFilesComposer(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	hash, err := composerContentHash([]byte(testLockComposerJson))
+	assert.NoError(t, err)
+
+	writeLockTestFile(t, filepath.Join(dir, "composer.json"), testLockComposerJson)
+	writeLockTestFile(t, filepath.Join(dir, "composer.lock"), `{"content-hash": "`+hash+`"}`)
+
+	ctx := NewValidationContext(&plugin)
+	ValidateLockFiles(ctx)
+	assert.Empty(t, ctx.Errors())
+
+	writeLockTestFile(t, filepath.Join(dir, "composer.lock"), `{"content-hash": "outdated"}`)
+
+	ctx = NewValidationContext(&plugin)
+	ValidateLockFiles(ctx)
+	assert.Equal(t, []string{"composer.lock is not up to date with composer.json, run composer update --lock"}, ctx.Errors())
+}
+
+func TestValidateLockFilesNpm(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	adminDir := filepath.Join(dir, "src", "Resources", "app", "administration")
+	storefrontDir := filepath.Join(dir, "src", "Resources", "app", "storefront")
+
+	writeLockTestFile(t, filepath.Join(adminDir, "package.json"), `{"name": "admin", "dependencies": {"lodash": "^4.17.0"}, "devDependencies": {"jest": "^29.0.0"}}`)
+	writeLockTestFile(t, filepath.Join(adminDir, "package-lock.json"), `{"lockfileVersion": 3, "packages": {"": {"dependencies": {"lodash": "^4.17.0"}, "devDependencies": {"jest": "^29.0.0"}}}}`)
+
+	writeLockTestFile(t, filepath.Join(storefrontDir, "package.json"), `{"dependencies": {"swiper": "^11.0.0", "lodash": "^4.17.0"}}`)
+	writeLockTestFile(t, filepath.Join(storefrontDir, "package-lock.json"), `{"lockfileVersion": 1, "dependencies": {"lodash": {"version": "4.17.21"}}}`)
+
+	ctx := NewValidationContext(&plugin)
+	ValidateLockFiles(ctx)
+
+	assert.Equal(t, []string{"src/Resources/app/storefront/package-lock.json is not up to date with package.json, run npm install"}, ctx.Errors())
+
+	writeLockTestFile(t, filepath.Join(adminDir, "package.json"), `{"name": "admin", "dependencies": {"lodash": "^4.17.21"}, "devDependencies": {"jest": "^29.0.0"}}`)
+	assert.NoError(t, os.Remove(filepath.Join(storefrontDir, "package-lock.json")))
+
+	ctx = NewValidationContext(&plugin)
+	ValidateLockFiles(ctx)
+
+	assert.Equal(t, []string{"src/Resources/app/administration/package-lock.json is not up to date with package.json, run npm install"}, ctx.Errors())
+}
+
+func TestValidateLockFilesRequired(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+	plugin.config = &Config{}
+	plugin.config.Validation.LockFiles.Required = true
+
+	writeLockTestFile(t, filepath.Join(dir, "composer.json"), testLockComposerJson)
+	writeLockTestFile(t, filepath.Join(dir, "src", "Resources", "app", "storefront", "package.json"), `{"dependencies": {"swiper": "^11.0.0"}}`)
+	writeLockTestFile(t, filepath.Join(dir, "src", "Resources", "app", "administration", "package.json"), `{"name": "admin"}`)
+
+	ctx := NewValidationContext(&plugin)
+	ValidateLockFiles(ctx)
+
+	assert.Equal(t, []string{
+		"composer.lock is missing, commit it to make the installed dependencies reproducible",
+		"src/Resources/app/storefront/package.json has dependencies, but no lock file like package-lock.json",
+	}, ctx.Errors())
+}
@@ -0,0 +1,311 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+var (
+	composerVersionRegex      = regexp.MustCompile(`("version"\s*:\s*")([^"]*)(")`)
+	manifestVersionRegex      = regexp.MustCompile(`(<version>)([^<]*)(</version>)`)
+	phpVersionConstantRegex   = regexp.MustCompile(`(const\s+(?:string\s+)?(?:PLUGIN_|EXTENSION_)?VERSION\s*=\s*['"])([^'"]+)(['"])`)
+	changelogVersionHeadRegex = regexp.MustCompile(`(?m)^#{1,2} (.+)$`)
+)
+
+type VersionMismatch struct {
+	// File is relative to the extension root
+	File     string
+	Found    string
+	Expected string
+}
+
+func (m VersionMismatch) String() string {
+	if m.Found == "" {
+		return fmt.Sprintf("%s does not contain version %s", m.File, m.Expected)
+	}
+
+	return fmt.Sprintf("%s contains version %s, but the extension version is %s", m.File, m.Found, m.Expected)
+}
+
+// versionFile is a file containing the version of the extension. The regex needs three groups with the version as second group.
+type versionFile struct {
+	path  string
+	regex *regexp.Regexp
+	// primary files are the composer.json and manifest.xml, only their first match is the extension version
+	primary bool
+}
+
+func (f versionFile) findVersions(content string) []string {
+	limit := -1
+	if f.primary {
+		limit = 1
+	}
+
+	versions := make([]string, 0)
+
+	for _, match := range f.regex.FindAllStringSubmatch(content, limit) {
+		versions = append(versions, match[2])
+	}
+
+	return versions
+}
+
+func getVersionFiles(ext Extension) []versionFile {
+	files := []versionFile{
+		{path: "composer.json", regex: composerVersionRegex, primary: true},
+		{path: "manifest.xml", regex: manifestVersionRegex, primary: true},
+	}
+
+	if plugin, ok := asPlatformPlugin(ext); ok {
+		if classFile := plugin.getPluginClassFile(); classFile != "" {
+			files = append(files, versionFile{path: classFile, regex: phpVersionConstantRegex})
+		}
+	}
+
+	return files
+}
+
+func asPlatformPlugin(ext Extension) (PlatformPlugin, bool) {
+	switch plugin := ext.(type) {
+	case PlatformPlugin:
+		return plugin, true
+	case *PlatformPlugin:
+		return *plugin, true
+	case *PlatformTheme:
+		return *plugin.PlatformPlugin, true
+	}
+
+	return PlatformPlugin{}, false
+}
+
+// getPluginClassFile resolves the plugin class with the psr-4 autoloading to the file relative to the plugin root.
+func (p PlatformPlugin) getPluginClassFile() string {
+	class := p.composer.Extra.ShopwarePluginClass
+
+	for prefix, dir := range p.composer.Autoload.Psr4 {
+		if !strings.HasPrefix(class, prefix) {
+			continue
+		}
+
+		return filepath.Join(dir, strings.ReplaceAll(strings.TrimPrefix(class, prefix), "\\", "/")+".php")
+	}
+
+	return ""
+}
+
+// CheckVersionConsistency compares the version of the extension with composer.json, manifest.xml, the version constants of the plugin class and the newest changelog entries.
+func CheckVersionConsistency(ext Extension) ([]VersionMismatch, error) {
+	extVersion, err := ext.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	expected := extVersion.String()
+	mismatches := make([]VersionMismatch, 0)
+
+	for _, file := range getVersionFiles(ext) {
+		content, err := os.ReadFile(filepath.Join(ext.GetPath(), file.path))
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, found := range file.findVersions(string(content)) {
+			if !sameVersion(found, extVersion) {
+				mismatches = append(mismatches, VersionMismatch{File: file.path, Found: found, Expected: expected})
+			}
+		}
+	}
+
+	changelogFiles, err := filepath.Glob(filepath.Join(ext.GetPath(), "CHANGELOG*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, changelogFile := range changelogFiles {
+		content, err := os.ReadFile(changelogFile)
+		if err != nil {
+			return nil, err
+		}
+
+		newest := newestChangelogVersion(string(content))
+		if newest == "" || !sameVersion(newest, extVersion) {
+			mismatches = append(mismatches, VersionMismatch{File: filepath.Base(changelogFile), Found: newest, Expected: expected})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// newestChangelogVersion returns the first heading which is a valid version, headings like "Unreleased" are skipped.
+func newestChangelogVersion(content string) string {
+	for _, match := range changelogVersionHeadRegex.FindAllStringSubmatch(content, -1) {
+		heading := parseKeepAChangelogVersion(match[1])
+
+		if _, err := version.NewVersion(heading); err == nil {
+			return heading
+		}
+	}
+
+	return ""
+}
+
+func sameVersion(value string, expected *version.Version) bool {
+	v, err := version.NewVersion(value)
+	if err != nil {
+		return false
+	}
+
+	return v.Equal(expected)
+}
+
+// BumpVersion increases the major, minor or patch segment of the version. Any other value is used as the new version.
+func BumpVersion(current *version.Version, bump string) (string, error) {
+	segments := append(current.Segments(), 0, 0, 0)
+
+	switch bump {
+	case "major":
+		return fmt.Sprintf("%d.0.0", segments[0]+1), nil
+	case "minor":
+		return fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1), nil
+	case "patch":
+		return fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1), nil
+	}
+
+	newVersion, err := version.NewVersion(bump)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %s, use major, minor, patch or a version: %w", bump, err)
+	}
+
+	return newVersion.String(), nil
+}
+
+// SetExtensionVersion writes the version into composer.json or manifest.xml. With updateAll the version constants of the plugin class
+// and the changelogs are updated as well: an "Unreleased" heading is renamed, otherwise a new heading is added on top.
+// It returns the changed files relative to the extension root.
+func SetExtensionVersion(ext Extension, newVersion string, updateAll bool) ([]string, error) {
+	changed := make([]string, 0)
+
+	for _, file := range getVersionFiles(ext) {
+		if !file.primary && !updateAll {
+			continue
+		}
+
+		filePath := filepath.Join(ext.GetPath(), file.path)
+
+		content, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		updated := replaceVersion(string(content), file.regex, newVersion, file.primary)
+		if updated == string(content) {
+			continue
+		}
+
+		if err := os.WriteFile(filePath, []byte(updated), os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		changed = append(changed, file.path)
+	}
+
+	if !updateAll {
+		return changed, nil
+	}
+
+	changelogFiles, err := filepath.Glob(filepath.Join(ext.GetPath(), "CHANGELOG*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, changelogFile := range changelogFiles {
+		content, err := os.ReadFile(changelogFile)
+		if err != nil {
+			return nil, err
+		}
+
+		updated := addChangelogVersion(string(content), newVersion, time.Now())
+		if updated == string(content) {
+			continue
+		}
+
+		if err := os.WriteFile(changelogFile, []byte(updated), os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		changed = append(changed, filepath.Base(changelogFile))
+	}
+
+	return changed, nil
+}
+
+// replaceVersion replaces the version matched by the regex, with onlyFirst nested version fields (e.g. of composer requirements) are kept.
+func replaceVersion(content string, regex *regexp.Regexp, newVersion string, onlyFirst bool) string {
+	replaced := false
+
+	return regex.ReplaceAllStringFunc(content, func(match string) string {
+		if onlyFirst && replaced {
+			return match
+		}
+
+		replaced = true
+		groups := regex.FindStringSubmatch(match)
+
+		return groups[1] + newVersion + groups[3]
+	})
+}
+
+// addChangelogVersion adds a heading for the version unless the newest entry already is this version.
+func addChangelogVersion(content, newVersion string, date time.Time) string {
+	if newestChangelogVersion(content) == newVersion {
+		return content
+	}
+
+	if !isKeepAChangelog(content) {
+		return fmt.Sprintf("# %s\n\n", newVersion) + content
+	}
+
+	heading := fmt.Sprintf("## [%s] - %s", newVersion, date.Format("2006-01-02"))
+	lines := strings.Split(content, "\n")
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+
+		if strings.EqualFold(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "## ")), "[]"), "unreleased") {
+			lines[i] = heading
+		} else {
+			lines = append(lines[:i], append([]string{heading, ""}, lines[i:]...)...)
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	return strings.TrimRight(content, "\n") + "\n\n" + heading + "\n"
+}
+
+func validateVersionConsistency(ctx *ValidationContext) {
+	mismatches, err := CheckVersionConsistency(ctx.Extension)
+	if err != nil {
+		return
+	}
+
+	for _, mismatch := range mismatches {
+		ctx.AddFileError(mismatch.File, 0, mismatch.String())
+	}
+}
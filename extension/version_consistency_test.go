@@ -0,0 +1,96 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+func writeVersionTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for file, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), os.ModePerm))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), os.ModePerm))
+	}
+}
+
+func TestCheckVersionConsistency(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"composer.json":        "{\n    \"version\": \"1.0.0\",\n    \"require\": {\"shopware/core\": \"~6.5.0\"}\n}\n",
+		"src/FroshTools.php":   "<?php\n\nclass FroshTools\n{\n    public const PLUGIN_VERSION = '0.9.0';\n    public const MIN_SHOPWARE_VERSION = '6.5.0';\n}\n",
+		"CHANGELOG_en-GB.md":   "# 1.0.0\n\n- First release\n",
+		"CHANGELOG_de-DE.md":   "# 0.9.0\n\n- Beta\n",
+		"CHANGELOG_Unknown.md": "Nothing",
+	})
+
+	mismatches, err := CheckVersionConsistency(plugin)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []VersionMismatch{
+		{File: "src/FroshTools.php", Found: "0.9.0", Expected: "1.0.0"},
+		{File: "CHANGELOG_de-DE.md", Found: "0.9.0", Expected: "1.0.0"},
+		{File: "CHANGELOG_Unknown.md", Found: "", Expected: "1.0.0"},
+	}, mismatches)
+	assert.Equal(t, "CHANGELOG_Unknown.md does not contain version 1.0.0", VersionMismatch{File: "CHANGELOG_Unknown.md", Expected: "1.0.0"}.String())
+	assert.Equal(t, "src/FroshTools.php contains version 0.9.0, but the extension version is 1.0.0", VersionMismatch{File: "src/FroshTools.php", Found: "0.9.0", Expected: "1.0.0"}.String())
+}
+
+func TestSetExtensionVersion(t *testing.T) {
+	dir := t.TempDir()
+	plugin := getTestPlugin(dir)
+
+	writeVersionTestFiles(t, dir, map[string]string{
+		"composer.json":      "{\n    \"version\": \"1.0.0\"\n}\n",
+		"src/FroshTools.php": "<?php\n\nclass FroshTools\n{\n    public const string VERSION = \"1.0.0\";\n}\n",
+		"CHANGELOG_en-GB.md": "# 1.0.0\n\n- First release\n",
+	})
+
+	changed, err := SetExtensionVersion(plugin, "1.1.0", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"composer.json"}, changed)
+
+	changed, err = SetExtensionVersion(plugin, "1.1.0", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/FroshTools.php", "CHANGELOG_en-GB.md"}, changed)
+
+	composer, _ := os.ReadFile(filepath.Join(dir, "composer.json"))
+	assert.Equal(t, "{\n    \"version\": \"1.1.0\"\n}\n", string(composer))
+
+	class, _ := os.ReadFile(filepath.Join(dir, "src/FroshTools.php"))
+	assert.Contains(t, string(class), "public const string VERSION = \"1.1.0\";")
+
+	changelog, _ := os.ReadFile(filepath.Join(dir, "CHANGELOG_en-GB.md"))
+	assert.Equal(t, "# 1.1.0\n\n# 1.0.0\n\n- First release\n", string(changelog))
+}
+
+func TestAddChangelogVersionKeepAChangelog(t *testing.T) {
+	date := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	unreleased := "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Feature\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- First\n"
+	assert.Equal(t, "# Changelog\n\n## [1.1.0] - 2024-02-01\n\n### Added\n\n- Feature\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- First\n", addChangelogVersion(unreleased, "1.1.0", date))
+
+	released := "# Changelog\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- First\n"
+	assert.Equal(t, "# Changelog\n\n## [1.1.0] - 2024-02-01\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- First\n", addChangelogVersion(released, "1.1.0", date))
+	assert.Equal(t, released, addChangelogVersion(released, "1.0.0", date))
+}
+
+func TestBumpVersion(t *testing.T) {
+	current := version.Must(version.NewVersion("1.2.3"))
+
+	for bump, expected := range map[string]string{"major": "2.0.0", "minor": "1.3.0", "patch": "1.2.4", "v3.0.0": "3.0.0"} {
+		newVersion, err := BumpVersion(current, bump)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, newVersion)
+	}
+
+	_, err := BumpVersion(current, "next")
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package extension
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"context"
 	"encoding/json"
 	"encoding/xml"
@@ -11,13 +12,15 @@ import (
 	"io/fs"
 	"net/http"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/FriendsOfShopware/shopware-cli/internal/changelog"
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/internal/staticdata"
 
 	"github.com/FriendsOfShopware/shopware-cli/logging"
 	"github.com/FriendsOfShopware/shopware-cli/version"
@@ -110,49 +113,146 @@ func Unzip(r *zip.Reader, dest string) error {
 	return nil
 }
 
-func CreateZip(baseFolder, zipFile string) error {
+// CreateZip packs the folder into the zip file. When it fails or the context is canceled, the partial zip file is removed.
+func CreateZip(ctx context.Context, baseFolder, zipFile string, pack ConfigZipPack) error {
 	// Get a Buffer to Write To
 	outFile, err := os.Create(zipFile)
 	if err != nil {
 		return fmt.Errorf("create zipfile: %w", err)
 	}
 
-	defer func() {
+	if err := writeZip(ctx, outFile, baseFolder, pack); err != nil {
 		_ = outFile.Close()
-	}()
+		_ = os.Remove(zipFile)
+
+		return err
+	}
+
+	if err := outFile.Close(); err != nil {
+		_ = os.Remove(zipFile)
+
+		return fmt.Errorf("create zipfile: %w", err)
+	}
+
+	return nil
+}
 
+func writeZip(ctx context.Context, outFile io.Writer, baseFolder string, pack ConfigZipPack) error {
 	// Create a new zip archive.
 	w := zip.NewWriter(outFile)
 
-	defer func() {
-		_ = w.Close()
-	}()
+	if pack.Compression.Level != nil {
+		level := *pack.Compression.Level
+
+		w.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+
+	var modified time.Time
+
+	if pack.Reproducible {
+		var err error
+		if modified, err = SourceDateEpoch(); err != nil {
+			return err
+		}
+	}
+
+	if err := addZipFiles(ctx, w, baseFolder, "", pack, modified); err != nil {
+		return err
+	}
 
-	return AddZipFiles(w, baseFolder, "")
+	return w.Close()
 }
 
 func AddZipFiles(w *zip.Writer, basePath, baseInZip string) error {
+	return addZipFiles(context.Background(), w, basePath, baseInZip, ConfigZipPack{}, time.Time{})
+}
+
+// addZipFiles adds the files sorted by name, in reproducible mode with the modified timestamp.
+func addZipFiles(ctx context.Context, w *zip.Writer, basePath, baseInZip string, pack ConfigZipPack, modified time.Time) error {
 	files, err := os.ReadDir(basePath)
 	if err != nil {
 		return fmt.Errorf("could not zip dir, basePath: %q, baseInZip: %q, %w", basePath, baseInZip, err)
 	}
 
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if file.IsDir() {
 			// Add files of directory recursively
-			if err = AddZipFiles(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name())); err != nil {
+			if err = addZipFiles(ctx, w, filepath.Join(basePath, file.Name()), path.Join(baseInZip, file.Name()), pack, modified); err != nil {
 				return err
 			}
-		} else {
-			if err = addFileToZip(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name())); err != nil {
+
+			continue
+		}
+
+		// zip entries always use forward slashes, also on Windows
+		zipPath := path.Join(baseInZip, file.Name())
+
+		if pack.MaxFileSize > 0 {
+			info, err := file.Info()
+			if err != nil {
+				return err
+			}
+
+			if info.Size() > pack.MaxFileSize*megabyte {
+				return fmt.Errorf("file %s has a size of %.2f MB and exceeds build.zip.pack.max_file_size of %d MB, exclude it with build.zip.pack.excludes.paths or raise the limit", zipPath, float64(info.Size())/megabyte, pack.MaxFileSize)
+			}
+		}
+
+		header := &zip.FileHeader{Name: zipPath, Method: pack.Compression.method(zipPath)}
+
+		if pack.Reproducible {
+			if err := normalizeZipHeader(header, file, modified); err != nil {
 				return err
 			}
 		}
+
+		if err = addFileToZip(w, filepath.Join(basePath, file.Name()), header); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// method returns zip.Store for files matching a store pattern and zip.Deflate otherwise.
+// Patterns without a slash match the file name, others the path inside the zip.
+func (c ConfigZipCompression) method(zipPath string) uint16 {
+	if c.Level != nil && *c.Level == 0 {
+		return zip.Store
+	}
+
+	if matchesZipPattern(c.Deflate, zipPath) {
+		return zip.Deflate
+	}
+
+	if matchesZipPattern(c.Store, zipPath) {
+		return zip.Store
+	}
+
+	return zip.Deflate
+}
+
+func matchesZipPattern(patterns []string, zipPath string) bool {
+	for _, pattern := range patterns {
+		subject := path.Base(zipPath)
+		if strings.Contains(pattern, "/") {
+			subject = zipPath
+		}
+
+		if matched, _ := path.Match(pattern, subject); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 func CleanupExtensionFolder(path string, additionalPaths []string) error {
 	defaultNotAllowedPaths = append(defaultNotAllowedPaths, additionalPaths...)
 
@@ -240,6 +340,7 @@ func PrepareFolderForZipping(ctx context.Context, path string, ext Extension, ex
 	filtered := filterRequires(composer, extCfg)
 
 	if len(filtered["require"].(map[string]interface{})) == 0 {
+		logging.FromContext(ctx).Infof("No third-party Composer dependencies, skipping composer install")
 		return nil
 	}
 
@@ -264,7 +365,7 @@ func PrepareFolderForZipping(ctx context.Context, path string, ext Extension, ex
 	}
 
 	// Execute composer in this directory
-	composerInstallCmd := exec.Command("composer", "install", "-d", path, "--no-dev", "-n", "-o")
+	composerInstallCmd := process.Command(ctx, "composer", "install", "-d", path, "--no-dev", "-n", "-o")
 	composerInstallCmd.Stdout = os.Stdout
 	composerInstallCmd.Stderr = os.Stderr
 	err = composerInstallCmd.Run()
@@ -279,20 +380,66 @@ func PrepareFolderForZipping(ctx context.Context, path string, ext Extension, ex
 	return nil
 }
 
-func addFileToZip(zipWriter *zip.Writer, sourcePath string, zipPath string) error {
+// normalizeZipHeader sets the same timestamp for all files and only keeps the executable bit of the permissions,
+// so the zip does not depend on the checkout time or umask.
+func normalizeZipHeader(header *zip.FileHeader, file fs.DirEntry, modified time.Time) error {
+	info, err := file.Info()
+	if err != nil {
+		return err
+	}
+
+	header.Modified = modified
+
+	if info.Mode()&0o111 != 0 {
+		header.SetMode(0o755)
+	} else {
+		header.SetMode(0o644)
+	}
+
+	return nil
+}
+
+// zipEpoch is the oldest timestamp a zip file can store.
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SourceDateEpoch returns the timestamp of the SOURCE_DATE_EPOCH environment variable, which reproducible builds use
+// for all generated files, and the oldest zip timestamp without it.
+func SourceDateEpoch() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return zipEpoch, nil
+	}
+
+	modified, err := parseSourceDateEpoch(epoch)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if modified.Before(zipEpoch) {
+		return zipEpoch, nil
+	}
+
+	return modified, nil
+}
+
+// addFileToZip streams the file into the zip, so big files are never loaded into memory.
+func addFileToZip(zipWriter *zip.Writer, sourcePath string, header *zip.FileHeader) error {
 	zipErrorFormat := "could not zip file, sourcePath: %q, zipPath: %q, %w"
+	zipPath := header.Name
 
-	dat, err := os.ReadFile(sourcePath)
+	source, err := os.Open(sourcePath)
 	if err != nil {
 		return fmt.Errorf(zipErrorFormat, sourcePath, zipPath, err)
 	}
 
-	f, err := zipWriter.Create(zipPath)
+	defer source.Close()
+
+	f, err := zipWriter.CreateHeader(header)
 	if err != nil {
 		return fmt.Errorf(zipErrorFormat, sourcePath, zipPath, err)
 	}
 
-	if _, err := f.Write(dat); err != nil {
+	if _, err := io.Copy(f, source); err != nil {
 		return fmt.Errorf(zipErrorFormat, sourcePath, zipPath, err)
 	}
 
@@ -382,30 +529,9 @@ func addComposerReplacements(ctx context.Context, composer map[string]interface{
 }
 
 func lookupForMinMatchingVersion(ctx context.Context, versionConstraint *version.Constraints) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://swagger.docs.fos.gg/composer/versions.json", http.NoBody)
-	if err != nil {
-		return "", fmt.Errorf("create composer version request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("fetch composer versions: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logging.FromContext(ctx).Errorf("lookupForMinMatchingVersion: %v", err)
-		}
-	}()
-
-	versionString, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("read version body: %w", err)
-	}
-
-	var versions []string
-	err = json.Unmarshal(versionString, &versions)
+	versions, err := staticdata.ShopwareVersions(ctx)
 	if err != nil {
-		return "", fmt.Errorf("unmarshal composer versions: %w", err)
+		return "", err
 	}
 
 	return getMinMatchingVersion(versionConstraint, versions)
@@ -463,7 +589,7 @@ func PrepareExtensionForRelease(ctx context.Context, sourceRoot, extensionRoot s
 
 		changelogFile := fmt.Sprintf("# %s\n%s", v.String(), content)
 
-		if err := os.WriteFile(path.Join(extensionRoot, "CHANGELOG_en-GB.md"), []byte(changelogFile), os.ModePerm); err != nil {
+		if err := os.WriteFile(filepath.Join(extensionRoot, "CHANGELOG_en-GB.md"), []byte(changelogFile), os.ModePerm); err != nil {
 			return err
 		}
 	}
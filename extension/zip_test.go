@@ -1,7 +1,11 @@
 package extension
 
 import (
+	"archive/zip"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -24,3 +28,109 @@ func TestDetermineMinVersion(t *testing.T) {
 	matchingVersion, _ = getMinMatchingVersion(&constraint, []string{"6.5.0.0-rc1", "abc", "6.4.0.0"})
 	assert.Equal(t, "6.5.0.0-rc1", matchingVersion)
 }
+
+func TestCreateZipCompression(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "FroshTools", "src", "Resources", "public"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "FroshTools", "composer.json"), []byte("{}"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "FroshTools", "src", "Resources", "public", "logo.png"), []byte("png"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "FroshTools", "src", "Resources", "public", "icon.svg"), []byte("<svg/>"), os.ModePerm))
+
+	level := 9
+	zipFile := filepath.Join(t.TempDir(), "FroshTools.zip")
+	compression := ConfigZipCompression{Level: &level, Store: []string{"*.png", "FroshTools/src/Resources/public/*"}, Deflate: []string{"*.svg"}}
+
+	assert.NoError(t, CreateZip(getTestContext(), dir, zipFile, ConfigZipPack{Compression: compression}))
+
+	reader, err := zip.OpenReader(zipFile)
+	assert.NoError(t, err)
+
+	defer reader.Close()
+
+	methods := map[string]uint16{}
+	for _, file := range reader.File {
+		methods[file.Name] = file.Method
+	}
+
+	assert.Equal(t, map[string]uint16{
+		"FroshTools/composer.json":                 zip.Deflate,
+		"FroshTools/src/Resources/public/icon.svg": zip.Deflate,
+		"FroshTools/src/Resources/public/logo.png": zip.Store,
+	}, methods)
+
+	level = 0
+	assert.Equal(t, zip.Store, compression.method("FroshTools/composer.json"))
+}
+
+func TestCreateZipMaxFileSize(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "FroshTools"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "FroshTools", "composer.json"), []byte("{}"), os.ModePerm))
+
+	export, err := os.Create(filepath.Join(dir, "FroshTools", "export.sql"))
+	assert.NoError(t, err)
+	assert.NoError(t, export.Truncate(2*megabyte))
+	assert.NoError(t, export.Close())
+
+	zipFile := filepath.Join(t.TempDir(), "FroshTools.zip")
+
+	err = CreateZip(getTestContext(), dir, zipFile, ConfigZipPack{MaxFileSize: 1})
+	assert.ErrorContains(t, err, "file FroshTools/export.sql has a size of 2.00 MB and exceeds build.zip.pack.max_file_size of 1 MB")
+
+	assert.NoError(t, CreateZip(getTestContext(), dir, zipFile, ConfigZipPack{MaxFileSize: 0}))
+}
+
+func TestCreateZipReproducible(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+	createSources := func(mode os.FileMode, modified time.Time) string {
+		dir := t.TempDir()
+		pluginDir := filepath.Join(dir, "FroshTools")
+
+		assert.NoError(t, os.MkdirAll(filepath.Join(pluginDir, "bin"), os.ModePerm))
+		assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "composer.json"), []byte("{}"), mode))
+		assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "bin", "build.sh"), []byte("#!/bin/sh"), 0o775))
+		assert.NoError(t, os.Chtimes(filepath.Join(pluginDir, "composer.json"), modified, modified))
+
+		return dir
+	}
+
+	first := filepath.Join(t.TempDir(), "first.zip")
+	second := filepath.Join(t.TempDir(), "second.zip")
+
+	assert.NoError(t, CreateZip(getTestContext(), createSources(0o600, time.Now()), first, ConfigZipPack{Reproducible: true}))
+	assert.NoError(t, CreateZip(getTestContext(), createSources(0o664, time.Now().Add(-time.Hour)), second, ConfigZipPack{Reproducible: true}))
+
+	firstContent, err := os.ReadFile(first)
+	assert.NoError(t, err)
+
+	secondContent, err := os.ReadFile(second)
+	assert.NoError(t, err)
+
+	assert.Equal(t, firstContent, secondContent)
+
+	reader, err := zip.OpenReader(first)
+	assert.NoError(t, err)
+
+	defer reader.Close()
+
+	assert.Equal(t, "FroshTools/bin/build.sh", reader.File[0].Name)
+	assert.Equal(t, os.FileMode(0o755), reader.File[0].Mode())
+	assert.Equal(t, os.FileMode(0o644), reader.File[1].Mode())
+	assert.Equal(t, int64(1700000000), reader.File[1].Modified.Unix())
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+
+	epoch, err := SourceDateEpoch()
+	assert.NoError(t, err)
+	assert.Equal(t, 1980, epoch.Year())
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+
+	_, err = SourceDateEpoch()
+	assert.ErrorContains(t, err, "invalid SOURCE_DATE_EPOCH yesterday")
+}
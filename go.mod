@@ -18,6 +18,7 @@ require (
 	github.com/otiai10/copy v1.12.0
 	github.com/sashabaranov/go-openai v1.15.3
 	github.com/spf13/cobra v1.7.0
+	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.8.4
 	github.com/vulcand/oxy/v2 v2.0.0-20230427132221-be5cf38f3c1c
 	github.com/yuin/goldmark v1.5.6
@@ -43,7 +44,6 @@ require (
 	github.com/klauspost/compress v1.17.0
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.4.4 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/net v0.15.0
 	golang.org/x/oauth2 v0.11.0 // indirect
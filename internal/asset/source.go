@@ -3,4 +3,13 @@ package asset
 type Source struct {
 	Name string
 	Path string
+	// AdministrationWebpackConfig is an additional webpack config which is merged into the administration build
+	AdministrationWebpackConfig string
+	// StorefrontWebpackConfig is an additional webpack config which is merged into the storefront build
+	StorefrontWebpackConfig string
+}
+
+// HasCustomWebpackConfig returns true when the source declares an additional webpack config.
+func (s Source) HasCustomWebpackConfig() bool {
+	return s.AdministrationWebpackConfig != "" || s.StorefrontWebpackConfig != ""
 }
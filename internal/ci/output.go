@@ -0,0 +1,95 @@
+package ci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const defaultDotenvFile = "shopware-cli.env"
+
+// IsGitHubActions returns true when running inside GitHub Actions.
+func IsGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// IsGitLabCI returns true when running inside GitLab CI.
+func IsGitLabCI() bool {
+	return os.Getenv("GITLAB_CI") == "true"
+}
+
+// IsCI returns true when running inside any CI system. Most of them set the CI variable.
+func IsCI() bool {
+	switch strings.ToLower(os.Getenv("CI")) {
+	case "true", "1":
+		return true
+	}
+
+	return IsGitHubActions() || IsGitLabCI()
+}
+
+// SetOutputs exports the given values for later pipeline stages. GitHub Actions receives them as step outputs,
+// GitLab CI as dotenv file which can be used as artifacts:reports:dotenv. Outside of a CI nothing is written.
+func SetOutputs(ctx context.Context, outputs map[string]string) {
+	if IsGitHubActions() {
+		if err := appendToFile(os.Getenv("GITHUB_OUTPUT"), formatOutputs(outputs, false)); err != nil {
+			logging.FromContext(ctx).Errorf("Cannot write GitHub Actions outputs: %v", err)
+		}
+	}
+
+	if IsGitLabCI() {
+		dotenvFile := os.Getenv("SHOPWARE_CLI_DOTENV_FILE")
+		if dotenvFile == "" {
+			dotenvFile = defaultDotenvFile
+		}
+
+		if err := appendToFile(dotenvFile, formatOutputs(outputs, true)); err != nil {
+			logging.FromContext(ctx).Errorf("Cannot write GitLab CI dotenv file: %v", err)
+		}
+	}
+}
+
+func formatOutputs(outputs map[string]string, dotenv bool) string {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var builder strings.Builder
+
+	for _, key := range keys {
+		value := strings.ReplaceAll(outputs[key], "\n", " ")
+
+		if dotenv {
+			key = "SHOPWARE_CLI_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		}
+
+		builder.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	}
+
+	return builder.String()
+}
+
+func appendToFile(file, content string) error {
+	if file == "" {
+		return fmt.Errorf("output file is not set")
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,38 @@
+package ci
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOutputsGitHub(t *testing.T) {
+	outputFile := path.Join(t.TempDir(), "output")
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("GITHUB_OUTPUT", outputFile)
+
+	SetOutputs(context.Background(), map[string]string{"version": "1.0.0", "artifact-path": "/tmp/Foo.zip"})
+
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "artifact-path=/tmp/Foo.zip\nversion=1.0.0\n", string(content))
+}
+
+func TestSetOutputsGitLab(t *testing.T) {
+	outputFile := path.Join(t.TempDir(), "build.env")
+
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("SHOPWARE_CLI_DOTENV_FILE", outputFile)
+
+	SetOutputs(context.Background(), map[string]string{"validation-status": "failed"})
+
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "SHOPWARE_CLI_VALIDATION_STATUS=failed\n", string(content))
+}
@@ -0,0 +1,222 @@
+// Package cliplugin discovers external subcommands. Like git, every executable named shopware-cli-<name> on the PATH
+// becomes the subcommand <name>. Plugins can also be installed with a plugin.yml manifest in a plugin directory.
+package cliplugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExecutablePrefix = "shopware-cli-"
+	ManifestFile     = "plugin.yml"
+
+	SourcePath     = "path"
+	SourceManifest = "manifest"
+)
+
+type Plugin struct {
+	Name  string `json:"name"`
+	Short string `json:"short"`
+	// Executable is the absolute path of the program to run
+	Executable string `json:"executable"`
+	Source     string `json:"source"`
+}
+
+type manifest struct {
+	Name    string `yaml:"name"`
+	Short   string `yaml:"short"`
+	Command string `yaml:"command"`
+}
+
+// ManifestDirs returns the plugin directory next to the global config file and the directories of
+// SHOPWARE_CLI_PLUGIN_PATH. Without config file passed by --config, the user config dir is used.
+func ManifestDirs(configFile string) []string {
+	dirs := make([]string, 0)
+
+	if configFile != "" {
+		dirs = append(dirs, filepath.Join(filepath.Dir(configFile), "shopware-cli", "plugins"))
+	} else if configDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(configDir, "shopware-cli", "plugins"))
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("SHOPWARE_CLI_PLUGIN_PATH")) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// ConfigFlag returns the value of --config in the arguments, which are not parsed yet when the plugins are discovered
+// and are passed unparsed to plugins.
+func ConfigFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			return value
+		}
+
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// Discover returns all plugins sorted by name. Manifest plugins take precedence over executables on the PATH,
+// for the same name on the PATH the first directory wins.
+func Discover(pathEnv string, manifestDirs []string) ([]Plugin, error) {
+	plugins := make(map[string]Plugin)
+
+	for _, dir := range filepath.SplitList(pathEnv) {
+		for _, plugin := range discoverExecutables(dir) {
+			if _, ok := plugins[plugin.Name]; !ok {
+				plugins[plugin.Name] = plugin
+			}
+		}
+	}
+
+	manifestPlugins := make(map[string]bool)
+
+	for _, dir := range manifestDirs {
+		found, err := discoverManifests(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plugin := range found {
+			if manifestPlugins[plugin.Name] {
+				continue
+			}
+
+			manifestPlugins[plugin.Name] = true
+			plugins[plugin.Name] = plugin
+		}
+	}
+
+	list := make([]Plugin, 0, len(plugins))
+	for _, plugin := range plugins {
+		list = append(list, plugin)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func discoverExecutables(dir string) []Plugin {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	plugins := make([]Plugin, 0)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, ExecutablePrefix) || entry.IsDir() {
+			continue
+		}
+
+		file := filepath.Join(dir, name)
+		if !isExecutable(file) {
+			continue
+		}
+
+		if runtime.GOOS == "windows" {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+
+		plugins = append(plugins, Plugin{
+			Name:       strings.TrimPrefix(name, ExecutablePrefix),
+			Short:      fmt.Sprintf("External command %s", name),
+			Executable: file,
+			Source:     SourcePath,
+		})
+	}
+
+	return plugins
+}
+
+func isExecutable(file string) bool {
+	stat, err := os.Stat(file)
+	if err != nil || stat.IsDir() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+
+	return stat.Mode()&0o111 != 0
+}
+
+// discoverManifests reads <dir>/<plugin>/plugin.yml files. A missing directory has no plugins.
+func discoverManifests(dir string) ([]Plugin, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make([]Plugin, 0)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var m manifest
+		if err := yaml.Unmarshal(content, &m); err != nil {
+			return nil, fmt.Errorf("cannot parse plugin manifest %s: %w", file, err)
+		}
+
+		if m.Name == "" || m.Command == "" {
+			return nil, fmt.Errorf("plugin manifest %s requires name and command", file)
+		}
+
+		executable := m.Command
+		if !filepath.IsAbs(executable) {
+			executable = filepath.Join(filepath.Dir(file), executable)
+		}
+
+		plugins = append(plugins, Plugin{
+			Name:       m.Name,
+			Short:      m.Short,
+			Executable: executable,
+			Source:     SourceManifest,
+		})
+	}
+
+	return plugins, nil
+}
+
+// Environment returns the variables passed to a plugin, so it can reuse the configuration and log level of the CLI.
+func Environment(binary, cliVersion, configFile string, verbose bool) []string {
+	env := append(os.Environ(),
+		"SHOPWARE_CLI_BIN="+binary,
+		"SHOPWARE_CLI_VERSION="+cliVersion,
+		"SHOPWARE_CLI_CONFIG="+configFile,
+	)
+
+	if verbose {
+		env = append(env, "SHOPWARE_CLI_VERBOSE=1")
+	}
+
+	return env
+}
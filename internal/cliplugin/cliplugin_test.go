@@ -0,0 +1,69 @@
+package cliplugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bits are not supported on windows")
+	}
+
+	binA := t.TempDir()
+	binB := t.TempDir()
+	pluginDir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(binA, "shopware-cli-deploy"), []byte("#!/bin/sh"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(binA, "shopware-cli-notes"), []byte("text"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(binB, "shopware-cli-deploy"), []byte("#!/bin/sh"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(binB, "shopware-cli-lint"), []byte("#!/bin/sh"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(binB, "other"), []byte("#!/bin/sh"), 0o755))
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(pluginDir, "company", "bin"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "company", ManifestFile), []byte("name: lint\nshort: Company lint rules\ncommand: bin/lint\n"), os.ModePerm))
+
+	plugins, err := Discover(binA+string(os.PathListSeparator)+binB, []string{pluginDir, filepath.Join(pluginDir, "missing")})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Plugin{
+		{Name: "deploy", Short: "External command shopware-cli-deploy", Executable: filepath.Join(binA, "shopware-cli-deploy"), Source: SourcePath},
+		{Name: "lint", Short: "Company lint rules", Executable: filepath.Join(pluginDir, "company", "bin", "lint"), Source: SourceManifest},
+	}, plugins)
+}
+
+func TestDiscoverInvalidManifest(t *testing.T) {
+	pluginDir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(pluginDir, "broken"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, "broken", ManifestFile), []byte("name: broken\n"), os.ModePerm))
+
+	_, err := Discover("", []string{pluginDir})
+	assert.ErrorContains(t, err, "requires name and command")
+}
+
+func TestManifestDirs(t *testing.T) {
+	t.Setenv("SHOPWARE_CLI_PLUGIN_PATH", "")
+
+	assert.Equal(t, []string{filepath.Join("/etc", "shopware-cli", "plugins")}, ManifestDirs(filepath.Join("/etc", "shopware-cli.yml")))
+}
+
+func TestConfigFlag(t *testing.T) {
+	assert.Equal(t, "a.yml", ConfigFlag([]string{"deploy", "--config", "a.yml"}))
+	assert.Equal(t, "b.yml", ConfigFlag([]string{"--config=b.yml", "deploy"}))
+	assert.Equal(t, "", ConfigFlag([]string{"deploy", "--", "--config", "c.yml"}))
+	assert.Equal(t, "", ConfigFlag([]string{"deploy", "--config"}))
+}
+
+func TestEnvironment(t *testing.T) {
+	env := Environment("/usr/bin/shopware-cli", "1.0.0", "/home/user/.config/.shopware-cli.yml", true)
+
+	assert.Contains(t, env, "SHOPWARE_CLI_BIN=/usr/bin/shopware-cli")
+	assert.Contains(t, env, "SHOPWARE_CLI_VERSION=1.0.0")
+	assert.Contains(t, env, "SHOPWARE_CLI_CONFIG=/home/user/.config/.shopware-cli.yml")
+	assert.Contains(t, env, "SHOPWARE_CLI_VERBOSE=1")
+}
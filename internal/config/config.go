@@ -29,18 +29,12 @@ type configData struct {
 		Password string `env:"SHOPWARE_CLI_ACCOUNT_PASSWORD" yaml:"password"`
 		Company  int    `env:"SHOPWARE_CLI_ACCOUNT_COMPANY" yaml:"company"`
 	} `yaml:"account"`
-}
-
-type ExtensionConfig struct {
-	Name             string
-	Namespace        string
-	ComposerPackage  string
-	ShopwareVersion  string
-	Description      string
-	License          string
-	Label            string
-	ManufacturerLink string
-	SupportLink      string
+	Paas struct {
+		Token string `env:"SHOPWARE_CLI_PAAS_TOKEN" yaml:"token,omitempty"`
+	} `yaml:"paas"`
+	Telemetry struct {
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"telemetry"`
 }
 
 type Config struct{}
@@ -197,6 +191,46 @@ func (Config) SetAccountCompanyId(id int) error {
 	return nil
 }
 
+func (Config) GetPaasToken() string {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.inner.Paas.Token
+}
+
+func (Config) SetPaasToken(token string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.loadedFromEnv {
+		return fmt.Errorf(environmentConfigErrorFormat, "paas.token", "***")
+	}
+	state.modified = true
+	state.inner.Paas.Token = token
+	return nil
+}
+
+func (Config) GetTelemetryEnabled() bool {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.inner.Telemetry.Enabled
+}
+
+func (Config) SetTelemetryEnabled(enabled bool) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.loadedFromEnv {
+		return fmt.Errorf(environmentConfigErrorFormat, "telemetry.enabled", strconv.FormatBool(enabled))
+	}
+	state.modified = true
+	state.inner.Telemetry.Enabled = enabled
+	return nil
+}
+
 func (Config) Save() error {
 	return SaveConfig()
 }
+
+func (Config) GetPath() string {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.cfgPath
+}
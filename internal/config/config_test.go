@@ -110,6 +110,24 @@ func TestSaveConfig(t *testing.T) {
 	assert.Equal(t, testData.companyId, newConf.Account.Company)
 }
 
+func TestTelemetryIsDisabledByDefault(t *testing.T) {
+	defer resetState()
+
+	testConfig := path.Join(t.TempDir(), ".shopware-cli.yml")
+
+	assert.NoError(t, InitConfig(testConfig))
+
+	configService := Config{}
+	assert.False(t, configService.GetTelemetryEnabled())
+
+	assert.NoError(t, configService.SetTelemetryEnabled(true))
+	assert.NoError(t, SaveConfig())
+
+	resetState()
+	assert.NoError(t, InitConfig(testConfig))
+	assert.True(t, configService.GetTelemetryEnabled())
+}
+
 func TestDontWriteEnvConfig(t *testing.T) {
 	defer resetState()
 
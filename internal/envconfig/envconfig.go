@@ -0,0 +1,144 @@
+// Package envconfig overrides keys of a YAML config with environment variables. The variable name is the prefix followed by the
+// keys of the path joined by two underscores, e.g. SHOPWARE_CLI_PROJECT__ADMIN_API__CLIENT_SECRET sets admin_api.client_secret.
+package envconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ProjectPrefix   = "SHOPWARE_CLI_PROJECT__"
+	ExtensionPrefix = "SHOPWARE_CLI_EXTENSION__"
+
+	pathSeparator = "__"
+)
+
+// Decode unmarshals the YAML content into target after applying all overrides of environ (os.Environ format) starting with prefix.
+// Empty content is treated as an empty document, so overrides also work without a config file.
+func Decode(content []byte, prefix string, environ []string, target interface{}) error {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return err
+	}
+
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if err := Apply(document.Content[0], prefix, environ); err != nil {
+		return err
+	}
+
+	return document.Decode(target)
+}
+
+// Apply sets all overrides of environ starting with prefix in the mapping node. Variables are applied sorted by name.
+func Apply(root *yaml.Node, prefix string, environ []string) error {
+	overrides := make(map[string]string)
+	names := make([]string, 0)
+
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+			continue
+		}
+
+		overrides[name] = value
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := strings.Split(strings.TrimPrefix(name, prefix), pathSeparator)
+
+		if err := setPath(root, path, overrides[name]); err != nil {
+			return fmt.Errorf("cannot apply %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setPath walks the path through mappings and sequences and creates missing mappings. Keys are matched case-insensitive.
+func setPath(node *yaml.Node, path []string, value string) error {
+	for i, key := range path {
+		last := i == len(path)-1
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			child := findMappingValue(node, key)
+
+			if child == nil {
+				child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: newMappingKey(key)}, child)
+			}
+
+			if last {
+				*child = scalarNode(value)
+				return nil
+			}
+
+			node = child
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index > len(node.Content) {
+				return fmt.Errorf("invalid list index %s", key)
+			}
+
+			if index == len(node.Content) {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+			}
+
+			if last {
+				*node.Content[index] = scalarNode(value)
+				return nil
+			}
+
+			node = node.Content[index]
+		default:
+			// a scalar in the middle of the path is replaced with a mapping
+			*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			return setPath(node, path[i:], value)
+		}
+	}
+
+	return nil
+}
+
+func findMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// newMappingKey returns the key created for a missing path segment. Segments in upper case follow the convention of
+// environment variables and create the lower case keys of the config, other segments keep their case.
+func newMappingKey(key string) string {
+	if key == strings.ToUpper(key) {
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+// scalarNode keeps the raw value, so secrets containing YAML syntax are not interpreted, while booleans and numbers still decode into typed fields.
+// An empty value is an empty string instead of null, which would keep the value of the file.
+func scalarNode(value string) yaml.Node {
+	if value == "" {
+		return yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	}
+
+	return yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
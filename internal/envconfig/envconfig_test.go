@@ -0,0 +1,79 @@
+package envconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	URL      string `yaml:"url"`
+	AdminApi *struct {
+		ClientId     string `yaml:"client_id"`
+		ClientSecret string `yaml:"client_secret"`
+	} `yaml:"admin_api"`
+	Build struct {
+		DisableAssetCopy bool `yaml:"disable_asset_copy"`
+		Workers          int  `yaml:"workers"`
+	} `yaml:"build"`
+	CacheBackends map[string]string `yaml:"cache_backends"`
+	Sync          struct {
+		Config []struct {
+			SalesChannel *string                `yaml:"sales_channel"`
+			Settings     map[string]interface{} `yaml:"settings"`
+		} `yaml:"config"`
+	} `yaml:"sync"`
+}
+
+func TestDecode(t *testing.T) {
+	content := []byte(`url: http://localhost
+admin_api:
+  client_id: local
+cache_backends:
+  Default: redis://localhost
+sync:
+  config:
+    - settings:
+        core.basicInformation.email: local@example.com
+`)
+
+	environ := []string{
+		"SHOPWARE_CLI_PROJECT__URL=https://shop.example.com",
+		"SHOPWARE_CLI_PROJECT__ADMIN_API__CLIENT_SECRET=se:cr#et",
+		"SHOPWARE_CLI_PROJECT__BUILD__DISABLE_ASSET_COPY=true",
+		"SHOPWARE_CLI_PROJECT__BUILD__WORKERS=4",
+		"SHOPWARE_CLI_PROJECT__CACHE_BACKENDS__DEFAULT=redis://redis:6379",
+		"SHOPWARE_CLI_PROJECT__CACHE_BACKENDS__SESSION=redis://redis:6379/1",
+		"SHOPWARE_CLI_PROJECT__CACHE_BACKENDS__RateLimiter=redis://redis:6379/2",
+		"SHOPWARE_CLI_PROJECT__ADMIN_API__CLIENT_ID=",
+		"SHOPWARE_CLI_PROJECT__SYNC__CONFIG__0__SETTINGS__CORE.BASICINFORMATION.EMAIL=ci@example.com",
+		"SHOPWARE_CLI_EXTENSION__STORE__ICON=ignored",
+		"PATH=/usr/bin",
+	}
+
+	var cfg testConfig
+	assert.NoError(t, Decode(content, ProjectPrefix, environ, &cfg))
+
+	assert.Equal(t, "https://shop.example.com", cfg.URL)
+	assert.Equal(t, "", cfg.AdminApi.ClientId)
+	assert.Equal(t, "se:cr#et", cfg.AdminApi.ClientSecret)
+	assert.True(t, cfg.Build.DisableAssetCopy)
+	assert.Equal(t, 4, cfg.Build.Workers)
+	assert.Equal(t, map[string]string{"Default": "redis://redis:6379", "session": "redis://redis:6379/1", "RateLimiter": "redis://redis:6379/2"}, cfg.CacheBackends)
+	assert.Equal(t, "ci@example.com", cfg.Sync.Config[0].Settings["core.basicInformation.email"])
+}
+
+func TestDecodeWithoutContent(t *testing.T) {
+	var cfg testConfig
+	assert.NoError(t, Decode(nil, ProjectPrefix, []string{"SHOPWARE_CLI_PROJECT__URL=https://shop.example.com", "SHOPWARE_CLI_PROJECT__BUILD__WORKERS=2"}, &cfg))
+
+	assert.Equal(t, "https://shop.example.com", cfg.URL)
+	assert.Equal(t, 2, cfg.Build.Workers)
+}
+
+func TestDecodeInvalidIndex(t *testing.T) {
+	var cfg testConfig
+	err := Decode([]byte("sync:\n  config: []\n"), ProjectPrefix, []string{"SHOPWARE_CLI_PROJECT__SYNC__CONFIG__5__SETTINGS__FOO=bar"}, &cfg)
+
+	assert.ErrorContains(t, err, "SHOPWARE_CLI_PROJECT__SYNC__CONFIG__5__SETTINGS__FOO")
+}
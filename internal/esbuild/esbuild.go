@@ -24,6 +24,8 @@ type AssetCompileOptions struct {
 	OutputDir      string
 	Name           string
 	Path           string
+	// Externals maps imports of the Shopware sources to the globals, which provide them at runtime
+	Externals map[string]string
 }
 
 func NewAssetCompileOptionsAdmin(name, path string) AssetCompileOptions {
@@ -33,6 +35,7 @@ func NewAssetCompileOptionsAdmin(name, path string) AssetCompileOptions {
 		EntrypointDir:  "Resources/app/administration/src",
 		OutputDir:      "Resources/public/administration",
 		ProductionMode: true,
+		Externals:      AdminExternals,
 	}
 }
 
@@ -43,6 +46,7 @@ func NewAssetCompileOptionsStorefront(name, path string) AssetCompileOptions {
 		EntrypointDir:  "Resources/app/storefront/src",
 		OutputDir:      "Resources/app/storefront/dist/storefront",
 		ProductionMode: true,
+		Externals:      StorefrontExternals,
 	}
 }
 
@@ -68,7 +72,7 @@ func getEsbuildOptions(ctx context.Context, options AssetCompileOptions) (*api.B
 		Bundle:            true,
 		Write:             false,
 		LogLevel:          api.LogLevelWarning,
-		Plugins:           []api.Plugin{newScssPlugin(ctx)},
+		Plugins:           []api.Plugin{newShopwareExternalsPlugin(options.Externals), newScssPlugin(ctx)},
 		Loader: map[string]api.Loader{
 			".twig": api.LoaderText,
 			".scss": api.LoaderCSS,
@@ -105,7 +109,7 @@ func CompileExtensionAsset(ctx context.Context, options AssetCompileOptions) (*A
 	result := api.Build(*bundlerOptions)
 
 	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("initial compile failed")
+		return nil, fmt.Errorf("initial compile failed: %s", result.Errors[0].Text)
 	}
 
 	if err := writeBundlerResultToDisk(result, jsFile, cssFile); err != nil {
@@ -0,0 +1,49 @@
+package esbuild
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+const shopwareExternalNamespace = "shopware-external"
+
+// AdminExternals are the modules of the Shopware administration, which are available at runtime as globals.
+var AdminExternals = map[string]string{
+	"src/core/shopware": "window.Shopware",
+}
+
+// StorefrontExternals are the modules of the Shopware storefront, which are available at runtime as globals.
+var StorefrontExternals = map[string]string{
+	"src/plugin-system/plugin.class":   "window.PluginBaseClass",
+	"src/plugin-system/plugin.manager": "window.PluginManager",
+	"src/helper/feature.helper":        "window.Feature",
+}
+
+// newShopwareExternalsPlugin resolves imports of the Shopware sources to the globals of the running Shopware, so the
+// extension can be bundled without a Shopware checkout. Other imports of the Shopware sources fail with a hint.
+func newShopwareExternalsPlugin(externals map[string]string) api.Plugin {
+	return api.Plugin{
+		Name: "shopware-externals",
+		Setup: func(build api.PluginBuild) {
+			build.OnResolve(api.OnResolveOptions{Filter: `^src/`},
+				func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+					path := strings.TrimSuffix(strings.TrimSuffix(args.Path, ".js"), ".ts")
+
+					if _, ok := externals[path]; !ok {
+						return api.OnResolveResult{}, fmt.Errorf("%s is part of the Shopware sources, which are not available in the esbuild build. Disable enable_es_build_for_admin or enable_es_build_for_storefront to build with the Shopware sources", args.Path)
+					}
+
+					return api.OnResolveResult{Path: path, Namespace: shopwareExternalNamespace}, nil
+				})
+
+			build.OnLoad(api.OnLoadOptions{Filter: `.*`, Namespace: shopwareExternalNamespace},
+				func(args api.OnLoadArgs) (api.OnLoadResult, error) {
+					contents := fmt.Sprintf("module.exports = %s;", externals[args.Path])
+
+					return api.OnLoadResult{Contents: &contents, Loader: api.LoaderJS}, nil
+				})
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package esbuild
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildWithExternals(t *testing.T, source string) api.BuildResult {
+	t.Helper()
+
+	dir := t.TempDir()
+	entryPoint := path.Join(dir, "main.js")
+
+	assert.NoError(t, os.WriteFile(entryPoint, []byte(source), os.ModePerm))
+
+	return api.Build(api.BuildOptions{
+		EntryPoints: []string{entryPoint},
+		Bundle:      true,
+		Write:       false,
+		Outfile:     path.Join(dir, "extension.js"),
+		Plugins:     []api.Plugin{newShopwareExternalsPlugin(StorefrontExternals)},
+	})
+}
+
+func TestShopwareExternals(t *testing.T) {
+	result := buildWithExternals(t, `import Plugin from 'src/plugin-system/plugin.class';
+class ExamplePlugin extends Plugin {}
+window.PluginManager.register('ExamplePlugin', ExamplePlugin);`)
+
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.OutputFiles, 1)
+	assert.Contains(t, string(result.OutputFiles[0].Contents), "module.exports = window.PluginBaseClass;")
+}
+
+func TestShopwareExternalsUnknownImport(t *testing.T) {
+	result := buildWithExternals(t, `import HttpClient from 'src/service/http-client.service';
+new HttpClient();`)
+
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Text, "src/service/http-client.service is part of the Shopware sources")
+}
@@ -2,14 +2,20 @@ package esbuild
 
 import (
 	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -19,113 +25,309 @@ import (
 
 const dartSassVersion = "1.67.0"
 
+// dartSassReleaseAPI lists the assets of the release with their sha256 digests.
+var dartSassReleaseAPI = "https://api.github.com/repos/sass/dart-sass/releases/tags/" + dartSassVersion
+
 //go:embed static/variables.scss
 var scssVariables []byte
 
 //go:embed static/mixins.scss
 var scssMixins []byte
 
+// dartSassPlatforms maps GOARCH to the architecture names of the dart-sass releases.
+var dartSassPlatforms = map[string]string{
+	"amd64": "x64",
+	"arm64": "arm64",
+	"386":   "ia32",
+	"arm":   "arm",
+}
+
+// dartSassTarget returns the release name like linux-arm64-musl for the platform.
+func dartSassTarget(goos, goarch string, musl bool) (string, error) {
+	arch, ok := dartSassPlatforms[goarch]
+	if !ok {
+		return "", fmt.Errorf("dart-sass is not available for %s/%s, install it manually and set SHOPWARE_CLI_DART_SASS_BINARY", goos, goarch)
+	}
+
+	switch goos {
+	case "darwin":
+		return "macos-" + arch, nil
+	case "windows":
+		return "windows-" + arch, nil
+	case "linux":
+		if musl {
+			return "linux-" + arch + "-musl", nil
+		}
+
+		return "linux-" + arch, nil
+	}
+
+	return "", fmt.Errorf("dart-sass is not available for %s/%s, install it manually and set SHOPWARE_CLI_DART_SASS_BINARY", goos, goarch)
+}
+
+func dartSassDownloadURL(target string) string {
+	extension := "tar.gz"
+	if strings.HasPrefix(target, "windows-") {
+		extension = "zip"
+	}
+
+	return fmt.Sprintf("https://github.com/sass/dart-sass/releases/download/%s/dart-sass-%s-%s.%s", dartSassVersion, dartSassVersion, target, extension)
+}
+
+// fetchDartSassChecksum returns the sha256 checksum GitHub publishes for the release asset.
+func fetchDartSassChecksum(ctx context.Context, assetName string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, dartSassReleaseAPI, nil)
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("cannot fetch the checksum of dart-sass: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot fetch the checksum of dart-sass: %s with http code %s", resp.Request.URL, resp.Status)
+	}
+
+	var release struct {
+		Assets []struct {
+			Name   string `json:"name"`
+			Digest string `json:"digest"`
+		} `json:"assets"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("cannot fetch the checksum of dart-sass: %w", err)
+	}
+
+	for _, asset := range release.Assets {
+		if asset.Name != assetName {
+			continue
+		}
+
+		if checksum, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok && checksum != "" {
+			return checksum, nil
+		}
+
+		break
+	}
+
+	return "", fmt.Errorf("found no sha256 checksum of %s, install dart-sass manually and set SHOPWARE_CLI_DART_SASS_BINARY", assetName)
+}
+
+// isMusl detects Alpine and other musl based systems, the glibc builds of dart-sass can't run there.
+func isMusl() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	matches, _ := filepath.Glob("/lib/ld-musl-*.so.1")
+
+	return len(matches) > 0
+}
+
 func downloadDartSass(ctx context.Context) (string, error) {
+	if path := os.Getenv("SHOPWARE_CLI_DART_SASS_BINARY"); path != "" {
+		return path, nil
+	}
+
 	if path, err := exec.LookPath("dart-sass"); err == nil {
 		return path, nil
 	}
 
+	target, err := dartSassTarget(runtime.GOOS, runtime.GOARCH, isMusl())
+	if err != nil {
+		return "", err
+	}
+
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
-		cacheDir = "/tmp"
+		cacheDir = os.TempDir()
 	}
 
-	cacheDir += "/dart-sass-" + dartSassVersion
+	sassDir := filepath.Join(cacheDir, "shopware-cli", fmt.Sprintf("dart-sass-%s-%s", dartSassVersion, target))
 
-	expectedPath := fmt.Sprintf("%s/sass", cacheDir)
+	binary := "sass"
+	if runtime.GOOS == "windows" {
+		binary = "sass.bat"
+	}
+
+	expectedPath := filepath.Join(sassDir, binary)
 
 	if _, err := os.Stat(expectedPath); err == nil {
 		return expectedPath, nil
 	}
 
-	if _, err := os.Stat(filepath.Dir(expectedPath)); os.IsNotExist(err) {
-		if err := os.MkdirAll(filepath.Dir(expectedPath), os.ModePerm); err != nil {
-			return "", err
-		}
+	if err := os.MkdirAll(filepath.Dir(sassDir), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	logging.FromContext(ctx).Infof("Downloading dart-sass %s for %s", dartSassVersion, target)
+
+	downloadURL := dartSassDownloadURL(target)
+
+	checksum, err := fetchDartSassChecksum(ctx, path.Base(downloadURL))
+	if err != nil {
+		return "", err
 	}
 
-	logging.FromContext(ctx).Infof("Downloading dart-sass")
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
 
-	osType := runtime.GOOS
-	arch := runtime.GOARCH
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("cannot download dart-sass: %w", err)
+	}
+
+	defer resp.Body.Close()
 
-	switch runtime.GOARCH {
-	case "arm64":
-		arch = "arm64"
-	case "amd64":
-		arch = "x64"
-	case "386":
-		arch = "ia32"
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download dart-sass: %s with http code %s", resp.Request.URL, resp.Status)
 	}
 
-	if osType == "darwin" {
-		osType = "macos"
+	// extract into a temporary folder first, so an aborted download is never used as cache
+	tmpDir, err := os.MkdirTemp(filepath.Dir(sassDir), "dart-sass-download")
+	if err != nil {
+		return "", err
 	}
 
-	request, _ := http.NewRequestWithContext(context.Background(), "GET", fmt.Sprintf("https://github.com/sass/dart-sass/releases/download/%s/dart-sass-%s-%s-%s.tar.gz", dartSassVersion, dartSassVersion, osType, arch), nil)
+	defer os.RemoveAll(tmpDir)
+
+	// the archive is hashed while it is extracted, the extracted files are only moved into the cache when it matches
+	hash := sha256.New()
+	body := io.TeeReader(resp.Body, hash)
+
+	if strings.HasPrefix(target, "windows-") {
+		err = extractDartSassZip(body, tmpDir)
+	} else {
+		err = extractDartSassTar(body, tmpDir)
+	}
 
-	tarFile, err := http.DefaultClient.Do(request)
 	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(io.Discard, body); err != nil {
 		return "", fmt.Errorf("cannot download dart-sass: %w", err)
 	}
 
-	defer tarFile.Body.Close()
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return "", fmt.Errorf("the checksum of %s is %s, expected %s", downloadURL, actual, checksum)
+	}
+
+	if err := os.Rename(tmpDir, sassDir); err != nil {
+		// another process was faster
+		if _, statErr := os.Stat(expectedPath); statErr == nil {
+			return expectedPath, nil
+		}
+
+		return "", fmt.Errorf("cannot move dart-sass into cache: %w", err)
+	}
+
+	return expectedPath, nil
+}
+
+// dartSassFilePath returns the path of the archive entry inside dir, the dart-sass/ folder of the archives is stripped.
+func dartSassFilePath(dir, name string) (string, bool) {
+	name = strings.TrimPrefix(name, "dart-sass/")
 
-	if tarFile.StatusCode != 200 {
-		return "", fmt.Errorf("cannot download dart-sass: %s with http code %s", tarFile.Request.URL, tarFile.Status)
+	if name == "" || strings.Contains(name, "..") {
+		return "", false
 	}
 
-	uncompressedStream, err := gzip.NewReader(tarFile.Body)
+	return filepath.Join(dir, filepath.FromSlash(name)), true
+}
+
+func extractDartSassTar(reader io.Reader, dir string) error {
+	uncompressedStream, err := gzip.NewReader(reader)
 	if err != nil {
-		return "", fmt.Errorf("cannot open gzip tar file: %w", err)
+		return fmt.Errorf("cannot open gzip tar file: %w", err)
 	}
 
 	tarReader := tar.NewReader(uncompressedStream)
 
 	for {
 		header, err := tarReader.Next()
-
 		if err == io.EOF {
 			break
 		}
 
-		name := strings.TrimPrefix(header.Name, "dart-sass/")
+		if err != nil {
+			return fmt.Errorf("cannot read dart-sass archive: %w", err)
+		}
 
-		if strings.Contains(name, "..") {
+		file, ok := dartSassFilePath(dir, header.Name)
+		if !ok {
 			continue
 		}
 
-		folder := filepath.Join(cacheDir, filepath.Dir(name))
-		file := filepath.Join(cacheDir, name)
-
-		if !strings.HasSuffix(folder, ".") {
-			if _, err := os.Stat(folder); os.IsNotExist(err) {
-				if err := os.MkdirAll(folder, os.ModePerm); err != nil {
-					return "", err
-				}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(file, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeDartSassFile(file, tarReader, os.FileMode(header.Mode)); err != nil {
+				return err
 			}
 		}
+	}
 
-		outFile, err := os.Create(file)
-		if err != nil {
-			return "", fmt.Errorf("cannot create dart-sass in temp: %w", err)
-		}
-		if _, err := io.CopyN(outFile, tarReader, header.Size); err != nil {
-			return "", fmt.Errorf("cannot copy dart-sass in temp: %w", err)
+	return nil
+}
+
+func extractDartSassZip(reader io.Reader, dir string) error {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("cannot download dart-sass: %w", err)
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return fmt.Errorf("cannot open dart-sass zip file: %w", err)
+	}
+
+	for _, zipFile := range zipReader.File {
+		file, ok := dartSassFilePath(dir, zipFile.Name)
+		if !ok || zipFile.FileInfo().IsDir() {
+			continue
 		}
-		if err := outFile.Close(); err != nil {
-			return "", fmt.Errorf("cannot close dart-sass in temp: %w", err)
+
+		source, err := zipFile.Open()
+		if err != nil {
+			return err
 		}
 
-		if err := os.Chmod(file, os.FileMode(header.Mode)); err != nil {
-			return "", fmt.Errorf("cannot chmod dart-sass in temp: %w", err)
+		err = writeDartSassFile(file, source, zipFile.Mode())
+		_ = source.Close()
+
+		if err != nil {
+			return err
 		}
 	}
 
-	return expectedPath, nil
+	return nil
+}
+
+func writeDartSassFile(file string, reader io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return err
+	}
+
+	outFile, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0o600)
+	if err != nil {
+		return fmt.Errorf("cannot create dart-sass file: %w", err)
+	}
+
+	if _, err := io.Copy(outFile, reader); err != nil {
+		_ = outFile.Close()
+
+		return fmt.Errorf("cannot copy dart-sass file: %w", err)
+	}
+
+	return outFile.Close()
 }
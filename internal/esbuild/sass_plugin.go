@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bep/godartsass/v2"
 	"github.com/evanw/esbuild/pkg/api"
@@ -100,3 +101,92 @@ func (scssImporter) Load(canonicalizedURL string) (godartsass.Import, error) {
 		SourceSyntax: godartsass.SourceSyntaxSCSS,
 	}, nil
 }
+
+// CompileScss compiles the given SCSS source using dart-sass. Imports prefixed with ~ are resolved against the node_modules folders of the given include paths.
+func CompileScss(ctx context.Context, source, sourceURL string, includePaths []string) (string, error) {
+	dartSassBinary, err := downloadDartSass(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	transpiler, err := godartsass.Start(godartsass.Options{
+		DartSassEmbeddedFilename: dartSassBinary,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = transpiler.Close()
+	}()
+
+	result, err := transpiler.Execute(godartsass.Args{
+		Source:         source,
+		URL:            sourceURL,
+		IncludePaths:   includePaths,
+		ImportResolver: nodeModulesImporter{paths: includePaths},
+		OutputStyle:    godartsass.OutputStyleCompressed,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CSS, nil
+}
+
+type nodeModulesImporter struct {
+	paths []string
+}
+
+func (i nodeModulesImporter) CanonicalizeURL(url string) (string, error) {
+	if canonicalized, err := (scssImporter{}).CanonicalizeURL(url); canonicalized != "" || err != nil {
+		return canonicalized, err
+	}
+
+	if !strings.HasPrefix(url, "~") {
+		return "", nil
+	}
+
+	name := strings.TrimPrefix(url, "~")
+
+	for _, includePath := range i.paths {
+		base := filepath.Join(includePath, "node_modules", name)
+
+		candidates := []string{
+			base,
+			base + ".scss",
+			filepath.Join(filepath.Dir(base), "_"+filepath.Base(base)+".scss"),
+			filepath.Join(base, "_index.scss"),
+			filepath.Join(base, "index.scss"),
+		}
+
+		for _, candidate := range candidates {
+			if stat, err := os.Stat(candidate); err == nil && !stat.IsDir() {
+				return "file://" + candidate, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (i nodeModulesImporter) Load(canonicalizedURL string) (godartsass.Import, error) {
+	if canonicalizedURL == InternalVariablesScssPath || canonicalizedURL == InternalMixinsScssPath {
+		return (scssImporter{}).Load(canonicalizedURL)
+	}
+
+	content, err := os.ReadFile(strings.TrimPrefix(canonicalizedURL, "file://"))
+	if err != nil {
+		return godartsass.Import{}, err
+	}
+
+	syntax := godartsass.SourceSyntaxSCSS
+	if strings.HasSuffix(canonicalizedURL, ".css") {
+		syntax = godartsass.SourceSyntaxCSS
+	}
+
+	return godartsass.Import{
+		Content:      string(content),
+		SourceSyntax: syntax,
+	}, nil
+}
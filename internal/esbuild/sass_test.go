@@ -0,0 +1,107 @@
+package esbuild
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDartSassTarget(t *testing.T) {
+	cases := []struct {
+		goos, goarch string
+		musl         bool
+		expected     string
+	}{
+		{"linux", "amd64", false, "linux-x64"},
+		{"linux", "arm64", true, "linux-arm64-musl"},
+		{"darwin", "arm64", false, "macos-arm64"},
+		{"windows", "amd64", false, "windows-x64"},
+	}
+
+	for _, c := range cases {
+		target, err := dartSassTarget(c.goos, c.goarch, c.musl)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, target)
+	}
+
+	_, err := dartSassTarget("linux", "riscv64", false)
+	assert.ErrorContains(t, err, "SHOPWARE_CLI_DART_SASS_BINARY")
+
+	assert.Equal(t, "https://github.com/sass/dart-sass/releases/download/1.67.0/dart-sass-1.67.0-linux-arm64-musl.tar.gz", dartSassDownloadURL("linux-arm64-musl"))
+	assert.Equal(t, "https://github.com/sass/dart-sass/releases/download/1.67.0/dart-sass-1.67.0-windows-x64.zip", dartSassDownloadURL("windows-x64"))
+}
+
+func TestExtractDartSassTar(t *testing.T) {
+	var archive bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, entry := range []struct {
+		name    string
+		dir     bool
+		content string
+	}{
+		{name: "dart-sass/", dir: true},
+		{name: "dart-sass/sass", content: "#!/bin/sh"},
+		{name: "dart-sass/src/dart", content: "binary"},
+		{name: "dart-sass/../evil", content: "evil"},
+	} {
+		header := &tar.Header{Name: entry.name, Mode: 0o755, Size: int64(len(entry.content)), Typeflag: tar.TypeReg}
+		if entry.dir {
+			header.Typeflag = tar.TypeDir
+		}
+
+		assert.NoError(t, tarWriter.WriteHeader(header))
+		_, err := tarWriter.Write([]byte(entry.content))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+
+	dir := t.TempDir()
+	assert.NoError(t, extractDartSassTar(&archive, dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "src", "dart"))
+	assert.NoError(t, err)
+	assert.Equal(t, "binary", string(content))
+
+	assert.FileExists(t, filepath.Join(dir, "sass"))
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "evil"))
+}
+
+func TestFetchDartSassChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"assets": [
+			{"name": "dart-sass-1.67.0-linux-x64.tar.gz", "digest": "sha256:abc123"},
+			{"name": "dart-sass-1.67.0-linux-arm64.tar.gz", "digest": null}
+		]}`))
+	}))
+	defer server.Close()
+
+	releaseAPI := dartSassReleaseAPI
+	dartSassReleaseAPI = server.URL
+
+	defer func() {
+		dartSassReleaseAPI = releaseAPI
+	}()
+
+	checksum, err := fetchDartSassChecksum(context.Background(), "dart-sass-1.67.0-linux-x64.tar.gz")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", checksum)
+
+	_, err = fetchDartSassChecksum(context.Background(), "dart-sass-1.67.0-linux-arm64.tar.gz")
+	assert.Error(t, err)
+
+	_, err = fetchDartSassChecksum(context.Background(), "dart-sass-1.67.0-windows-x64.zip")
+	assert.Error(t, err)
+}
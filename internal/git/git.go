@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path"
+	"path/filepath"
 	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
 )
 
 type GitCommit struct {
@@ -15,7 +16,7 @@ type GitCommit struct {
 }
 
 func runGit(ctx context.Context, repo string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd := process.Command(ctx, "git", args...)
 	cmd.Dir = repo
 
 	output, err := cmd.CombinedOutput()
@@ -129,7 +130,7 @@ func GetPublicVCSURL(ctx context.Context, repo string) (string, error) {
 }
 
 func unshallowRepository(ctx context.Context, repo string) error {
-	if _, err := os.Stat(path.Join(repo, ".git", "shallow")); os.IsNotExist(err) {
+	if _, err := os.Stat(filepath.Join(repo, ".git", "shallow")); os.IsNotExist(err) {
 		return nil
 	}
 
@@ -0,0 +1,206 @@
+// Package httpdump records outgoing HTTP requests and responses into files, so they can be attached to bug reports.
+// Credentials in headers, query parameters, JSON and form bodies are redacted before writing.
+package httpdump
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	redacted = "***"
+	// maxBodySize is the maximum amount of bytes written of a body
+	maxBodySize = 1024 * 1024
+)
+
+var (
+	dumpDir string
+	mu      sync.RWMutex
+
+	sensitiveHeaders = map[string]bool{
+		"authorization":       true,
+		"proxy-authorization": true,
+		"cookie":              true,
+		"set-cookie":          true,
+		"x-shopware-token":    true,
+		"sw-access-key":       true,
+		"sw-context-token":    true,
+	}
+
+	sensitiveKeyRegex  = regexp.MustCompile(`(?i)(password|secret|token|api[_-]?key)`)
+	sensitiveJSONRegex = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token|api[_-]?key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// Enable records all requests of wrapped transports into dir.
+func Enable(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	dumpDir = dir
+
+	return nil
+}
+
+// Wrap returns a transport recording all requests when dumping is enabled, otherwise the transport itself.
+func Wrap(transport http.RoundTripper) http.RoundTripper {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if dumpDir == "" {
+		return transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &recordingTransport{dir: dumpDir, next: transport}
+}
+
+type recordingTransport struct {
+	dir     string
+	next    http.RoundTripper
+	counter atomic.Int64
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		requestBody = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	var dump strings.Builder
+
+	dump.WriteString(fmt.Sprintf("%s %s\n", req.Method, redactURL(req.URL)))
+	writeHeaders(&dump, req.Header)
+	dump.WriteString("\n")
+	dump.WriteString(formatBody(requestBody, req.Header.Get("Content-Type")))
+
+	if err != nil {
+		dump.WriteString(fmt.Sprintf("\n\n--- error after %s ---\n%s\n", duration.Round(time.Millisecond), err.Error()))
+	} else {
+		responseBody, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+		dump.WriteString(fmt.Sprintf("\n\n--- response %s after %s ---\n", resp.Status, duration.Round(time.Millisecond)))
+		writeHeaders(&dump, resp.Header)
+		dump.WriteString("\n")
+		dump.WriteString(formatBody(responseBody, resp.Header.Get("Content-Type")))
+
+		if readErr != nil {
+			dump.WriteString(fmt.Sprintf("\n\n--- error reading body ---\n%s\n", readErr.Error()))
+		}
+	}
+
+	t.write(req, dump.String())
+
+	return resp, err
+}
+
+func (t *recordingTransport) write(req *http.Request, content string) {
+	number := t.counter.Add(1)
+	host := strings.NewReplacer(":", "_", "/", "_").Replace(req.URL.Host)
+	fileName := fmt.Sprintf("%s-%04d-%s-%s.txt", time.Now().Format("20060102-150405"), number, req.Method, host)
+
+	_ = os.WriteFile(filepath.Join(t.dir, fileName), []byte(content), 0o600)
+}
+
+func writeHeaders(dump *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			if sensitiveHeaders[strings.ToLower(name)] {
+				value = redacted
+			}
+
+			dump.WriteString(fmt.Sprintf("%s: %s\n", name, value))
+		}
+	}
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+
+	if redactedURL.User != nil {
+		redactedURL.User = url.User(redactedURL.User.Username())
+	}
+
+	query := redactedURL.Query()
+	for key := range query {
+		if sensitiveKeyRegex.MatchString(key) {
+			query.Set(key, redacted)
+		}
+	}
+
+	redactedURL.RawQuery = query.Encode()
+
+	return redactedURL.String()
+}
+
+// formatBody redacts JSON and form bodies and omits binary content like zip uploads.
+func formatBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes form body could not be parsed>", len(body))
+		}
+
+		for key := range values {
+			if sensitiveKeyRegex.MatchString(key) {
+				values.Set(key, redacted)
+			}
+		}
+
+		return values.Encode()
+	case mediaType == "multipart/form-data", mediaType == "application/zip", mediaType == "application/octet-stream", strings.HasPrefix(mediaType, "image/"):
+		return fmt.Sprintf("<%d bytes %s body omitted>", len(body), mediaType)
+	}
+
+	if len(body) > maxBodySize {
+		body = append(body[:maxBodySize:maxBodySize], []byte("\n<truncated>")...)
+	}
+
+	return sensitiveJSONRegex.ReplaceAllString(string(body), `$1"`+redacted+`"`)
+}
@@ -0,0 +1,68 @@
+package httpdump
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"email":"user@example.com","password":"hunter2"}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"abc","userId":1}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	transport := &recordingTransport{dir: dir, next: http.DefaultTransport}
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/accesstokens?access_token=secret&page=1", strings.NewReader(`{"email":"user@example.com","password":"hunter2"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Shopware-Token", "abc")
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"token":"abc","userId":1}`, string(body))
+	_ = resp.Body.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Contains(t, files[0], "-0001-POST-")
+
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+
+	dump := string(content)
+	assert.Contains(t, dump, "/accesstokens?access_token=%2A%2A%2A&page=1")
+	assert.Contains(t, dump, "X-Shopware-Token: ***")
+	assert.Contains(t, dump, `{"email":"user@example.com","password":"***"}`)
+	assert.Contains(t, dump, "--- response 200 OK after")
+	assert.Contains(t, dump, `{"token":"***","userId":1}`)
+	assert.NotContains(t, dump, "hunter2")
+	assert.NotContains(t, dump, "secret")
+}
+
+func TestFormatBody(t *testing.T) {
+	assert.Equal(t, "client_secret=%2A%2A%2A&grant_type=client_credentials", formatBody([]byte("grant_type=client_credentials&client_secret=abc"), "application/x-www-form-urlencoded"))
+	assert.Equal(t, "<3 bytes application/zip body omitted>", formatBody([]byte("zip"), "application/zip"))
+	assert.Equal(t, `{"client_secret": "***"}`, formatBody([]byte(`{"client_secret": "a\"b"}`), "application/json"))
+	assert.Equal(t, "", formatBody(nil, "application/json"))
+}
+
+func TestWrapDisabled(t *testing.T) {
+	assert.Equal(t, http.DefaultTransport, Wrap(http.DefaultTransport))
+}
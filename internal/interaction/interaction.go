@@ -0,0 +1,42 @@
+// Package interaction decides whether commands may prompt the user. Prompts are disabled with --no-interaction,
+// SHOPWARE_CLI_NO_INTERACTION=1 or automatically inside a CI, so pipelines fail fast instead of hanging.
+package interaction
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/ci"
+)
+
+var nonInteractive atomic.Bool
+
+// SetNonInteractive disables all prompts, it is set by the global --no-interaction flag.
+func SetNonInteractive(value bool) {
+	nonInteractive.Store(value)
+}
+
+// IsInteractive returns false when prompts are disabled by flag, environment variable or a detected CI.
+func IsInteractive() bool {
+	if nonInteractive.Load() {
+		return false
+	}
+
+	switch strings.ToLower(os.Getenv("SHOPWARE_CLI_NO_INTERACTION")) {
+	case "true", "1":
+		return false
+	}
+
+	return !ci.IsCI()
+}
+
+// Ensure returns an error naming the prompt and how to provide the value instead, when prompts are disabled.
+func Ensure(prompt, alternative string) error {
+	if IsInteractive() {
+		return nil
+	}
+
+	return fmt.Errorf("cannot prompt for %q in non-interactive mode, %s", prompt, alternative)
+}
@@ -0,0 +1,29 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInteractive(t *testing.T) {
+	t.Setenv("CI", "")
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("SHOPWARE_CLI_NO_INTERACTION", "")
+
+	assert.True(t, IsInteractive())
+	assert.NoError(t, Ensure("Password", "set SHOPWARE_CLI_ACCOUNT_PASSWORD"))
+
+	SetNonInteractive(true)
+	assert.False(t, IsInteractive())
+	assert.EqualError(t, Ensure("Password", "set SHOPWARE_CLI_ACCOUNT_PASSWORD"), `cannot prompt for "Password" in non-interactive mode, set SHOPWARE_CLI_ACCOUNT_PASSWORD`)
+	SetNonInteractive(false)
+
+	t.Setenv("SHOPWARE_CLI_NO_INTERACTION", "1")
+	assert.False(t, IsInteractive())
+
+	t.Setenv("SHOPWARE_CLI_NO_INTERACTION", "")
+	t.Setenv("CI", "true")
+	assert.False(t, IsInteractive())
+}
@@ -0,0 +1,55 @@
+// Package offline forbids network access of the CLI, when it runs with --offline or SHOPWARE_CLI_OFFLINE=1.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync/atomic"
+)
+
+var enabled atomic.Bool
+
+// ErrOffline is returned for requests which were blocked.
+var ErrOffline = errors.New("network access is disabled with --offline")
+
+func Enable() {
+	enabled.Store(true)
+}
+
+func IsEnabled() bool {
+	return enabled.Load() || os.Getenv("SHOPWARE_CLI_OFFLINE") == "1"
+}
+
+// Wrap returns a transport which refuses requests to other hosts than the local machine while offline mode is enabled.
+func Wrap(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &offlineTransport{next: transport}
+}
+
+type offlineTransport struct {
+	next http.RoundTripper
+}
+
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if IsEnabled() && !isLocalHost(req.URL.Hostname()) {
+		return nil, fmt.Errorf("cannot request %s: %w", req.URL.Host, ErrOffline)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+func isLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
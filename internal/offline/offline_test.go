@@ -0,0 +1,41 @@
+package offline
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Wrap(nil)}
+
+	t.Cleanup(func() {
+		enabled.Store(false)
+	})
+
+	Enable()
+	assert.True(t, IsEnabled())
+
+	// the test server listens on 127.0.0.1, local shops stay reachable
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	_, err = client.Get("https://example.com")
+	assert.ErrorIs(t, err, ErrOffline)
+}
+
+func TestIsLocalHost(t *testing.T) {
+	assert.True(t, isLocalHost("localhost"))
+	assert.True(t, isLocalHost("127.0.0.1"))
+	assert.True(t, isLocalHost("::1"))
+	assert.False(t, isLocalHost("shop.example.com"))
+	assert.False(t, isLocalHost("192.168.1.10"))
+}
@@ -0,0 +1,176 @@
+package paas
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ActivityStateComplete = "complete"
+	ActivityResultSuccess = "success"
+
+	// activityLookupCount is the number of recent activities searched for an activity created by a push
+	activityLookupCount = 10
+)
+
+type Activity struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	State       string `json:"state"`
+	Result      string `json:"result"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// IsFailed reports whether the activity finished without success.
+func (a Activity) IsFailed() bool {
+	return a.State == ActivityStateComplete && a.Result != ActivityResultSuccess
+}
+
+type operationResponse struct {
+	Embedded struct {
+		Activities []Activity `json:"activities"`
+	} `json:"_embedded"`
+}
+
+// Deploy triggers a deployment of the environment and returns the started activity.
+func (c *Client) Deploy(ctx context.Context, projectID, environment string) (*Activity, error) {
+	return c.runEnvironmentOperation(ctx, projectID, environment, "deploy")
+}
+
+// Redeploy redeploys the current code of the environment and returns the started activity.
+func (c *Client) Redeploy(ctx context.Context, projectID, environment string) (*Activity, error) {
+	return c.runEnvironmentOperation(ctx, projectID, environment, "redeploy")
+}
+
+func (c *Client) runEnvironmentOperation(ctx context.Context, projectID, environment, operation string) (*Activity, error) {
+	r, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/environments/%s/%s", url.PathEscape(projectID), url.PathEscape(environment), operation), strings.NewReader("{}"))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := doRequest(c.httpClient, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot %s environment %s: %w", operation, environment, err)
+	}
+
+	var response operationResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("runEnvironmentOperation: %v", err)
+	}
+
+	if len(response.Embedded.Activities) == 0 {
+		return nil, fmt.Errorf("%s of environment %s did not start an activity", operation, environment)
+	}
+
+	return &response.Embedded.Activities[0], nil
+}
+
+// GetLatestActivity returns the newest activity of the environment.
+func (c *Client) GetLatestActivity(ctx context.Context, projectID, environment string) (*Activity, error) {
+	var activities []Activity
+
+	if err := c.getJSON(ctx, fmt.Sprintf("/projects/%s/environments/%s/activities?count=1", url.PathEscape(projectID), url.PathEscape(environment)), &activities); err != nil {
+		return nil, fmt.Errorf("GetLatestActivity: %w", err)
+	}
+
+	if len(activities) == 0 {
+		return nil, fmt.Errorf("environment %s has no activities", environment)
+	}
+
+	return &activities[0], nil
+}
+
+// GetActivityCreatedAfter returns the first activity of the environment created at or after the given time, nil when the
+// environment has none yet. The activities of the API are sorted newest first.
+func (c *Client) GetActivityCreatedAfter(ctx context.Context, projectID, environment string, after time.Time) (*Activity, error) {
+	var activities []Activity
+
+	if err := c.getJSON(ctx, fmt.Sprintf("/projects/%s/environments/%s/activities?count=%d", url.PathEscape(projectID), url.PathEscape(environment), activityLookupCount), &activities); err != nil {
+		return nil, fmt.Errorf("GetActivityCreatedAfter: %w", err)
+	}
+
+	var found *Activity
+
+	for i := range activities {
+		createdAt, err := time.Parse(time.RFC3339, activities[i].CreatedAt)
+		if err != nil || createdAt.Before(after) {
+			continue
+		}
+
+		found = &activities[i]
+	}
+
+	return found, nil
+}
+
+func (c *Client) GetActivity(ctx context.Context, projectID, activityID string) (*Activity, error) {
+	var activity Activity
+
+	if err := c.getJSON(ctx, fmt.Sprintf("/projects/%s/activities/%s", url.PathEscape(projectID), url.PathEscape(activityID)), &activity); err != nil {
+		return nil, fmt.Errorf("GetActivity: %w", err)
+	}
+
+	return &activity, nil
+}
+
+type activityLogLine struct {
+	Seal bool `json:"seal"`
+	Data struct {
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// StreamActivityLog writes the log of the activity to w until the activity has finished.
+func (c *Client) StreamActivityLog(ctx context.Context, projectID, activityID string, w io.Writer) error {
+	r, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/activities/%s/log", url.PathEscape(projectID), url.PathEscape(activityID)), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return fmt.Errorf("StreamActivityLog: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		data, _ := io.ReadAll(resp.Body)
+
+		return fmt.Errorf("cannot stream log of activity %s with status %d: %s", activityID, resp.StatusCode, string(data))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var line activityLogLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return fmt.Errorf("StreamActivityLog: %v", err)
+		}
+
+		if line.Seal {
+			return nil
+		}
+
+		if _, err := io.WriteString(w, line.Data.Message); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
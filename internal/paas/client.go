@@ -0,0 +1,141 @@
+// Package paas is a small client for the Shopware PaaS (platform.sh) API.
+package paas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	DefaultAPIURL  = "https://api.platform.sh"
+	DefaultAuthURL = "https://auth.api.platform.sh/oauth2/token"
+
+	apiTokenClientID = "platform-api-user"
+)
+
+type Client struct {
+	apiURL      string
+	accessToken string
+	httpClient  *http.Client
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// NewClient exchanges the API token of the PaaS account for an access token.
+func NewClient(ctx context.Context, apiToken string) (*Client, error) {
+	return newClient(ctx, http.DefaultClient, DefaultAPIURL, DefaultAuthURL, apiToken)
+}
+
+func newClient(ctx context.Context, httpClient *http.Client, apiURL, authURL, apiToken string) (*Client, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("no PaaS API token configured, use shopware-cli project paas login or set SHOPWARE_CLI_PAAS_TOKEN")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "api_token")
+	form.Set("api_token", apiToken)
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	r.SetBasicAuth(apiTokenClientID, "")
+	r.Header.Set("content-type", "application/x-www-form-urlencoded")
+	r.Header.Set("accept", "application/json")
+
+	data, err := doRequest(httpClient, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot authenticate against PaaS: %w", err)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("NewClient: %v", err)
+	}
+
+	return &Client{apiURL: strings.TrimSuffix(apiURL, "/"), accessToken: token.AccessToken, httpClient: httpClient}, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	logging.FromContext(ctx).Debugf("%s: %s", method, path)
+
+	r, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set("content-type", "application/json")
+	r.Header.Set("accept", "application/json")
+	r.Header.Set("authorization", "Bearer "+c.accessToken)
+
+	return r, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, target interface{}) error {
+	r, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	data, err := doRequest(c.httpClient, r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+func doRequest(httpClient *http.Client, request *http.Request) ([]byte, error) {
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("doRequest: %v", err)
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		return nil, fmt.Errorf("doRequest: %v", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("request %s failed with status %d: %s", request.URL.Path, resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
+
+type Project struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Repository struct {
+		URL string `json:"url"`
+	} `json:"repository"`
+}
+
+// GetProject returns the project, the repository url is used to push the code.
+func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	var project Project
+
+	if err := c.getJSON(ctx, fmt.Sprintf("/projects/%s", url.PathEscape(projectID)), &project); err != nil {
+		return nil, fmt.Errorf("GetProject: %w", err)
+	}
+
+	return &project, nil
+}
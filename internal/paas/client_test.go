@@ -0,0 +1,114 @@
+package paas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, apiTokenClientID, user)
+		assert.NoError(t, r.ParseForm())
+
+		if r.PostForm.Get("api_token") != "valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"access_token": "access", "token_type": "bearer", "expires_in": 900}`)
+	})
+
+	mux.HandleFunc("/projects/abc", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access", r.Header.Get("authorization"))
+		_, _ = fmt.Fprint(w, `{"id": "abc", "repository": {"url": "abc@git.example.com:abc.git"}}`)
+	})
+
+	mux.HandleFunc("/projects/abc/environments/main/deploy", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		_, _ = fmt.Fprint(w, `{"status": "accepted", "_embedded": {"activities": [{"id": "act1", "state": "pending"}]}}`)
+	})
+
+	mux.HandleFunc("/projects/abc/activities/act1/log", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "1", "data": {"message": "Building application\n"}}`+"\n")
+		_, _ = fmt.Fprint(w, `{"id": "2", "data": {"message": "Deploying\n"}}`+"\n")
+		_, _ = fmt.Fprint(w, `{"seal": true}`+"\n")
+	})
+
+	mux.HandleFunc("/projects/abc/environments/main/activities", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "10", r.URL.Query().Get("count"))
+		_, _ = fmt.Fprint(w, `[{"id": "act3", "created_at": "2024-03-01T10:05:00+00:00"}, {"id": "act2", "created_at": "2024-03-01T10:01:00+00:00"}, {"id": "act0", "created_at": "2024-03-01T09:00:00+00:00"}]`)
+	})
+
+	mux.HandleFunc("/projects/abc/activities/act1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "act1", "state": "complete", "result": "failure"}`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestNewClientAuthentication(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	_, err := newClient(context.Background(), server.Client(), server.URL, server.URL+"/oauth2/token", "invalid")
+	assert.Error(t, err)
+
+	_, err = newClient(context.Background(), server.Client(), server.URL, server.URL+"/oauth2/token", "")
+	assert.Error(t, err)
+
+	client, err := newClient(context.Background(), server.Client(), server.URL, server.URL+"/oauth2/token", "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "access", client.accessToken)
+}
+
+func TestDeployAndStreamLog(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := newClient(context.Background(), server.Client(), server.URL, server.URL+"/oauth2/token", "valid")
+	assert.NoError(t, err)
+
+	project, err := client.GetProject(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc@git.example.com:abc.git", project.Repository.URL)
+
+	activity, err := client.Deploy(context.Background(), "abc", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "act1", activity.ID)
+
+	var log bytes.Buffer
+	assert.NoError(t, client.StreamActivityLog(context.Background(), "abc", activity.ID, &log))
+	assert.Equal(t, "Building application\nDeploying\n", log.String())
+
+	activity, err = client.GetActivity(context.Background(), "abc", activity.ID)
+	assert.NoError(t, err)
+	assert.True(t, activity.IsFailed())
+}
+
+func TestGetActivityCreatedAfter(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := newClient(context.Background(), server.Client(), server.URL, server.URL+"/oauth2/token", "valid")
+	assert.NoError(t, err)
+
+	activity, err := client.GetActivityCreatedAfter(context.Background(), "abc", "main", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "act2", activity.ID)
+
+	activity, err = client.GetActivityCreatedAfter(context.Background(), "abc", "main", time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Nil(t, activity)
+}
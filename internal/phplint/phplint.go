@@ -0,0 +1,213 @@
+// Package phplint detects PHP language features which are not available in an older PHP version. It works
+// on tokens only and does not need a PHP binary or network access.
+package phplint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+type Issue struct {
+	Line    int
+	Feature string
+	// Version is the minimum PHP version of the feature
+	Version string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s requires PHP %s", i.Line, i.Feature, i.Version)
+}
+
+// Check returns all features used in the PHP content which need a newer PHP version than phpVersion (e.g. "7.4").
+func Check(content string, phpVersion string) ([]Issue, error) {
+	minVersion, err := version.NewVersion(phpVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid php version %s: %w", phpVersion, err)
+	}
+
+	issues := make([]Issue, 0)
+
+	for _, issue := range detectFeatures(tokenize(content)) {
+		featureVersion := version.Must(version.NewVersion(issue.Version))
+
+		if featureVersion.GreaterThan(minVersion) {
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+type tokenList []token
+
+func (t tokenList) value(i int) string {
+	if i < 0 || i >= len(t) {
+		return ""
+	}
+
+	return t[i].value
+}
+
+func (t tokenList) kind(i int) tokenKind {
+	if i < 0 || i >= len(t) {
+		return tokenOperator
+	}
+
+	return t[i].kind
+}
+
+// closingParen returns the index of the parenthesis closing the one at index i.
+func (t tokenList) closingParen(i int) int {
+	depth := 0
+
+	for j := i; j < len(t); j++ {
+		if t[j].kind != tokenOperator {
+			continue
+		}
+
+		switch t[j].value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+
+	return -1
+}
+
+func isMemberAccess(value string) bool {
+	return value == "->" || value == "?->" || value == "::" || value == "function" || value == "const"
+}
+
+func detectFeatures(tokens tokenList) []Issue {
+	issues := make([]Issue, 0)
+
+	add := func(i int, feature, phpVersion string) {
+		issues = append(issues, Issue{Line: tokens[i].line, Feature: feature, Version: phpVersion})
+	}
+
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokenNumber:
+			if len(tok.value) > 2 && tok.value[:2] == "0o" {
+				add(i, "explicit octal notation", "8.1")
+			} else if strings.ContainsRune(tok.value, '_') {
+				add(i, "numeric literal separator", "7.4")
+			}
+		case tokenOperator:
+			switch tok.value {
+			case "??=":
+				add(i, "null coalescing assignment operator", "7.4")
+			case "?->":
+				add(i, "nullsafe operator", "8.0")
+			case "...":
+				if tokens.value(i-1) == "(" && tokens.value(i+1) == ")" {
+					add(i, "first-class callable syntax", "8.1")
+				}
+			case "::":
+				if tokens.value(i+1) == "{" {
+					add(i, "dynamic class constant fetch", "8.3")
+				}
+			case ")":
+				if tokens.value(i+1) != ":" || tokens.kind(i+2) != tokenIdentifier {
+					continue
+				}
+
+				switch tokens.value(i + 2) {
+				case "static", "mixed":
+					add(i, tokens.value(i+2)+" return type", "8.0")
+				case "never":
+					add(i, "never return type", "8.1")
+				case "true", "false", "null":
+					if next := tokens.value(i + 3); next == "{" || next == ";" {
+						add(i, "standalone "+tokens.value(i+2)+" type", "8.2")
+					}
+				}
+			}
+		case tokenIdentifier:
+			if isMemberAccess(tokens.value(i - 1)) {
+				continue
+			}
+
+			switch tok.value {
+			case "fn":
+				if tokens.value(i+1) == "(" {
+					add(i, "arrow function", "7.4")
+				}
+			case "match":
+				if tokens.value(i+1) == "(" && tokens.value(i-1) != "new" {
+					if end := tokens.closingParen(i + 1); end != -1 && tokens.value(end+1) == "{" {
+						add(i, "match expression", "8.0")
+					}
+				}
+			case "throw":
+				switch tokens.value(i - 1) {
+				case "??", "?:", "=>":
+					add(i, "throw expression", "8.0")
+				}
+			case "function":
+				if tokens.value(i+1) == "__construct" && tokens.value(i+2) == "(" && hasPromotedProperty(tokens, i+2) {
+					add(i, "constructor property promotion", "8.0")
+				}
+			case "enum":
+				if tokens.kind(i+1) == tokenIdentifier {
+					switch tokens.value(i + 2) {
+					case "{", ":", "implements":
+						add(i, "enumerations", "8.1")
+					}
+				}
+			case "readonly":
+				next := tokens.value(i + 1)
+
+				switch {
+				case next == "class" || (next == "final" || next == "abstract") && tokens.value(i+2) == "class":
+					add(i, "readonly classes", "8.2")
+				case next == "(":
+				case tokens.kind(i+1) == tokenIdentifier || tokens.kind(i+1) == tokenVariable || next == "?":
+					add(i, "readonly properties", "8.1")
+				}
+			case "const":
+				if tokens.kind(i+1) == tokenIdentifier && tokens.kind(i+2) == tokenIdentifier && tokens.value(i+3) == "=" {
+					add(i, "typed class constants", "8.3")
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func hasPromotedProperty(tokens tokenList, openParen int) bool {
+	end := tokens.closingParen(openParen)
+	if end == -1 {
+		return false
+	}
+
+	depth := 0
+
+	for j := openParen + 1; j < end; j++ {
+		switch tokens[j].value {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		case "public", "protected", "private", "readonly":
+			if depth == 0 && tokens[j].kind == tokenIdentifier {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+package phplint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func features(t *testing.T, content, phpVersion string) []string {
+	t.Helper()
+
+	issues, err := Check(content, phpVersion)
+	assert.NoError(t, err)
+
+	result := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, issue.Feature)
+	}
+
+	return result
+}
+
+func TestCheckDetectsNewerFeatures(t *testing.T) {
+	content := `<?php
+namespace Foo;
+
+enum Status: string {
+    case Active = 'active';
+}
+
+final readonly class Bar
+{
+    public function __construct(private Service $service, public readonly int $count = 1_000) {}
+
+    public function run(?Service $service): never
+    {
+        $name = $service?->getName();
+        $value = match ($name) {
+            'a' => 1,
+            default => 2,
+        };
+        $cb = strlen(...);
+        $fn = fn($x) => $x ?? throw new \Exception();
+        $value ??= 0o16;
+    }
+}
+`
+
+	assert.Equal(t, []string{
+		"enumerations",
+		"readonly classes",
+		"constructor property promotion",
+		"readonly properties",
+		"numeric literal separator",
+		"never return type",
+		"nullsafe operator",
+		"match expression",
+		"first-class callable syntax",
+		"arrow function",
+		"throw expression",
+		"null coalescing assignment operator",
+		"explicit octal notation",
+	}, features(t, content, "7.3"))
+
+	assert.Equal(t, []string{"readonly classes"}, features(t, content, "8.1"))
+}
+
+func TestCheckIgnoresStringsAndComments(t *testing.T) {
+	content := `<?php
+// enum Foo {}
+/* $a?->b; */
+# match ($a) {}
+$a = 'enum Foo { } ?->';
+$b = "readonly class";
+$c = <<<EOT
+fn($x) => 1
+EOT;
+$d = $object->match($x);
+$e = $object->enum;
+`
+
+	assert.Empty(t, features(t, content, "7.2"))
+}
+
+func TestCheckTypedClassConstants(t *testing.T) {
+	issues, err := Check("<?php\nclass A {\n    const string FOO = 'a';\n    const BAR = 1;\n}\n", "8.2")
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "line 3: typed class constants requires PHP 8.3", issues[0].String())
+}
+
+func TestCheckInvalidVersion(t *testing.T) {
+	_, err := Check("<?php", "foo")
+	assert.Error(t, err)
+}
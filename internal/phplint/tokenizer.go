@@ -0,0 +1,191 @@
+package phplint
+
+import (
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota
+	tokenVariable
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenAttribute
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// tokenize splits PHP source code into tokens. Comments, whitespace and inline HTML are dropped, strings are
+// collapsed into a single token, so the checks never match on their content.
+func tokenize(content string) []token {
+	tokens := make([]token, 0)
+	line := 1
+	inPHP := false
+	i := 0
+
+	for i < len(content) {
+		if !inPHP {
+			next := strings.Index(content[i:], "<?php")
+			short := strings.Index(content[i:], "<?=")
+
+			if next == -1 || (short != -1 && short < next) {
+				next = short
+			}
+
+			if next == -1 {
+				break
+			}
+
+			line += strings.Count(content[i:i+next], "\n")
+			i += next + 2
+			inPHP = true
+
+			continue
+		}
+
+		c := content[i]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(content[i:], "?>"):
+			inPHP = false
+			i += 2
+		case strings.HasPrefix(content[i:], "#["):
+			tokens = append(tokens, token{kind: tokenAttribute, value: "#[", line: line})
+			i += 2
+		case c == '#' || strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' && !strings.HasPrefix(content[i:], "?>") {
+				i++
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				end = len(content) - i - 2
+			}
+
+			line += strings.Count(content[i:i+2+end], "\n")
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			start := i
+			i = skipQuoted(content, i)
+			line += strings.Count(content[start:min(i, len(content))], "\n")
+			tokens = append(tokens, token{kind: tokenString, value: "string", line: line})
+		case strings.HasPrefix(content[i:], "<<<"):
+			start := i
+			i = skipHeredoc(content, i)
+			tokens = append(tokens, token{kind: tokenString, value: "string", line: line})
+			line += strings.Count(content[start:min(i, len(content))], "\n")
+		case c == '$' && i+1 < len(content) && isIdentifierStart(content[i+1]):
+			start := i
+			i++
+			for i < len(content) && isIdentifierPart(content[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenVariable, value: content[start:i], line: line})
+		case isDigit(c):
+			start := i
+			for i < len(content) && (isIdentifierPart(content[i]) || content[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: strings.ToLower(content[start:i]), line: line})
+		case isIdentifierStart(c) || c == '\\':
+			start := i
+			for i < len(content) && (isIdentifierPart(content[i]) || content[i] == '\\') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: strings.ToLower(content[start:i]), line: line})
+		default:
+			op := readOperator(content[i:])
+			tokens = append(tokens, token{kind: tokenOperator, value: op, line: line})
+			i += len(op)
+		}
+	}
+
+	return tokens
+}
+
+var operators = []string{
+	"??=", "?->", "...", "<=>", "**=", "===", "!==", "<<=", ">>=",
+	"??", "?:", "->", "=>", "::", "==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=", "*=", "/=", ".=", "%=", "&=", "|=", "^=", "<<", ">>", "**",
+}
+
+func readOperator(content string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(content, op) {
+			return op
+		}
+	}
+
+	return content[:1]
+}
+
+func skipQuoted(content string, i int) int {
+	quote := content[i]
+	i++
+
+	for i < len(content) {
+		switch content[i] {
+		case '\\':
+			i += 2
+			continue
+		case quote:
+			return i + 1
+		}
+		i++
+	}
+
+	return i
+}
+
+func skipHeredoc(content string, i int) int {
+	lineEnd := strings.IndexByte(content[i:], '\n')
+	if lineEnd == -1 {
+		return len(content)
+	}
+
+	label := strings.Trim(strings.TrimSpace(content[i+3:i+lineEnd]), "'\"")
+	i += lineEnd + 1
+
+	for i < len(content) {
+		next := strings.IndexByte(content[i:], '\n')
+		current := content[i:]
+		if next != -1 {
+			current = content[i : i+next]
+		}
+
+		trimmed := strings.TrimLeft(current, " \t")
+		if strings.HasPrefix(trimmed, label) && (len(trimmed) == len(label) || !isIdentifierPart(trimmed[len(label)])) {
+			return i + len(current) - len(trimmed) + len(label)
+		}
+
+		if next == -1 {
+			return len(content)
+		}
+
+		i += next + 1
+	}
+
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c)
+}
@@ -0,0 +1,42 @@
+package process
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// WaitDelay is the time a subprocess gets to exit after it has been stopped, before it is killed.
+const WaitDelay = 10 * time.Second
+
+// Command is like exec.CommandContext, but stops the process gracefully when the context is done. Tools like npm forward
+// the signal to their own child processes like webpack, a kill would leave them running as orphans.
+func Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = WaitDelay
+
+	// In a terminal, Ctrl+C reaches all processes of the foreground job. Otherwise, f.e. in CI, the subprocess gets its own
+	// process group, so the whole tree can be stopped.
+	if !stdinIsTerminal() {
+		setProcessGroup(cmd)
+	}
+
+	cmd.Cancel = func() error {
+		return stop(cmd)
+	}
+
+	return cmd
+}
+
+// stdinIsTerminal reports whether the stdin is a character device other than /dev/null, which CI runners often attach.
+func stdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+
+	devNull, err := os.Stat(os.DevNull)
+
+	return err != nil || !os.SameFile(stat, devNull)
+}
@@ -0,0 +1,56 @@
+package process
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandIsStoppedWithContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// the trap proves that the process has been stopped gracefully instead of being killed
+	cmd := Command(ctx, "sh", "-c", "trap 'echo stopped; exit 3' TERM; while true; do sleep 0.05; done")
+
+	start := time.Now()
+	output, err := cmd.Output()
+
+	assert.Error(t, err)
+	assert.Equal(t, "stopped\n", string(output))
+	assert.Less(t, time.Since(start), WaitDelay)
+}
+
+func TestCommandStopsProcessGroup(t *testing.T) {
+	if runtime.GOOS == "windows" || stdinIsTerminal() {
+		t.Skip("the process group is only used without terminal")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// sh waits for sleep before handling the signal, only stopping the group ends both
+	start := time.Now()
+	err := Command(ctx, "sh", "-c", "sleep 30; echo done").Run()
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), WaitDelay)
+}
+
+func TestCommandSucceeds(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+
+	output, err := Command(context.Background(), "sh", "-c", "echo ok").Output()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok\n", string(output))
+}
@@ -0,0 +1,26 @@
+//go:build !windows
+
+package process
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// stop sends SIGTERM to the process, or to its process group when it has one.
+func stop(cmd *exec.Cmd) error {
+	pid := cmd.Process.Pid
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		pid = -pid
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return cmd.Process.Kill()
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package process
+
+import (
+	"os/exec"
+)
+
+func setProcessGroup(*exec.Cmd) {}
+
+// stop kills the process, as Windows does not support sending signals to other processes.
+func stop(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,260 @@
+// Package redis is a minimal Redis client speaking the RESP protocol, which is enough for maintenance commands.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// commandTimeout limits the time to send a command and read its reply, so an unresponsive server doesn't block forever.
+// FLUSHDB of a big database can take a while, so it is generous.
+var commandTimeout = 60 * time.Second
+
+type Options struct {
+	Address  string
+	Username string
+	Password string
+	Database int
+	TLS      bool
+}
+
+// ParseDSN parses redis://[[user]:password@]host[:port][/database] and rediss:// for TLS connections.
+func ParseDSN(dsn string) (*Options, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis dsn: %w", err)
+	}
+
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid redis dsn %s: scheme must be redis or rediss", MaskDSN(dsn))
+	}
+
+	opts := &Options{Address: u.Host, TLS: u.Scheme == "rediss"}
+
+	if u.Port() == "" {
+		opts.Address = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts.Username = u.User.Username()
+			opts.Password = password
+		} else {
+			opts.Password = u.User.Username()
+		}
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if opts.Database, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid redis database %s", db)
+		}
+	}
+
+	return opts, nil
+}
+
+// MaskDSN hides the password of the dsn for output.
+func MaskDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil {
+		return dsn
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxx")
+	} else {
+		u.User = url.User("xxx")
+	}
+
+	return u.String()
+}
+
+type Client struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Dial connects to the server, authenticates and selects the database.
+func Dial(ctx context.Context, opts *Options) (*Client, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var conn net.Conn
+	var err error
+
+	if opts.TLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", opts.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", opts.Address)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewClient(conn)
+
+	if opts.Password != "" {
+		args := []string{"AUTH", opts.Password}
+		if opts.Username != "" {
+			args = []string{"AUTH", opts.Username, opts.Password}
+		}
+
+		if _, err := client.Do(args...); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	if opts.Database != 0 {
+		if _, err := client.Do("SELECT", strconv.Itoa(opts.Database)); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+func NewClient(conn net.Conn) *Client {
+	return &Client{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends the command and returns the reply as string, int64, []interface{} or nil.
+func (c *Client) Do(args ...string) (interface{}, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(commandTimeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+
+	return c.readReply()
+}
+
+func (c *Client) readReply() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+
+	if len(line) == 0 {
+		return nil, fmt.Errorf("invalid redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, buf); err != nil {
+			return nil, err
+		}
+
+		return string(buf[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, 0, size)
+
+		for i := 0; i < size; i++ {
+			item, err := c.readReply()
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("unknown redis reply type %q", line[0])
+}
+
+// DBSize returns the amount of keys in the selected database.
+func (c *Client) DBSize() (int64, error) {
+	reply, err := c.Do("DBSIZE")
+	if err != nil {
+		return 0, err
+	}
+
+	size, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected DBSIZE reply %v", reply)
+	}
+
+	return size, nil
+}
+
+// UsedMemory returns the human readable used memory of the server.
+func (c *Client) UsedMemory() (string, error) {
+	reply, err := c.Do("INFO", "memory")
+	if err != nil {
+		return "", err
+	}
+
+	info, ok := reply.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected INFO reply %v", reply)
+	}
+
+	for _, line := range strings.Split(info, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "used_memory_human:"); found {
+			return value, nil
+		}
+	}
+
+	return "", nil
+}
+
+// FlushDB deletes all keys of the selected database.
+func (c *Client) FlushDB() error {
+	_, err := c.Do("FLUSHDB")
+
+	return err
+}
@@ -0,0 +1,181 @@
+// Package staticdata provides version data of Shopware. The data is fetched from the internet and falls back to the
+// snapshots in data/ when the network is unavailable or the CLI runs offline. Update them with scripts/update-static-data.sh.
+package staticdata
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/offline"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	PHPVersionsURL         = "https://raw.githubusercontent.com/FriendsOfShopware/shopware-static-data/main/data/php-version.json"
+	ShopwareVersionsURL    = "https://swagger.docs.fos.gg/composer/versions.json"
+	ShopwareReleasesURL    = "https://releases.shopware.com/changelog/index.json"
+	ShopwareCorePackageURL = "https://repo.packagist.org/p2/shopware/core.json"
+	// AdminComponentsURL is formatted with the Shopware version
+	AdminComponentsURL = "https://raw.githubusercontent.com/FriendsOfShopware/shopware-static-data/main/data/admin-components/%s.json"
+)
+
+const fetchTimeout = 10 * time.Second
+
+//go:embed data/php-version.json
+var phpVersionsSnapshot []byte
+
+//go:embed data/shopware-versions.json
+var shopwareVersionsSnapshot []byte
+
+//go:embed data/shopware-releases.json
+var shopwareReleasesSnapshot []byte
+
+// PHPVersions returns the minimum PHP version by Shopware version.
+func PHPVersions(ctx context.Context) (map[string]string, error) {
+	var versions map[string]string
+
+	return versions, fetch(ctx, PHPVersionsURL, phpVersionsSnapshot, &versions)
+}
+
+// BundledPHPVersions returns the minimum PHP version by Shopware version from the snapshot bundled with shopware-cli,
+// for checks which must not access the network.
+func BundledPHPVersions() (map[string]string, error) {
+	var versions map[string]string
+
+	if err := json.Unmarshal(phpVersionsSnapshot, &versions); err != nil {
+		return nil, fmt.Errorf("cannot read bundled data of %s: %w", PHPVersionsURL, err)
+	}
+
+	return versions, nil
+}
+
+// ShopwareVersions returns all versions of shopware/core including pre-releases.
+func ShopwareVersions(ctx context.Context) ([]string, error) {
+	var versions []string
+
+	return versions, fetch(ctx, ShopwareVersionsURL, shopwareVersionsSnapshot, &versions)
+}
+
+// ShopwareReleases returns the released Shopware versions without pre-releases.
+func ShopwareReleases(ctx context.Context) ([]string, error) {
+	var versions []string
+
+	return versions, fetch(ctx, ShopwareReleasesURL, shopwareReleasesSnapshot, &versions)
+}
+
+// AdminComponents returns the template blocks by administration component of the Shopware version. The index of all
+// versions is too big to be bundled, so it's not available offline.
+func AdminComponents(ctx context.Context, shopwareVersion string) (map[string][]string, error) {
+	if offline.IsEnabled() {
+		return nil, fmt.Errorf("the component index of Shopware %s cannot be fetched: %w", shopwareVersion, offline.ErrOffline)
+	}
+
+	var components map[string][]string
+
+	if err := fetchJSON(ctx, fmt.Sprintf(AdminComponentsURL, shopwareVersion), &components); err != nil {
+		return nil, fmt.Errorf("the component index of Shopware %s cannot be fetched: %w", shopwareVersion, err)
+	}
+
+	return components, nil
+}
+
+// ShopwarePHPConstraints returns the PHP requirement of shopware/core by Shopware version. The package metadata is too big
+// to be bundled, so it's not available offline.
+func ShopwarePHPConstraints(ctx context.Context) (map[string]string, error) {
+	if offline.IsEnabled() {
+		return nil, fmt.Errorf("the PHP requirements of Shopware cannot be fetched: %w", offline.ErrOffline)
+	}
+
+	var metadata packagistMetadata
+
+	if err := fetchJSON(ctx, ShopwareCorePackageURL, &metadata); err != nil {
+		return nil, fmt.Errorf("the PHP requirements of Shopware cannot be fetched: %w", err)
+	}
+
+	return metadata.phpConstraints("shopware/core"), nil
+}
+
+// packagistMetadata is the minified metadata of the composer 2 repository, a version only contains the fields which
+// changed compared to the previous version.
+type packagistMetadata struct {
+	Packages map[string][]struct {
+		Version string          `json:"version"`
+		Require json.RawMessage `json:"require"`
+	} `json:"packages"`
+}
+
+func (m packagistMetadata) phpConstraints(name string) map[string]string {
+	constraints := make(map[string]string)
+	php := ""
+
+	for _, v := range m.Packages[name] {
+		if len(v.Require) > 0 {
+			// "__unset" removes the requirements of the previous version
+			var require map[string]string
+			if err := json.Unmarshal(v.Require, &require); err != nil {
+				require = nil
+			}
+
+			php = require["php"]
+		}
+
+		if php != "" {
+			constraints[strings.TrimPrefix(v.Version, "v")] = php
+		}
+	}
+
+	return constraints
+}
+
+func fetch(ctx context.Context, url string, snapshot []byte, target interface{}) error {
+	if offline.IsEnabled() {
+		logging.FromContext(ctx).Debugf("Using the bundled data instead of %s as offline mode is enabled", url)
+	} else {
+		err := fetchJSON(ctx, url, target)
+		if err == nil {
+			return nil
+		}
+
+		logging.FromContext(ctx).Warnf("Cannot fetch %s, using the data bundled with shopware-cli which may be outdated: %v", url, err)
+	}
+
+	if err := json.Unmarshal(snapshot, target); err != nil {
+		return fmt.Errorf("cannot read bundled data of %s: %w", url, err)
+	}
+
+	return nil
+}
+
+func fetchJSON(ctx context.Context, url string, target interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, target)
+}
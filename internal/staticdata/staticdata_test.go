@@ -0,0 +1,88 @@
+package staticdata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/offline"
+)
+
+func TestSnapshotsAreValid(t *testing.T) {
+	t.Setenv("SHOPWARE_CLI_OFFLINE", "1")
+
+	phpVersions, err := PHPVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "8.1", phpVersions["6.5.0.0"])
+
+	versions, err := ShopwareVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, versions, "6.4.20.2")
+
+	releases, err := ShopwareReleases(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, versions, "6.5.0.0-rc1")
+	assert.Contains(t, releases, "6.5.0.0")
+	assert.NotContains(t, releases, "6.5.0.0-rc1")
+}
+
+func TestFetchFallsBackToSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`["6.6.0.0"]`))
+	}))
+	defer server.Close()
+
+	var versions []string
+	assert.NoError(t, fetch(context.Background(), server.URL, []byte(`["6.5.0.0"]`), &versions))
+	assert.Equal(t, []string{"6.6.0.0"}, versions)
+
+	versions = nil
+	assert.NoError(t, fetch(context.Background(), server.URL+"/broken", []byte(`["6.5.0.0"]`), &versions))
+	assert.Equal(t, []string{"6.5.0.0"}, versions)
+
+	t.Setenv("SHOPWARE_CLI_OFFLINE", "1")
+
+	versions = nil
+	assert.NoError(t, fetch(context.Background(), server.URL, []byte(`["6.5.0.0"]`), &versions))
+	assert.Equal(t, []string{"6.5.0.0"}, versions)
+}
+
+func TestAdminComponentsOffline(t *testing.T) {
+	t.Setenv("SHOPWARE_CLI_OFFLINE", "1")
+
+	_, err := AdminComponents(context.Background(), "6.6.0.0")
+	assert.ErrorIs(t, err, offline.ErrOffline)
+}
+
+func TestPackagistMetadataPHPConstraints(t *testing.T) {
+	var metadata packagistMetadata
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"packages": {"shopware/core": [
+		{"version": "v6.6.0.0", "require": {"php": "~8.2.0 || ~8.3.0", "ext-json": "*"}},
+		{"version": "v6.5.8.0", "require": {"php": "~8.1.0 || ~8.2.0 || ~8.3.0"}},
+		{"version": "v6.5.7.0"},
+		{"version": "v6.0.0.0", "require": "__unset"}
+	]}}`), &metadata))
+
+	assert.Equal(t, map[string]string{
+		"6.6.0.0": "~8.2.0 || ~8.3.0",
+		"6.5.8.0": "~8.1.0 || ~8.2.0 || ~8.3.0",
+		"6.5.7.0": "~8.1.0 || ~8.2.0 || ~8.3.0",
+	}, metadata.phpConstraints("shopware/core"))
+}
+
+func TestShopwarePHPConstraintsOffline(t *testing.T) {
+	t.Setenv("SHOPWARE_CLI_OFFLINE", "1")
+
+	_, err := ShopwarePHPConstraints(context.Background())
+	assert.ErrorIs(t, err, offline.ErrOffline)
+}
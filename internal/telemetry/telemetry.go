@@ -0,0 +1,88 @@
+// Package telemetry sends anonymous usage data, when the user has enabled it with shopware-cli telemetry enable.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+)
+
+// DefaultEndpoint receives the events, it can be changed with SHOPWARE_CLI_TELEMETRY_ENDPOINT.
+const DefaultEndpoint = "https://telemetry.fos.gg/shopware-cli"
+
+// sendTimeout keeps a slow or unreachable endpoint from delaying the command.
+const sendTimeout = 2 * time.Second
+
+// Event contains everything that is sent. There are no arguments, paths, hostnames or identifiers.
+type Event struct {
+	Command    string `json:"command"`
+	DurationMs int64  `json:"duration_ms"`
+	Version    string `json:"version"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+}
+
+func NewEvent(command string, duration time.Duration, version string) Event {
+	return Event{
+		Command:    command,
+		DurationMs: duration.Milliseconds(),
+		Version:    version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+}
+
+// IsDisabledByEnvironment reports whether DO_NOT_TRACK or SHOPWARE_CLI_DISABLE_TELEMETRY overrule the configuration.
+func IsDisabledByEnvironment() bool {
+	for _, name := range []string{"DO_NOT_TRACK", "SHOPWARE_CLI_DISABLE_TELEMETRY"} {
+		if value := os.Getenv(name); value != "" && value != "0" && value != "false" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func Endpoint() string {
+	if endpoint := os.Getenv("SHOPWARE_CLI_TELEMETRY_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+
+	return DefaultEndpoint
+}
+
+// Send posts the event to the endpoint. Errors are returned for logging only, telemetry must never fail a command.
+func Send(ctx context.Context, endpoint string, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("telemetry endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	var received map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, Send(context.Background(), server.URL, NewEvent("project dump", 1500*time.Millisecond, "0.4.0")))
+
+	assert.Equal(t, map[string]interface{}{
+		"command":     "project dump",
+		"duration_ms": float64(1500),
+		"version":     "0.4.0",
+		"os":          runtime.GOOS,
+		"arch":        runtime.GOARCH,
+	}, received)
+}
+
+func TestSendFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	assert.ErrorContains(t, Send(context.Background(), server.URL, NewEvent("project dump", 0, "dev")), "status 500")
+}
+
+func TestIsDisabledByEnvironment(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("SHOPWARE_CLI_DISABLE_TELEMETRY", "")
+	assert.False(t, IsDisabledByEnvironment())
+
+	t.Setenv("DO_NOT_TRACK", "0")
+	assert.False(t, IsDisabledByEnvironment())
+
+	t.Setenv("DO_NOT_TRACK", "1")
+	assert.True(t, IsDisabledByEnvironment())
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Setenv("SHOPWARE_CLI_TELEMETRY_ENDPOINT", "")
+	assert.Equal(t, DefaultEndpoint, Endpoint())
+
+	t.Setenv("SHOPWARE_CLI_TELEMETRY_ENDPOINT", "http://localhost:8080")
+	assert.Equal(t, "http://localhost:8080", Endpoint())
+}
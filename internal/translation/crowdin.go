@@ -0,0 +1,116 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// crowdin uses the API v2, each snippet file is a file named <name>.json in the project root.
+type crowdin struct {
+	client  *apiClient
+	project string
+}
+
+type crowdinFile struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *crowdin) Push(ctx context.Context, name, _ string, content []byte) error {
+	data, err := c.client.do(ctx, http.MethodPost, "/storages", "application/json", bytes.NewReader(content), map[string]string{"Crowdin-API-FileName": name + ".json"})
+	if err != nil {
+		return fmt.Errorf("crowdin upload storage: %w", err)
+	}
+
+	var storage struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &storage); err != nil {
+		return fmt.Errorf("crowdin upload storage: %w", err)
+	}
+
+	file, err := c.findFile(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if file == nil {
+		body, _ := json.Marshal(map[string]interface{}{"storageId": storage.Data.ID, "name": name + ".json"})
+		_, err = c.client.do(ctx, http.MethodPost, c.projectPath("/files"), "application/json", bytes.NewReader(body), nil)
+	} else {
+		body, _ := json.Marshal(map[string]interface{}{"storageId": storage.Data.ID})
+		_, err = c.client.do(ctx, http.MethodPut, c.projectPath(fmt.Sprintf("/files/%d", file.ID)), "application/json", bytes.NewReader(body), nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("crowdin update file %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (c *crowdin) Pull(ctx context.Context, name, locale string) ([]byte, error) {
+	file, err := c.findFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("crowdin file %s.json does not exist, push the source snippets first", name)
+	}
+
+	body, _ := json.Marshal(map[string]string{"targetLanguageId": locale})
+
+	data, err := c.client.do(ctx, http.MethodPost, c.projectPath(fmt.Sprintf("/translations/builds/files/%d", file.ID)), "application/json", bytes.NewReader(body), nil)
+	if err != nil {
+		return nil, fmt.Errorf("crowdin build file %s: %w", name, err)
+	}
+
+	var build struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &build); err != nil {
+		return nil, fmt.Errorf("crowdin build file %s: %w", name, err)
+	}
+
+	return c.client.do(ctx, http.MethodGet, build.Data.URL, "", nil, nil)
+}
+
+func (c *crowdin) findFile(ctx context.Context, name string) (*crowdinFile, error) {
+	data, err := c.client.do(ctx, http.MethodGet, c.projectPath("/files?limit=500"), "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("crowdin list files: %w", err)
+	}
+
+	var files struct {
+		Data []struct {
+			Data crowdinFile `json:"data"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("crowdin list files: %w", err)
+	}
+
+	for _, file := range files.Data {
+		if file.Data.Name == name+".json" {
+			return &file.Data, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *crowdin) projectPath(path string) string {
+	return "/projects/" + url.PathEscape(c.project) + path
+}
@@ -0,0 +1,49 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// phrase uploads all snippet files into the same project, the keys of each file are tagged with its name.
+type phrase struct {
+	client  *apiClient
+	project string
+}
+
+func (p *phrase) Push(ctx context.Context, name, locale string, content []byte) error {
+	body, contentType, err := multipartBody(name+".json", content, map[string]string{
+		"file_format":         "nested_json",
+		"locale_id":           locale,
+		"tags":                name,
+		"update_translations": "true",
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.client.do(ctx, http.MethodPost, p.projectPath("/uploads"), contentType, body, nil); err != nil {
+		return fmt.Errorf("phrase upload %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (p *phrase) Pull(ctx context.Context, name, locale string) ([]byte, error) {
+	query := url.Values{}
+	query.Set("file_format", "nested_json")
+	query.Set("tags", name)
+
+	data, err := p.client.do(ctx, http.MethodGet, p.projectPath("/locales/"+url.PathEscape(locale)+"/download?"+query.Encode()), "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("phrase download %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+func (p *phrase) projectPath(path string) string {
+	return "/projects/" + url.PathEscape(p.project) + path
+}
@@ -0,0 +1,159 @@
+// Package translation uploads snippet files to translation platforms and downloads the translated files.
+package translation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+const (
+	ProviderCrowdin = "crowdin"
+	ProviderWeblate = "weblate"
+	ProviderPhrase  = "phrase"
+)
+
+// Provider synchronizes snippet files with a translation platform. The name identifies the snippet file on the platform.
+type Provider interface {
+	// Push uploads the content of the source language
+	Push(ctx context.Context, name, locale string, content []byte) error
+	// Pull downloads the translated file for the locale
+	Pull(ctx context.Context, name, locale string) ([]byte, error)
+}
+
+type Options struct {
+	Provider string
+	// Project is the project id (Crowdin, Phrase) or the project slug (Weblate)
+	Project string
+	// URL overrides the API url, required for Weblate
+	URL   string
+	Token string
+}
+
+// NewProvider creates the client for the configured platform.
+func NewProvider(opts Options) (Provider, error) {
+	if opts.Project == "" {
+		return nil, fmt.Errorf("translations.project is not configured")
+	}
+
+	if opts.Token == "" {
+		return nil, fmt.Errorf("no API token for %s configured, set SHOPWARE_CLI_EXTENSION__TRANSLATIONS__TOKEN", opts.Provider)
+	}
+
+	client := &apiClient{httpClient: http.DefaultClient, baseURL: strings.TrimSuffix(opts.URL, "/")}
+
+	switch opts.Provider {
+	case ProviderCrowdin:
+		if client.baseURL == "" {
+			client.baseURL = "https://api.crowdin.com/api/v2"
+		}
+
+		client.authorization = "Bearer " + opts.Token
+
+		return &crowdin{client: client, project: opts.Project}, nil
+	case ProviderWeblate:
+		if client.baseURL == "" {
+			return nil, fmt.Errorf("translations.url is required for weblate")
+		}
+
+		client.baseURL += "/api"
+		client.authorization = "Token " + opts.Token
+
+		return &weblate{client: client, project: opts.Project}, nil
+	case ProviderPhrase:
+		if client.baseURL == "" {
+			client.baseURL = "https://api.phrase.com/v2"
+		}
+
+		client.authorization = "token " + opts.Token
+
+		return &phrase{client: client, project: opts.Project}, nil
+	}
+
+	return nil, fmt.Errorf("unknown translation provider %q, use crowdin, weblate or phrase", opts.Provider)
+}
+
+type apiClient struct {
+	httpClient    *http.Client
+	baseURL       string
+	authorization string
+}
+
+// do sends the request, paths starting with a slash are relative to the base url.
+func (c *apiClient) do(ctx context.Context, method, path, contentType string, body io.Reader, headers map[string]string) ([]byte, error) {
+	requestURL := path
+	if strings.HasPrefix(path, "/") {
+		requestURL = c.baseURL + path
+	}
+
+	logging.FromContext(ctx).Debugf("%s: %s", method, requestURL)
+
+	r, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// signed download urls must not get the token
+	if strings.HasPrefix(requestURL, c.baseURL) {
+		r.Header.Set("authorization", c.authorization)
+	}
+
+	if contentType != "" {
+		r.Header.Set("content-type", contentType)
+	}
+
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("request %s %s failed with status %d: %s", method, r.URL.Path, resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
+
+// multipartBody builds a form with the file and the additional fields.
+func multipartBody(fileName string, content []byte, fields map[string]string) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return nil, "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
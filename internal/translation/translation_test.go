@@ -0,0 +1,134 @@
+package translation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProvider(t *testing.T, provider string, handler http.HandlerFunc) Provider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider(Options{Provider: provider, Project: "demo", URL: server.URL, Token: "secret"})
+	assert.NoError(t, err)
+
+	return p
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider(Options{Provider: ProviderCrowdin, Project: "demo"})
+	assert.ErrorContains(t, err, "SHOPWARE_CLI_EXTENSION__TRANSLATIONS__TOKEN")
+
+	_, err = NewProvider(Options{Provider: ProviderWeblate, Project: "demo", Token: "secret"})
+	assert.ErrorContains(t, err, "translations.url")
+
+	_, err = NewProvider(Options{Provider: "transifex", Project: "demo", Token: "secret"})
+	assert.ErrorContains(t, err, "unknown translation provider")
+}
+
+func TestCrowdin(t *testing.T) {
+	requests := make([]string, 0)
+
+	p := newTestProvider(t, ProviderCrowdin, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /storages":
+			assert.Equal(t, "Bearer secret", r.Header.Get("authorization"))
+			assert.Contains(t, []string{"storefront.json", "administration.json"}, r.Header.Get("Crowdin-API-FileName"))
+			assert.Equal(t, `{"foo":"bar"}`, string(body))
+			_, _ = w.Write([]byte(`{"data":{"id":5}}`))
+		case "GET /projects/demo/files":
+			_, _ = w.Write([]byte(`{"data":[{"data":{"id":9,"name":"storefront.json"}}]}`))
+		case "PUT /projects/demo/files/9":
+			assert.JSONEq(t, `{"storageId":5}`, string(body))
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /projects/demo/files":
+			assert.JSONEq(t, `{"storageId":5,"name":"administration.json"}`, string(body))
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /projects/demo/translations/builds/files/9":
+			assert.JSONEq(t, `{"targetLanguageId":"de"}`, string(body))
+			_, _ = w.Write([]byte(`{"data":{"url":"http://` + r.Host + `/download/9"}}`))
+		case "GET /download/9":
+			_, _ = w.Write([]byte(`{"foo":"baz"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, p.Push(context.Background(), "storefront", "en-GB", []byte(`{"foo":"bar"}`)))
+	assert.Equal(t, []string{"POST /storages", "GET /projects/demo/files", "PUT /projects/demo/files/9"}, requests)
+
+	requests = requests[:0]
+	assert.NoError(t, p.Push(context.Background(), "administration", "en-GB", []byte(`{"foo":"bar"}`)))
+	assert.Equal(t, "POST /projects/demo/files", requests[2])
+
+	content, err := p.Pull(context.Background(), "storefront", "de")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":"baz"}`, string(content))
+
+	_, err = p.Pull(context.Background(), "missing", "de")
+	assert.ErrorContains(t, err, "push the source snippets first")
+}
+
+func TestWeblate(t *testing.T) {
+	p := newTestProvider(t, ProviderWeblate, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token secret", r.Header.Get("authorization"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/translations/demo/storefront/en-GB/file/":
+			file, _, err := r.FormFile("file")
+			assert.NoError(t, err)
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, `{"foo":"bar"}`, string(content))
+			assert.Equal(t, "replace", r.FormValue("method"))
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /api/translations/demo/storefront/de-DE/file/":
+			_, _ = w.Write([]byte(`{"foo":"baz"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	assert.NoError(t, p.Push(context.Background(), "storefront", "en-GB", []byte(`{"foo":"bar"}`)))
+
+	content, err := p.Pull(context.Background(), "storefront", "de-DE")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":"baz"}`, string(content))
+
+	_, err = p.Pull(context.Background(), "storefront", "fr-FR")
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestPhrase(t *testing.T) {
+	p := newTestProvider(t, ProviderPhrase, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("authorization"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /projects/demo/uploads":
+			assert.Equal(t, "nested_json", r.FormValue("file_format"))
+			assert.Equal(t, "en-GB", r.FormValue("locale_id"))
+			assert.Equal(t, "storefront", r.FormValue("tags"))
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /projects/demo/locales/de-DE/download":
+			assert.Equal(t, "storefront", r.URL.Query().Get("tags"))
+			_, _ = w.Write([]byte(`{"foo":"baz"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, p.Push(context.Background(), "storefront", "en-GB", []byte(`{"foo":"bar"}`)))
+
+	content, err := p.Pull(context.Background(), "storefront", "de-DE")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":"baz"}`, string(content))
+}
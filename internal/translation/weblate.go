@@ -0,0 +1,40 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// weblate maps each snippet file to a component with the name as slug, the components have to exist in the project.
+type weblate struct {
+	client  *apiClient
+	project string
+}
+
+func (w *weblate) Push(ctx context.Context, name, locale string, content []byte) error {
+	body, contentType, err := multipartBody(name+".json", content, map[string]string{"method": "replace"})
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.client.do(ctx, http.MethodPost, w.filePath(name, locale), contentType, body, nil); err != nil {
+		return fmt.Errorf("weblate upload %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (w *weblate) Pull(ctx context.Context, name, locale string) ([]byte, error) {
+	data, err := w.client.do(ctx, http.MethodGet, w.filePath(name, locale), "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("weblate download %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+func (w *weblate) filePath(name, locale string) string {
+	return fmt.Sprintf("/translations/%s/%s/%s/file/", url.PathEscape(w.project), url.PathEscape(name), url.PathEscape(locale))
+}
@@ -0,0 +1,8 @@
+#!/usr/bin/env bash
+
+set -euo pipefail
+
+# Updates the snapshots used by shopware-cli when it runs offline
+curl -sSf https://raw.githubusercontent.com/FriendsOfShopware/shopware-static-data/main/data/php-version.json | jq . > internal/staticdata/data/php-version.json
+curl -sSf https://swagger.docs.fos.gg/composer/versions.json | jq . > internal/staticdata/data/shopware-versions.json
+curl -sSf https://releases.shopware.com/changelog/index.json | jq . > internal/staticdata/data/shopware-releases.json
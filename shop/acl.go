@@ -0,0 +1,233 @@
+package shop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// FeaturePrivileges are the ACL privileges the Admin API calls of a CLI feature need. Optional calls, whose failure is
+// tolerated like the update check of fleet status, are not included. Routes of the _info namespace need no privilege.
+var FeaturePrivileges = map[string][]string{
+	// search of system_config, sales_channel and theme, the theme configuration and mail templates with type and translations
+	"config-pull": {
+		"system_config:read", "sales_channel:read", "theme:read",
+		"mail_template:read", "mail_template_type:read", "mail_template_translation:read", "language:read",
+	},
+	// the reads of config-pull, the system config batch, the theme update and the mail template sync
+	"config-push": {
+		"system_config:read", "system_config:create", "system_config:update", "system_config:delete", "sales_channel:read", "theme:read", "theme:update",
+		"mail_template:read", "mail_template:create", "mail_template:update", "mail_template_type:read",
+		"mail_template_translation:read", "mail_template_translation:create", "mail_template_translation:update", "language:read",
+	},
+	// DELETE /api/_action/cache
+	"clear-cache": {"system:clear:cache"},
+	// the extension routes of /api/_action/extension, upload clears the cache afterwards
+	"extension": {"system:plugin:maintain", "system:clear:cache"},
+	// demodata customers upserts customers with their default address, demodata orders switches the customer of the sales
+	// channel proxy, orders via the proxy and transitions the order, transaction and delivery states
+	"demodata": {
+		"sales_channel:read", "salutation:read", "customer_group:read", "customer:read", "customer:create", "customer:update",
+		"customer_address:create", "customer_address:update", "product:read", "api_proxy_switch-customer",
+		"order:read", "order:create", "order:update", "order_transaction:read", "order_transaction:update",
+		"order_delivery:read", "order_delivery:update",
+	},
+	// GET /api/_action/extension/installed, the update check and the PHP version are optional
+	"fleet-status": {"system:plugin:maintain"},
+	// search of custom_field_set with its custom fields and relations
+	"schema-dump": {"custom_field_set:read", "custom_field:read", "custom_field_set_relation:read"},
+	// creates the webhook and deletes it on exit
+	"events-listen": {"webhook:create", "webhook:delete"},
+	"media-pull":    {"media:read", "media_folder:read"},
+	// looks up the roles by name, creates the role of the feature privileges and the integration
+	"integration": {"acl_role:read", "acl_role:create", "integration:create"},
+}
+
+// selfInspectionPrivileges allow an integration to read its own privileges for project integration check.
+var selfInspectionPrivileges = []string{"integration:read", "acl_role:read"}
+
+// FeatureNames returns the names of all features with privileges.
+func FeatureNames() []string {
+	names := make([]string, 0, len(FeaturePrivileges))
+	for name := range FeaturePrivileges {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// PrivilegesForFeatures returns the sorted privileges needed by the features, including the ones to inspect the own privileges.
+func PrivilegesForFeatures(features []string) ([]string, error) {
+	unique := make(map[string]bool)
+
+	for _, privilege := range selfInspectionPrivileges {
+		unique[privilege] = true
+	}
+
+	for _, feature := range features {
+		privileges, ok := FeaturePrivileges[feature]
+		if !ok {
+			return nil, fmt.Errorf("unknown feature %s, use one of: %s", feature, strings.Join(FeatureNames(), ", "))
+		}
+
+		for _, privilege := range privileges {
+			unique[privilege] = true
+		}
+	}
+
+	privileges := make([]string, 0, len(unique))
+	for privilege := range unique {
+		privileges = append(privileges, privilege)
+	}
+
+	sort.Strings(privileges)
+
+	return privileges, nil
+}
+
+// CreateAclRole creates a role with the given privileges and returns its id.
+func CreateAclRole(ctx adminSdk.ApiContext, client *adminSdk.Client, name string, privileges []string) (string, error) {
+	id := NewUuid()
+
+	payload := map[string]interface{}{
+		"id":         id,
+		"name":       name,
+		"privileges": privileges,
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/acl-role", payload, nil, nil); err != nil {
+		return "", fmt.Errorf("cannot create role %s: %w", name, err)
+	}
+
+	return id, nil
+}
+
+// GrantedPrivileges are the privileges of the Admin API credentials.
+type GrantedPrivileges struct {
+	Admin      bool
+	Privileges map[string]bool
+}
+
+// Missing returns the privileges which are not granted.
+func (g GrantedPrivileges) Missing(privileges []string) []string {
+	missing := make([]string, 0)
+
+	if g.Admin {
+		return missing
+	}
+
+	for _, privilege := range privileges {
+		if !g.Privileges[privilege] {
+			missing = append(missing, privilege)
+		}
+	}
+
+	return missing
+}
+
+type aclRolePrivileges struct {
+	Privileges []string `json:"privileges"`
+}
+
+func newGrantedPrivileges(admin bool, roles []aclRolePrivileges) *GrantedPrivileges {
+	granted := &GrantedPrivileges{Admin: admin, Privileges: make(map[string]bool)}
+
+	for _, role := range roles {
+		for _, privilege := range role.Privileges {
+			granted.Privileges[privilege] = true
+		}
+	}
+
+	return granted
+}
+
+// FetchGrantedPrivileges reads the privileges of the configured user or integration. An integration needs the
+// privileges integration:read and acl_role:read to read its own roles.
+func FetchGrantedPrivileges(ctx adminSdk.ApiContext, client *adminSdk.Client, cfg *ConfigAdminApi) (*GrantedPrivileges, error) {
+	if cfg.Username != "" {
+		var me struct {
+			Data struct {
+				Admin    bool                `json:"admin"`
+				AclRoles []aclRolePrivileges `json:"aclRoles"`
+			} `json:"data"`
+		}
+
+		if err := adminRequest(ctx, client, "GET", "/api/_info/me", nil, &me, nil); err != nil {
+			return nil, fmt.Errorf("cannot read the privileges of user %s: %w", cfg.Username, err)
+		}
+
+		return newGrantedPrivileges(me.Data.Admin, me.Data.AclRoles), nil
+	}
+
+	criteria := map[string]interface{}{
+		"limit":        1,
+		"filter":       []interface{}{equalsFilter("accessKey", cfg.ClientId)},
+		"associations": map[string]interface{}{"aclRoles": map[string]interface{}{}},
+	}
+
+	var res struct {
+		Data []struct {
+			Admin    bool                `json:"admin"`
+			AclRoles []aclRolePrivileges `json:"aclRoles"`
+		} `json:"data"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/integration", criteria, &res, nil); err != nil {
+		return nil, fmt.Errorf("cannot read the privileges of integration %s: %w", cfg.ClientId, err)
+	}
+
+	if len(res.Data) == 0 {
+		return nil, fmt.Errorf("cannot find integration %s", cfg.ClientId)
+	}
+
+	return newGrantedPrivileges(res.Data[0].Admin, res.Data[0].AclRoles), nil
+}
+
+// MissingPrivilegesError is returned, when an Admin API call fails with 403 because of missing ACL privileges.
+type MissingPrivilegesError struct {
+	Privileges []string
+	err        error
+}
+
+func (e *MissingPrivilegesError) Error() string {
+	return fmt.Sprintf("%s, missing privileges: %s", e.err, strings.Join(e.Privileges, ", "))
+}
+
+func (e *MissingPrivilegesError) Unwrap() error {
+	return e.err
+}
+
+// MissingPrivileges returns the privileges listed in a 403 response of the Admin API, which is part of the error chain.
+func MissingPrivileges(err error) []string {
+	var privilegesErr *MissingPrivilegesError
+	if errors.As(err, &privilegesErr) {
+		return privilegesErr.Privileges
+	}
+
+	var apiErr *adminSdk.ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.Response == nil || apiErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+
+	privileges := make([]string, 0)
+
+	for _, detail := range apiErr.Errors {
+		var missing struct {
+			MissingPrivileges []string `json:"missingPrivileges"`
+		}
+
+		// Shopware encodes the missing privileges as json into the detail of the error
+		if err := json.Unmarshal([]byte(detail.Detail), &missing); err == nil {
+			privileges = append(privileges, missing.MissingPrivileges...)
+		}
+	}
+
+	return privileges
+}
@@ -0,0 +1,80 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivilegesForFeatures(t *testing.T) {
+	privileges, err := PrivilegesForFeatures([]string{"clear-cache", "extension"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acl_role:read", "integration:read", "system:clear:cache", "system:plugin:maintain"}, privileges)
+
+	_, err = PrivilegesForFeatures([]string{"unknown"})
+	assert.ErrorContains(t, err, "unknown feature unknown, use one of: clear-cache")
+}
+
+func TestGrantedPrivilegesMissing(t *testing.T) {
+	granted := newGrantedPrivileges(false, []aclRolePrivileges{{Privileges: []string{"system:clear:cache"}}, {Privileges: []string{"media:read"}}})
+
+	assert.Equal(t, []string{"system:plugin:maintain"}, granted.Missing(FeaturePrivileges["extension"]))
+	assert.Empty(t, newGrantedPrivileges(true, nil).Missing(FeaturePrivileges["extension"]))
+}
+
+func TestCreateIntegrationWithPrivileges(t *testing.T) {
+	var role, created map[string]interface{}
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.URL.Path {
+		case "/api/acl-role":
+			role = body
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/integration":
+			created = body
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	_, err := CreateIntegration(adminSdk.NewApiContext(context.Background()), client, "CI", nil, []string{"system:clear:cache"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "CI", role["name"])
+	assert.Equal(t, []interface{}{"system:clear:cache"}, role["privileges"])
+	assert.Equal(t, false, created["admin"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": role["id"]}}, created["aclRoles"])
+}
+
+func TestFetchGrantedPrivilegesOfIntegration(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		assert.Equal(t, "/api/search/integration", r.URL.Path)
+		assert.Equal(t, []interface{}{map[string]interface{}{"type": "equals", "field": "accessKey", "value": "SWIAKEY"}}, body["filter"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [{"admin": false, "aclRoles": [{"privileges": ["system:clear:cache"]}]}]}`))
+	})
+
+	granted, err := FetchGrantedPrivileges(adminSdk.NewApiContext(context.Background()), client, &ConfigAdminApi{ClientId: "SWIAKEY"})
+	assert.NoError(t, err)
+	assert.False(t, granted.Admin)
+	assert.Equal(t, map[string]bool{"system:clear:cache": true}, granted.Privileges)
+}
+
+func TestMissingPrivilegesOfForbiddenResponse(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": [{"status": "403", "code": "FRAMEWORK__MISSING_PRIVILEGE_ERROR", "title": "Forbidden", "detail": "{\"message\":\"Missing privilege\",\"missingPrivileges\":[\"system_config:update\"]}"}]}`))
+	})
+
+	err := adminRequest(adminSdk.NewApiContext(context.Background()), client, "POST", "/api/_action/system-config/batch", map[string]interface{}{}, nil, nil)
+	assert.ErrorContains(t, err, "missing privileges: system_config:update")
+	assert.Equal(t, []string{"system_config:update"}, MissingPrivileges(err))
+
+	assert.Nil(t, MissingPrivileges(assert.AnError))
+}
@@ -0,0 +1,303 @@
+package shop
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	AssetStrategyCopy    = "copy"
+	AssetStrategySymlink = "symlink"
+	AssetStrategyCDN     = "cdn"
+
+	// assetCDNConfigFile is written into the project with the cdn strategy, so Shopware generates the asset URLs with the CDN
+	assetCDNConfigFile = "config/packages/zz-shopware-cli-assets.yaml"
+	// assetManifestFile is written by assets:install with the checksums of the copied files per bundle
+	assetManifestFile = "public/asset-manifest.json"
+	// assetCDNManifestFile lists the CDN URL of each copied file with its checksum to upload or purge changed files
+	assetCDNManifestFile = "public/asset-manifest.cdn.json"
+	// assetIntegrityFile lists the subresource integrity hash of each CSS and JS file by its CDN URL
+	assetIntegrityFile = "public/asset-integrity.json"
+)
+
+// assetPublicDirs are the folders of public with the installed bundle assets and the compiled themes.
+var assetPublicDirs = []string{"bundles", "theme"}
+
+// assetRootURLRegex matches root-relative URLs of the bundles and themes in CSS url() and JS strings.
+var assetRootURLRegex = regexp.MustCompile(`(url\(\s*["']?|["'\x60])/(bundles|theme)/`)
+
+// PlatformAssetBundles are the bundles of Shopware with public assets by their source folder relative to the project root.
+var PlatformAssetBundles = map[string]string{
+	"Administration": "vendor/shopware/administration/Resources/public",
+	"Storefront":     "vendor/shopware/storefront/Resources/public",
+	"Framework":      "vendor/shopware/core/Framework/Resources/public",
+}
+
+// ConfigBuildAssets configures how the public assets of the bundles are deployed after assets:install.
+type ConfigBuildAssets struct {
+	// Strategy is copy (default), symlink or cdn
+	Strategy string `yaml:"strategy,omitempty"`
+	// CDNURL is the URL prefix the public folder is served from with the cdn strategy
+	CDNURL string `yaml:"cdn_url,omitempty"`
+	// RewriteURLs prefixes the root-relative /bundles/ URLs in the compiled CSS and JS with the CDN URL
+	RewriteURLs bool `yaml:"rewrite_urls,omitempty"`
+}
+
+// AssetBundleDirectory returns the folder name of the bundle in public/bundles like assets:install.
+func AssetBundleDirectory(bundleName string) string {
+	return strings.TrimSuffix(strings.ToLower(bundleName), "bundle")
+}
+
+// ValidateAssetStrategy checks the strategy without changing the project.
+func ValidateAssetStrategy(cfg ConfigBuildAssets) error {
+	switch cfg.Strategy {
+	case "", AssetStrategyCopy, AssetStrategySymlink:
+		return nil
+	case AssetStrategyCDN:
+		return validateAssetCDNURL(cfg.CDNURL)
+	}
+
+	return fmt.Errorf("unknown asset strategy %s, use copy, symlink or cdn", cfg.Strategy)
+}
+
+// PrepareAssetStrategy validates the strategy and writes the config for it. It has to run before the container is built,
+// as the cdn strategy configures the asset URL of Shopware, so only project ci calls it.
+func PrepareAssetStrategy(projectRoot string, cfg ConfigBuildAssets) error {
+	if err := ValidateAssetStrategy(cfg); err != nil {
+		return err
+	}
+
+	if cfg.Strategy == AssetStrategyCDN {
+		return writeAssetCDNConfig(projectRoot, cfg.CDNURL)
+	}
+
+	return nil
+}
+
+// ApplyAssetStrategy post-processes public/bundles after assets:install. bundles maps the bundle names to the absolute
+// path of their Resources/public folder.
+func ApplyAssetStrategy(projectRoot string, cfg ConfigBuildAssets, bundles map[string]string) error {
+	switch cfg.Strategy {
+	case "", AssetStrategyCopy:
+		return nil
+	case AssetStrategySymlink:
+		return symlinkAssetBundles(projectRoot, bundles)
+	case AssetStrategyCDN:
+		if cfg.RewriteURLs {
+			if err := rewriteAssetURLs(projectRoot, cfg.CDNURL); err != nil {
+				return err
+			}
+		}
+
+		// the hashes are created after the rewrite, as it changes the files
+		if err := writeAssetIntegrityManifest(projectRoot, cfg.CDNURL); err != nil {
+			return err
+		}
+
+		return writeAssetCDNManifest(projectRoot, cfg.CDNURL)
+	}
+
+	return fmt.Errorf("unknown asset strategy %s, use copy, symlink or cdn", cfg.Strategy)
+}
+
+// walkAssetFiles calls fn for all CSS and JS files in public/bundles and public/theme with their path relative to the
+// public folder.
+func walkAssetFiles(projectRoot string, fn func(path, relPath string) error) error {
+	publicDir := filepath.Join(projectRoot, "public")
+
+	for _, dir := range assetPublicDirs {
+		if err := walkAssetDir(publicDir, filepath.Join(publicDir, dir), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkAssetDir(publicDir, dir string, fn func(path, relPath string) error) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		if ext := filepath.Ext(path); ext != ".css" && ext != ".js" {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(publicDir, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(path, filepath.ToSlash(relPath))
+	})
+}
+
+func rewriteAssetURLs(projectRoot, cdnURL string) error {
+	replacement := "${1}" + strings.TrimRight(cdnURL, "/") + "/${2}/"
+
+	return walkAssetFiles(projectRoot, func(path, _ string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rewritten := assetRootURLRegex.ReplaceAll(content, []byte(replacement))
+		if string(rewritten) == string(content) {
+			return nil
+		}
+
+		return os.WriteFile(path, rewritten, 0o644)
+	})
+}
+
+func writeAssetIntegrityManifest(projectRoot, cdnURL string) error {
+	integrity := make(map[string]string)
+
+	err := walkAssetFiles(projectRoot, func(path, relPath string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		hash := sha512.Sum384(content)
+		integrity[strings.TrimRight(cdnURL, "/")+"/"+relPath] = "sha384-" + base64.StdEncoding.EncodeToString(hash[:])
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(integrity) == 0 {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(integrity, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(projectRoot, filepath.FromSlash(assetIntegrityFile)), content, 0o644)
+}
+
+func symlinkAssetBundles(projectRoot string, bundles map[string]string) error {
+	bundlesDir := filepath.Join(projectRoot, "public", "bundles")
+
+	names := make([]string, 0, len(bundles))
+	for name := range bundles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		source := bundles[name]
+
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			continue
+		}
+
+		target := filepath.Join(bundlesDir, AssetBundleDirectory(name))
+
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(bundlesDir, 0o755); err != nil {
+			return err
+		}
+
+		// relative links keep working, when the build is moved to the server
+		link, err := filepath.Rel(bundlesDir, source)
+		if err != nil {
+			return err
+		}
+
+		if err := os.Symlink(link, target); err != nil {
+			return fmt.Errorf("cannot link the assets of %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateAssetCDNURL(cdnURL string) error {
+	parsed, err := url.Parse(cdnURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("the cdn asset strategy requires build.assets.cdn_url like https://cdn.example.com, got %q", cdnURL)
+	}
+
+	return nil
+}
+
+func writeAssetCDNConfig(projectRoot, cdnURL string) error {
+	config := fmt.Sprintf(`# Generated by shopware-cli, the public assets are served by the CDN
+shopware:
+    filesystem:
+        asset:
+            url: '%s'
+`, strings.ReplaceAll(strings.TrimRight(cdnURL, "/"), "'", "''"))
+
+	configPath := filepath.Join(projectRoot, filepath.FromSlash(assetCDNConfigFile))
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(configPath, []byte(config), 0o644)
+}
+
+func writeAssetCDNManifest(projectRoot, cdnURL string) error {
+	content, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(assetManifestFile)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cdnManifest, err := RewriteAssetManifest(content, cdnURL)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(projectRoot, filepath.FromSlash(assetCDNManifestFile)), cdnManifest, 0o644)
+}
+
+// RewriteAssetManifest turns the asset-manifest.json of assets:install, which lists the checksums of the files per bundle,
+// into a mapping of the CDN URL of each file to its checksum.
+func RewriteAssetManifest(content []byte, cdnURL string) ([]byte, error) {
+	var manifest map[string]map[string]string
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", assetManifestFile, err)
+	}
+
+	rewritten := make(map[string]string)
+
+	for bundle, files := range manifest {
+		for file, hash := range files {
+			rewritten[fmt.Sprintf("%s/bundles/%s/%s", strings.TrimRight(cdnURL, "/"), bundle, strings.TrimLeft(file, "/"))] = hash
+		}
+	}
+
+	return json.MarshalIndent(rewritten, "", "    ")
+}
@@ -0,0 +1,127 @@
+package shop
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetBundleDirectory(t *testing.T) {
+	assert.Equal(t, "frostools", AssetBundleDirectory("FrosTools"))
+	assert.Equal(t, "administration", AssetBundleDirectory("Administration"))
+	assert.Equal(t, "swagpaypal", AssetBundleDirectory("SwagPayPalBundle"))
+}
+
+func TestApplyAssetStrategySymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "custom", "plugins", "FroshTools", "src", "Resources", "public")
+
+	assert.NoError(t, os.MkdirAll(source, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(source, "logo.png"), []byte("png"), os.ModePerm))
+
+	// assets:install copied the folder before
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "public", "bundles", "froshtools"), os.ModePerm))
+
+	cfg := ConfigBuildAssets{Strategy: AssetStrategySymlink}
+	bundles := map[string]string{
+		"FroshTools": source,
+		"Missing":    filepath.Join(dir, "custom", "plugins", "Missing", "src", "Resources", "public"),
+	}
+
+	assert.NoError(t, PrepareAssetStrategy(dir, cfg))
+	assert.NoError(t, ApplyAssetStrategy(dir, cfg, bundles))
+
+	link, err := os.Readlink(filepath.Join(dir, "public", "bundles", "froshtools"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("..", "..", "custom", "plugins", "FroshTools", "src", "Resources", "public"), link)
+
+	content, err := os.ReadFile(filepath.Join(dir, "public", "bundles", "froshtools", "logo.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+
+	assert.NoDirExists(t, filepath.Join(dir, "public", "bundles", "missing"))
+}
+
+func TestApplyAssetStrategyCDN(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "public"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "public", "asset-manifest.json"), []byte(`{"froshtools": {"logo.png": "abc"}}`), os.ModePerm))
+
+	cfg := ConfigBuildAssets{Strategy: AssetStrategyCDN, CDNURL: "https://cdn.example.com/"}
+
+	assert.NoError(t, PrepareAssetStrategy(dir, cfg))
+	assert.NoError(t, ApplyAssetStrategy(dir, cfg, nil))
+
+	config, err := os.ReadFile(filepath.Join(dir, "config", "packages", "zz-shopware-cli-assets.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), "url: 'https://cdn.example.com'")
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "public", "asset-manifest.cdn.json"))
+	assert.NoError(t, err)
+
+	var rewritten map[string]string
+	assert.NoError(t, json.Unmarshal(manifest, &rewritten))
+	assert.Equal(t, map[string]string{"https://cdn.example.com/bundles/froshtools/logo.png": "abc"}, rewritten)
+}
+
+func TestApplyAssetStrategyCDNRewriteURLs(t *testing.T) {
+	dir := t.TempDir()
+	bundleDir := filepath.Join(dir, "public", "bundles", "froshtools")
+
+	assert.NoError(t, os.MkdirAll(bundleDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, "app.css"), []byte(`.a{background:url("/bundles/froshtools/logo.png")}.b{background:url(/bundles/storefront/x.svg)}.c{background:url(../logo.png)}`), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, "app.js"), []byte(`const a = "/bundles/froshtools/chunk.js", b = "/api/bundles/";`), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, "logo.png"), []byte("png"), os.ModePerm))
+
+	themeDir := filepath.Join(dir, "public", "theme", "abc123", "css")
+
+	assert.NoError(t, os.MkdirAll(themeDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(themeDir, "all.css"), []byte(`@font-face{src:url("/bundles/storefront/assets/font.woff")}.logo{background:url('/theme/abc123/assets/logo.svg')}`), 0o644))
+
+	cfg := ConfigBuildAssets{Strategy: AssetStrategyCDN, CDNURL: "https://cdn.example.com/", RewriteURLs: true}
+
+	assert.NoError(t, ApplyAssetStrategy(dir, cfg, nil))
+
+	css, _ := os.ReadFile(filepath.Join(bundleDir, "app.css"))
+	assert.Equal(t, `.a{background:url("https://cdn.example.com/bundles/froshtools/logo.png")}.b{background:url(https://cdn.example.com/bundles/storefront/x.svg)}.c{background:url(../logo.png)}`, string(css))
+
+	js, _ := os.ReadFile(filepath.Join(bundleDir, "app.js"))
+	assert.Equal(t, `const a = "https://cdn.example.com/bundles/froshtools/chunk.js", b = "/api/bundles/";`, string(js))
+
+	theme, _ := os.ReadFile(filepath.Join(themeDir, "all.css"))
+	assert.Equal(t, `@font-face{src:url("https://cdn.example.com/bundles/storefront/assets/font.woff")}.logo{background:url('https://cdn.example.com/theme/abc123/assets/logo.svg')}`, string(theme))
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "public", "asset-integrity.json"))
+	assert.NoError(t, err)
+
+	var integrity map[string]string
+	assert.NoError(t, json.Unmarshal(manifest, &integrity))
+	assert.Len(t, integrity, 3)
+	assert.Equal(t, "sha384-", integrity["https://cdn.example.com/bundles/froshtools/app.css"][:7])
+	assert.Contains(t, integrity, "https://cdn.example.com/bundles/froshtools/app.js")
+	assert.Contains(t, integrity, "https://cdn.example.com/theme/abc123/css/all.css")
+}
+
+func TestPrepareAssetStrategyInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.ErrorContains(t, PrepareAssetStrategy(dir, ConfigBuildAssets{Strategy: "rsync"}), "unknown asset strategy rsync")
+	assert.ErrorContains(t, PrepareAssetStrategy(dir, ConfigBuildAssets{Strategy: AssetStrategyCDN}), "requires build.assets.cdn_url")
+	assert.ErrorContains(t, PrepareAssetStrategy(dir, ConfigBuildAssets{Strategy: AssetStrategyCDN, CDNURL: "cdn.example.com"}), "requires build.assets.cdn_url")
+}
+
+func TestValidateAssetStrategy(t *testing.T) {
+	assert.NoError(t, ValidateAssetStrategy(ConfigBuildAssets{}))
+	assert.NoError(t, ValidateAssetStrategy(ConfigBuildAssets{Strategy: AssetStrategyCDN, CDNURL: "https://cdn.example.com"}))
+	assert.ErrorContains(t, ValidateAssetStrategy(ConfigBuildAssets{Strategy: "rsync"}), "unknown asset strategy rsync")
+	assert.ErrorContains(t, ValidateAssetStrategy(ConfigBuildAssets{Strategy: AssetStrategyCDN}), "requires build.assets.cdn_url")
+}
@@ -0,0 +1,251 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+const packagistSecurityAdvisoriesURL = "https://packagist.org/api/security-advisories/"
+
+var auditSeverities = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+type AuditPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Source is the composer.lock the package was found in
+	Source string `json:"source"`
+}
+
+type AuditAdvisory struct {
+	Package          string `json:"package"`
+	Version          string `json:"version"`
+	Source           string `json:"source"`
+	AdvisoryID       string `json:"advisoryId"`
+	Title            string `json:"title"`
+	Link             string `json:"link"`
+	CVE              string `json:"cve"`
+	Severity         string `json:"severity"`
+	AffectedVersions string `json:"affectedVersions"`
+}
+
+type packagistAdvisory struct {
+	AdvisoryID       string `json:"advisoryId"`
+	Title            string `json:"title"`
+	Link             string `json:"link"`
+	CVE              string `json:"cve"`
+	Severity         string `json:"severity"`
+	AffectedVersions string `json:"affectedVersions"`
+}
+
+type auditComposerLock struct {
+	Packages    []AuditPackage `json:"packages"`
+	PackagesDev []AuditPackage `json:"packages-dev"`
+}
+
+// CollectAuditPackages reads the composer.lock of the project and of all extensions in custom/plugins and custom/static-plugins.
+func CollectAuditPackages(projectRoot string) ([]AuditPackage, error) {
+	lockFiles := []string{filepath.Join(projectRoot, "composer.lock")}
+
+	for _, folder := range []string{"plugins", "static-plugins"} {
+		extensionLocks, err := filepath.Glob(filepath.Join(projectRoot, "custom", folder, "*", "composer.lock"))
+		if err != nil {
+			return nil, err
+		}
+
+		lockFiles = append(lockFiles, extensionLocks...)
+	}
+
+	packages := make([]AuditPackage, 0)
+
+	for _, lockFile := range lockFiles {
+		content, err := os.ReadFile(lockFile)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("CollectAuditPackages: %w", err)
+		}
+
+		var lock auditComposerLock
+		if err := json.Unmarshal(content, &lock); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", lockFile, err)
+		}
+
+		source, _ := filepath.Rel(projectRoot, lockFile)
+
+		for _, pkg := range append(lock.Packages, lock.PackagesDev...) {
+			pkg.Source = source
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages, nil
+}
+
+// FetchSecurityAdvisories looks up the known security advisories of the packages at Packagist and returns those affecting the installed versions.
+func FetchSecurityAdvisories(ctx context.Context, packages []AuditPackage) ([]AuditAdvisory, error) {
+	form := url.Values{}
+	seen := make(map[string]bool)
+
+	for _, pkg := range packages {
+		if seen[pkg.Name] {
+			continue
+		}
+
+		seen[pkg.Name] = true
+		form.Add("packages[]", pkg.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, packagistSecurityAdvisoriesURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create security advisories request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch security advisories: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logging.FromContext(ctx).Errorf("FetchSecurityAdvisories: %v", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read security advisories: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch security advisories failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		Advisories map[string][]packagistAdvisory `json:"advisories"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decode security advisories: %w", err)
+	}
+
+	return matchAdvisories(ctx, packages, response.Advisories), nil
+}
+
+func matchAdvisories(ctx context.Context, packages []AuditPackage, advisories map[string][]packagistAdvisory) []AuditAdvisory {
+	matches := make([]AuditAdvisory, 0)
+
+	for _, pkg := range packages {
+		installed, err := version.NewVersion(pkg.Version)
+		if err != nil {
+			logging.FromContext(ctx).Debugf("Skipping %s as version %s cannot be parsed", pkg.Name, pkg.Version)
+			continue
+		}
+
+		for _, advisory := range advisories[pkg.Name] {
+			constraint, err := parseComposerConstraint(advisory.AffectedVersions)
+			if err != nil {
+				logging.FromContext(ctx).Debugf("Cannot parse affected versions %s of %s: %v", advisory.AffectedVersions, advisory.AdvisoryID, err)
+				continue
+			}
+
+			if !constraint.Check(installed) {
+				continue
+			}
+
+			matches = append(matches, AuditAdvisory{
+				Package:          pkg.Name,
+				Version:          pkg.Version,
+				Source:           pkg.Source,
+				AdvisoryID:       advisory.AdvisoryID,
+				Title:            advisory.Title,
+				Link:             advisory.Link,
+				CVE:              advisory.CVE,
+				Severity:         advisory.Severity,
+				AffectedVersions: advisory.AffectedVersions,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return auditSeverities[matches[i].Severity] > auditSeverities[matches[j].Severity]
+	})
+
+	return matches
+}
+
+// parseComposerConstraint converts the composer syntax with "|", "," and wildcards like 8.1.* into a constraint.
+func parseComposerConstraint(constraint string) (version.Constraints, error) {
+	ors := strings.Split(strings.ReplaceAll(constraint, "||", "|"), "|")
+
+	for i, or := range ors {
+		ands := strings.Fields(strings.ReplaceAll(or, ",", " "))
+
+		for j, and := range ands {
+			ands[j] = convertComposerWildcard(and)
+		}
+
+		ors[i] = strings.Join(ands, " ")
+	}
+
+	return version.NewConstraint(strings.Join(ors, " || "))
+}
+
+// convertComposerWildcard converts * to any version, 8.* to ^8.0 and 8.1.* to ~8.1.0.
+func convertComposerWildcard(constraint string) string {
+	if constraint == "*" {
+		return ">=0.0.0"
+	}
+
+	prefix, ok := strings.CutSuffix(constraint, ".*")
+	if !ok {
+		return constraint
+	}
+
+	if strings.Contains(prefix, ".") {
+		return "~" + prefix + ".0"
+	}
+
+	return "^" + prefix + ".0"
+}
+
+// FilterAdvisoriesBySeverity returns all advisories with at least the given severity. Advisories without severity are only kept without filter.
+func FilterAdvisoriesBySeverity(advisories []AuditAdvisory, minSeverity string) ([]AuditAdvisory, error) {
+	if minSeverity == "" {
+		return advisories, nil
+	}
+
+	minLevel, ok := auditSeverities[minSeverity]
+	if !ok {
+		return nil, fmt.Errorf("unknown severity %s, use low, medium, high or critical", minSeverity)
+	}
+
+	filtered := make([]AuditAdvisory, 0)
+
+	for _, advisory := range advisories {
+		if auditSeverities[advisory.Severity] >= minLevel {
+			filtered = append(filtered, advisory)
+		}
+	}
+
+	return filtered, nil
+}
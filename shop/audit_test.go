@@ -0,0 +1,62 @@
+package shop
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectAuditPackages(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(path.Join(tmpDir, "composer.lock"), []byte(`{"packages":[{"name":"symfony/http-kernel","version":"v6.2.0"}],"packages-dev":[{"name":"phpunit/phpunit","version":"9.5.0"}]}`), os.ModePerm))
+	assert.NoError(t, os.MkdirAll(path.Join(tmpDir, "custom", "plugins", "FroshTools"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path.Join(tmpDir, "custom", "plugins", "FroshTools", "composer.lock"), []byte(`{"packages":[{"name":"guzzlehttp/psr7","version":"2.4.0"}]}`), os.ModePerm))
+
+	packages, err := CollectAuditPackages(tmpDir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []AuditPackage{
+		{Name: "symfony/http-kernel", Version: "v6.2.0", Source: "composer.lock"},
+		{Name: "phpunit/phpunit", Version: "9.5.0", Source: "composer.lock"},
+		{Name: "guzzlehttp/psr7", Version: "2.4.0", Source: "custom/plugins/FroshTools/composer.lock"},
+	}, packages)
+}
+
+func TestMatchAdvisories(t *testing.T) {
+	packages := []AuditPackage{
+		{Name: "guzzlehttp/psr7", Version: "2.4.0", Source: "composer.lock"},
+		{Name: "symfony/http-kernel", Version: "v6.2.0", Source: "composer.lock"},
+		{Name: "shopware/core", Version: "dev-trunk", Source: "composer.lock"},
+	}
+
+	advisories := map[string][]packagistAdvisory{
+		"guzzlehttp/psr7": {
+			{AdvisoryID: "PKSA-1", Severity: "medium", AffectedVersions: "<1.9.1|>=2,<2.4.5"},
+			{AdvisoryID: "PKSA-2", Severity: "high", AffectedVersions: "<1.8.4|>=2,<2.1.1"},
+		},
+		"symfony/http-kernel": {
+			{AdvisoryID: "PKSA-3", Severity: "high", AffectedVersions: ">=6.0.0,<6.2.6"},
+		},
+		"shopware/core": {
+			{AdvisoryID: "PKSA-4", Severity: "critical", AffectedVersions: "<6.5.0"},
+		},
+	}
+
+	matches := matchAdvisories(context.Background(), packages, advisories)
+
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "PKSA-3", matches[0].AdvisoryID)
+	assert.Equal(t, "PKSA-1", matches[1].AdvisoryID)
+
+	filtered, err := FilterAdvisoriesBySeverity(matches, "high")
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "symfony/http-kernel", filtered[0].Package)
+
+	_, err = FilterAdvisoriesBySeverity(matches, "foo")
+	assert.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sort"
+	"time"
+)
+
+type BenchmarkTiming struct {
+	// TTFB is the time to first byte
+	TTFB  time.Duration `json:"ttfb"`
+	Total time.Duration `json:"total"`
+}
+
+type BenchmarkPercentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+}
+
+type BenchmarkResult struct {
+	URL string `json:"url"`
+	// Cold is the first request, which has to fill the caches
+	Cold      BenchmarkTiming      `json:"cold"`
+	WarmTTFB  BenchmarkPercentiles `json:"warmTtfb"`
+	WarmTotal BenchmarkPercentiles `json:"warmTotal"`
+}
+
+type BenchmarkRegression struct {
+	URL      string        `json:"url"`
+	Metric   string        `json:"metric"`
+	Baseline time.Duration `json:"baseline"`
+	Current  time.Duration `json:"current"`
+}
+
+func (r BenchmarkRegression) String() string {
+	return fmt.Sprintf("%s: %s increased from %s to %s", r.URL, r.Metric, r.Baseline, r.Current)
+}
+
+// RunBenchmark requests the url once cold and then requests times warm.
+func RunBenchmark(ctx context.Context, client *http.Client, url string, requests int) (*BenchmarkResult, error) {
+	cold, err := measureRequest(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	ttfbs := make([]time.Duration, 0, requests)
+	totals := make([]time.Duration, 0, requests)
+
+	for i := 0; i < requests; i++ {
+		timing, err := measureRequest(ctx, client, url)
+		if err != nil {
+			return nil, err
+		}
+
+		ttfbs = append(ttfbs, timing.TTFB)
+		totals = append(totals, timing.Total)
+	}
+
+	return &BenchmarkResult{
+		URL:       url,
+		Cold:      *cold,
+		WarmTTFB:  calculatePercentiles(ttfbs),
+		WarmTotal: calculatePercentiles(totals),
+	}, nil
+}
+
+func measureRequest(ctx context.Context, client *http.Client, url string) (*BenchmarkTiming, error) {
+	var start time.Time
+	var timing BenchmarkTiming
+
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() {
+			timing.TTFB = time.Since(start)
+		},
+	}
+
+	r, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	start = time.Now()
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	timing.Total = time.Since(start)
+
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("request %s failed with status %d", url, resp.StatusCode)
+	}
+
+	return &timing, nil
+}
+
+// calculatePercentiles uses the nearest-rank method.
+func calculatePercentiles(durations []time.Duration) BenchmarkPercentiles {
+	if len(durations) == 0 {
+		return BenchmarkPercentiles{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+
+		return sorted[rank-1]
+	}
+
+	return BenchmarkPercentiles{
+		P50: percentile(50),
+		P90: percentile(90),
+		P95: percentile(95),
+		P99: percentile(99),
+	}
+}
+
+// CompareBenchmark returns all metrics which are more than threshold percent slower than in the baseline.
+func CompareBenchmark(baseline, current []BenchmarkResult, threshold float64) []BenchmarkRegression {
+	baselineByURL := make(map[string]BenchmarkResult)
+	for _, result := range baseline {
+		baselineByURL[result.URL] = result
+	}
+
+	regressions := make([]BenchmarkRegression, 0)
+
+	for _, result := range current {
+		base, ok := baselineByURL[result.URL]
+		if !ok {
+			continue
+		}
+
+		metrics := []struct {
+			name     string
+			baseline time.Duration
+			current  time.Duration
+		}{
+			{"cold ttfb", base.Cold.TTFB, result.Cold.TTFB},
+			{"warm ttfb p50", base.WarmTTFB.P50, result.WarmTTFB.P50},
+			{"warm ttfb p95", base.WarmTTFB.P95, result.WarmTTFB.P95},
+			{"warm total p50", base.WarmTotal.P50, result.WarmTotal.P50},
+			{"warm total p95", base.WarmTotal.P95, result.WarmTotal.P95},
+		}
+
+		for _, metric := range metrics {
+			if metric.baseline <= 0 {
+				continue
+			}
+
+			if float64(metric.current) > float64(metric.baseline)*(1+threshold/100) {
+				regressions = append(regressions, BenchmarkRegression{URL: result.URL, Metric: metric.name, Baseline: metric.baseline, Current: metric.current})
+			}
+		}
+	}
+
+	return regressions
+}
+
+func ReadBenchmarkBaseline(file string) ([]BenchmarkResult, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BenchmarkResult
+	if err := json.Unmarshal(content, &results); err != nil {
+		return nil, fmt.Errorf("cannot parse benchmark baseline %s: %w", file, err)
+	}
+
+	return results, nil
+}
+
+func WriteBenchmarkBaseline(file string, results []BenchmarkResult) error {
+	content, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, content, os.ModePerm)
+}
@@ -0,0 +1,82 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatePercentiles(t *testing.T) {
+	durations := make([]time.Duration, 0)
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	percentiles := calculatePercentiles(durations)
+
+	assert.Equal(t, 50*time.Millisecond, percentiles.P50)
+	assert.Equal(t, 90*time.Millisecond, percentiles.P90)
+	assert.Equal(t, 95*time.Millisecond, percentiles.P95)
+	assert.Equal(t, 99*time.Millisecond, percentiles.P99)
+	assert.Equal(t, BenchmarkPercentiles{}, calculatePercentiles(nil))
+}
+
+func TestRunBenchmark(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, "<html></html>")
+	}))
+	defer server.Close()
+
+	result, err := RunBenchmark(context.Background(), server.Client(), server.URL+"/", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, requests)
+	assert.Equal(t, server.URL+"/", result.URL)
+	assert.Greater(t, result.Cold.Total, time.Duration(0))
+	assert.GreaterOrEqual(t, result.WarmTotal.P99, result.WarmTotal.P50)
+
+	_, err = RunBenchmark(context.Background(), server.Client(), server.URL+"/broken", 5)
+	assert.Error(t, err)
+}
+
+func TestCompareBenchmark(t *testing.T) {
+	baseline := []BenchmarkResult{
+		{URL: "/", Cold: BenchmarkTiming{TTFB: 100 * time.Millisecond}, WarmTTFB: BenchmarkPercentiles{P50: 10 * time.Millisecond, P95: 20 * time.Millisecond}},
+	}
+
+	current := []BenchmarkResult{
+		{URL: "/", Cold: BenchmarkTiming{TTFB: 110 * time.Millisecond}, WarmTTFB: BenchmarkPercentiles{P50: 15 * time.Millisecond, P95: 20 * time.Millisecond}},
+		{URL: "/new", Cold: BenchmarkTiming{TTFB: time.Second}},
+	}
+
+	regressions := CompareBenchmark(baseline, current, 20)
+
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, "warm ttfb p50", regressions[0].Metric)
+	assert.Equal(t, "/: warm ttfb p50 increased from 10ms to 15ms", regressions[0].String())
+}
+
+func TestBenchmarkBaseline(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	results := []BenchmarkResult{{URL: "/", Cold: BenchmarkTiming{TTFB: time.Millisecond, Total: 2 * time.Millisecond}}}
+
+	assert.NoError(t, WriteBenchmarkBaseline(file, results))
+
+	read, err := ReadBenchmarkBaseline(file)
+	assert.NoError(t, err)
+	assert.Equal(t, results, read)
+}
@@ -0,0 +1,245 @@
+package shop
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type sitemapDocument struct {
+	// Sitemaps is filled for a sitemap index, which points to further sitemaps
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+}
+
+// storeApiWarmupDepth is the depth of the main navigation, whose categories are warmed up.
+const storeApiWarmupDepth = 3
+
+type storeApiCategory struct {
+	ID       string             `json:"id"`
+	Children []storeApiCategory `json:"children"`
+}
+
+type WarmupResult struct {
+	URL        string
+	StatusCode int
+	Duration   time.Duration
+	Error      error
+}
+
+// FetchSitemapURLs returns all page urls of the sitemap. Sitemap indexes and gzip compressed sitemaps like the ones of Shopware are followed.
+func FetchSitemapURLs(ctx context.Context, client *http.Client, sitemapURL string) ([]string, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch sitemap %s: %w", sitemapURL, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot fetch sitemap %s: status %d", sitemapURL, resp.StatusCode)
+	}
+
+	var body io.Reader = resp.Body
+
+	if strings.HasSuffix(r.URL.Path, ".gz") && resp.Header.Get("Content-Encoding") == "" {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress sitemap %s: %w", sitemapURL, err)
+		}
+
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+
+		body = gzipReader
+	}
+
+	var document sitemapDocument
+	if err := xml.NewDecoder(body).Decode(&document); err != nil {
+		return nil, fmt.Errorf("cannot parse sitemap %s: %w", sitemapURL, err)
+	}
+
+	urls := make([]string, 0, len(document.URLs))
+
+	for _, u := range document.URLs {
+		urls = append(urls, strings.TrimSpace(u.Loc))
+	}
+
+	for _, sitemap := range document.Sitemaps {
+		childURLs, err := FetchSitemapURLs(ctx, client, strings.TrimSpace(sitemap.Loc))
+		if err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, childURLs...)
+	}
+
+	return urls, nil
+}
+
+// ReadURLList reads a file with one url per line, empty lines and lines starting with # are skipped.
+func ReadURLList(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	urls := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		urls = append(urls, line)
+	}
+
+	return urls, scanner.Err()
+}
+
+// WarmupURLs requests all urls with the given concurrency, onResult is called for every finished request.
+func WarmupURLs(ctx context.Context, client *http.Client, urls []string, concurrency int, onResult func(WarmupResult)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	queue := make(chan string)
+	results := make(chan WarmupResult)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for u := range queue {
+				results <- warmupURL(ctx, client, u)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+
+		for _, u := range urls {
+			select {
+			case queue <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		onResult(result)
+	}
+}
+
+func warmupURL(ctx context.Context, client *http.Client, u string) WarmupResult {
+	result := WarmupResult{URL: u}
+	start := time.Now()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	result.Duration = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	result.Error = err
+
+	if result.Error == nil && resp.StatusCode >= 400 {
+		result.Error = fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// WarmupStoreApi requests the main navigation and the page of each of its categories with the context of the store-api
+// client, so the caches used by headless storefronts are warmed up as well. The requests are sent one after another,
+// as they share the context token of the client.
+func WarmupStoreApi(ctx context.Context, client *StoreApiClient, onResult func(WarmupResult)) error {
+	navigationPath := "/store-api/navigation/main-navigation/main-navigation"
+	start := time.Now()
+
+	var navigation []storeApiCategory
+
+	if err := client.Request(ctx, http.MethodPost, navigationPath, map[string]interface{}{"depth": storeApiWarmupDepth}, &navigation); err != nil {
+		return fmt.Errorf("cannot read the main navigation: %w", err)
+	}
+
+	onResult(WarmupResult{URL: navigationPath, StatusCode: http.StatusOK, Duration: time.Since(start)})
+
+	for _, id := range flattenStoreApiCategories(navigation) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result := WarmupResult{URL: "/store-api/category/" + id, StatusCode: http.StatusOK}
+		start := time.Now()
+
+		if result.Error = client.Request(ctx, http.MethodPost, result.URL, nil, nil); result.Error != nil {
+			result.StatusCode = 0
+		}
+
+		result.Duration = time.Since(start)
+		onResult(result)
+	}
+
+	return nil
+}
+
+func flattenStoreApiCategories(categories []storeApiCategory) []string {
+	ids := make([]string, 0, len(categories))
+
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+		ids = append(ids, flattenStoreApiCategories(category.Children)...)
+	}
+
+	return ids
+}
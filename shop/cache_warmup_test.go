@@ -0,0 +1,128 @@
+package shop
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchSitemapURLs(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<sitemap><loc>%s/sitemap/1.xml.gz</loc></sitemap>
+</sitemapindex>`, server.URL)
+		case "/sitemap/1.xml.gz":
+			gzipWriter := gzip.NewWriter(w)
+			_, _ = fmt.Fprintf(gzipWriter, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<url><loc>%s/</loc></url>
+	<url><loc>%s/Clothing/</loc></url>
+</urlset>`, server.URL, server.URL)
+			_ = gzipWriter.Close()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	urls, err := FetchSitemapURLs(context.Background(), server.Client(), server.URL+"/sitemap.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{server.URL + "/", server.URL + "/Clothing/"}, urls)
+
+	_, err = FetchSitemapURLs(context.Background(), server.Client(), server.URL+"/missing.xml")
+	assert.Error(t, err)
+}
+
+func TestReadURLList(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "urls.txt")
+	assert.NoError(t, os.WriteFile(file, []byte("# homepage\nhttp://localhost/\n\nhttp://localhost/account\n"), os.ModePerm))
+
+	urls, err := ReadURLList(file)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost/", "http://localhost/account"}, urls)
+}
+
+func TestWarmupURLs(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c", server.URL + "/broken"}
+	failed := make([]string, 0)
+	finished := 0
+
+	WarmupURLs(context.Background(), server.Client(), urls, 2, func(result WarmupResult) {
+		finished++
+
+		if result.Error != nil {
+			failed = append(failed, result.URL)
+		}
+	})
+
+	sort.Strings(failed)
+
+	assert.Equal(t, int32(4), requests)
+	assert.Equal(t, 4, finished)
+	assert.Equal(t, []string{server.URL + "/broken"}, failed)
+}
+
+func TestWarmupStoreApi(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SWSCKEY", r.Header.Get("sw-access-key"))
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		switch r.URL.Path {
+		case "/store-api/navigation/main-navigation/main-navigation":
+			w.Header().Set("sw-context-token", "token")
+			_, _ = w.Write([]byte(`[{"id": "shoes", "children": [{"id": "sneakers", "children": []}]}, {"id": "shirts"}]`))
+		case "/store-api/category/shirts":
+			assert.Equal(t, "token", r.Header.Get("sw-context-token"))
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Equal(t, "token", r.Header.Get("sw-context-token"))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	requested := make([]string, 0)
+	failed := make([]string, 0)
+
+	err := WarmupStoreApi(context.Background(), NewStoreApiClient(server.Client(), server.URL, "SWSCKEY"), func(result WarmupResult) {
+		requested = append(requested, result.URL)
+
+		if result.Error != nil {
+			failed = append(failed, result.URL)
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/store-api/navigation/main-navigation/main-navigation",
+		"/store-api/category/shoes",
+		"/store-api/category/sneakers",
+		"/store-api/category/shirts",
+	}, requested)
+	assert.Equal(t, []string{"/store-api/category/shirts"}, failed)
+}
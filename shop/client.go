@@ -7,6 +7,9 @@ import (
 	"net/http"
 
 	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/httpdump"
+	"github.com/FriendsOfShopware/shopware-cli/internal/offline"
 )
 
 func newShopCredentials(config *Config) adminSdk.OAuthCredentials {
@@ -32,7 +35,7 @@ func NewShopClient(ctx context.Context, config *Config) (*adminSdk.Client, error
 			InsecureSkipVerify: config.AdminApi.DisableSSLCheck, // nolint:gosec
 		},
 	}
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: offline.Wrap(httpdump.Wrap(tr))}
 
 	return adminSdk.NewApiClient(ctx, config.URL, newShopCredentials(config), client)
 }
@@ -0,0 +1,221 @@
+package shop
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/process"
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+// ComposerPatches maps a composer package to its patches, like the patches section of cweagans/composer-patches.
+type ComposerPatches map[string]ComposerPatchList
+
+type ComposerPatch struct {
+	Description string
+	// Source is a path relative to the project root or an http(s) URL
+	Source string
+	// Sha256 is the checksum of the patch, required for patches from an URL
+	Sha256 string
+}
+
+// composerPatchLevels are tried in the order of cweagans/composer-patches.
+var composerPatchLevels = []string{"-p1", "-p0", "-p2", "-p4"}
+
+type ComposerPatchList []ComposerPatch
+
+// UnmarshalYAML reads the description to source mapping and keeps the order of the config, as a patch can depend on a previous one.
+func (l *ComposerPatchList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: the patches of a package must be a mapping of description to patch file", node.Line)
+	}
+
+	patches := make(ComposerPatchList, 0, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		patch := ComposerPatch{Description: node.Content[i].Value}
+
+		if node.Content[i+1].Kind == yaml.MappingNode {
+			var source struct {
+				Source string `yaml:"source"`
+				Sha256 string `yaml:"sha256"`
+			}
+
+			if err := node.Content[i+1].Decode(&source); err != nil {
+				return err
+			}
+
+			patch.Source = source.Source
+			patch.Sha256 = strings.ToLower(source.Sha256)
+		} else if err := node.Content[i+1].Decode(&patch.Source); err != nil {
+			return err
+		}
+
+		patches = append(patches, patch)
+	}
+
+	*l = patches
+
+	return nil
+}
+
+// ApplyComposerPatches applies the patches to the installed packages in the vendor folder. A patch, which does not apply
+// cleanly anymore, stops with an error before the package is modified.
+func ApplyComposerPatches(ctx context.Context, projectRoot string, patches ComposerPatches) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	if _, err := exec.LookPath("patch"); err != nil {
+		return fmt.Errorf("the patch binary is required to apply composer patches: %w", err)
+	}
+
+	packages := make([]string, 0, len(patches))
+	for name := range patches {
+		packages = append(packages, name)
+	}
+
+	sort.Strings(packages)
+
+	for _, name := range packages {
+		packageDir := filepath.Join(projectRoot, "vendor", filepath.FromSlash(name))
+
+		if _, err := os.Stat(packageDir); os.IsNotExist(err) {
+			return fmt.Errorf("cannot patch %s, the package is not installed", name)
+		}
+
+		for _, patch := range patches[name] {
+			logging.FromContext(ctx).Infof("Applying patch %s to %s", patch.Description, name)
+
+			if err := applyComposerPatch(ctx, projectRoot, packageDir, patch); err != nil {
+				return fmt.Errorf("cannot apply patch \"%s\" (%s) to %s: %w", patch.Description, patch.Source, name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyComposerPatch(ctx context.Context, projectRoot, packageDir string, patch ComposerPatch) error {
+	patchFile, cleanup, err := resolveComposerPatch(ctx, projectRoot, patch)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	var dryRunOutput []byte
+
+	// the dry run keeps the package untouched, when only some hunks of the patch apply
+	for _, level := range composerPatchLevels {
+		dryRunOutput, err = runPatch(ctx, packageDir, level, patchFile, true)
+		if err != nil {
+			continue
+		}
+
+		if output, err := runPatch(ctx, packageDir, level, patchFile, false); err != nil {
+			return fmt.Errorf("the patch does not apply anymore, update or remove it\n%s", strings.TrimSpace(string(output)))
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("the patch does not apply anymore, update or remove it\n%s", strings.TrimSpace(string(dryRunOutput)))
+}
+
+func runPatch(ctx context.Context, packageDir, level, patchFile string, dryRun bool) ([]byte, error) {
+	args := []string{level, "--forward", "--batch", "--input", patchFile}
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+
+	patchCmd := process.Command(ctx, "patch", args...)
+	patchCmd.Dir = packageDir
+
+	return patchCmd.CombinedOutput()
+}
+
+// resolveComposerPatch returns the local path of the patch, patches from an URL are downloaded into a temporary file
+// and must match their checksum.
+func resolveComposerPatch(ctx context.Context, projectRoot string, patch ComposerPatch) (string, func(), error) {
+	source := patch.Source
+
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		path := source
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, filepath.FromSlash(source))
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return "", nil, fmt.Errorf("cannot find patch file %s", path)
+		}
+
+		return path, func() {}, nil
+	}
+
+	if patch.Sha256 == "" {
+		return "", nil, fmt.Errorf("a patch from an URL requires a sha256 checksum")
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, source, http.NoBody)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot download patch: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("cannot download patch: status %d", resp.StatusCode)
+	}
+
+	file, err := os.CreateTemp("", "composer-patch-*.patch")
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		_ = file.Close()
+		cleanup()
+
+		return "", nil, err
+	}
+
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != patch.Sha256 {
+		_ = file.Close()
+		cleanup()
+
+		return "", nil, fmt.Errorf("the checksum of the downloaded patch is %s, expected %s", checksum, patch.Sha256)
+	}
+
+	if err := file.Close(); err != nil {
+		cleanup()
+
+		return "", nil, err
+	}
+
+	return file.Name(), cleanup, nil
+}
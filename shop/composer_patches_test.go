@@ -0,0 +1,139 @@
+package shop
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const testComposerPatch = `--- a/src/Kernel.php
++++ b/src/Kernel.php
+@@ -1,3 +1,3 @@
+ <?php
+
+-echo 'broken';
++echo 'fixed';
+`
+
+func TestComposerPatchesKeepOrder(t *testing.T) {
+	var build ConfigBuild
+
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+composer_patches:
+  shopware/core:
+    "Fix the kernel": patches/kernel.patch
+    "Fix the cart":
+      source: https://example.com/cart.patch
+      sha256: ABC123
+    "Another fix": patches/another.patch
+`), &build))
+
+	assert.Equal(t, ComposerPatchList{
+		{Description: "Fix the kernel", Source: "patches/kernel.patch"},
+		{Description: "Fix the cart", Source: "https://example.com/cart.patch", Sha256: "abc123"},
+		{Description: "Another fix", Source: "patches/another.patch"},
+	}, build.ComposerPatches["shopware/core"])
+}
+
+func TestComposerPatchesInvalidConfig(t *testing.T) {
+	var build ConfigBuild
+
+	assert.ErrorContains(t, yaml.Unmarshal([]byte(`
+composer_patches:
+  shopware/core:
+    - patches/kernel.patch
+`), &build), "must be a mapping of description to patch file")
+}
+
+func TestApplyComposerPatches(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("patch is not installed")
+	}
+
+	projectRoot := t.TempDir()
+	kernel := filepath.Join(projectRoot, "vendor", "shopware", "core", "src", "Kernel.php")
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(kernel), os.ModePerm))
+	assert.NoError(t, os.WriteFile(kernel, []byte("<?php\n\necho 'broken';\n"), os.ModePerm))
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "patches"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectRoot, "patches", "kernel.patch"), []byte(testComposerPatch), os.ModePerm))
+
+	patches := ComposerPatches{"shopware/core": {{Description: "Fix the kernel", Source: "patches/kernel.patch"}}}
+
+	assert.NoError(t, ApplyComposerPatches(context.Background(), projectRoot, patches))
+
+	content, err := os.ReadFile(kernel)
+	assert.NoError(t, err)
+	assert.Equal(t, "<?php\n\necho 'fixed';\n", string(content))
+
+	// the patch was already applied, so it does not apply anymore
+	err = ApplyComposerPatches(context.Background(), projectRoot, patches)
+	assert.ErrorContains(t, err, `cannot apply patch "Fix the kernel" (patches/kernel.patch) to shopware/core: the patch does not apply anymore`)
+}
+
+func TestApplyComposerPatchesMissingPackage(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("patch is not installed")
+	}
+
+	err := ApplyComposerPatches(context.Background(), t.TempDir(), ComposerPatches{"shopware/core": {{Description: "Fix", Source: "fix.patch"}}})
+
+	assert.EqualError(t, err, "cannot patch shopware/core, the package is not installed")
+}
+
+func TestApplyComposerPatchesFallsBackToOtherPatchLevels(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("patch is not installed")
+	}
+
+	projectRoot := t.TempDir()
+	kernel := filepath.Join(projectRoot, "vendor", "shopware", "core", "src", "Kernel.php")
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(kernel), 0o755))
+	assert.NoError(t, os.WriteFile(kernel, []byte("<?php\n\necho 'broken';\n"), 0o644))
+
+	// a patch created inside the package without the a/ and b/ prefixes needs -p0
+	patchFile := filepath.Join(projectRoot, "kernel.patch")
+	assert.NoError(t, os.WriteFile(patchFile, []byte(strings.NewReplacer("a/src", "src", "b/src", "src").Replace(testComposerPatch)), 0o644))
+
+	assert.NoError(t, ApplyComposerPatches(context.Background(), projectRoot, ComposerPatches{"shopware/core": {{Description: "Fix the kernel", Source: "kernel.patch"}}}))
+
+	content, err := os.ReadFile(kernel)
+	assert.NoError(t, err)
+	assert.Equal(t, "<?php\n\necho 'fixed';\n", string(content))
+}
+
+func TestResolveComposerPatchVerifiesChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testComposerPatch))
+	}))
+	defer server.Close()
+
+	hash := sha256.Sum256([]byte(testComposerPatch))
+	checksum := hex.EncodeToString(hash[:])
+
+	patchFile, cleanup, err := resolveComposerPatch(context.Background(), t.TempDir(), ComposerPatch{Source: server.URL, Sha256: checksum})
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(patchFile)
+	assert.NoError(t, err)
+	assert.Equal(t, testComposerPatch, string(content))
+
+	cleanup()
+
+	_, _, err = resolveComposerPatch(context.Background(), t.TempDir(), ComposerPatch{Source: server.URL, Sha256: "abc123"})
+	assert.ErrorContains(t, err, "expected abc123")
+
+	_, _, err = resolveComposerPatch(context.Background(), t.TempDir(), ComposerPatch{Source: server.URL})
+	assert.EqualError(t, err, "a patch from an URL requires a sha256 checksum")
+}
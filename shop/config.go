@@ -8,15 +8,52 @@ import (
 	"github.com/doutorfinancas/go-mad/core"
 	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
 	"github.com/google/uuid"
-	"gopkg.in/yaml.v3"
+
+	"github.com/FriendsOfShopware/shopware-cli/internal/envconfig"
 )
 
 type Config struct {
-	URL        string          `yaml:"url"`
-	Build      *ConfigBuild    `yaml:"build,omitempty"`
-	AdminApi   *ConfigAdminApi `yaml:"admin_api,omitempty"`
-	ConfigDump *ConfigDump     `yaml:"dump,omitempty"`
-	Sync       *ConfigSync     `yaml:"sync,omitempty"`
+	URL string `yaml:"url"`
+	// Protected requires --force and a typed confirmation for destructive commands against the shop
+	Protected    bool                         `yaml:"protected,omitempty"`
+	Build        *ConfigBuild                 `yaml:"build,omitempty"`
+	AdminApi     *ConfigAdminApi              `yaml:"admin_api,omitempty"`
+	ConfigDump   *ConfigDump                  `yaml:"dump,omitempty"`
+	Sync         *ConfigSync                  `yaml:"sync,omitempty"`
+	Environments map[string]ConfigEnvironment `yaml:"environments,omitempty"`
+	Docker       *ConfigDocker                `yaml:"docker,omitempty"`
+	Paas         *ConfigPaas                  `yaml:"paas,omitempty"`
+	Benchmark    *ConfigBenchmark             `yaml:"benchmark,omitempty"`
+	// CacheBackends maps a pool name like cache, session or lock to its redis dsn
+	CacheBackends map[string]string `yaml:"cache_backends,omitempty"`
+	Systemd       *ConfigSystemd    `yaml:"systemd,omitempty"`
+	E2E           *ConfigE2E        `yaml:"e2e,omitempty"`
+	SmokeTest     *ConfigSmokeTest  `yaml:"smoke_test,omitempty"`
+	StoreApi      *ConfigStoreApi   `yaml:"store_api,omitempty"`
+	Hosting       *ConfigHosting    `yaml:"hosting,omitempty"`
+	// EnvTemplate are the variables of the env files generated by project env init
+	EnvTemplate []ConfigEnvVariable `yaml:"env_template,omitempty"`
+}
+
+type ConfigBenchmark struct {
+	// URLs to benchmark, relative URLs are resolved against the shop URL
+	URLs     []string `yaml:"urls,omitempty"`
+	Baseline string   `yaml:"baseline,omitempty"`
+}
+
+// ConfigPaas points to the Shopware PaaS project used by the project paas commands.
+type ConfigPaas struct {
+	Project     string `yaml:"project"`
+	Environment string `yaml:"environment,omitempty"`
+}
+
+const DefaultEnvironment = "default"
+
+// ConfigEnvironment describes another shop (f.e. staging or production) of the same project.
+type ConfigEnvironment struct {
+	URL       string          `yaml:"url"`
+	AdminApi  *ConfigAdminApi `yaml:"admin_api,omitempty"`
+	Protected bool            `yaml:"protected,omitempty"`
 }
 
 type ConfigBuild struct {
@@ -25,6 +62,25 @@ type ConfigBuild struct {
 	KeepExtensionSource   bool     `yaml:"keep_extension_source,omitempty"`
 	CleanupPaths          []string `yaml:"cleanup_paths,omitempty"`
 	Browserslist          string   `yaml:"browserslist,omitempty"`
+	// AssetConcurrency is the number of extensions built in parallel, defaults to the CPU count
+	AssetConcurrency int `yaml:"asset_concurrency,omitempty"`
+	Webpack          struct {
+		Administration string `yaml:"administration,omitempty"`
+		Storefront     string `yaml:"storefront,omitempty"`
+	} `yaml:"webpack,omitempty"`
+	Console ConfigBuildConsole `yaml:"console,omitempty"`
+	// ComposerPatches are applied by project ci after the composer install
+	ComposerPatches ComposerPatches `yaml:"composer_patches,omitempty"`
+	// Assets configures how assets:install deploys the public assets
+	Assets ConfigBuildAssets `yaml:"assets,omitempty"`
+}
+
+type ConfigBuildConsole struct {
+	// PHPBinary is used to run the console, defaults to php
+	PHPBinary string `yaml:"php_binary,omitempty"`
+	// Path of the console relative to the project root, defaults to bin/console or vendor/bin/console
+	Path    string `yaml:"path,omitempty"`
+	NoDebug bool   `yaml:"no_debug,omitempty"`
 }
 
 type ConfigAdminApi struct {
@@ -86,6 +142,10 @@ func ReadConfig(fileName string, allowFallback bool) (*Config, error) {
 
 	if os.IsNotExist(err) {
 		if allowFallback {
+			if err := envconfig.Decode(nil, envconfig.ProjectPrefix, os.Environ(), config); err != nil {
+				return nil, fmt.Errorf("ReadConfig: %v", err)
+			}
+
 			return fillEmptyConfig(config), nil
 		}
 
@@ -101,8 +161,9 @@ func ReadConfig(fileName string, allowFallback bool) (*Config, error) {
 		return nil, fmt.Errorf("ReadConfig: %v", err)
 	}
 
+	// ${VAR} placeholders are substituted first, SHOPWARE_CLI_PROJECT__* variables override the resulting keys
 	substitutedConfig := os.ExpandEnv(string(fileHandle))
-	err = yaml.Unmarshal([]byte(substitutedConfig), &config)
+	err = envconfig.Decode([]byte(substitutedConfig), envconfig.ProjectPrefix, os.Environ(), config)
 
 	if err != nil {
 		return nil, fmt.Errorf("ReadConfig: %v", err)
@@ -111,6 +172,26 @@ func ReadConfig(fileName string, allowFallback bool) (*Config, error) {
 	return fillEmptyConfig(config), nil
 }
 
+// ForEnvironment returns a copy of the config pointing to the given environment. An empty name or default returns the config itself.
+func (c *Config) ForEnvironment(name string) (*Config, error) {
+	if name == "" || name == DefaultEnvironment {
+		return c, nil
+	}
+
+	env, ok := c.Environments[name]
+	if !ok {
+		return nil, fmt.Errorf("environment %s is not configured in .shopware-project.yml", name)
+	}
+
+	envConfig := *c
+	envConfig.URL = env.URL
+	envConfig.Protected = env.Protected
+	// the credentials of the default shop are not sent to the host of another environment
+	envConfig.AdminApi = env.AdminApi
+
+	return &envConfig, nil
+}
+
 func fillEmptyConfig(c *Config) *Config {
 	if c.Build == nil {
 		c.Build = &ConfigBuild{}
@@ -0,0 +1,110 @@
+package shop
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	ConfigDifferenceAdded   = "added"
+	ConfigDifferenceRemoved = "removed"
+	ConfigDifferenceChanged = "changed"
+)
+
+type ConfigDifference struct {
+	Key  string      `json:"key"`
+	Type string      `json:"type"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// FlattenSyncConfig converts the sync config into a flat map with keys like system_config.global.core.basicInformation.email.
+func FlattenSyncConfig(sync *ConfigSync) map[string]interface{} {
+	flat := make(map[string]interface{})
+
+	if sync == nil {
+		return flat
+	}
+
+	for _, cfg := range sync.Config {
+		scope := "global"
+		if cfg.SalesChannel != nil {
+			scope = *cfg.SalesChannel
+		}
+
+		for key, value := range cfg.Settings {
+			flat[fmt.Sprintf("system_config.%s.%s", scope, key)] = value
+		}
+	}
+
+	for _, theme := range sync.Theme {
+		for key, value := range theme.Settings {
+			flat[fmt.Sprintf("theme.%s.%s", theme.Name, key)] = value.Value
+		}
+	}
+
+	for _, template := range sync.MailTemplate {
+		for _, translation := range template.Translations {
+			prefix := fmt.Sprintf("mail_template.%s.%s", template.Id, translation.Language)
+
+			flat[prefix+".sender_name"] = translation.SenderName
+			flat[prefix+".subject"] = translation.Subject
+			flat[prefix+".html"] = translation.HTML
+			flat[prefix+".plain"] = translation.Plain
+		}
+	}
+
+	for i, entity := range sync.Entity {
+		for key, value := range entity.Payload {
+			flat[fmt.Sprintf("entity.%s.%d.%s", entity.Entity, i, key)] = value
+		}
+	}
+
+	return flat
+}
+
+// CompareSyncConfig returns all differences between two pulled configurations sorted by key.
+func CompareSyncConfig(from, to *ConfigSync) []ConfigDifference {
+	fromFlat := FlattenSyncConfig(from)
+	toFlat := FlattenSyncConfig(to)
+
+	differences := make([]ConfigDifference, 0)
+
+	for key, fromValue := range fromFlat {
+		toValue, ok := toFlat[key]
+
+		if !ok {
+			differences = append(differences, ConfigDifference{Key: key, Type: ConfigDifferenceRemoved, From: fromValue})
+			continue
+		}
+
+		if !configValuesEqual(fromValue, toValue) {
+			differences = append(differences, ConfigDifference{Key: key, Type: ConfigDifferenceChanged, From: fromValue, To: toValue})
+		}
+	}
+
+	for key, toValue := range toFlat {
+		if _, ok := fromFlat[key]; !ok {
+			differences = append(differences, ConfigDifference{Key: key, Type: ConfigDifferenceAdded, To: toValue})
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Key < differences[j].Key
+	})
+
+	return differences
+}
+
+// configValuesEqual compares the JSON representation, as the same value can be decoded into different types.
+func configValuesEqual(a, b interface{}) bool {
+	aJson, aErr := json.Marshal(a)
+	bJson, bErr := json.Marshal(b)
+
+	if aErr != nil || bErr != nil {
+		return false
+	}
+
+	return string(aJson) == string(bJson)
+}
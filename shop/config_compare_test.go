@@ -0,0 +1,71 @@
+package shop
+
+import (
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareSyncConfig(t *testing.T) {
+	storefront := "Storefront"
+
+	from := &ConfigSync{
+		Config: []ConfigSyncConfig{
+			{Settings: map[string]interface{}{"core.basicInformation.email": "staging@example.com", "core.listing.productsPerPage": 24}},
+			{SalesChannel: &storefront, Settings: map[string]interface{}{"core.cart.maxQuantity": 100}},
+		},
+		Theme: []ThemeConfig{
+			{Name: "Storefront", Settings: map[string]adminSdk.ThemeConfigValue{"sw-color-brand-primary": {Value: "#008490"}}},
+		},
+	}
+
+	to := &ConfigSync{
+		Config: []ConfigSyncConfig{
+			{Settings: map[string]interface{}{"core.basicInformation.email": "shop@example.com", "core.listing.productsPerPage": float64(24)}},
+			{SalesChannel: &storefront, Settings: map[string]interface{}{}},
+		},
+		Theme: []ThemeConfig{
+			{Name: "Storefront", Settings: map[string]adminSdk.ThemeConfigValue{"sw-color-brand-primary": {Value: "#008490"}, "sw-logo-desktop": {Value: "logo"}}},
+		},
+	}
+
+	assert.Equal(t, []ConfigDifference{
+		{Key: "system_config.Storefront.core.cart.maxQuantity", Type: ConfigDifferenceRemoved, From: 100},
+		{Key: "system_config.global.core.basicInformation.email", Type: ConfigDifferenceChanged, From: "staging@example.com", To: "shop@example.com"},
+		{Key: "theme.Storefront.sw-logo-desktop", Type: ConfigDifferenceAdded, To: "logo"},
+	}, CompareSyncConfig(from, to))
+}
+
+func TestConfigForEnvironment(t *testing.T) {
+	cfg := &Config{
+		URL: "http://localhost",
+		Environments: map[string]ConfigEnvironment{
+			"prod":    {URL: "https://shop.example.com", AdminApi: &ConfigAdminApi{ClientId: "id"}},
+			"staging": {URL: "https://staging.example.com"},
+		},
+		AdminApi: &ConfigAdminApi{ClientId: "local"},
+	}
+
+	envCfg, err := cfg.ForEnvironment("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://shop.example.com", envCfg.URL)
+	assert.Equal(t, "id", envCfg.AdminApi.ClientId)
+	assert.Equal(t, "http://localhost", cfg.URL)
+
+	// the default credentials are not used for another host
+	stagingCfg, err := cfg.ForEnvironment("staging")
+	assert.NoError(t, err)
+	assert.Nil(t, stagingCfg.AdminApi)
+
+	defaultCfg, err := cfg.ForEnvironment("")
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, defaultCfg)
+
+	defaultCfg, err = cfg.ForEnvironment(DefaultEnvironment)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, defaultCfg)
+
+	_, err = cfg.ForEnvironment("testing")
+	assert.Error(t, err)
+}
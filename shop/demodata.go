@@ -0,0 +1,63 @@
+package shop
+
+import (
+	"fmt"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// storefrontSalesChannelTypeID is the id of the storefront sales channel type in every shop.
+const storefrontSalesChannelTypeID = "8a243080f92e4c719546314b577cf82b"
+
+// adminRequest sends the body as JSON to the Admin API and decodes the response into target, which can be nil.
+func adminRequest(ctx adminSdk.ApiContext, client *adminSdk.Client, method, path string, body interface{}, target interface{}, headers map[string]string) error {
+	r, err := client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(ctx.Context, r, target)
+	if err != nil {
+		if apiErr, ok := err.(*adminSdk.ErrorResponse); ok {
+			requestErr := fmt.Errorf("%s %s failed with status %d: %s", method, path, apiErr.Response.StatusCode, apiErr.Content)
+
+			if privileges := MissingPrivileges(apiErr); len(privileges) > 0 {
+				return &MissingPrivilegesError{Privileges: privileges, err: requestErr}
+			}
+
+			return requestErr
+		}
+
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// searchFirstID returns the id of the first entity matching the criteria, or an empty string.
+func searchFirstID(ctx adminSdk.ApiContext, client *adminSdk.Client, entity string, criteria map[string]interface{}) (string, error) {
+	criteria["limit"] = 1
+
+	var res struct {
+		Total int      `json:"total"`
+		Data  []string `json:"data"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", fmt.Sprintf("/api/search-ids/%s", entity), criteria, &res, nil); err != nil {
+		return "", err
+	}
+
+	if len(res.Data) == 0 {
+		return "", nil
+	}
+
+	return res.Data[0], nil
+}
+
+func equalsFilter(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "equals", "field": field, "value": value}
+}
@@ -0,0 +1,211 @@
+package shop
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// defaultCustomerGroupID is the standard customer group of every shop.
+const defaultCustomerGroupID = "cfbd5018d38d41d8adca10d94fc8bdd6"
+
+var slugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+type DemodataCustomerOptions struct {
+	// SalesChannelID defaults to the first active storefront
+	SalesChannelID string
+	EmailDomain    string
+	Password       string
+}
+
+type DemodataCustomer struct {
+	ID            string
+	Email         string
+	Guest         bool
+	Company       string
+	CustomerGroup string
+	Salutation    string
+}
+
+type demodataSalesChannel struct {
+	ID              string `json:"id"`
+	LanguageID      string `json:"languageId"`
+	PaymentMethodID string `json:"paymentMethodId"`
+	CountryID       string `json:"countryId"`
+}
+
+type demodataNamedEntity struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	SalutationKey string `json:"salutationKey"`
+}
+
+// demodataID derives a stable id from the key, so running the command again updates the customers instead of creating new ones.
+func demodataID(key string) string {
+	hash := md5.Sum([]byte("shopware-cli-demodata:" + key)) //nolint:gosec
+
+	return hex.EncodeToString(hash[:])
+}
+
+// planDemodataCustomers returns the customers to create: a guest, a customer for each salutation, a B2B customer with company
+// and a customer for each additional customer group. Names resulting in the same email, like the groups "Dealer" and
+// "Dealer!", get a numbered suffix, so every customer has an own email.
+func planDemodataCustomers(domain string, salutations, groups []demodataNamedEntity) []DemodataCustomer {
+	defaultSalutation := ""
+	if len(salutations) > 0 {
+		defaultSalutation = salutations[0].SalutationKey
+	}
+
+	used := make(map[string]bool)
+
+	customer := func(local string) DemodataCustomer {
+		unique := local
+		for i := 2; used[unique]; i++ {
+			unique = fmt.Sprintf("%s-%d", local, i)
+		}
+
+		used[unique] = true
+		email := fmt.Sprintf("%s@%s", unique, domain)
+
+		return DemodataCustomer{ID: demodataID(email), Email: email, CustomerGroup: defaultCustomerGroupID, Salutation: defaultSalutation}
+	}
+
+	guest := customer("guest")
+	guest.Guest = true
+
+	customers := []DemodataCustomer{guest}
+
+	for _, salutation := range salutations {
+		c := customer(strings.TrimSuffix("customer-"+strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(salutation.SalutationKey), "-"), "-"), "-"))
+		c.Salutation = salutation.SalutationKey
+		customers = append(customers, c)
+	}
+
+	b2b := customer("b2b")
+	b2b.Company = "Shopware CLI Test GmbH"
+	customers = append(customers, b2b)
+
+	for _, group := range groups {
+		if group.ID == defaultCustomerGroupID {
+			continue
+		}
+
+		c := customer(strings.TrimSuffix("group-"+strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(group.Name), "-"), "-"), "-"))
+		c.CustomerGroup = group.ID
+		customers = append(customers, c)
+	}
+
+	return customers
+}
+
+// CreateDemodataCustomers creates or updates the test customers with the given password in one sync request.
+func CreateDemodataCustomers(ctx adminSdk.ApiContext, client *adminSdk.Client, opts DemodataCustomerOptions) ([]DemodataCustomer, error) {
+	salesChannel, err := findDemodataSalesChannel(ctx, client, opts.SalesChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var salutations, groups struct {
+		Data []demodataNamedEntity `json:"data"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/salutation", map[string]interface{}{"sort": []map[string]string{{"field": "salutationKey"}}}, &salutations, nil); err != nil {
+		return nil, err
+	}
+
+	// new groups are added at the end, so the emails of the existing groups stay the same
+	if err := adminRequest(ctx, client, "POST", "/api/search/customer-group", map[string]interface{}{"sort": []map[string]string{{"field": "createdAt"}, {"field": "id"}}}, &groups, nil); err != nil {
+		return nil, err
+	}
+
+	salutationIDs := make(map[string]string)
+	for _, salutation := range salutations.Data {
+		salutationIDs[salutation.SalutationKey] = salutation.ID
+	}
+
+	customers := planDemodataCustomers(opts.EmailDomain, salutations.Data, groups.Data)
+	payload := make([]map[string]interface{}, 0, len(customers))
+
+	for _, customer := range customers {
+		address := map[string]interface{}{
+			"id":           demodataID(customer.Email + ":address"),
+			"salutationId": salutationIDs[customer.Salutation],
+			"firstName":    "Test",
+			"lastName":     "Customer",
+			"street":       "Teststraße 1",
+			"zipcode":      "48624",
+			"city":         "Schöppingen",
+			"countryId":    salesChannel.CountryID,
+		}
+
+		entry := map[string]interface{}{
+			"id":                       customer.ID,
+			"email":                    customer.Email,
+			"guest":                    customer.Guest,
+			"active":                   true,
+			"customerNumber":           "CLI-" + strings.ToUpper(customer.ID[:8]),
+			"salutationId":             salutationIDs[customer.Salutation],
+			"firstName":                "Test",
+			"lastName":                 "Customer",
+			"groupId":                  customer.CustomerGroup,
+			"salesChannelId":           salesChannel.ID,
+			"languageId":               salesChannel.LanguageID,
+			"defaultPaymentMethodId":   salesChannel.PaymentMethodID,
+			"defaultBillingAddress":    address,
+			"defaultShippingAddressId": address["id"],
+			"accountType":              "private",
+		}
+
+		if !customer.Guest {
+			entry["password"] = opts.Password
+		}
+
+		if customer.Company != "" {
+			entry["accountType"] = "business"
+			entry["company"] = customer.Company
+			entry["vatIds"] = []string{"DE123456789"}
+			address["company"] = customer.Company
+		}
+
+		payload = append(payload, entry)
+	}
+
+	operations := map[string]adminSdk.SyncOperation{
+		"demodata-customers": {Entity: "customer", Action: "upsert", Payload: payload},
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/_action/sync", operations, nil, nil); err != nil {
+		return nil, fmt.Errorf("cannot create customers: %w", err)
+	}
+
+	return customers, nil
+}
+
+func findDemodataSalesChannel(ctx adminSdk.ApiContext, client *adminSdk.Client, id string) (*demodataSalesChannel, error) {
+	criteria := map[string]interface{}{
+		"limit":  1,
+		"filter": []interface{}{equalsFilter("active", true), equalsFilter("typeId", storefrontSalesChannelTypeID)},
+	}
+
+	if id != "" {
+		criteria = map[string]interface{}{"ids": []string{id}}
+	}
+
+	var res struct {
+		Data []demodataSalesChannel `json:"data"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/sales-channel", criteria, &res, nil); err != nil {
+		return nil, err
+	}
+
+	if len(res.Data) == 0 {
+		return nil, fmt.Errorf("found no active storefront sales channel, pass --sales-channel")
+	}
+
+	return &res.Data[0], nil
+}
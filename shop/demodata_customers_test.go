@@ -0,0 +1,90 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanDemodataCustomers(t *testing.T) {
+	salutations := []demodataNamedEntity{{ID: "mr", SalutationKey: "mr"}, {ID: "mrs", SalutationKey: "mrs"}}
+	groups := []demodataNamedEntity{{ID: defaultCustomerGroupID, Name: "Standard customer group"}, {ID: "net", Name: "Net customers"}}
+
+	customers := planDemodataCustomers("example.com", salutations, groups)
+
+	emails := make([]string, 0, len(customers))
+	for _, customer := range customers {
+		emails = append(emails, customer.Email)
+	}
+
+	assert.Equal(t, []string{"guest@example.com", "customer-mr@example.com", "customer-mrs@example.com", "b2b@example.com", "group-net-customers@example.com"}, emails)
+	assert.True(t, customers[0].Guest)
+	assert.Equal(t, "mrs", customers[2].Salutation)
+	assert.NotEmpty(t, customers[3].Company)
+	assert.Equal(t, "net", customers[4].CustomerGroup)
+
+	// the ids are stable, so a second run updates the customers
+	assert.Equal(t, customers, planDemodataCustomers("example.com", salutations, groups))
+}
+
+func TestPlanDemodataCustomersDeduplicatesEmails(t *testing.T) {
+	salutations := []demodataNamedEntity{{ID: "mr", SalutationKey: "mr"}, {ID: "mr-2", SalutationKey: "MR"}}
+	groups := []demodataNamedEntity{{ID: "dealer", Name: "Dealer"}, {ID: "dealer-2", Name: "Dealer!"}, {ID: "empty", Name: "!!!"}, {ID: "other", Name: ""}}
+
+	emails := make([]string, 0)
+	for _, customer := range planDemodataCustomers("example.com", salutations, groups) {
+		emails = append(emails, customer.Email)
+	}
+
+	assert.Equal(t, []string{
+		"guest@example.com", "customer-mr@example.com", "customer-mr-2@example.com", "b2b@example.com",
+		"group-dealer@example.com", "group-dealer-2@example.com", "group@example.com", "group-2@example.com",
+	}, emails)
+}
+
+func TestCreateDemodataCustomers(t *testing.T) {
+	var sync map[string]interface{}
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.URL.Path {
+		case "/api/search/sales-channel":
+			_, _ = w.Write([]byte(`{"data": [{"id": "channel", "languageId": "language", "paymentMethodId": "payment", "countryId": "country"}]}`))
+		case "/api/search/salutation":
+			_, _ = w.Write([]byte(`{"data": [{"id": "mr-id", "salutationKey": "mr"}]}`))
+		case "/api/search/customer-group":
+			_, _ = w.Write([]byte(`{"data": [{"id": "` + defaultCustomerGroupID + `", "name": "Standard customer group"}]}`))
+		case "/api/_action/sync":
+			sync = body
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	customers, err := CreateDemodataCustomers(adminSdk.NewApiContext(context.Background()), client, DemodataCustomerOptions{EmailDomain: "example.com", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Len(t, customers, 3)
+
+	operation := sync["demodata-customers"].(map[string]interface{})
+	assert.Equal(t, "customer", operation["entity"])
+	assert.Equal(t, "upsert", operation["action"])
+
+	payload := operation["payload"].([]interface{})
+	assert.Len(t, payload, 3)
+
+	guest := payload[0].(map[string]interface{})
+	assert.Equal(t, true, guest["guest"])
+	assert.NotContains(t, guest, "password")
+
+	registered := payload[1].(map[string]interface{})
+	assert.Equal(t, "secret", registered["password"])
+	assert.Equal(t, "mr-id", registered["salutationId"])
+	assert.Equal(t, "channel", registered["salesChannelId"])
+
+	b2b := payload[2].(map[string]interface{})
+	assert.Equal(t, "business", b2b["accountType"])
+	assert.Equal(t, "country", b2b["defaultBillingAddress"].(map[string]interface{})["countryId"])
+}
@@ -0,0 +1,261 @@
+package shop
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// The transitions lead from the initial state open to each state of the default state machines.
+var (
+	orderStateTransitions = map[string][]string{
+		"open":        {},
+		"in_progress": {"process"},
+		"completed":   {"process", "complete"},
+		"cancelled":   {"cancel"},
+	}
+
+	deliveryStateTransitions = map[string][]string{
+		"open":               {},
+		"shipped":            {"ship"},
+		"shipped_partially":  {"ship_partially"},
+		"returned":           {"ship", "retour"},
+		"returned_partially": {"ship", "retour_partially"},
+		"cancelled":          {"cancel"},
+	}
+
+	transactionStateTransitions = map[string][]string{
+		"open":               {},
+		"paid":               {"paid"},
+		"paid_partially":     {"paid_partially"},
+		"in_progress":        {"do_pay"},
+		"failed":             {"do_pay", "fail"},
+		"cancelled":          {"cancel"},
+		"reminded":           {"remind"},
+		"authorized":         {"authorize"},
+		"refunded":           {"paid", "refund"},
+		"refunded_partially": {"paid", "refund_partially"},
+		"chargeback":         {"paid", "chargeback"},
+	}
+)
+
+type OrderStateCombination struct {
+	Order       string
+	Delivery    string
+	Transaction string
+}
+
+func (c OrderStateCombination) String() string {
+	return fmt.Sprintf("%s:%s:%s", c.Order, c.Delivery, c.Transaction)
+}
+
+type DemodataOrderOptions struct {
+	SalesChannelID string
+	CustomerID     string
+	ProductID      string
+}
+
+type DemodataOrder struct {
+	ID          string
+	OrderNumber string
+	States      OrderStateCombination
+}
+
+func sortedStates(transitions map[string][]string) []string {
+	states := make([]string, 0, len(transitions))
+
+	for state := range transitions {
+		states = append(states, state)
+	}
+
+	sort.Strings(states)
+
+	return states
+}
+
+// AllOrderStateCombinations returns every combination of the order, delivery and transaction states.
+func AllOrderStateCombinations() []OrderStateCombination {
+	combinations := make([]OrderStateCombination, 0)
+
+	for _, order := range sortedStates(orderStateTransitions) {
+		for _, delivery := range sortedStates(deliveryStateTransitions) {
+			for _, transaction := range sortedStates(transactionStateTransitions) {
+				combinations = append(combinations, OrderStateCombination{Order: order, Delivery: delivery, Transaction: transaction})
+			}
+		}
+	}
+
+	return combinations
+}
+
+// ParseOrderStateCombinations parses "all" or a comma separated list like completed:shipped:paid,cancelled:cancelled:refunded.
+func ParseOrderStateCombinations(value string) ([]OrderStateCombination, error) {
+	if value == "all" {
+		return AllOrderStateCombinations(), nil
+	}
+
+	combinations := make([]OrderStateCombination, 0)
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid state combination %q, use order:delivery:transaction like completed:shipped:paid", entry)
+		}
+
+		combination := OrderStateCombination{Order: parts[0], Delivery: parts[1], Transaction: parts[2]}
+
+		for _, check := range []struct {
+			name        string
+			state       string
+			transitions map[string][]string
+		}{
+			{"order", combination.Order, orderStateTransitions},
+			{"delivery", combination.Delivery, deliveryStateTransitions},
+			{"transaction", combination.Transaction, transactionStateTransitions},
+		} {
+			if _, ok := check.transitions[check.state]; !ok {
+				return nil, fmt.Errorf("unknown %s state %s, use one of %s", check.name, check.state, strings.Join(sortedStates(check.transitions), ", "))
+			}
+		}
+
+		combinations = append(combinations, combination)
+	}
+
+	return combinations, nil
+}
+
+// ResolveDemodataOrderOptions fills the sales channel, customer and product with the first matching entity of the shop.
+// The customer can be passed as email and the product as product number.
+func ResolveDemodataOrderOptions(ctx adminSdk.ApiContext, client *adminSdk.Client, customerEmail, productNumber, salesChannelID string) (*DemodataOrderOptions, error) {
+	opts := &DemodataOrderOptions{SalesChannelID: salesChannelID}
+	var err error
+
+	if opts.SalesChannelID == "" {
+		opts.SalesChannelID, err = searchFirstID(ctx, client, "sales-channel", map[string]interface{}{
+			"filter": []interface{}{equalsFilter("active", true), equalsFilter("typeId", storefrontSalesChannelTypeID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.SalesChannelID == "" {
+			return nil, fmt.Errorf("found no active storefront sales channel, pass --sales-channel")
+		}
+	}
+
+	customerFilter := []interface{}{equalsFilter("active", true), equalsFilter("guest", false)}
+	if customerEmail != "" {
+		customerFilter = []interface{}{equalsFilter("email", customerEmail)}
+	}
+
+	if opts.CustomerID, err = searchFirstID(ctx, client, "customer", map[string]interface{}{"filter": customerFilter}); err != nil {
+		return nil, err
+	}
+
+	if opts.CustomerID == "" {
+		return nil, fmt.Errorf("found no customer to place the orders, create one or pass the email with --customer")
+	}
+
+	productFilter := []interface{}{
+		equalsFilter("active", true),
+		// products with variants can't be bought, only their variants
+		map[string]interface{}{"type": "multi", "operator": "or", "queries": []interface{}{equalsFilter("childCount", 0), equalsFilter("childCount", nil)}},
+	}
+	if productNumber != "" {
+		productFilter = []interface{}{equalsFilter("productNumber", productNumber)}
+	}
+
+	if opts.ProductID, err = searchFirstID(ctx, client, "product", map[string]interface{}{"filter": productFilter}); err != nil {
+		return nil, err
+	}
+
+	if opts.ProductID == "" {
+		return nil, fmt.Errorf("found no product to order, pass the product number with --product")
+	}
+
+	return opts, nil
+}
+
+// CreateDemodataOrder places an order with the admin order creation of Shopware and moves it into the states of the combination.
+func CreateDemodataOrder(ctx adminSdk.ApiContext, client *adminSdk.Client, opts DemodataOrderOptions, states OrderStateCombination) (*DemodataOrder, error) {
+	var contextToken struct {
+		Token string `json:"sw-context-token"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/_proxy/switch-customer", map[string]string{"salesChannelId": opts.SalesChannelID, "customerId": opts.CustomerID}, &contextToken, nil); err != nil {
+		return nil, fmt.Errorf("cannot switch to customer: %w", err)
+	}
+
+	tokenHeader := map[string]string{"sw-context-token": contextToken.Token}
+
+	lineItem := map[string]interface{}{
+		"items": []map[string]interface{}{{"id": opts.ProductID, "referencedId": opts.ProductID, "type": "product", "quantity": 1}},
+	}
+
+	if err := adminRequest(ctx, client, "POST", fmt.Sprintf("/api/_proxy/store-api/%s/checkout/cart/line-item", opts.SalesChannelID), lineItem, nil, tokenHeader); err != nil {
+		return nil, fmt.Errorf("cannot add product to cart: %w", err)
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", fmt.Sprintf("/api/_proxy-order/%s", opts.SalesChannelID), map[string]interface{}{}, &created, tokenHeader); err != nil {
+		return nil, fmt.Errorf("cannot create order: %w", err)
+	}
+
+	var search struct {
+		Data []struct {
+			OrderNumber string `json:"orderNumber"`
+			Deliveries  []struct {
+				ID string `json:"id"`
+			} `json:"deliveries"`
+			Transactions []struct {
+				ID string `json:"id"`
+			} `json:"transactions"`
+		} `json:"data"`
+	}
+
+	criteria := map[string]interface{}{"ids": []string{created.ID}, "associations": map[string]interface{}{"deliveries": map[string]interface{}{}, "transactions": map[string]interface{}{}}}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/order", criteria, &search, nil); err != nil {
+		return nil, err
+	}
+
+	if len(search.Data) == 0 {
+		return nil, fmt.Errorf("cannot find created order %s", created.ID)
+	}
+
+	order := &DemodataOrder{ID: created.ID, OrderNumber: search.Data[0].OrderNumber, States: states}
+
+	// the order state is changed last, as a cancelled or completed order is usually not touched anymore
+	for _, transaction := range search.Data[0].Transactions {
+		if err := transitionState(ctx, client, "order_transaction", transaction.ID, transactionStateTransitions[states.Transaction]); err != nil {
+			return order, err
+		}
+	}
+
+	for _, delivery := range search.Data[0].Deliveries {
+		if err := transitionState(ctx, client, "order_delivery", delivery.ID, deliveryStateTransitions[states.Delivery]); err != nil {
+			return order, err
+		}
+	}
+
+	if err := transitionState(ctx, client, "order", order.ID, orderStateTransitions[states.Order]); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+func transitionState(ctx adminSdk.ApiContext, client *adminSdk.Client, entity, id string, transitions []string) error {
+	for _, transition := range transitions {
+		if err := adminRequest(ctx, client, "POST", fmt.Sprintf("/api/_action/%s/%s/state/%s", entity, id, transition), map[string]interface{}{}, nil, nil); err != nil {
+			return fmt.Errorf("cannot apply transition %s on %s: %w", transition, entity, err)
+		}
+	}
+
+	return nil
+}
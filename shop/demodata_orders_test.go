@@ -0,0 +1,128 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAdminClient starts a fake shop which answers the OAuth token request and passes all other requests to the handler.
+func newTestAdminClient(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, body map[string]interface{})) *adminSdk.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 600}`))
+
+			return
+		}
+
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		handler(w, r, body)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := adminSdk.NewApiClient(context.Background(), server.URL, adminSdk.NewIntegrationCredentials("id", "secret", []string{"write"}), server.Client())
+	assert.NoError(t, err)
+
+	return client
+}
+
+func TestParseOrderStateCombinations(t *testing.T) {
+	all, err := ParseOrderStateCombinations("all")
+	assert.NoError(t, err)
+	assert.Len(t, all, 4*6*11)
+	assert.Equal(t, "cancelled:cancelled:authorized", all[0].String())
+
+	combinations, err := ParseOrderStateCombinations("completed:shipped:paid, cancelled:cancelled:refunded")
+	assert.NoError(t, err)
+	assert.Equal(t, []OrderStateCombination{
+		{Order: "completed", Delivery: "shipped", Transaction: "paid"},
+		{Order: "cancelled", Delivery: "cancelled", Transaction: "refunded"},
+	}, combinations)
+
+	_, err = ParseOrderStateCombinations("completed:shipped")
+	assert.ErrorContains(t, err, "use order:delivery:transaction")
+
+	_, err = ParseOrderStateCombinations("completed:lost:paid")
+	assert.ErrorContains(t, err, "unknown delivery state lost")
+}
+
+func TestCreateDemodataOrder(t *testing.T) {
+	var mu sync.Mutex
+	requests := make([]string, 0)
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+
+		assert.Equal(t, "1", r.Header.Get("sw-skip-trigger-flow"))
+
+		switch {
+		case r.URL.Path == "/api/_proxy/switch-customer":
+			assert.Equal(t, "customer", body["customerId"])
+			_, _ = w.Write([]byte(`{"sw-context-token": "ctx"}`))
+		case strings.HasSuffix(r.URL.Path, "/checkout/cart/line-item"):
+			assert.Equal(t, "ctx", r.Header.Get("sw-context-token"))
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/_proxy-order/channel":
+			assert.Equal(t, "ctx", r.Header.Get("sw-context-token"))
+			_, _ = w.Write([]byte(`{"id": "order"}`))
+		case r.URL.Path == "/api/search/order":
+			_, _ = w.Write([]byte(`{"data": [{"orderNumber": "10001", "deliveries": [{"id": "delivery"}], "transactions": [{"id": "transaction"}]}]}`))
+		case strings.HasPrefix(r.URL.Path, "/api/_action/"):
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	apiCtx := adminSdk.NewApiContext(context.Background())
+	apiCtx.SkipFlows = true
+
+	order, err := CreateDemodataOrder(apiCtx, client, DemodataOrderOptions{SalesChannelID: "channel", CustomerID: "customer", ProductID: "product"}, OrderStateCombination{Order: "completed", Delivery: "returned", Transaction: "refunded"})
+	assert.NoError(t, err)
+	assert.Equal(t, "10001", order.OrderNumber)
+
+	assert.Equal(t, []string{
+		"/api/_proxy/switch-customer",
+		"/api/_proxy/store-api/channel/checkout/cart/line-item",
+		"/api/_proxy-order/channel",
+		"/api/search/order",
+		"/api/_action/order_transaction/transaction/state/paid",
+		"/api/_action/order_transaction/transaction/state/refund",
+		"/api/_action/order_delivery/delivery/state/ship",
+		"/api/_action/order_delivery/delivery/state/retour",
+		"/api/_action/order/order/state/process",
+		"/api/_action/order/order/state/complete",
+	}, requests)
+}
+
+func TestResolveDemodataOrderOptions(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.URL.Path {
+		case "/api/search-ids/sales-channel":
+			_, _ = w.Write([]byte(`{"total": 1, "data": ["channel"]}`))
+		case "/api/search-ids/customer":
+			filter := body["filter"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "email", filter["field"])
+			_, _ = w.Write([]byte(`{"total": 1, "data": ["customer"]}`))
+		case "/api/search-ids/product":
+			_, _ = w.Write([]byte(`{"total": 0, "data": []}`))
+		}
+	})
+
+	_, err := ResolveDemodataOrderOptions(adminSdk.NewApiContext(context.Background()), client, "test@example.com", "", "")
+	assert.ErrorContains(t, err, "found no product")
+}
@@ -0,0 +1,43 @@
+package shop
+
+// ConfigDocker runs the PHP commands of the project inside a docker compose service.
+type ConfigDocker struct {
+	Service string `yaml:"service"`
+	// ComposeFile is optional, docker compose looks up the file in the project root by default
+	ComposeFile string `yaml:"compose_file,omitempty"`
+	Workdir     string `yaml:"workdir,omitempty"`
+	User        string `yaml:"user,omitempty"`
+}
+
+// IsEnabled reports whether commands should be executed inside the container.
+func (d *ConfigDocker) IsEnabled() bool {
+	return d != nil && d.Service != ""
+}
+
+// ExecArgs returns the docker arguments to run command inside the configured service. Without tty a pseudo-TTY is disabled,
+// so the command can be used in pipes and CI.
+func (d *ConfigDocker) ExecArgs(command []string, tty bool) []string {
+	args := []string{"compose"}
+
+	if d.ComposeFile != "" {
+		args = append(args, "-f", d.ComposeFile)
+	}
+
+	args = append(args, "exec")
+
+	if !tty {
+		args = append(args, "-T")
+	}
+
+	if d.Workdir != "" {
+		args = append(args, "--workdir", d.Workdir)
+	}
+
+	if d.User != "" {
+		args = append(args, "--user", d.User)
+	}
+
+	args = append(args, d.Service)
+
+	return append(args, command...)
+}
@@ -0,0 +1,26 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerIsEnabled(t *testing.T) {
+	var docker *ConfigDocker
+
+	assert.False(t, docker.IsEnabled())
+	assert.False(t, (&ConfigDocker{}).IsEnabled())
+	assert.True(t, (&ConfigDocker{Service: "web"}).IsEnabled())
+}
+
+func TestDockerExecArgs(t *testing.T) {
+	docker := &ConfigDocker{Service: "web"}
+
+	assert.Equal(t, []string{"compose", "exec", "web", "php", "bin/console", "cache:clear"}, docker.ExecArgs([]string{"php", "bin/console", "cache:clear"}, true))
+	assert.Equal(t, []string{"compose", "exec", "-T", "web", "composer", "install"}, docker.ExecArgs([]string{"composer", "install"}, false))
+
+	docker = &ConfigDocker{Service: "web", ComposeFile: "docker/compose.yml", Workdir: "/var/www/html", User: "www-data"}
+
+	assert.Equal(t, []string{"compose", "-f", "docker/compose.yml", "exec", "--workdir", "/var/www/html", "--user", "www-data", "web", "php", "bin/console"}, docker.ExecArgs([]string{"php", "bin/console"}, true))
+}
@@ -0,0 +1,323 @@
+package shop
+
+import (
+	"fmt"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+const (
+	E2EFrameworkPlaywright = "playwright"
+	E2EFrameworkCypress    = "cypress"
+
+	defaultE2EPath   = "tests/e2e"
+	e2eAdminUsername = "e2e-admin"
+	// e2eDomainPath is appended to the shop URL for the domain of the e2e sales channel
+	e2eDomainPath = "/e2e"
+)
+
+// ConfigE2E configures the end-to-end tests of project e2e.
+type ConfigE2E struct {
+	Framework string `yaml:"framework,omitempty"`
+	// Path of the test suite relative to the project root
+	Path string `yaml:"path,omitempty"`
+	// Password of the created admin user and customers, a random one is generated per run when empty
+	Password string `yaml:"password,omitempty"`
+}
+
+// WithDefaults returns the config with the default framework and path filled in.
+func (c ConfigE2E) WithDefaults() ConfigE2E {
+	if c.Framework == "" {
+		c.Framework = E2EFrameworkPlaywright
+	}
+
+	if c.Path == "" {
+		c.Path = defaultE2EPath
+	}
+
+	return c
+}
+
+// E2EFixtures are the entities created for the tests, they are passed to the test runner as environment variables.
+type E2EFixtures struct {
+	ShopURL          string
+	StorefrontURL    string
+	SalesChannelID   string
+	AccessKey        string
+	AdminUsername    string
+	AdminPassword    string
+	CustomerEmail    string
+	CustomerPassword string
+}
+
+// Env returns the fixtures as E2E_* variables. Cypress exposes the CYPRESS_* variables with Cypress.env().
+func (f E2EFixtures) Env() []string {
+	values := [][2]string{
+		{"SHOP_URL", f.ShopURL},
+		{"STOREFRONT_URL", f.StorefrontURL},
+		{"SALES_CHANNEL_ID", f.SalesChannelID},
+		{"ACCESS_KEY", f.AccessKey},
+		{"ADMIN_USERNAME", f.AdminUsername},
+		{"ADMIN_PASSWORD", f.AdminPassword},
+		{"CUSTOMER_EMAIL", f.CustomerEmail},
+		{"CUSTOMER_PASSWORD", f.CustomerPassword},
+	}
+
+	env := make([]string, 0, len(values)*2)
+
+	for _, value := range values {
+		env = append(env, fmt.Sprintf("E2E_%s=%s", value[0], value[1]), fmt.Sprintf("CYPRESS_%s=%s", value[0], value[1]))
+	}
+
+	return env
+}
+
+// SeedE2EFixtures creates or updates a storefront sales channel, a customer and an admin user for the tests. The sales
+// channel copies the settings of the first storefront and is reachable under /e2e of the shop URL. Without a password a
+// random one is generated, so no well-known admin login is left in the shop.
+func SeedE2EFixtures(ctx adminSdk.ApiContext, client *adminSdk.Client, shopURL, password string) (*E2EFixtures, error) {
+	if password == "" {
+		var err error
+		if password, err = GeneratePassword(); err != nil {
+			return nil, err
+		}
+	}
+
+	salesChannelID, accessKey, err := upsertE2ESalesChannel(ctx, client, shopURL)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := CreateDemodataCustomers(ctx, client, DemodataCustomerOptions{
+		SalesChannelID: salesChannelID,
+		EmailDomain:    "e2e.example.com",
+		Password:       password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := &E2EFixtures{
+		ShopURL:          strings.TrimRight(shopURL, "/"),
+		StorefrontURL:    strings.TrimRight(shopURL, "/") + e2eDomainPath,
+		SalesChannelID:   salesChannelID,
+		AccessKey:        accessKey,
+		AdminUsername:    e2eAdminUsername,
+		AdminPassword:    password,
+		CustomerPassword: password,
+	}
+
+	for _, customer := range customers {
+		if !customer.Guest && customer.Company == "" {
+			fixtures.CustomerEmail = customer.Email
+			break
+		}
+	}
+
+	if err := upsertE2EAdminUser(ctx, client, password); err != nil {
+		return nil, err
+	}
+
+	return fixtures, nil
+}
+
+func upsertE2ESalesChannel(ctx adminSdk.ApiContext, client *adminSdk.Client, shopURL string) (string, string, error) {
+	id := demodataID("e2e:sales-channel")
+
+	var existing struct {
+		Data []struct {
+			AccessKey string `json:"accessKey"`
+		} `json:"data"`
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/sales-channel", map[string]interface{}{"ids": []string{id}}, &existing, nil); err != nil {
+		return "", "", err
+	}
+
+	if len(existing.Data) > 0 {
+		return id, existing.Data[0].AccessKey, nil
+	}
+
+	var template struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+
+	criteria := map[string]interface{}{
+		"limit":        1,
+		"filter":       []interface{}{equalsFilter("active", true), equalsFilter("typeId", storefrontSalesChannelTypeID)},
+		"associations": map[string]interface{}{"domains": map[string]interface{}{}},
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/search/sales-channel", criteria, &template, nil); err != nil {
+		return "", "", err
+	}
+
+	if len(template.Data) == 0 {
+		return "", "", fmt.Errorf("found no active storefront sales channel to copy the settings from")
+	}
+
+	source := template.Data[0]
+
+	domains, _ := source["domains"].([]interface{})
+	if len(domains) == 0 {
+		return "", "", fmt.Errorf("the storefront sales channel %s has no domain", source["id"])
+	}
+
+	domain, _ := domains[0].(map[string]interface{})
+
+	var accessKey struct {
+		AccessKey string `json:"accessKey"`
+	}
+
+	if err := adminRequest(ctx, client, "GET", "/api/_action/access-key/sales-channel", nil, &accessKey, nil); err != nil {
+		return "", "", err
+	}
+
+	payload := map[string]interface{}{
+		"id":        id,
+		"name":      "E2E Storefront",
+		"active":    true,
+		"accessKey": accessKey.AccessKey,
+		"domains": []map[string]interface{}{{
+			"id":           demodataID("e2e:sales-channel-domain"),
+			"url":          strings.TrimRight(shopURL, "/") + e2eDomainPath,
+			"languageId":   domain["languageId"],
+			"currencyId":   domain["currencyId"],
+			"snippetSetId": domain["snippetSetId"],
+		}},
+	}
+
+	for _, field := range []string{"typeId", "languageId", "currencyId", "paymentMethodId", "shippingMethodId", "countryId", "customerGroupId", "navigationCategoryId"} {
+		payload[field] = source[field]
+	}
+
+	for association, field := range map[string]string{"languages": "languageId", "currencies": "currencyId", "paymentMethods": "paymentMethodId", "shippingMethods": "shippingMethodId", "countries": "countryId"} {
+		payload[association] = []map[string]interface{}{{"id": source[field]}}
+	}
+
+	operations := map[string]adminSdk.SyncOperation{
+		"e2e-sales-channel": {Entity: "sales_channel", Action: "upsert", Payload: []map[string]interface{}{payload}},
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/_action/sync", operations, nil, nil); err != nil {
+		return "", "", fmt.Errorf("cannot create sales channel: %w", err)
+	}
+
+	return id, accessKey.AccessKey, nil
+}
+
+func upsertE2EAdminUser(ctx adminSdk.ApiContext, client *adminSdk.Client, password string) error {
+	localeID, err := searchFirstID(ctx, client, "locale", map[string]interface{}{"filter": []interface{}{equalsFilter("code", "en-GB")}})
+	if err != nil {
+		return err
+	}
+
+	operations := map[string]adminSdk.SyncOperation{
+		"e2e-admin": {Entity: "user", Action: "upsert", Payload: []map[string]interface{}{{
+			"id":        demodataID("e2e:admin"),
+			"username":  e2eAdminUsername,
+			"password":  password,
+			"firstName": "E2E",
+			"lastName":  "Admin",
+			"email":     e2eAdminUsername + "@example.com",
+			"localeId":  localeID,
+			"admin":     true,
+		}}},
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/_action/sync", operations, nil, nil); err != nil {
+		return fmt.Errorf("cannot create admin user: %w", err)
+	}
+
+	return nil
+}
+
+// E2EScaffoldFiles returns the files of a minimal test suite for the framework by their path relative to the suite.
+func E2EScaffoldFiles(framework string) (map[string]string, error) {
+	switch framework {
+	case E2EFrameworkPlaywright:
+		return map[string]string{
+			"package.json": `{
+    "private": true,
+    "scripts": {
+        "test": "playwright test"
+    },
+    "devDependencies": {
+        "@playwright/test": "^1.40.0"
+    }
+}
+`,
+			"playwright.config.ts": `import { defineConfig } from '@playwright/test';
+
+export default defineConfig({
+    testDir: './tests',
+    retries: process.env.CI ? 1 : 0,
+    reporter: process.env.CI ? 'junit' : 'list',
+    use: {
+        baseURL: process.env.E2E_STOREFRONT_URL,
+        trace: 'retain-on-failure',
+    },
+});
+`,
+			"tests/storefront.spec.ts": `import { test, expect } from '@playwright/test';
+
+test('customer can log in', async ({ page }) => {
+    await page.goto('/account/login');
+    await page.fill('#loginMail', process.env.E2E_CUSTOMER_EMAIL!);
+    await page.fill('#loginPassword', process.env.E2E_CUSTOMER_PASSWORD!);
+    await page.click('.login-submit button[type=submit]');
+
+    await expect(page).toHaveURL(/\/account/);
+});
+`,
+		}, nil
+	case E2EFrameworkCypress:
+		return map[string]string{
+			"package.json": `{
+    "private": true,
+    "scripts": {
+        "test": "cypress run"
+    },
+    "devDependencies": {
+        "cypress": "^13.6.0"
+    }
+}
+`,
+			"cypress.config.js": `const { defineConfig } = require('cypress');
+
+module.exports = defineConfig({
+    e2e: {
+        baseUrl: process.env.E2E_STOREFRONT_URL,
+        supportFile: false,
+    },
+});
+`,
+			"cypress/e2e/storefront.cy.js": `describe('Storefront', () => {
+    it('customer can log in', () => {
+        cy.visit('/account/login');
+        cy.get('#loginMail').type(Cypress.env('CUSTOMER_EMAIL'));
+        cy.get('#loginPassword').type(Cypress.env('CUSTOMER_PASSWORD'));
+        cy.get('.login-submit button[type=submit]').click();
+
+        cy.url().should('include', '/account');
+    });
+});
+`,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported e2e framework %s, use playwright or cypress", framework)
+}
+
+// E2ERunCommand returns the npx arguments to run the test suite of the framework.
+func E2ERunCommand(framework string, args []string) ([]string, error) {
+	switch framework {
+	case E2EFrameworkPlaywright:
+		return append([]string{"playwright", "test"}, args...), nil
+	case E2EFrameworkCypress:
+		return append([]string{"cypress", "run"}, args...), nil
+	}
+
+	return nil, fmt.Errorf("unsupported e2e framework %s, use playwright or cypress", framework)
+}
@@ -0,0 +1,88 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedE2EFixtures(t *testing.T) {
+	syncs := make(map[string]interface{})
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.URL.Path {
+		case "/api/search/sales-channel":
+			if _, ok := body["ids"]; ok {
+				if _, created := syncs["e2e-sales-channel"]; created {
+					_, _ = w.Write([]byte(`{"data": [{"id": "e2e", "languageId": "language", "paymentMethodId": "payment", "countryId": "country"}]}`))
+				} else {
+					_, _ = w.Write([]byte(`{"data": []}`))
+				}
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data": [{"id": "channel", "typeId": "type", "languageId": "language", "currencyId": "currency", "paymentMethodId": "payment", "countryId": "country", "domains": [{"languageId": "language", "currencyId": "currency", "snippetSetId": "snippets"}]}]}`))
+		case "/api/_action/access-key/sales-channel":
+			_, _ = w.Write([]byte(`{"accessKey": "SWSC123"}`))
+		case "/api/search/salutation":
+			_, _ = w.Write([]byte(`{"data": [{"id": "mr-id", "salutationKey": "mr"}]}`))
+		case "/api/search/customer-group":
+			_, _ = w.Write([]byte(`{"data": []}`))
+		case "/api/search-ids/locale":
+			_, _ = w.Write([]byte(`{"total": 1, "data": ["locale"]}`))
+		case "/api/_action/sync":
+			for key, value := range body {
+				syncs[key] = value
+			}
+
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	fixtures, err := SeedE2EFixtures(adminSdk.NewApiContext(context.Background()), client, "https://shop.test/", "secret")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://shop.test/e2e", fixtures.StorefrontURL)
+	assert.Equal(t, "SWSC123", fixtures.AccessKey)
+	assert.Equal(t, "customer-mr@e2e.example.com", fixtures.CustomerEmail)
+	assert.Equal(t, "e2e-admin", fixtures.AdminUsername)
+	assert.Contains(t, fixtures.Env(), "E2E_CUSTOMER_PASSWORD=secret")
+	assert.Contains(t, fixtures.Env(), "CYPRESS_STOREFRONT_URL=https://shop.test/e2e")
+
+	salesChannel := syncs["e2e-sales-channel"].(map[string]interface{})["payload"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, fixtures.SalesChannelID, salesChannel["id"])
+	assert.Equal(t, "payment", salesChannel["paymentMethodId"])
+	assert.Equal(t, "https://shop.test/e2e", salesChannel["domains"].([]interface{})[0].(map[string]interface{})["url"])
+
+	customers := syncs["demodata-customers"].(map[string]interface{})["payload"].([]interface{})
+	assert.Equal(t, "e2e", customers[0].(map[string]interface{})["salesChannelId"])
+
+	admin := syncs["e2e-admin"].(map[string]interface{})["payload"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "locale", admin["localeId"])
+	assert.Equal(t, true, admin["admin"])
+}
+
+func TestE2EScaffoldFiles(t *testing.T) {
+	files, err := E2EScaffoldFiles(E2EFrameworkPlaywright)
+	assert.NoError(t, err)
+	assert.Contains(t, files, "playwright.config.ts")
+
+	files, err = E2EScaffoldFiles(E2EFrameworkCypress)
+	assert.NoError(t, err)
+	assert.Contains(t, files, "cypress.config.js")
+
+	_, err = E2EScaffoldFiles("selenium")
+	assert.ErrorContains(t, err, "unsupported e2e framework selenium")
+
+	args, err := E2ERunCommand(E2EFrameworkCypress, []string{"--spec", "a.cy.js"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cypress", "run", "--spec", "a.cy.js"}, args)
+
+	assert.Equal(t, ConfigE2E{Framework: "playwright", Path: "tests/e2e"}, ConfigE2E{}.WithDefaults())
+}
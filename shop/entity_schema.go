@@ -0,0 +1,374 @@
+package shop
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+const customFieldSetPageSize = 500
+
+// EntitySchema is the entity schema of the Admin API by entity name.
+type EntitySchema map[string]EntityDefinition
+
+type EntityDefinition struct {
+	Entity     string                    `json:"entity"`
+	Properties map[string]EntityProperty `json:"properties"`
+}
+
+type EntityProperty struct {
+	// Type is uuid, string, text, int, float, boolean, date, json_object, json_list or association
+	Type string `json:"type"`
+	// Relation is many_to_one, one_to_one, one_to_many or many_to_many for associations
+	Relation string `json:"relation,omitempty"`
+	// Entity is the referenced entity of an association
+	Entity string                 `json:"entity,omitempty"`
+	Flags  map[string]interface{} `json:"flags,omitempty"`
+}
+
+// CustomField is a custom field of a custom field set, which is assigned to an entity.
+type CustomField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// FetchEntitySchema returns the entity schema of all entities including the ones of extensions.
+func FetchEntitySchema(ctx adminSdk.ApiContext, client *adminSdk.Client) (EntitySchema, error) {
+	var schema EntitySchema
+
+	if err := adminRequest(ctx, client, "GET", "/api/_info/entity-schema.json", nil, &schema, nil); err != nil {
+		return nil, fmt.Errorf("cannot fetch the entity schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// FetchCustomFields returns the custom fields by the entity name their set is assigned to.
+func FetchCustomFields(ctx adminSdk.ApiContext, client *adminSdk.Client) (map[string][]CustomField, error) {
+	customFields := make(map[string][]CustomField)
+
+	for page := 1; ; page++ {
+		criteria := map[string]interface{}{
+			"page":  page,
+			"limit": customFieldSetPageSize,
+			"associations": map[string]interface{}{
+				"customFields": map[string]interface{}{},
+				"relations":    map[string]interface{}{},
+			},
+		}
+
+		var res struct {
+			Data []struct {
+				CustomFields []CustomField `json:"customFields"`
+				Relations    []struct {
+					EntityName string `json:"entityName"`
+				} `json:"relations"`
+			} `json:"data"`
+		}
+
+		if err := adminRequest(ctx, client, "POST", "/api/search/custom-field-set", criteria, &res, nil); err != nil {
+			return nil, fmt.Errorf("cannot fetch the custom fields: %w", err)
+		}
+
+		for _, set := range res.Data {
+			for _, relation := range set.Relations {
+				customFields[relation.EntityName] = append(customFields[relation.EntityName], set.CustomFields...)
+			}
+		}
+
+		if len(res.Data) < customFieldSetPageSize {
+			break
+		}
+	}
+
+	for entity := range customFields {
+		sort.Slice(customFields[entity], func(i, j int) bool {
+			return customFields[entity][i].Name < customFields[entity][j].Name
+		})
+	}
+
+	return customFields, nil
+}
+
+// Filter returns only the given entities, all entities are kept without names.
+func (s EntitySchema) Filter(entities []string) (EntitySchema, error) {
+	if len(entities) == 0 {
+		return s, nil
+	}
+
+	filtered := make(EntitySchema, len(entities))
+
+	for _, entity := range entities {
+		definition, ok := s[entity]
+		if !ok {
+			return nil, fmt.Errorf("entity %s does not exist in the shop", entity)
+		}
+
+		filtered[entity] = definition
+	}
+
+	return filtered, nil
+}
+
+func (s EntitySchema) entityNames() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (d EntityDefinition) propertyNames() []string {
+	names := make([]string, 0, len(d.Properties))
+	for name := range d.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (p EntityProperty) required() bool {
+	required, _ := p.Flags["required"].(bool)
+	primaryKey, _ := p.Flags["primary_key"].(bool)
+
+	return required || primaryKey
+}
+
+func (p EntityProperty) toMany() bool {
+	return p.Relation == "one_to_many" || p.Relation == "many_to_many"
+}
+
+// EntityTypeName converts an entity name like product_manufacturer to ProductManufacturer.
+func EntityTypeName(entity string) string {
+	var name strings.Builder
+
+	for _, part := range strings.FieldsFunc(entity, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return name.String()
+}
+
+// GenerateTypeScript generates an interface for every entity of the schema. The custom fields of an entity get an own interface.
+func GenerateTypeScript(schema EntitySchema, customFields map[string][]CustomField) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Generated by shopware-cli project schema dump, do not edit.\n")
+
+	for _, entity := range schema.entityNames() {
+		definition := schema[entity]
+		typeName := EntityTypeName(entity)
+		fields := customFields[entity]
+
+		if len(fields) > 0 {
+			fmt.Fprintf(&buf, "\nexport interface %sCustomFields {\n", typeName)
+
+			for _, field := range fields {
+				fmt.Fprintf(&buf, "    %q?: %s;\n", field.Name, typeScriptCustomFieldType(field.Type))
+			}
+
+			buf.WriteString("    [key: string]: unknown;\n}\n")
+		}
+
+		fmt.Fprintf(&buf, "\nexport interface %s {\n", typeName)
+
+		for _, name := range definition.propertyNames() {
+			property := definition.Properties[name]
+			propertyType := typeScriptPropertyType(schema, property)
+
+			if name == "customFields" && len(fields) > 0 {
+				propertyType = typeName + "CustomFields"
+			}
+
+			optional := "?"
+			if property.required() {
+				optional = ""
+			}
+
+			fmt.Fprintf(&buf, "    %s%s: %s;\n", name, optional, propertyType)
+		}
+
+		buf.WriteString("}\n")
+	}
+
+	return buf.Bytes()
+}
+
+func typeScriptPropertyType(schema EntitySchema, property EntityProperty) string {
+	switch property.Type {
+	case "uuid", "string", "text", "date":
+		return "string"
+	case "int", "float":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "json_list":
+		return "unknown[]"
+	case "association":
+		target := "Record<string, unknown>"
+		if _, ok := schema[property.Entity]; ok {
+			target = EntityTypeName(property.Entity)
+		}
+
+		if property.toMany() {
+			return target + "[]"
+		}
+
+		return target
+	}
+
+	return "Record<string, unknown>"
+}
+
+func typeScriptCustomFieldType(fieldType string) string {
+	switch fieldType {
+	case "int", "float":
+		return "number"
+	case "bool", "checkbox", "switch":
+		return "boolean"
+	case "text", "html", "date", "datetime", "colorpicker", "media":
+		return "string"
+	}
+
+	return "unknown"
+}
+
+// GenerateGo generates a struct for every entity of the schema. Optional scalar properties are pointers, so they are
+// omitted when writing an entity.
+func GenerateGo(schema EntitySchema, customFields map[string][]CustomField, packageName string) ([]byte, error) {
+	var body bytes.Buffer
+	usesTime := false
+
+	for _, entity := range schema.entityNames() {
+		definition := schema[entity]
+		typeName := EntityTypeName(entity)
+		fields := customFields[entity]
+
+		if len(fields) > 0 {
+			fmt.Fprintf(&body, "\ntype %sCustomFields struct {\n", typeName)
+
+			for _, field := range fields {
+				fmt.Fprintf(&body, "%s %s `json:%q`\n", goFieldName(field.Name), goCustomFieldType(field.Type), field.Name+",omitempty")
+			}
+
+			body.WriteString("}\n")
+		}
+
+		fmt.Fprintf(&body, "\ntype %s struct {\n", typeName)
+
+		for _, name := range definition.propertyNames() {
+			property := definition.Properties[name]
+			propertyType := goPropertyType(schema, property)
+
+			if name == "customFields" && len(fields) > 0 {
+				propertyType = "*" + typeName + "CustomFields"
+			}
+
+			if property.Type == "date" {
+				usesTime = true
+			}
+
+			fmt.Fprintf(&body, "%s %s `json:%q`\n", goFieldName(name), propertyType, name+",omitempty")
+		}
+
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by shopware-cli project schema dump. DO NOT EDIT.\n\npackage %s\n", packageName)
+
+	if usesTime {
+		buf.WriteString("\nimport \"time\"\n")
+	}
+
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func goPropertyType(schema EntitySchema, property EntityProperty) string {
+	var scalar string
+
+	switch property.Type {
+	case "uuid", "string", "text":
+		scalar = "string"
+	case "int":
+		scalar = "int64"
+	case "float":
+		scalar = "float64"
+	case "boolean":
+		scalar = "bool"
+	case "date":
+		return "*time.Time"
+	case "json_list":
+		return "[]interface{}"
+	case "association":
+		if _, ok := schema[property.Entity]; !ok {
+			if property.toMany() {
+				return "[]map[string]interface{}"
+			}
+
+			return "map[string]interface{}"
+		}
+
+		if property.toMany() {
+			return "[]" + EntityTypeName(property.Entity)
+		}
+
+		return "*" + EntityTypeName(property.Entity)
+	default:
+		return "map[string]interface{}"
+	}
+
+	if property.required() {
+		return scalar
+	}
+
+	return "*" + scalar
+}
+
+func goCustomFieldType(fieldType string) string {
+	switch fieldType {
+	case "int":
+		return "*int64"
+	case "float":
+		return "*float64"
+	case "bool", "checkbox", "switch":
+		return "*bool"
+	case "text", "html", "date", "datetime", "colorpicker", "media":
+		return "*string"
+	}
+
+	return "interface{}"
+}
+
+// goFieldName converts a property like productNumber or custom_field_1 into an exported Go field name.
+func goFieldName(name string) string {
+	var field strings.Builder
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		field.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	if field.Len() == 0 {
+		return "Field"
+	}
+
+	if first := field.String()[0]; first >= '0' && first <= '9' {
+		return "F" + field.String()
+	}
+
+	return field.String()
+}
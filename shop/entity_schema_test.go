@@ -0,0 +1,128 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEntitySchema() EntitySchema {
+	return EntitySchema{
+		"product": {
+			Entity: "product",
+			Properties: map[string]EntityProperty{
+				"id":            {Type: "uuid", Flags: map[string]interface{}{"primary_key": true, "required": true}},
+				"productNumber": {Type: "string", Flags: map[string]interface{}{"required": true}},
+				"stock":         {Type: "int"},
+				"active":        {Type: "boolean"},
+				"releaseDate":   {Type: "date"},
+				"customFields":  {Type: "json_object"},
+				"manufacturer":  {Type: "association", Relation: "many_to_one", Entity: "product_manufacturer"},
+				"categories":    {Type: "association", Relation: "many_to_many", Entity: "category"},
+			},
+		},
+		"product_manufacturer": {
+			Entity: "product_manufacturer",
+			Properties: map[string]EntityProperty{
+				"id":   {Type: "uuid", Flags: map[string]interface{}{"primary_key": true}},
+				"name": {Type: "string"},
+			},
+		},
+	}
+}
+
+func TestEntityTypeName(t *testing.T) {
+	assert.Equal(t, "ProductManufacturer", EntityTypeName("product_manufacturer"))
+	assert.Equal(t, "SwagPaypalPosSalesChannel", EntityTypeName("swag_paypal_pos_sales_channel"))
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	content := string(GenerateTypeScript(testEntitySchema(), map[string][]CustomField{
+		"product": {{Name: "custom_product_color", Type: "text"}, {Name: "custom_product_weight", Type: "float"}},
+	}))
+
+	assert.Contains(t, content, `export interface ProductCustomFields {
+    "custom_product_color"?: string;
+    "custom_product_weight"?: number;
+    [key: string]: unknown;
+}`)
+	assert.Contains(t, content, `export interface Product {
+    active?: boolean;
+    categories?: Record<string, unknown>[];
+    customFields?: ProductCustomFields;
+    id: string;
+    manufacturer?: ProductManufacturer;
+    productNumber: string;
+    releaseDate?: string;
+    stock?: number;
+}`)
+	assert.Contains(t, content, "export interface ProductManufacturer {")
+}
+
+func TestGenerateGo(t *testing.T) {
+	content, err := GenerateGo(testEntitySchema(), map[string][]CustomField{
+		"product": {{Name: "custom_product_color", Type: "text"}},
+	}, "entities")
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package entities")
+	assert.Contains(t, string(content), `import "time"`)
+	assert.Contains(t, string(content), "CustomProductColor *string `json:\"custom_product_color,omitempty\"`")
+	assert.Contains(t, string(content), "ProductNumber string                   `json:\"productNumber,omitempty\"`")
+	assert.Contains(t, string(content), "Stock         *int64")
+	assert.Contains(t, string(content), "Manufacturer  *ProductManufacturer")
+	assert.Contains(t, string(content), "Categories    []map[string]interface{}")
+	assert.Contains(t, string(content), "CustomFields  *ProductCustomFields")
+	assert.Contains(t, string(content), "ReleaseDate   *time.Time")
+}
+
+func TestEntitySchemaFilter(t *testing.T) {
+	filtered, err := testEntitySchema().Filter([]string{"product_manufacturer"})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+
+	_, err = testEntitySchema().Filter([]string{"missing"})
+	assert.ErrorContains(t, err, "entity missing does not exist")
+}
+
+func TestFetchCustomFields(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		assert.Equal(t, "/api/search/custom-field-set", r.URL.Path)
+		assert.Contains(t, body["associations"], "relations")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [{"customFields": [{"name": "b", "type": "int"}, {"name": "a", "type": "text"}], "relations": [{"entityName": "product"}, {"entityName": "category"}]}]}`))
+	})
+
+	customFields, err := FetchCustomFields(adminSdk.NewApiContext(context.Background()), client)
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomField{{Name: "a", Type: "text"}, {Name: "b", Type: "int"}}, customFields["product"])
+	assert.Len(t, customFields["category"], 2)
+}
+
+func TestFetchCustomFieldsPaginates(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		sets := make([]string, 0)
+
+		if body["page"] == float64(1) {
+			for i := 0; i < customFieldSetPageSize; i++ {
+				sets = append(sets, fmt.Sprintf(`{"customFields": [{"name": "field_%d", "type": "int"}], "relations": [{"entityName": "product"}]}`, i))
+			}
+		} else {
+			assert.Equal(t, float64(2), body["page"])
+			sets = append(sets, `{"customFields": [{"name": "last", "type": "text"}], "relations": [{"entityName": "product"}]}`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [` + strings.Join(sets, ",") + `]}`))
+	})
+
+	customFields, err := FetchCustomFields(adminSdk.NewApiContext(context.Background()), client)
+	assert.NoError(t, err)
+	assert.Len(t, customFields["product"], customFieldSetPageSize+1)
+}
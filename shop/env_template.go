@@ -0,0 +1,119 @@
+package shop
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	envVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	envPlainValueRegex   = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+	envNameRegex         = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ConfigEnvVariable is a variable of the env file template used by project env init.
+type ConfigEnvVariable struct {
+	Name string `yaml:"name"`
+	// Value can contain the placeholders {environment} and {url}, variables without value are prompted
+	Value string `yaml:"value,omitempty"`
+	// Description is written as comment above the variable and can contain the same placeholders as Value
+	Description string `yaml:"description,omitempty"`
+	// Secret hides the input of the prompt, the value should never be stored in the project config
+	Secret bool `yaml:"secret,omitempty"`
+	// Generate creates a random value like for APP_SECRET
+	Generate bool `yaml:"generate,omitempty"`
+}
+
+// EnvPrompt asks for the value of a variable, which has no value in the template and was not passed.
+type EnvPrompt func(variable ConfigEnvVariable) (string, error)
+
+// EnvFileName returns the name of the env file of the environment like .env.staging. The name of the environment is
+// restricted to letters, digits, underscores and dashes, so the file cannot be written outside of the project.
+func EnvFileName(environment string) (string, error) {
+	if !envNameRegex.MatchString(environment) {
+		return "", fmt.Errorf("invalid environment %q, only letters, digits, underscores and dashes are allowed", environment)
+	}
+
+	return ".env." + environment, nil
+}
+
+// RenderEnvFile returns the content of the env file for the environment. Passed values win over the template, then
+// configured values are used, generated values are created and the remaining variables are prompted.
+func RenderEnvFile(template []ConfigEnvVariable, environment, url string, values map[string]string, prompt EnvPrompt) (string, error) {
+	if len(template) == 0 {
+		return "", fmt.Errorf("no env_template configured in .shopware-project.yml")
+	}
+
+	known := make(map[string]bool)
+
+	for _, variable := range template {
+		if !envVariableNameRegex.MatchString(variable.Name) {
+			return "", fmt.Errorf("invalid variable name %q in env_template", variable.Name)
+		}
+
+		if known[variable.Name] {
+			return "", fmt.Errorf("variable %s is defined twice in env_template", variable.Name)
+		}
+
+		known[variable.Name] = true
+	}
+
+	for name := range values {
+		if !known[name] {
+			return "", fmt.Errorf("variable %s is not defined in env_template", name)
+		}
+	}
+
+	placeholders := strings.NewReplacer("{environment}", environment, "{url}", url)
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# Generated by shopware-cli project env init for the environment %s\n\n", environment))
+
+	for _, variable := range template {
+		value, ok := values[variable.Name]
+		variable.Description = placeholders.Replace(variable.Description)
+
+		switch {
+		case ok:
+		case variable.Value != "":
+			value = placeholders.Replace(variable.Value)
+		case variable.Generate:
+			random := make([]byte, 32)
+			if _, err := rand.Read(random); err != nil {
+				return "", err
+			}
+
+			value = hex.EncodeToString(random)
+		default:
+			var err error
+			if value, err = prompt(variable); err != nil {
+				return "", err
+			}
+		}
+
+		if variable.Description != "" {
+			content.WriteString(fmt.Sprintf("\n# %s\n", variable.Description))
+		}
+
+		content.WriteString(fmt.Sprintf("%s=%s\n", variable.Name, quoteEnvValue(value)))
+	}
+
+	return content.String(), nil
+}
+
+// quoteEnvValue quotes values for the Symfony Dotenv component. Single quotes keep the value literal, values containing
+// a single quote are double-quoted with escaped variable references.
+func quoteEnvValue(value string) string {
+	if envPlainValueRegex.MatchString(value) {
+		return value
+	}
+
+	if !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`).Replace(value) + `"`
+}
@@ -0,0 +1,75 @@
+package shop
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEnvFile(t *testing.T) {
+	template := []ConfigEnvVariable{
+		{Name: "APP_ENV", Value: "prod"},
+		{Name: "APP_URL", Value: "{url}", Description: "URL of the {environment} shop"},
+		{Name: "APP_SECRET", Generate: true},
+		{Name: "DATABASE_URL", Secret: true},
+		{Name: "MAILER_DSN"},
+		{Name: "SHOPWARE_HTTP_CACHE_ENABLED", Value: "1"},
+	}
+
+	prompted := make([]string, 0)
+	prompt := func(variable ConfigEnvVariable) (string, error) {
+		prompted = append(prompted, variable.Name)
+		return "mysql://shop:p@ss word@db/shop", nil
+	}
+
+	content, err := RenderEnvFile(template, "staging", "https://staging.example.com", map[string]string{"MAILER_DSN": "null://null", "SHOPWARE_HTTP_CACHE_ENABLED": "0"}, prompt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"DATABASE_URL"}, prompted)
+	assert.Contains(t, content, "# Generated by shopware-cli project env init for the environment staging\n")
+	assert.Contains(t, content, "\nAPP_ENV=prod\n")
+	assert.Contains(t, content, "\n# URL of the staging shop\nAPP_URL=https://staging.example.com\n")
+	assert.Contains(t, content, "\nDATABASE_URL='mysql://shop:p@ss word@db/shop'\n")
+	assert.Contains(t, content, "\nMAILER_DSN=null://null\n")
+	assert.Contains(t, content, "\nSHOPWARE_HTTP_CACHE_ENABLED=0\n")
+	assert.Regexp(t, `\nAPP_SECRET=[0-9a-f]{64}\n`, content)
+	assert.Less(t, strings.Index(content, "APP_ENV"), strings.Index(content, "SHOPWARE_HTTP_CACHE_ENABLED"))
+}
+
+func TestRenderEnvFileErrors(t *testing.T) {
+	prompt := func(variable ConfigEnvVariable) (string, error) {
+		return "", fmt.Errorf("cannot prompt for %s", variable.Name)
+	}
+
+	_, err := RenderEnvFile(nil, "staging", "", nil, prompt)
+	assert.EqualError(t, err, "no env_template configured in .shopware-project.yml")
+
+	_, err = RenderEnvFile([]ConfigEnvVariable{{Name: "APP-ENV"}}, "staging", "", nil, prompt)
+	assert.EqualError(t, err, `invalid variable name "APP-ENV" in env_template`)
+
+	_, err = RenderEnvFile([]ConfigEnvVariable{{Name: "APP_ENV", Value: "prod"}}, "staging", "", map[string]string{"APP_DEBUG": "1"}, prompt)
+	assert.EqualError(t, err, "variable APP_DEBUG is not defined in env_template")
+
+	_, err = RenderEnvFile([]ConfigEnvVariable{{Name: "DATABASE_URL", Secret: true}}, "staging", "", nil, prompt)
+	assert.EqualError(t, err, "cannot prompt for DATABASE_URL")
+}
+
+func TestEnvFileName(t *testing.T) {
+	name, err := EnvFileName("staging_2-eu")
+	assert.NoError(t, err)
+	assert.Equal(t, ".env.staging_2-eu", name)
+
+	for _, environment := range []string{"", "../x", "a/b", "prod.local"} {
+		_, err := EnvFileName(environment)
+		assert.Error(t, err, environment)
+	}
+}
+
+func TestQuoteEnvValue(t *testing.T) {
+	assert.Equal(t, "", quoteEnvValue(""))
+	assert.Equal(t, "redis://localhost:6379/0", quoteEnvValue("redis://localhost:6379/0"))
+	assert.Equal(t, "'a b$c'", quoteEnvValue("a b$c"))
+	assert.Equal(t, `"it's \$HOME \"x\""`, quoteEnvValue(`it's $HOME "x"`))
+}
@@ -0,0 +1,99 @@
+package shop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/FriendsOfShopware/shopware-cli/logging"
+)
+
+// EnvironmentNames returns the default environment followed by the configured environments sorted by name.
+func (c *Config) EnvironmentNames() []string {
+	names := make([]string, 0, len(c.Environments)+1)
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return append([]string{DefaultEnvironment}, names...)
+}
+
+// EnvironmentResult is the outcome of a command in one environment.
+type EnvironmentResult struct {
+	Environment string
+	URL         string
+	Duration    time.Duration
+	Error       error
+}
+
+// RunForEnvironments runs fn for the given environments with the given concurrency. All environments are resolved before,
+// so a typo does not stop the run halfway. The results are in the order of the names and the log messages of fn are
+// tagged with the environment.
+func RunForEnvironments(ctx context.Context, c *Config, names []string, concurrency int, fn func(ctx context.Context, name string, cfg *Config) error) ([]EnvironmentResult, error) {
+	configs := make([]*Config, 0, len(names))
+
+	for _, name := range names {
+		envCfg, err := c.ForEnvironment(name)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, envCfg)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]EnvironmentResult, len(names))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := range names {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			envCtx := logging.WithLogger(ctx, logging.FromContext(ctx).With("environment", names[i]))
+			start := time.Now()
+
+			results[i] = EnvironmentResult{
+				Environment: names[i],
+				URL:         configs[i].URL,
+				Error:       fn(envCtx, names[i], configs[i]),
+				Duration:    time.Since(start),
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// EnvironmentResultsError joins the errors of the failed environments, it returns nil when all succeeded.
+func EnvironmentResultsError(results []EnvironmentResult) error {
+	errs := make([]error, 0)
+
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Environment, result.Error))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d environments failed: %w", len(errs), len(results), errors.Join(errs...))
+}
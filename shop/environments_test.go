@@ -0,0 +1,63 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunForEnvironments(t *testing.T) {
+	cfg := &Config{
+		URL: "http://localhost",
+		Environments: map[string]ConfigEnvironment{
+			"staging":    {URL: "https://staging.example.com"},
+			"production": {URL: "https://example.com"},
+		},
+	}
+
+	assert.Equal(t, []string{DefaultEnvironment, "production", "staging"}, cfg.EnvironmentNames())
+
+	var running, maxRunning atomic.Int32
+
+	results, err := RunForEnvironments(context.Background(), cfg, cfg.EnvironmentNames(), 2, func(_ context.Context, name string, envCfg *Config) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		if name == "staging" {
+			return fmt.Errorf("cannot reach %s", envCfg.URL)
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+	assert.Len(t, results, 3)
+	assert.Equal(t, "https://example.com", results[1].URL)
+	assert.NoError(t, results[1].Error)
+	assert.EqualError(t, results[2].Error, "cannot reach https://staging.example.com")
+	assert.EqualError(t, EnvironmentResultsError(results), "1 of 3 environments failed: staging: cannot reach https://staging.example.com")
+
+	assert.NoError(t, EnvironmentResultsError(results[:2]))
+}
+
+func TestRunForEnvironmentsUnknown(t *testing.T) {
+	cfg := &Config{URL: "http://localhost"}
+
+	_, err := RunForEnvironments(context.Background(), cfg, []string{"production"}, 1, func(context.Context, string, *Config) error {
+		t.Fail()
+		return nil
+	})
+
+	assert.ErrorContains(t, err, "environment production is not configured")
+}
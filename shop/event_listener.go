@@ -0,0 +1,92 @@
+package shop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// eventWebhookPrefix marks the webhooks of the event listener, so leftovers of an aborted run can be recognized.
+const eventWebhookPrefix = "shopware-cli-listen-"
+
+// RegisterEventWebhooks creates a webhook for each business event which sends the event to the given URL.
+func RegisterEventWebhooks(ctx adminSdk.ApiContext, client *adminSdk.Client, events []string, url string) ([]string, error) {
+	ids := make([]string, 0, len(events))
+
+	for _, event := range events {
+		id := NewUuid()
+
+		payload := map[string]interface{}{
+			"id":        id,
+			"name":      eventWebhookPrefix + event,
+			"eventName": event,
+			"url":       url,
+			"active":    true,
+		}
+
+		if err := adminRequest(ctx, client, "POST", "/api/webhook", payload, nil, nil); err != nil {
+			// remove the already registered webhooks again
+			_ = DeleteEventWebhooks(ctx, client, ids)
+
+			return nil, fmt.Errorf("cannot register webhook for %s: %w", event, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// DeleteEventWebhooks removes the webhooks created by RegisterEventWebhooks.
+func DeleteEventWebhooks(ctx adminSdk.ApiContext, client *adminSdk.Client, ids []string) error {
+	for _, id := range ids {
+		if err := adminRequest(ctx, client, "DELETE", fmt.Sprintf("/api/webhook/%s", id), nil, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewEventPrintHandler returns a handler which accepts the webhook calls of Shopware and prints the payloads indented to out.
+func NewEventPrintHandler(out io.Writer) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var event struct {
+			Data struct {
+				Event string `json:"event"`
+			} `json:"data"`
+		}
+
+		_ = json.Unmarshal(body, &event)
+
+		if event.Data.Event == "" {
+			event.Data.Event = "unknown event"
+		}
+
+		var formatted bytes.Buffer
+		if err := json.Indent(&formatted, body, "", "  "); err != nil {
+			formatted.Reset()
+			formatted.Write(body)
+		}
+
+		mu.Lock()
+		fmt.Fprintf(out, "[%s] %s\n%s\n\n", time.Now().Format(time.TimeOnly), event.Data.Event, formatted.String())
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,64 @@
+package shop
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterEventWebhooks(t *testing.T) {
+	created := make([]map[string]interface{}, 0)
+	deleted := make([]string, 0)
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/webhook":
+			if body["eventName"] == "broken.event" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors": []}`))
+
+				return
+			}
+
+			created = append(created, body)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/webhook/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/webhook/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	apiCtx := adminSdk.NewApiContext(context.Background())
+
+	ids, err := RegisterEventWebhooks(apiCtx, client, []string{"checkout.order.placed"}, "http://localhost:8888")
+	assert.NoError(t, err)
+	assert.Len(t, ids, 1)
+	assert.Equal(t, "shopware-cli-listen-checkout.order.placed", created[0]["name"])
+	assert.Equal(t, "http://localhost:8888", created[0]["url"])
+
+	assert.NoError(t, DeleteEventWebhooks(apiCtx, client, ids))
+	assert.Equal(t, ids, deleted)
+
+	// a failing registration removes the already registered webhooks
+	_, err = RegisterEventWebhooks(apiCtx, client, []string{"customer.register", "broken.event"}, "http://localhost:8888")
+	assert.ErrorContains(t, err, "cannot register webhook for broken.event")
+	assert.Len(t, deleted, 2)
+}
+
+func TestEventPrintHandler(t *testing.T) {
+	var out bytes.Buffer
+
+	rec := httptest.NewRecorder()
+	NewEventPrintHandler(&out).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"data":{"event":"checkout.order.placed","payload":{"id":"1"}}}`)))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, out.String(), "checkout.order.placed\n{\n  \"data\": {")
+}
@@ -0,0 +1,165 @@
+package shop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// shopwareStoreApiURL is the store API the administration uses to check the extensions before a Shopware update.
+var shopwareStoreApiURL = "https://api.shopware.com"
+
+const (
+	ExtensionCompatible      = "compatible"
+	ExtensionNotCompatible   = "notCompatible"
+	ExtensionUpdatableNow    = "updatableNow"
+	ExtensionUpdatableFuture = "updatableFuture"
+	ExtensionNotInStore      = "notInStore"
+	// ExtensionUnknown is used for all extensions, when the store could not be asked
+	ExtensionUnknown = "unknown"
+)
+
+// extensionCompatibilityLabels describe the store status for the table output.
+var extensionCompatibilityLabels = map[string]string{
+	ExtensionCompatible:      "compatible",
+	ExtensionNotCompatible:   "not compatible",
+	ExtensionUpdatableNow:    "compatible after update",
+	ExtensionUpdatableFuture: "compatible version announced",
+	ExtensionNotInStore:      "unknown, not in store",
+	ExtensionUnknown:         "unknown, store not reachable",
+}
+
+type ExtensionCompatibility struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Active        bool   `json:"active"`
+	Version       string `json:"version"`
+	LatestVersion string `json:"latestVersion"`
+	UpdateSource  string `json:"updateSource"`
+	Outdated      bool   `json:"outdated"`
+	TargetVersion string `json:"targetVersion"`
+	Compatibility string `json:"compatibility"`
+}
+
+type storeCompatibilityResult struct {
+	Name   string `json:"name"`
+	Status struct {
+		Name string `json:"name"`
+	} `json:"status"`
+}
+
+// CheckExtensionCompatibility lists the installed extensions with their latest version and asks the Shopware store,
+// whether they support the target version of a shop running currentVersion. When the store cannot be asked, the list is
+// returned with an unknown compatibility together with the error.
+func CheckExtensionCompatibility(ctx context.Context, extensions adminSdk.ExtensionList, currentVersion, targetVersion string) ([]ExtensionCompatibility, error) {
+	result := make([]ExtensionCompatibility, 0)
+	plugins := make([]map[string]string, 0)
+
+	for _, extension := range extensions {
+		if extension.InstalledAt == nil {
+			continue
+		}
+
+		result = append(result, ExtensionCompatibility{
+			Name:          extension.Name,
+			Type:          extension.Type,
+			Active:        extension.Active,
+			Version:       extension.Version,
+			LatestVersion: extension.LatestVersion,
+			UpdateSource:  extension.UpdateSource,
+			Outdated:      extension.IsUpdateAble(),
+			TargetVersion: targetVersion,
+			Compatibility: ExtensionNotInStore,
+		})
+
+		plugins = append(plugins, map[string]string{"name": extension.Name, "version": extension.Version})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	if len(plugins) == 0 {
+		return result, nil
+	}
+
+	statuses, err := fetchStoreCompatibility(ctx, currentVersion, targetVersion, plugins)
+	if err != nil {
+		for i := range result {
+			result[i].Compatibility = ExtensionUnknown
+		}
+
+		return result, err
+	}
+
+	for i := range result {
+		if status, ok := statuses[result[i].Name]; ok {
+			result[i].Compatibility = status
+		}
+	}
+
+	return result, nil
+}
+
+// Label returns the compatibility readable for humans.
+func (e ExtensionCompatibility) Label() string {
+	if label, ok := extensionCompatibilityLabels[e.Compatibility]; ok {
+		return label
+	}
+
+	return e.Compatibility
+}
+
+func fetchStoreCompatibility(ctx context.Context, currentVersion, targetVersion string, plugins []map[string]string) (map[string]string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"futureShopwareVersion": targetVersion,
+		"plugins":               plugins,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("shopwareVersion", currentVersion)
+	query.Set("language", "en-GB")
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, shopwareStoreApiURL+"/swplatform/autoupdate?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check the compatibility in the store: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot check the compatibility in the store: status %d", resp.StatusCode)
+	}
+
+	var results []storeCompatibilityResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("cannot parse the compatibility of the store: %w", err)
+	}
+
+	statuses := make(map[string]string)
+
+	for _, result := range results {
+		statuses[result.Name] = result.Status.Name
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,100 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckExtensionCompatibility(t *testing.T) {
+	var request struct {
+		FutureShopwareVersion string              `json:"futureShopwareVersion"`
+		Plugins               []map[string]string `json:"plugins"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/swplatform/autoupdate", r.URL.Path)
+		assert.Equal(t, "6.5.8.2", r.URL.Query().Get("shopwareVersion"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		_, _ = w.Write([]byte(`[{"name": "FroshTools", "status": {"name": "updatableNow"}}, {"name": "SwagPayPal", "status": {"name": "compatible"}}]`))
+	}))
+
+	defer server.Close()
+
+	previousURL := shopwareStoreApiURL
+	shopwareStoreApiURL = server.URL
+
+	defer func() {
+		shopwareStoreApiURL = previousURL
+	}()
+
+	installedAt := &struct {
+		Date         string `json:"date"`
+		TimezoneType int    `json:"timezone_type"`
+		Timezone     string `json:"timezone"`
+	}{Date: "2023-01-01 00:00:00"}
+
+	extensions := adminSdk.ExtensionList{
+		{Name: "SwagPayPal", Type: "plugin", Version: "8.0.0", InstalledAt: installedAt, Active: true},
+		{Name: "FroshTools", Type: "plugin", Version: "1.0.0", LatestVersion: "2.0.0", UpdateSource: "store", InstalledAt: installedAt},
+		{Name: "MyCustomTheme", Type: "plugin", Version: "1.0.0", InstalledAt: installedAt},
+		{Name: "SwagStoreOnly", Type: "plugin", Version: "1.0.0", Source: "store"},
+	}
+
+	report, err := CheckExtensionCompatibility(context.Background(), extensions, "6.5.8.2", "6.6.0.0")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "6.6.0.0", request.FutureShopwareVersion)
+	assert.Len(t, request.Plugins, 3)
+
+	assert.Equal(t, []ExtensionCompatibility{
+		{Name: "FroshTools", Type: "plugin", Version: "1.0.0", LatestVersion: "2.0.0", UpdateSource: "store", Outdated: true, TargetVersion: "6.6.0.0", Compatibility: ExtensionUpdatableNow},
+		{Name: "MyCustomTheme", Type: "plugin", Version: "1.0.0", TargetVersion: "6.6.0.0", Compatibility: ExtensionNotInStore},
+		{Name: "SwagPayPal", Type: "plugin", Active: true, Version: "8.0.0", TargetVersion: "6.6.0.0", Compatibility: ExtensionCompatible},
+	}, report)
+
+	assert.Equal(t, "compatible after update", report[0].Label())
+}
+
+func TestCheckExtensionCompatibilityWithoutExtensions(t *testing.T) {
+	report, err := CheckExtensionCompatibility(context.Background(), adminSdk.ExtensionList{}, "6.5.8.2", "6.6.0.0")
+
+	assert.NoError(t, err)
+	assert.Empty(t, report)
+}
+
+func TestCheckExtensionCompatibilityStoreUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	defer server.Close()
+
+	previousURL := shopwareStoreApiURL
+	shopwareStoreApiURL = server.URL
+
+	defer func() {
+		shopwareStoreApiURL = previousURL
+	}()
+
+	extensions := adminSdk.ExtensionList{
+		{Name: "FroshTools", Type: "plugin", Version: "1.0.0", LatestVersion: "2.0.0", InstalledAt: &struct {
+			Date         string `json:"date"`
+			TimezoneType int    `json:"timezone_type"`
+			Timezone     string `json:"timezone"`
+		}{Date: "2023-01-01 00:00:00"}},
+	}
+
+	report, err := CheckExtensionCompatibility(context.Background(), extensions, "6.5.8.2", "6.6.0.0")
+	assert.EqualError(t, err, "cannot check the compatibility in the store: status 502")
+
+	assert.Len(t, report, 1)
+	assert.True(t, report[0].Outdated)
+	assert.Equal(t, "unknown, store not reachable", report[0].Label())
+}
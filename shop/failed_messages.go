@@ -0,0 +1,192 @@
+package shop
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FailedMessage is a message of the messenger failure transport stored in the messenger_messages table.
+type FailedMessage struct {
+	ID                int64     `json:"id"`
+	Class             string    `json:"class"`
+	Error             string    `json:"error"`
+	OriginalTransport string    `json:"originalTransport"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+var (
+	serializedMessageClass = regexp.MustCompile(`Envelope\x00message";O:\d+:"([^"]+)"`)
+	serializedExceptionMsg = regexp.MustCompile(`exceptionMessage";s:(\d+):"`)
+	serializedReceiverName = regexp.MustCompile(`originalReceiverName";s:\d+:"([^"]+)"`)
+)
+
+// ParseFailedMessage reads the message class, the last error and the original transport from the stored envelope.
+// Both the PHP serializer of Shopware and the Symfony serializer with json headers are supported.
+func ParseFailedMessage(id int64, body, headers string, createdAt time.Time) FailedMessage {
+	message := FailedMessage{ID: id, CreatedAt: createdAt}
+
+	var jsonHeaders map[string]string
+	if err := json.Unmarshal([]byte(headers), &jsonHeaders); err == nil && jsonHeaders["type"] != "" {
+		message.Class = jsonHeaders["type"]
+
+		var errorDetails []struct {
+			ExceptionMessage string `json:"exceptionMessage"`
+		}
+
+		if err := json.Unmarshal([]byte(jsonHeaders["X-Message-Stamp-Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp"]), &errorDetails); err == nil && len(errorDetails) > 0 {
+			message.Error = errorDetails[len(errorDetails)-1].ExceptionMessage
+		}
+
+		var failureStamps []struct {
+			OriginalReceiverName string `json:"originalReceiverName"`
+		}
+
+		if err := json.Unmarshal([]byte(jsonHeaders["X-Message-Stamp-Symfony\\Component\\Messenger\\Stamp\\SentToFailureTransportStamp"]), &failureStamps); err == nil && len(failureStamps) > 0 {
+			message.OriginalTransport = failureStamps[0].OriginalReceiverName
+		}
+
+		return message
+	}
+
+	serialized := phpStripSlashes(body)
+
+	if match := serializedMessageClass.FindStringSubmatch(serialized); match != nil {
+		message.Class = match[1]
+	}
+
+	// the last error details stamp contains the latest exception
+	if matches := serializedExceptionMsg.FindAllStringSubmatchIndex(serialized, -1); len(matches) > 0 {
+		last := matches[len(matches)-1]
+		length, _ := strconv.Atoi(serialized[last[2]:last[3]])
+
+		if last[1]+length <= len(serialized) {
+			message.Error = serialized[last[1] : last[1]+length]
+		}
+	}
+
+	if match := serializedReceiverName.FindStringSubmatch(serialized); match != nil {
+		message.OriginalTransport = match[1]
+	}
+
+	return message
+}
+
+// transportQueues maps the doctrine transports of Shopware to their queue names. Symfony uses the queue "default" for
+// transports without a configured queue name.
+var transportQueues = map[string]string{
+	"async":        "default",
+	"low_priority": "low_priority",
+}
+
+// RetryQueue returns the queue of the transport the message failed on, so a worker of that transport handles it again.
+func (m FailedMessage) RetryQueue() string {
+	if queue, ok := transportQueues[m.OriginalTransport]; ok {
+		return queue
+	}
+
+	return "default"
+}
+
+// phpStripSlashes reverts addslashes of the PHP serializer.
+func phpStripSlashes(value string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			builder.WriteByte(value[i])
+			continue
+		}
+
+		i++
+
+		if value[i] == '0' {
+			builder.WriteByte(0)
+		} else {
+			builder.WriteByte(value[i])
+		}
+	}
+
+	return builder.String()
+}
+
+// FailedMessageFilter selects failed messages, empty fields match all messages.
+type FailedMessageFilter struct {
+	IDs   []int64
+	Class string
+	Error string
+}
+
+func (f FailedMessageFilter) Matches(message FailedMessage) bool {
+	if len(f.IDs) > 0 {
+		found := false
+
+		for _, id := range f.IDs {
+			if id == message.ID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.Class != "" && !strings.Contains(strings.ToLower(message.Class), strings.ToLower(f.Class)) {
+		return false
+	}
+
+	return f.Error == "" || strings.Contains(strings.ToLower(message.Error), strings.ToLower(f.Error))
+}
+
+// ListFailedMessages returns the messages of the given queue of the doctrine transport matching the filter.
+func ListFailedMessages(ctx context.Context, db *sql.DB, queue string, filter FailedMessageFilter) ([]FailedMessage, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, body, headers, created_at FROM messenger_messages WHERE queue_name = ? ORDER BY id", queue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the failed messages: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	messages := make([]FailedMessage, 0)
+
+	for rows.Next() {
+		var id int64
+		var body, headers, createdAt string
+
+		if err := rows.Scan(&id, &body, &headers, &createdAt); err != nil {
+			return nil, err
+		}
+
+		created, _ := time.Parse(time.DateTime, createdAt)
+		message := ParseFailedMessage(id, body, headers, created)
+
+		if filter.Matches(message) {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, rows.Err()
+}
+
+// RetryFailedMessage moves the message back into the given queue, so the next worker of that queue handles it again.
+func RetryFailedMessage(ctx context.Context, db *sql.DB, id int64, queue string) error {
+	_, err := db.ExecContext(ctx, "UPDATE messenger_messages SET queue_name = ?, available_at = UTC_TIMESTAMP(), delivered_at = NULL WHERE id = ?", queue, id)
+
+	return err
+}
+
+// DiscardFailedMessage deletes the message.
+func DiscardFailedMessage(ctx context.Context, db *sql.DB, id int64) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM messenger_messages WHERE id = ?", id)
+
+	return err
+}
@@ -0,0 +1,56 @@
+package shop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFailedMessagePhpSerializer(t *testing.T) {
+	// addslashes(serialize($envelope)) as written by the PHP serializer of the doctrine transport, shortened
+	body := `O:36:\"Symfony\\Component\\Messenger\\Envelope\":2:{s:44:\"\0Symfony\\Component\\Messenger\\Envelope\0stamps\";a:2:{` +
+		`s:56:\"Symfony\\Component\\Messenger\\Stamp\\SentToFailureTransportStamp\";a:1:{i:0;O:56:\"Symfony\\Component\\Messenger\\Stamp\\SentToFailureTransportStamp\":1:{s:78:\"\0Symfony\\Component\\Messenger\\Stamp\\SentToFailureTransportStamp\0originalReceiverName\";s:5:\"async\";}}` +
+		`s:46:\"Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp\";a:2:{` +
+		`i:0;O:46:\"Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp\":1:{s:64:\"\0Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp\0exceptionMessage\";s:13:\"first failure\";}` +
+		`i:1;O:46:\"Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp\":1:{s:64:\"\0Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp\0exceptionMessage\";s:25:\"Product \"abc\" was deleted\";}}}` +
+		`s:45:\"\0Symfony\\Component\\Messenger\\Envelope\0message\";O:54:\"Shopware\\Core\\Content\\Media\\Message\\GenerateThumbnailsMessage\":0:{}}`
+
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	message := ParseFailedMessage(42, body, "[]", created)
+
+	assert.Equal(t, FailedMessage{
+		ID:                42,
+		Class:             "Shopware\\Core\\Content\\Media\\Message\\GenerateThumbnailsMessage",
+		Error:             `Product "abc" was deleted`,
+		OriginalTransport: "async",
+		CreatedAt:         created,
+	}, message)
+}
+
+func TestParseFailedMessageJsonHeaders(t *testing.T) {
+	headers := `{"type": "Shopware\\Core\\Framework\\Webhook\\Message\\WebhookEventMessage", "X-Message-Stamp-Symfony\\Component\\Messenger\\Stamp\\ErrorDetailsStamp": "[{\"exceptionMessage\": \"timeout\"}]", "X-Message-Stamp-Symfony\\Component\\Messenger\\Stamp\\SentToFailureTransportStamp": "[{\"originalReceiverName\": \"low_priority\"}]"}`
+
+	message := ParseFailedMessage(1, "{}", headers, time.Time{})
+
+	assert.Equal(t, "Shopware\\Core\\Framework\\Webhook\\Message\\WebhookEventMessage", message.Class)
+	assert.Equal(t, "timeout", message.Error)
+	assert.Equal(t, "low_priority", message.OriginalTransport)
+}
+
+func TestFailedMessageFilter(t *testing.T) {
+	message := FailedMessage{ID: 3, Class: "Shopware\\Core\\Content\\Media\\Message\\GenerateThumbnailsMessage", Error: "File not found"}
+
+	assert.True(t, FailedMessageFilter{}.Matches(message))
+	assert.True(t, FailedMessageFilter{IDs: []int64{1, 3}, Class: "thumbnails", Error: "not found"}.Matches(message))
+	assert.False(t, FailedMessageFilter{IDs: []int64{1}}.Matches(message))
+	assert.False(t, FailedMessageFilter{Class: "Webhook"}.Matches(message))
+	assert.False(t, FailedMessageFilter{Error: "timeout"}.Matches(message))
+}
+
+func TestFailedMessageRetryQueue(t *testing.T) {
+	assert.Equal(t, "default", FailedMessage{OriginalTransport: "async"}.RetryQueue())
+	assert.Equal(t, "low_priority", FailedMessage{OriginalTransport: "low_priority"}.RetryQueue())
+	assert.Equal(t, "default", FailedMessage{OriginalTransport: "custom"}.RetryQueue())
+	assert.Equal(t, "default", FailedMessage{}.RetryQueue())
+}
@@ -0,0 +1,176 @@
+package shop
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Fleet is a list of shops maintained together, f.e. all shops of an agency.
+type Fleet struct {
+	Shops []FleetShop `yaml:"shops"`
+}
+
+type FleetShop struct {
+	Name     string          `yaml:"name"`
+	URL      string          `yaml:"url"`
+	AdminApi *ConfigAdminApi `yaml:"admin_api"`
+}
+
+// ReadFleet reads a fleet file, ${VAR} placeholders are substituted with environment variables to keep the credentials out of the file.
+func ReadFleet(fileName string) (*Fleet, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFleet: %w", err)
+	}
+
+	var fleet Fleet
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(content))), &fleet); err != nil {
+		return nil, fmt.Errorf("ReadFleet: %w", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, fleetShop := range fleet.Shops {
+		if fleetShop.Name == "" || fleetShop.URL == "" {
+			return nil, fmt.Errorf("ReadFleet: every shop requires a name and url")
+		}
+
+		if seen[fleetShop.Name] {
+			return nil, fmt.Errorf("ReadFleet: shop %s is listed twice", fleetShop.Name)
+		}
+
+		seen[fleetShop.Name] = true
+	}
+
+	return &fleet, nil
+}
+
+// Config returns a project config with every shop as environment, so the shops can be processed with RunForEnvironments.
+func (f *Fleet) Config() (*Config, []string) {
+	cfg := &Config{Environments: make(map[string]ConfigEnvironment, len(f.Shops))}
+	names := make([]string, 0, len(f.Shops))
+
+	for _, fleetShop := range f.Shops {
+		cfg.Environments[fleetShop.Name] = ConfigEnvironment{URL: fleetShop.URL, AdminApi: fleetShop.AdminApi}
+		names = append(names, fleetShop.Name)
+	}
+
+	return cfg, names
+}
+
+type FleetShopStatus struct {
+	Name            string           `json:"name"`
+	URL             string           `json:"url"`
+	ShopwareVersion string           `json:"shopwareVersion"`
+	ShopwareUpdate  string           `json:"shopwareUpdate,omitempty"`
+	PHPVersion      string           `json:"phpVersion"`
+	Extensions      []FleetExtension `json:"extensions"`
+	PendingUpdates  int              `json:"pendingUpdates"`
+	Error           string           `json:"error,omitempty"`
+}
+
+type FleetExtension struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	LatestVersion string `json:"latestVersion"`
+	Active        bool   `json:"active"`
+}
+
+// CollectShopStatus fetches the Shopware and PHP version, the available Shopware update and the installed extensions of a shop.
+// Pending updates count the Shopware update and all updatable extensions.
+func CollectShopStatus(ctx adminSdk.ApiContext, client *adminSdk.Client, status *FleetShopStatus) error {
+	info, _, err := client.Info.Info(ctx)
+	if err != nil {
+		return err
+	}
+
+	status.ShopwareVersion = info.Version
+	status.PHPVersion = GetPHPVersion(ctx, client)
+	status.ShopwareUpdate = getShopwareUpdate(ctx, client)
+
+	if status.ShopwareUpdate != "" {
+		status.PendingUpdates++
+	}
+
+	extensions, _, err := client.ExtensionManager.ListAvailableExtensions(ctx)
+	if err != nil {
+		return err
+	}
+
+	status.Extensions = make([]FleetExtension, 0)
+
+	for _, extension := range extensions {
+		if extension.InstalledAt == nil {
+			continue
+		}
+
+		if extension.IsUpdateAble() {
+			status.PendingUpdates++
+		}
+
+		status.Extensions = append(status.Extensions, FleetExtension{
+			Name:          extension.Name,
+			Version:       extension.Version,
+			LatestVersion: extension.LatestVersion,
+			Active:        extension.Active,
+		})
+	}
+
+	sort.Slice(status.Extensions, func(i, j int) bool {
+		return status.Extensions[i].Name < status.Extensions[j].Name
+	})
+
+	return nil
+}
+
+// getShopwareUpdate returns the version of the available Shopware update, or an empty string when the shop is up to date
+// or the update check is not accessible.
+func getShopwareUpdate(ctx adminSdk.ApiContext, client *adminSdk.Client) string {
+	var update *struct {
+		Version string `json:"version"`
+	}
+
+	if err := adminRequest(ctx, client, "GET", "/api/_action/update/check", nil, &update, nil); err != nil || update == nil {
+		return ""
+	}
+
+	return update.Version
+}
+
+// WriteFleetStatusCSV writes one row per installed extension of each shop, shops without extensions or with an error get one row.
+func WriteFleetStatusCSV(w io.Writer, statuses []FleetShopStatus) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"shop", "url", "shopware_version", "shopware_update", "php_version", "pending_updates", "extension", "extension_version", "extension_latest_version", "extension_active", "error"}); err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		row := []string{status.Name, status.URL, status.ShopwareVersion, status.ShopwareUpdate, status.PHPVersion, strconv.Itoa(status.PendingUpdates)}
+
+		if len(status.Extensions) == 0 {
+			if err := writer.Write(append(row, "", "", "", "", status.Error)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, extension := range status.Extensions {
+			if err := writer.Write(append(row[:6:6], extension.Name, extension.Version, extension.LatestVersion, strconv.FormatBool(extension.Active), status.Error)); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,93 @@
+package shop
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFleet(t *testing.T) {
+	t.Setenv("FLEET_SECRET", "secret")
+
+	file := filepath.Join(t.TempDir(), "fleet.yml")
+	assert.NoError(t, os.WriteFile(file, []byte(`shops:
+  - name: customer-a
+    url: https://a.example.com
+    admin_api:
+      client_id: id
+      client_secret: ${FLEET_SECRET}
+  - name: customer-b
+    url: https://b.example.com
+`), os.ModePerm))
+
+	fleet, err := ReadFleet(file)
+	assert.NoError(t, err)
+	assert.Len(t, fleet.Shops, 2)
+	assert.Equal(t, "secret", fleet.Shops[0].AdminApi.ClientSecret)
+
+	cfg, names := fleet.Config()
+	assert.Equal(t, []string{"customer-a", "customer-b"}, names)
+
+	shopB, err := cfg.ForEnvironment("customer-b")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://b.example.com", shopB.URL)
+
+	assert.NoError(t, os.WriteFile(file, []byte(`shops:
+  - name: customer-a
+    url: https://a.example.com
+  - name: customer-a
+    url: https://b.example.com
+`), os.ModePerm))
+
+	_, err = ReadFleet(file)
+	assert.ErrorContains(t, err, "shop customer-a is listed twice")
+}
+
+func TestCollectShopStatus(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, _ map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/_info/config":
+			_, _ = w.Write([]byte(`{"version": "6.5.8.2"}`))
+		case "/api/_action/update/check-requirements":
+			_, _ = w.Write([]byte(`[{"name": "phpVersion", "vars": {"currentVersion": "8.2.12"}}]`))
+		case "/api/_action/update/check":
+			_, _ = w.Write([]byte(`{"version": "6.5.8.3"}`))
+		case "/api/_action/extension/installed":
+			_, _ = w.Write([]byte(`[
+				{"name": "SwagPayPal", "version": "8.0.0", "latestVersion": "8.1.0", "active": true, "installedAt": {"date": "2024-01-01"}},
+				{"name": "FroshTools", "version": "1.0.0", "latestVersion": "1.0.0", "active": false, "installedAt": {"date": "2024-01-01"}},
+				{"name": "NotInstalled", "version": "1.0.0", "latestVersion": "2.0.0"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	status := FleetShopStatus{Name: "customer-a", URL: "https://a.example.com"}
+	assert.NoError(t, CollectShopStatus(adminSdk.NewApiContext(context.Background()), client, &status))
+
+	assert.Equal(t, "6.5.8.2", status.ShopwareVersion)
+	assert.Equal(t, "6.5.8.3", status.ShopwareUpdate)
+	assert.Equal(t, "8.2.12", status.PHPVersion)
+	assert.Equal(t, 2, status.PendingUpdates)
+	assert.Equal(t, []FleetExtension{
+		{Name: "FroshTools", Version: "1.0.0", LatestVersion: "1.0.0"},
+		{Name: "SwagPayPal", Version: "8.0.0", LatestVersion: "8.1.0", Active: true},
+	}, status.Extensions)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteFleetStatusCSV(&buf, []FleetShopStatus{status, {Name: "customer-b", URL: "https://b.example.com", Error: "cannot reach"}}))
+	assert.Equal(t, `shop,url,shopware_version,shopware_update,php_version,pending_updates,extension,extension_version,extension_latest_version,extension_active,error
+customer-a,https://a.example.com,6.5.8.2,6.5.8.3,8.2.12,2,FroshTools,1.0.0,1.0.0,false,
+customer-a,https://a.example.com,6.5.8.2,6.5.8.3,8.2.12,2,SwagPayPal,8.0.0,8.1.0,true,
+customer-b,https://b.example.com,,,,0,,,,,cannot reach
+`, buf.String())
+}
@@ -0,0 +1,147 @@
+package shop
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// hostingBuiltinExtensions are always compiled into PHP and cannot be missing on a server.
+var hostingBuiltinExtensions = []string{"core", "date", "hash", "json", "pcre", "random", "reflection", "spl", "standard"}
+
+// ConfigHosting describes the PHP of the server the project is deployed to.
+type ConfigHosting struct {
+	// PHP is the version like 8.2 or 8.2.12, a minor version is checked as its newest patch release
+	PHP string `yaml:"php,omitempty"`
+	// Extensions are the loaded PHP extensions like intl or redis, they are only checked when configured
+	Extensions []string `yaml:"extensions,omitempty"`
+}
+
+// HostingViolation is a platform requirement of a package, which the hosting profile does not fulfill.
+type HostingViolation struct {
+	Package string `json:"package"`
+	// Source is the composer.json or composer.lock the requirement was found in
+	Source      string `json:"source"`
+	Requirement string `json:"requirement"`
+	Constraint  string `json:"constraint"`
+	Message     string `json:"message"`
+}
+
+// CheckHostingProfile checks the PHP and extension requirements of the composer.json, the packages of the composer.lock
+// without dev dependencies and the plugins in custom/plugins and custom/static-plugins against the hosting profile.
+func CheckHostingProfile(projectRoot string, profile ConfigHosting) ([]HostingViolation, error) {
+	var phpVersion *version.Version
+
+	if profile.PHP != "" {
+		phpString := profile.PHP
+		if strings.Count(phpString, ".") == 1 {
+			phpString += ".9999"
+		}
+
+		var err error
+		if phpVersion, err = version.NewVersion(phpString); err != nil {
+			return nil, fmt.Errorf("invalid hosting.php %s: %w", profile.PHP, err)
+		}
+	}
+
+	var project upgradeComposerJson
+	if err := readUpgradeComposerFile(filepath.Join(projectRoot, "composer.json"), &project); err != nil {
+		return nil, err
+	}
+
+	packages := []upgradeComposerJson{project}
+	sources := []string{"composer.json"}
+	known := make(map[string]bool)
+
+	var lock upgradeComposerLock
+	if err := readUpgradeComposerFile(filepath.Join(projectRoot, "composer.lock"), &lock); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, pkg := range lock.Packages {
+		packages = append(packages, pkg)
+		sources = append(sources, "composer.lock")
+		known[pkg.Name] = true
+	}
+
+	for _, folder := range []string{"plugins", "static-plugins"} {
+		composerFiles, err := filepath.Glob(filepath.Join(projectRoot, "custom", folder, "*", "composer.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, composerFile := range composerFiles {
+			var pkg upgradeComposerJson
+			if err := readUpgradeComposerFile(composerFile, &pkg); err != nil {
+				return nil, err
+			}
+
+			// plugins installed with composer are already checked by the lock
+			if known[pkg.Name] {
+				continue
+			}
+
+			source, _ := filepath.Rel(projectRoot, composerFile)
+
+			packages = append(packages, pkg)
+			sources = append(sources, filepath.ToSlash(source))
+		}
+	}
+
+	extensions := make(map[string]bool)
+	for _, extension := range append(profile.Extensions, hostingBuiltinExtensions...) {
+		extensions[normalizePHPExtension(extension)] = true
+	}
+
+	violations := make([]HostingViolation, 0)
+
+	for i, pkg := range packages {
+		name := pkg.Name
+		if name == "" {
+			name = "root"
+		}
+
+		for requirement, constraint := range pkg.Require {
+			violation := HostingViolation{Package: name, Source: sources[i], Requirement: requirement, Constraint: constraint}
+
+			switch {
+			case requirement == "php" && phpVersion != nil:
+				parsed, err := parseComposerConstraint(constraint)
+				if err != nil || parsed.Check(phpVersion) {
+					continue
+				}
+
+				violation.Message = fmt.Sprintf("%s requires PHP %s, the hosting has %s", name, constraint, profile.PHP)
+			case strings.HasPrefix(requirement, "ext-") && len(profile.Extensions) > 0:
+				if extensions[normalizePHPExtension(strings.TrimPrefix(requirement, "ext-"))] {
+					continue
+				}
+
+				violation.Message = fmt.Sprintf("%s requires the PHP extension %s, which is not in the hosting profile", name, strings.TrimPrefix(requirement, "ext-"))
+			default:
+				continue
+			}
+
+			violations = append(violations, violation)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Package != violations[j].Package {
+			return violations[i].Package < violations[j].Package
+		}
+
+		return violations[i].Requirement < violations[j].Requirement
+	})
+
+	return violations, nil
+}
+
+// normalizePHPExtension matches composer names like ext-zend-opcache with php -m names like Zend OPcache.
+func normalizePHPExtension(name string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
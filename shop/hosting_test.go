@@ -0,0 +1,49 @@
+package shop
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHostingProfile(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.json"), `{"require": {"php": ">=8.1", "ext-intl": "*", "shopware/core": "~6.6.0"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.lock"), `{"packages": [
+		{"name": "shopware/core", "require": {"php": "~8.2.0 || ~8.3.0", "ext-json": "*", "ext-zend-opcache": "*", "lib-icu": ">=60"}},
+		{"name": "symfony/polyfill", "require": {"php": ">=7.2"}},
+		{"name": "frosh/tools", "require": {"php": "^8.3", "ext-redis": "*"}}
+	]}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "plugins", "SwagCustom", "composer.json"), `{"name": "swag/custom", "require": {"php": "8.1.*", "ext-sodium": "*"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "static-plugins", "FroshTools", "composer.json"), `{"name": "frosh/tools", "require": {"php": ">=9.0"}}`)
+
+	violations, err := CheckHostingProfile(projectRoot, ConfigHosting{PHP: "8.2", Extensions: []string{"intl", "Zend OPcache"}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []HostingViolation{
+		{Package: "frosh/tools", Source: "composer.lock", Requirement: "ext-redis", Constraint: "*", Message: "frosh/tools requires the PHP extension redis, which is not in the hosting profile"},
+		{Package: "frosh/tools", Source: "composer.lock", Requirement: "php", Constraint: "^8.3", Message: "frosh/tools requires PHP ^8.3, the hosting has 8.2"},
+		{Package: "swag/custom", Source: "custom/plugins/SwagCustom/composer.json", Requirement: "ext-sodium", Constraint: "*", Message: "swag/custom requires the PHP extension sodium, which is not in the hosting profile"},
+		{Package: "swag/custom", Source: "custom/plugins/SwagCustom/composer.json", Requirement: "php", Constraint: "8.1.*", Message: "swag/custom requires PHP 8.1.*, the hosting has 8.2"},
+	}, violations)
+}
+
+func TestCheckHostingProfileWithoutExtensions(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.json"), `{"require": {"php": "~8.1.0", "ext-redis": "*"}}`)
+
+	violations, err := CheckHostingProfile(projectRoot, ConfigHosting{PHP: "8.1.27"})
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = CheckHostingProfile(projectRoot, ConfigHosting{PHP: "8.3"})
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "root", violations[0].Package)
+
+	_, err = CheckHostingProfile(projectRoot, ConfigHosting{PHP: "eight"})
+	assert.ErrorContains(t, err, "invalid hosting.php eight")
+}
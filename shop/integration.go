@@ -0,0 +1,173 @@
+package shop
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+type Integration struct {
+	ID              string
+	Label           string
+	AccessKey       string
+	SecretAccessKey string
+}
+
+// generateAccessKey builds keys in the same format as the AccessKeyHelper of Shopware.
+func generateAccessKey(prefix string, length int) (string, error) {
+	random := make([]byte, length)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	key := strings.NewReplacer("+", "", "/", "", "=", "").Replace(base64.StdEncoding.EncodeToString(random))
+
+	if prefix != "" {
+		return prefix + strings.ToUpper(key), nil
+	}
+
+	return key, nil
+}
+
+// GeneratePassword returns a random password for users created by commands, which don't get one configured.
+func GeneratePassword() (string, error) {
+	return generateAccessKey("", 24)
+}
+
+// CreateIntegration creates an integration with the given ACL roles. The privileges are granted by an additional role named
+// like the integration. Without roles and privileges the integration gets admin access.
+func CreateIntegration(ctx adminSdk.ApiContext, client *adminSdk.Client, label string, roles []string, privileges []string) (*Integration, error) {
+	accessKey, err := generateAccessKey("SWIA", 16)
+	if err != nil {
+		return nil, err
+	}
+
+	secretAccessKey, err := generateAccessKey("", 38)
+	if err != nil {
+		return nil, err
+	}
+
+	integration := &Integration{ID: NewUuid(), Label: label, AccessKey: accessKey, SecretAccessKey: secretAccessKey}
+
+	aclRoles := make([]map[string]string, 0, len(roles))
+
+	for _, role := range roles {
+		roleID, err := searchFirstID(ctx, client, "acl-role", map[string]interface{}{"filter": []interface{}{equalsFilter("name", role)}})
+		if err != nil {
+			return nil, err
+		}
+
+		if roleID == "" {
+			return nil, fmt.Errorf("cannot find role %s", role)
+		}
+
+		aclRoles = append(aclRoles, map[string]string{"id": roleID})
+	}
+
+	if len(privileges) > 0 {
+		roleID, err := CreateAclRole(ctx, client, label, privileges)
+		if err != nil {
+			return nil, err
+		}
+
+		aclRoles = append(aclRoles, map[string]string{"id": roleID})
+	}
+
+	payload := map[string]interface{}{
+		"id":              integration.ID,
+		"label":           label,
+		"accessKey":       accessKey,
+		"secretAccessKey": secretAccessKey,
+		"admin":           len(aclRoles) == 0,
+		"aclRoles":        aclRoles,
+	}
+
+	if err := adminRequest(ctx, client, "POST", "/api/integration", payload, nil, nil); err != nil {
+		return nil, fmt.Errorf("cannot create integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// WriteAdminApiCredentials replaces the admin_api section of the project config with the integration credentials.
+// The file is edited as YAML tree, so comments and ${VAR} placeholders of the other keys are kept.
+func WriteAdminApiCredentials(fileName, clientID, clientSecret string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", fileName, err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot update %s, the root is not a mapping", fileName)
+	}
+
+	adminApi := &yaml.Node{Kind: yaml.MappingNode}
+	replaced := false
+
+	for i := 0; i < len(root.Content); i += 2 {
+		if root.Content[i].Value != "admin_api" {
+			continue
+		}
+
+		// keep options like disable_ssl_check, but drop the previous credentials
+		if root.Content[i+1].Kind == yaml.MappingNode {
+			for j := 0; j < len(root.Content[i+1].Content); j += 2 {
+				switch root.Content[i+1].Content[j].Value {
+				case "client_id", "client_secret", "username", "password":
+				default:
+					adminApi.Content = append(adminApi.Content, root.Content[i+1].Content[j], root.Content[i+1].Content[j+1])
+				}
+			}
+		}
+
+		root.Content[i+1] = adminApi
+		replaced = true
+	}
+
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "admin_api"}, adminApi)
+	}
+
+	adminApi.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "client_id"},
+		{Kind: yaml.ScalarNode, Value: clientID},
+		{Kind: yaml.ScalarNode, Value: "client_secret"},
+		{Kind: yaml.ScalarNode, Value: clientSecret},
+	}, adminApi.Content...)
+
+	var updated bytes.Buffer
+
+	encoder := yaml.NewEncoder(&updated)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	// the file contains the client secret now, only the user may read it
+	if err := os.WriteFile(fileName, updated.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	return os.Chmod(fileName, 0o600)
+}
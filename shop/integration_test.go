@@ -0,0 +1,74 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIntegration(t *testing.T) {
+	var created map[string]interface{}
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		switch r.URL.Path {
+		case "/api/search-ids/acl-role":
+			_, _ = w.Write([]byte(`{"total": 1, "data": ["role"]}`))
+		case "/api/integration":
+			created = body
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	integration, err := CreateIntegration(adminSdk.NewApiContext(context.Background()), client, "CI", []string{"Deployment"}, nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(integration.AccessKey, "SWIA"))
+	assert.NotEmpty(t, integration.SecretAccessKey)
+
+	assert.Equal(t, "CI", created["label"])
+	assert.Equal(t, integration.AccessKey, created["accessKey"])
+	assert.Equal(t, false, created["admin"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "role"}}, created["aclRoles"])
+}
+
+func TestWriteAdminApiCredentials(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".shopware-project.yml")
+
+	assert.NoError(t, os.WriteFile(configFile, []byte(`# my shop
+url: ${SHOP_URL}
+admin_api:
+  username: admin
+  password: shopware
+  disable_ssl_check: true
+`), os.ModePerm))
+
+	assert.NoError(t, WriteAdminApiCredentials(configFile, "SWIAKEY", "secret"))
+
+	content, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `# my shop
+url: ${SHOP_URL}
+admin_api:
+  client_id: SWIAKEY
+  client_secret: secret
+  disable_ssl_check: true
+`, string(content))
+
+	stat, err := os.Stat(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("url: http://localhost\n"), os.ModePerm))
+	assert.NoError(t, WriteAdminApiCredentials(configFile, "SWIAKEY", "secret"))
+
+	content, err = os.ReadFile(configFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "admin_api:\n  client_id: SWIAKEY\n")
+}
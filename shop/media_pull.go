@@ -0,0 +1,269 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+const mediaPageSize = 500
+
+// MediaMetadataFile is written into the target folder of project media pull with the metadata of all downloaded files.
+const MediaMetadataFile = "media.json"
+
+type MediaFolder struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+}
+
+type MediaFile struct {
+	ID            string `json:"id"`
+	FileName      string `json:"fileName"`
+	FileExtension string `json:"fileExtension"`
+	FileSize      int64  `json:"fileSize"`
+	MimeType      string `json:"mimeType"`
+	URL           string `json:"url"`
+	Title         string `json:"title,omitempty"`
+	Alt           string `json:"alt,omitempty"`
+	MediaFolderID string `json:"mediaFolderId,omitempty"`
+	UploadedAt    string `json:"uploadedAt,omitempty"`
+	// Path of the downloaded file relative to the target folder, it mirrors the media folders
+	Path string `json:"path"`
+}
+
+// ListMediaFolders returns all media folders by id.
+func ListMediaFolders(ctx adminSdk.ApiContext, client *adminSdk.Client) (map[string]MediaFolder, error) {
+	folders := make(map[string]MediaFolder)
+
+	for page := 1; ; page++ {
+		var res struct {
+			Data []MediaFolder `json:"data"`
+		}
+
+		if err := adminRequest(ctx, client, "POST", "/api/search/media-folder", map[string]interface{}{"page": page, "limit": mediaPageSize}, &res, nil); err != nil {
+			return nil, fmt.Errorf("cannot read the media folders: %w", err)
+		}
+
+		for _, folder := range res.Data {
+			folders[folder.ID] = folder
+		}
+
+		if len(res.Data) < mediaPageSize {
+			return folders, nil
+		}
+	}
+}
+
+// MediaFolderPath returns the path of the folder with its parent folders like Product Media/Shoes.
+func MediaFolderPath(folders map[string]MediaFolder, id string) string {
+	parts := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for id != "" && !seen[id] {
+		folder, ok := folders[id]
+		if !ok {
+			break
+		}
+
+		seen[id] = true
+		parts = append([]string{sanitizeMediaPathPart(folder.Name)}, parts...)
+		id = folder.ParentID
+	}
+
+	return path.Join(parts...)
+}
+
+// MediaFolderIDsByName returns the ids of the folders with the given name and all their sub folders.
+func MediaFolderIDsByName(folders map[string]MediaFolder, name string) []string {
+	ids := make([]string, 0)
+
+	for id := range folders {
+		seen := make(map[string]bool)
+
+		for current, ok := folders[id]; ok && !seen[current.ID]; current, ok = folders[current.ParentID] {
+			if current.Name == name {
+				ids = append(ids, id)
+				break
+			}
+
+			seen[current.ID] = true
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+func sanitizeMediaPathPart(name string) string {
+	name = strings.NewReplacer("/", "-", "\\", "-", "..", "-").Replace(strings.TrimSpace(name))
+
+	if name == "" {
+		return "-"
+	}
+
+	return name
+}
+
+// ListMedia returns the media files with an uploaded file, limited to the folders when folderIDs are given.
+func ListMedia(ctx adminSdk.ApiContext, client *adminSdk.Client, folders map[string]MediaFolder, folderIDs []string) ([]MediaFile, error) {
+	files := make([]MediaFile, 0)
+
+	for page := 1; ; page++ {
+		criteria := map[string]interface{}{
+			"page":   page,
+			"limit":  mediaPageSize,
+			"sort":   []interface{}{map[string]interface{}{"field": "id", "order": "ASC"}},
+			"filter": []interface{}{map[string]interface{}{"type": "not", "operator": "and", "queries": []interface{}{equalsFilter("uploadedAt", nil)}}},
+		}
+
+		if folderIDs != nil {
+			criteria["filter"] = append(criteria["filter"].([]interface{}), map[string]interface{}{"type": "equalsAny", "field": "mediaFolderId", "value": folderIDs})
+		}
+
+		var res struct {
+			Data []MediaFile `json:"data"`
+		}
+
+		if err := adminRequest(ctx, client, "POST", "/api/search/media", criteria, &res, nil); err != nil {
+			return nil, fmt.Errorf("cannot read the media: %w", err)
+		}
+
+		for _, file := range res.Data {
+			file.Path = path.Join(MediaFolderPath(folders, file.MediaFolderID), sanitizeMediaPathPart(file.FileName+"."+file.FileExtension))
+			files = append(files, file)
+		}
+
+		if len(res.Data) < mediaPageSize {
+			return files, nil
+		}
+	}
+}
+
+type MediaDownloadResult struct {
+	File    MediaFile
+	Skipped bool
+	Error   error
+}
+
+// DownloadMedia downloads the files into the target folder with the given concurrency. Existing files with the same size
+// are skipped, so a pull can be continued.
+func DownloadMedia(ctx context.Context, client *http.Client, files []MediaFile, target string, concurrency int, onResult func(MediaDownloadResult)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	queue := make(chan MediaFile)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for file := range queue {
+				result := downloadMediaFile(ctx, client, file, target)
+
+				mu.Lock()
+				onResult(result)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, file := range files {
+		if ctx.Err() != nil {
+			break
+		}
+
+		queue <- file
+	}
+
+	close(queue)
+	wg.Wait()
+}
+
+func downloadMediaFile(ctx context.Context, client *http.Client, file MediaFile, target string) MediaDownloadResult {
+	result := MediaDownloadResult{File: file}
+	filePath := filepath.Join(target, filepath.FromSlash(file.Path))
+
+	if stat, err := os.Stat(filePath); err == nil && stat.Size() == file.FileSize {
+		result.Skipped = true
+		return result
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, file.URL, nil)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		result.Error = fmt.Errorf("download of %s failed with status %d", file.URL, resp.StatusCode)
+		return result
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		result.Error = err
+		return result
+	}
+
+	// write into a temporary file, so an aborted download is not skipped on the next pull
+	tmpFile := filePath + ".download"
+
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpFile)
+		result.Error = err
+
+		return result
+	}
+
+	if err := f.Close(); err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Error = os.Rename(tmpFile, filePath)
+
+	return result
+}
+
+// WriteMediaMetadata writes the metadata of the files into the media.json of the target folder.
+func WriteMediaMetadata(target string, files []MediaFile) error {
+	content, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(target, MediaMetadataFile), content, 0o644)
+}
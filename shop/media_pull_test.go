@@ -0,0 +1,94 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFolderPath(t *testing.T) {
+	folders := map[string]MediaFolder{
+		"root":  {ID: "root", Name: "Product Media"},
+		"shoes": {ID: "shoes", Name: "Shoes", ParentID: "root"},
+		"cms":   {ID: "cms", Name: "CMS/Media"},
+	}
+
+	assert.Equal(t, "Product Media/Shoes", MediaFolderPath(folders, "shoes"))
+	assert.Equal(t, "CMS-Media", MediaFolderPath(folders, "cms"))
+	assert.Equal(t, "", MediaFolderPath(folders, ""))
+
+	assert.Equal(t, []string{"root", "shoes"}, MediaFolderIDsByName(folders, "Product Media"))
+	assert.Equal(t, []string{"shoes"}, MediaFolderIDsByName(folders, "Shoes"))
+	assert.Empty(t, MediaFolderIDsByName(folders, "Unknown"))
+}
+
+func TestPullMedia(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	t.Cleanup(files.Close)
+
+	var mediaCriteria map[string]interface{}
+
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/search/media-folder":
+			_, _ = w.Write([]byte(`{"data": [{"id": "root", "name": "Product Media"}, {"id": "shoes", "name": "Shoes", "parentId": "root"}]}`))
+		case "/api/search/media":
+			mediaCriteria = body
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]interface{}{
+				{"id": "1", "fileName": "sneaker", "fileExtension": "jpg", "fileSize": 23, "url": files.URL + "/sneaker.jpg", "mediaFolderId": "shoes", "alt": "Sneaker"},
+				{"id": "2", "fileName": "logo", "fileExtension": "png", "fileSize": 1, "url": files.URL + "/logo.png"},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	apiCtx := adminSdk.NewApiContext(context.Background())
+
+	folders, err := ListMediaFolders(apiCtx, client)
+	assert.NoError(t, err)
+
+	media, err := ListMedia(apiCtx, client, folders, []string{"shoes"})
+	assert.NoError(t, err)
+	assert.Len(t, media, 2)
+	assert.Equal(t, "Product Media/Shoes/sneaker.jpg", media[0].Path)
+	assert.Equal(t, "logo.png", media[1].Path)
+	assert.Contains(t, mediaCriteria["filter"], map[string]interface{}{"type": "equalsAny", "field": "mediaFolderId", "value": []interface{}{"shoes"}})
+
+	target := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(target, "logo.png"), []byte("x"), os.ModePerm))
+
+	results := make(map[string]MediaDownloadResult)
+
+	DownloadMedia(context.Background(), files.Client(), media, target, 2, func(result MediaDownloadResult) {
+		results[result.File.ID] = result
+	})
+
+	assert.NoError(t, results["1"].Error)
+	assert.False(t, results["1"].Skipped)
+	assert.True(t, results["2"].Skipped)
+
+	content, err := os.ReadFile(filepath.Join(target, "Product Media", "Shoes", "sneaker.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content of /sneaker.jpg", string(content))
+
+	assert.NoError(t, WriteMediaMetadata(target, media))
+
+	var metadata []MediaFile
+	content, err = os.ReadFile(filepath.Join(target, MediaMetadataFile))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(content, &metadata))
+	assert.Equal(t, "Sneaker", metadata[0].Alt)
+}
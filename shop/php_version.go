@@ -0,0 +1,30 @@
+package shop
+
+import (
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+)
+
+// GetPHPVersion returns the PHP version of the shop from the requirement checks of the updater. The Admin API has no
+// other endpoint for it, so an empty string is returned when the shop doesn't expose the checks or the user lacks the permission.
+func GetPHPVersion(ctx adminSdk.ApiContext, client *adminSdk.Client) string {
+	var checks []struct {
+		Name string                 `json:"name"`
+		Vars map[string]interface{} `json:"vars"`
+	}
+
+	if err := adminRequest(ctx, client, "GET", "/api/_action/update/check-requirements", nil, &checks, nil); err != nil {
+		return ""
+	}
+
+	for _, check := range checks {
+		if check.Name != "phpVersion" {
+			continue
+		}
+
+		if currentVersion, ok := check.Vars["currentVersion"].(string); ok {
+			return currentVersion
+		}
+	}
+
+	return ""
+}
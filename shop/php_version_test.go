@@ -0,0 +1,26 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	adminSdk "github.com/friendsofshopware/go-shopware-admin-api-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPHPVersion(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		assert.Equal(t, "/api/_action/update/check-requirements", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "writableCheck", "result": 2}, {"name": "phpVersion", "result": 2, "vars": {"minVersion": "8.1.0", "currentVersion": "8.2.10"}}]`))
+	})
+
+	assert.Equal(t, "8.2.10", GetPHPVersion(adminSdk.NewApiContext(context.Background()), client))
+
+	forbidden := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": []}`))
+	})
+
+	assert.Equal(t, "", GetPHPVersion(adminSdk.NewApiContext(context.Background()), forbidden))
+}
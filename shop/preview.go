@@ -0,0 +1,155 @@
+package shop
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// previewDisableMailKey is the mailer setting of Shopware, which stops sending any mail when true.
+const previewDisableMailKey = "core.mailerSettings.disableDelivery"
+
+type PreviewOptions struct {
+	// URL replaces the scheme and host of all sales channel domains
+	URL           string
+	AdminUsername string
+	// AdminPasswordHash is the bcrypt hash of the new admin password
+	AdminPasswordHash string
+}
+
+// PreparePreviewDatabase makes an imported copy of a shop safe to use as review app: the domains point to the preview URL,
+// the first admin user gets known credentials, mails are not sent anymore and webhooks are disabled.
+func PreparePreviewDatabase(ctx context.Context, db *sql.DB, opts PreviewOptions) error {
+	if err := rewritePreviewDomains(ctx, db, opts.URL); err != nil {
+		return fmt.Errorf("cannot rewrite the sales channel domains: %w", err)
+	}
+
+	if err := resetPreviewAdmin(ctx, db, opts.AdminUsername, opts.AdminPasswordHash); err != nil {
+		return fmt.Errorf("cannot reset the admin user: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM system_config WHERE configuration_key = ?", previewDisableMailKey); err != nil {
+		return fmt.Errorf("cannot disable mails: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO system_config (id, configuration_key, configuration_value, sales_channel_id, created_at) VALUES (UNHEX(?), ?, ?, NULL, NOW())", NewUuid(), previewDisableMailKey, `{"_value": true}`); err != nil {
+		return fmt.Errorf("cannot disable mails: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE webhook SET active = 0"); err != nil {
+		return fmt.Errorf("cannot disable webhooks: %w", err)
+	}
+
+	return nil
+}
+
+func rewritePreviewDomains(ctx context.Context, db *sql.DB, previewURL string) error {
+	rows, err := db.QueryContext(ctx, "SELECT LOWER(HEX(id)), url FROM sales_channel_domain")
+	if err != nil {
+		return err
+	}
+
+	domains := make(map[string]string)
+
+	for rows.Next() {
+		var id, domainURL string
+		if err := rows.Scan(&id, &domainURL); err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		domains[id] = domainURL
+	}
+
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	rewritten, err := PlanPreviewDomains(domains, previewURL)
+	if err != nil {
+		return err
+	}
+
+	for id, domainURL := range rewritten {
+		if _, err := db.ExecContext(ctx, "UPDATE sales_channel_domain SET url = ? WHERE id = UNHEX(?)", domainURL, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PlanPreviewDomains maps the domain URLs by id to the preview URL, keeping their paths. When two domains end up with the
+// same URL, f.e. shop.de and shop.com, the host of the later one is added as path.
+func PlanPreviewDomains(domains map[string]string, previewURL string) (map[string]string, error) {
+	preview, err := url.Parse(previewURL)
+	if err != nil || preview.Scheme == "" || preview.Host == "" {
+		return nil, fmt.Errorf("invalid preview url %s, use a url like https://review-123.example.com", previewURL)
+	}
+
+	base := preview.Scheme + "://" + preview.Host + strings.TrimRight(preview.Path, "/")
+
+	ids := make([]string, 0, len(domains))
+	for id := range domains {
+		ids = append(ids, id)
+	}
+
+	// the shortest URLs keep their path, so the main domain stays at the root of the preview
+	sort.Slice(ids, func(i, j int) bool {
+		if len(domains[ids[i]]) != len(domains[ids[j]]) {
+			return len(domains[ids[i]]) < len(domains[ids[j]])
+		}
+
+		return domains[ids[i]] < domains[ids[j]]
+	})
+
+	used := make(map[string]bool)
+	rewritten := make(map[string]string)
+
+	for _, id := range ids {
+		domain, err := url.Parse(domains[id])
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain url %s: %w", domains[id], err)
+		}
+
+		path := strings.TrimRight(domain.Path, "/")
+		target := base + path
+
+		if used[target] {
+			target = base + "/" + slugRegex.ReplaceAllString(strings.ToLower(domain.Hostname()), "-") + path
+		}
+
+		if used[target] {
+			return nil, fmt.Errorf("cannot map the domain %s to a unique url of the preview", domains[id])
+		}
+
+		used[target] = true
+		rewritten[id] = target
+	}
+
+	return rewritten, nil
+}
+
+func resetPreviewAdmin(ctx context.Context, db *sql.DB, username, passwordHash string) error {
+	var id string
+
+	if err := db.QueryRowContext(ctx, "SELECT LOWER(HEX(id)) FROM user WHERE admin = 1 ORDER BY created_at LIMIT 1").Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("found no admin user")
+		}
+
+		return err
+	}
+
+	// the username is unique, another user could have it after the anonymization
+	if _, err := db.ExecContext(ctx, "UPDATE user SET username = CONCAT(username, '-', LOWER(HEX(id))) WHERE username = ? AND id != UNHEX(?)", username, id); err != nil {
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, "UPDATE user SET username = ?, password = ?, active = 1 WHERE id = UNHEX(?)", username, passwordHash, id)
+
+	return err
+}
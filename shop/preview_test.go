@@ -0,0 +1,29 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPreviewDomains(t *testing.T) {
+	domains := map[string]string{
+		"a": "https://www.shop.de",
+		"b": "https://www.shop.de/en/",
+		"c": "https://www.shop.com",
+		"d": "http://localhost:8000",
+	}
+
+	rewritten, err := PlanPreviewDomains(domains, "https://review-42.example.com/")
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"a": "https://review-42.example.com",
+		"b": "https://review-42.example.com/en",
+		"c": "https://review-42.example.com/www-shop-com",
+		"d": "https://review-42.example.com/localhost",
+	}, rewritten)
+
+	_, err = PlanPreviewDomains(domains, "review-42.example.com")
+	assert.ErrorContains(t, err, "invalid preview url")
+}
@@ -0,0 +1,37 @@
+package shop
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ProtectedConfirmationEnv confirms destructive commands against a protected shop without a prompt, f.e. in a CI.
+// It has to contain the same value as the prompt.
+const ProtectedConfirmationEnv = "SHOPWARE_CLI_CONFIRM_PROTECTED"
+
+// ProtectionConfirmation returns the text to type to confirm a destructive command, the host of the shop.
+func (c *Config) ProtectionConfirmation() string {
+	parsed, err := url.Parse(c.URL)
+	if err != nil || parsed.Host == "" {
+		return c.URL
+	}
+
+	return parsed.Host
+}
+
+// CheckProtection returns an error, when the shop is protected and the destructive action is not forced or not confirmed.
+func (c *Config) CheckProtection(action string, force bool, confirmation string) error {
+	if !c.Protected {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf("the shop %s is protected, use --force to %s", c.URL, action)
+	}
+
+	if confirmation != c.ProtectionConfirmation() {
+		return fmt.Errorf("the shop %s is protected, confirm to %s by typing %s", c.URL, action, c.ProtectionConfirmation())
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckProtection(t *testing.T) {
+	cfg := &Config{URL: "https://shop.example.com/"}
+
+	assert.NoError(t, cfg.CheckProtection("clear the cache", false, ""))
+
+	cfg.Protected = true
+
+	assert.Equal(t, "shop.example.com", cfg.ProtectionConfirmation())
+	assert.ErrorContains(t, cfg.CheckProtection("clear the cache", false, "shop.example.com"), "use --force to clear the cache")
+	assert.ErrorContains(t, cfg.CheckProtection("clear the cache", true, "shop"), "by typing shop.example.com")
+	assert.NoError(t, cfg.CheckProtection("clear the cache", true, "shop.example.com"))
+}
+
+func TestForEnvironmentProtected(t *testing.T) {
+	cfg := &Config{
+		URL:       "http://localhost:8000",
+		Protected: true,
+		Environments: map[string]ConfigEnvironment{
+			"staging":    {URL: "https://staging.example.com"},
+			"production": {URL: "https://example.com", Protected: true},
+		},
+	}
+
+	staging, err := cfg.ForEnvironment("staging")
+	assert.NoError(t, err)
+	assert.False(t, staging.Protected)
+
+	production, err := cfg.ForEnvironment("production")
+	assert.NoError(t, err)
+	assert.True(t, production.Protected)
+	assert.Equal(t, "example.com", production.ProtectionConfirmation())
+
+	defaultCfg, err := cfg.ForEnvironment(DefaultEnvironment)
+	assert.NoError(t, err)
+	assert.True(t, defaultCfg.Protected)
+}
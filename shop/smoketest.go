@@ -0,0 +1,257 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ConfigSmokeTest configures the sales channel used by project smoke-test.
+type ConfigSmokeTest struct {
+	// AccessKey of the sales channel
+	AccessKey string `yaml:"access_key,omitempty"`
+	// ProductNumber is added to the cart, defaults to the first available product
+	ProductNumber string `yaml:"product_number,omitempty"`
+	// PaymentMethod is the technical name, handler or name of the payment method, defaults to the first available one
+	PaymentMethod string `yaml:"payment_method,omitempty"`
+	// StorefrontURL is the domain of the sales channel used for the guest registration, defaults to the shop url
+	StorefrontURL string `yaml:"storefront_url,omitempty"`
+}
+
+type SmokeTestStep struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Skipped  bool          `json:"skipped"`
+	Duration time.Duration `json:"duration"`
+	Detail   string        `json:"detail,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// SmokeTestPassed returns true, when all steps passed.
+func SmokeTestPassed(steps []SmokeTestStep) bool {
+	for _, step := range steps {
+		if !step.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+type smokeTest struct {
+	client    *StoreApiClient
+	cfg       ConfigSmokeTest
+	productID string
+}
+
+// RunSmokeTest finds a product, adds it to the cart, registers a guest and places an order with the store-api.
+// The steps after a failed step are skipped.
+func RunSmokeTest(ctx context.Context, client *http.Client, baseURL string, cfg ConfigSmokeTest) []SmokeTestStep {
+	test := &smokeTest{client: NewStoreApiClient(client, baseURL, cfg.AccessKey), cfg: cfg}
+
+	if test.cfg.StorefrontURL == "" {
+		test.cfg.StorefrontURL = strings.TrimRight(baseURL, "/")
+	}
+
+	steps := []struct {
+		name string
+		run  func(ctx context.Context) (string, error)
+	}{
+		{"Find product", test.findProduct},
+		{"Add to cart", test.addToCart},
+		{"Register guest", test.registerGuest},
+		{"Select payment method", test.selectPaymentMethod},
+		{"Place order", test.placeOrder},
+	}
+
+	results := make([]SmokeTestStep, 0, len(steps))
+	failed := false
+
+	for _, step := range steps {
+		if failed {
+			results = append(results, SmokeTestStep{Name: step.name, Skipped: true})
+			continue
+		}
+
+		start := time.Now()
+		detail, err := step.run(ctx)
+		result := SmokeTestStep{Name: step.name, Passed: err == nil, Duration: time.Since(start), Detail: detail}
+
+		if err != nil {
+			result.Error = err.Error()
+			failed = true
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (t *smokeTest) findProduct(ctx context.Context) (string, error) {
+	filter := map[string]interface{}{"type": "equals", "field": "available", "value": true}
+
+	if t.cfg.ProductNumber != "" {
+		filter = map[string]interface{}{"type": "equals", "field": "productNumber", "value": t.cfg.ProductNumber}
+	}
+
+	var products struct {
+		Elements []struct {
+			ID            string `json:"id"`
+			ProductNumber string `json:"productNumber"`
+		} `json:"elements"`
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/product", map[string]interface{}{"limit": 1, "filter": []interface{}{filter}}, &products); err != nil {
+		return "", err
+	}
+
+	if len(products.Elements) == 0 {
+		if t.cfg.ProductNumber != "" {
+			return "", fmt.Errorf("product %s is not visible in the sales channel", t.cfg.ProductNumber)
+		}
+
+		return "", fmt.Errorf("the sales channel has no available product")
+	}
+
+	t.productID = products.Elements[0].ID
+
+	return products.Elements[0].ProductNumber, nil
+}
+
+func (t *smokeTest) addToCart(ctx context.Context) (string, error) {
+	var cart struct {
+		LineItems []struct {
+			ReferencedID string `json:"referencedId"`
+		} `json:"lineItems"`
+		Price struct {
+			TotalPrice float64 `json:"totalPrice"`
+		} `json:"price"`
+		Errors map[string]struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	lineItem := map[string]interface{}{
+		"items": []map[string]interface{}{{"id": t.productID, "referencedId": t.productID, "type": "product", "quantity": 1}},
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/checkout/cart/line-item", lineItem, &cart); err != nil {
+		return "", err
+	}
+
+	for _, lineItem := range cart.LineItems {
+		if lineItem.ReferencedID == t.productID {
+			return fmt.Sprintf("total %.2f", cart.Price.TotalPrice), nil
+		}
+	}
+
+	for _, cartError := range cart.Errors {
+		return "", fmt.Errorf("the product is not in the cart: %s", cartError.Message)
+	}
+
+	return "", fmt.Errorf("the product is not in the cart")
+}
+
+func (t *smokeTest) registerGuest(ctx context.Context) (string, error) {
+	var salutations, countries struct {
+		Elements []struct {
+			ID string `json:"id"`
+		} `json:"elements"`
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/salutation", map[string]interface{}{"limit": 1}, &salutations); err != nil {
+		return "", err
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/country", map[string]interface{}{"limit": 1}, &countries); err != nil {
+		return "", err
+	}
+
+	if len(salutations.Elements) == 0 || len(countries.Elements) == 0 {
+		return "", fmt.Errorf("the sales channel has no salutation or country")
+	}
+
+	email := fmt.Sprintf("smoke-test+%s@example.com", NewUuid())
+
+	customer := map[string]interface{}{
+		"guest":                  true,
+		"email":                  email,
+		"salutationId":           salutations.Elements[0].ID,
+		"firstName":              "Smoke",
+		"lastName":               "Test",
+		"acceptedDataProtection": true,
+		"storefrontUrl":          t.cfg.StorefrontURL,
+		"billingAddress": map[string]interface{}{
+			"street":    "Smoke Test Street 1",
+			"zipcode":   "12345",
+			"city":      "Smoke Test City",
+			"countryId": countries.Elements[0].ID,
+		},
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/account/register", customer, nil); err != nil {
+		return "", err
+	}
+
+	return email, nil
+}
+
+func (t *smokeTest) selectPaymentMethod(ctx context.Context) (string, error) {
+	var paymentMethods struct {
+		Elements []struct {
+			ID                string `json:"id"`
+			TechnicalName     string `json:"technicalName"`
+			HandlerIdentifier string `json:"handlerIdentifier"`
+			Name              string `json:"name"`
+			Translated        struct {
+				Name string `json:"name"`
+			} `json:"translated"`
+		} `json:"elements"`
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/payment-method", map[string]interface{}{"onlyAvailable": true}, &paymentMethods); err != nil {
+		return "", err
+	}
+
+	for _, paymentMethod := range paymentMethods.Elements {
+		name := paymentMethod.Translated.Name
+		if name == "" {
+			name = paymentMethod.Name
+		}
+
+		if t.cfg.PaymentMethod != "" && !strings.EqualFold(t.cfg.PaymentMethod, paymentMethod.TechnicalName) && !strings.EqualFold(t.cfg.PaymentMethod, paymentMethod.HandlerIdentifier) && !strings.EqualFold(t.cfg.PaymentMethod, name) {
+			continue
+		}
+
+		if err := t.client.Request(ctx, http.MethodPatch, "/store-api/context", map[string]interface{}{"paymentMethodId": paymentMethod.ID}, nil); err != nil {
+			return "", err
+		}
+
+		return name, nil
+	}
+
+	if t.cfg.PaymentMethod != "" {
+		return "", fmt.Errorf("payment method %s is not available", t.cfg.PaymentMethod)
+	}
+
+	return "", fmt.Errorf("the sales channel has no available payment method")
+}
+
+func (t *smokeTest) placeOrder(ctx context.Context) (string, error) {
+	var order struct {
+		OrderNumber string `json:"orderNumber"`
+	}
+
+	if err := t.client.Request(ctx, http.MethodPost, "/store-api/checkout/order", map[string]interface{}{}, &order); err != nil {
+		return "", err
+	}
+
+	if order.OrderNumber == "" {
+		return "", fmt.Errorf("the response contains no order number")
+	}
+
+	return order.OrderNumber, nil
+}
@@ -0,0 +1,81 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSmokeTestServer(t *testing.T, paymentMethods string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SWSCKEY", r.Header.Get("sw-access-key"))
+
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/store-api/product":
+			assert.Equal(t, "", r.Header.Get("sw-context-token"))
+			w.Header().Set("sw-context-token", "cart-token")
+			_, _ = w.Write([]byte(`{"elements": [{"id": "product", "productNumber": "SW10001"}]}`))
+		case "/store-api/checkout/cart/line-item":
+			assert.Equal(t, "cart-token", r.Header.Get("sw-context-token"))
+			_, _ = w.Write([]byte(`{"lineItems": [{"referencedId": "product"}], "price": {"totalPrice": 19.99}}`))
+		case "/store-api/salutation", "/store-api/country":
+			_, _ = w.Write([]byte(`{"elements": [{"id": "id"}]}`))
+		case "/store-api/account/register":
+			assert.Equal(t, true, body["guest"])
+			assert.Equal(t, "https://shop.example.com", body["storefrontUrl"])
+			w.Header().Set("sw-context-token", "guest-token")
+			_, _ = w.Write([]byte(`{}`))
+		case "/store-api/payment-method":
+			_, _ = w.Write([]byte(paymentMethods))
+		case "/store-api/context":
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "guest-token", r.Header.Get("sw-context-token"))
+			assert.Equal(t, "invoice", body["paymentMethodId"])
+			_, _ = w.Write([]byte(`{}`))
+		case "/store-api/checkout/order":
+			assert.Equal(t, "guest-token", r.Header.Get("sw-context-token"))
+			_, _ = w.Write([]byte(`{"orderNumber": "10042"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunSmokeTest(t *testing.T) {
+	server := newSmokeTestServer(t, `{"elements": [
+		{"id": "prepayment", "technicalName": "payment_prepayment", "translated": {"name": "Paid in advance"}},
+		{"id": "invoice", "technicalName": "payment_invoice", "translated": {"name": "Invoice"}}
+	]}`)
+
+	steps := RunSmokeTest(context.Background(), server.Client(), server.URL, ConfigSmokeTest{AccessKey: "SWSCKEY", PaymentMethod: "invoice", StorefrontURL: "https://shop.example.com"})
+
+	assert.True(t, SmokeTestPassed(steps))
+	assert.Len(t, steps, 5)
+	assert.Equal(t, "SW10001", steps[0].Detail)
+	assert.Equal(t, "total 19.99", steps[1].Detail)
+	assert.Equal(t, "Invoice", steps[3].Detail)
+	assert.Equal(t, "10042", steps[4].Detail)
+}
+
+func TestRunSmokeTestSkipsAfterFailure(t *testing.T) {
+	server := newSmokeTestServer(t, `{"elements": [{"id": "prepayment", "technicalName": "payment_prepayment", "translated": {"name": "Paid in advance"}}]}`)
+
+	steps := RunSmokeTest(context.Background(), server.Client(), server.URL, ConfigSmokeTest{AccessKey: "SWSCKEY", PaymentMethod: "invoice", StorefrontURL: "https://shop.example.com"})
+
+	assert.False(t, SmokeTestPassed(steps))
+	assert.True(t, steps[2].Passed)
+	assert.Equal(t, "payment method invoice is not available", steps[3].Error)
+	assert.True(t, steps[4].Skipped)
+}
@@ -0,0 +1,137 @@
+package shop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ConfigStoreApi configures the sales channels used by the store-api (frontend API) commands.
+type ConfigStoreApi struct {
+	// SalesChannels maps a name like storefront or headless to the access key of the sales channel
+	SalesChannels map[string]ConfigStoreApiSalesChannel `yaml:"sales_channels,omitempty"`
+}
+
+type ConfigStoreApiSalesChannel struct {
+	AccessKey string `yaml:"access_key"`
+	// URL of the sales channel domain, defaults to the shop url
+	URL string `yaml:"url,omitempty"`
+}
+
+// StoreApiSalesChannel returns the configured sales channel with the given name. Without name the only configured sales
+// channel is returned. The URL defaults to the shop url.
+func (c *Config) StoreApiSalesChannel(name string) (*ConfigStoreApiSalesChannel, error) {
+	if c.StoreApi == nil || len(c.StoreApi.SalesChannels) == 0 {
+		return nil, fmt.Errorf("no sales channels configured, add them to store_api.sales_channels in .shopware-project.yml")
+	}
+
+	if name == "" {
+		if len(c.StoreApi.SalesChannels) > 1 {
+			names := make([]string, 0, len(c.StoreApi.SalesChannels))
+			for salesChannelName := range c.StoreApi.SalesChannels {
+				names = append(names, salesChannelName)
+			}
+
+			sort.Strings(names)
+
+			return nil, fmt.Errorf("multiple sales channels are configured, choose one of: %s", strings.Join(names, ", "))
+		}
+
+		for salesChannelName := range c.StoreApi.SalesChannels {
+			name = salesChannelName
+		}
+	}
+
+	salesChannel, ok := c.StoreApi.SalesChannels[name]
+	if !ok {
+		return nil, fmt.Errorf("sales channel %s is not configured in store_api.sales_channels", name)
+	}
+
+	if salesChannel.URL == "" {
+		salesChannel.URL = c.URL
+	}
+
+	return &salesChannel, nil
+}
+
+// StoreApiClient sends requests to the store-api like a headless storefront. The context token returned by the shop is
+// kept, so a cart or a logged-in customer lives across the requests.
+type StoreApiClient struct {
+	client       *http.Client
+	baseURL      string
+	accessKey    string
+	contextToken string
+}
+
+func NewStoreApiClient(client *http.Client, baseURL, accessKey string) *StoreApiClient {
+	return &StoreApiClient{client: client, baseURL: strings.TrimRight(baseURL, "/"), accessKey: accessKey}
+}
+
+func (c *StoreApiClient) ContextToken() string {
+	return c.contextToken
+}
+
+// SetContextToken continues an existing context, f.e. of a logged-in customer.
+func (c *StoreApiClient) SetContextToken(token string) {
+	c.contextToken = token
+}
+
+// Request sends the body as json to the store-api path like /store-api/product and decodes the response into target, when it is not nil.
+func (c *StoreApiClient) Request(ctx context.Context, method, path string, body interface{}, target interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(content)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("sw-access-key", c.accessKey)
+
+	if c.contextToken != "" {
+		r.Header.Set("sw-context-token", c.contextToken)
+	}
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if token := resp.Header.Get("sw-context-token"); token != "" {
+		c.contextToken = token
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s failed with status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, target)
+}
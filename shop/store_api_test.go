@@ -0,0 +1,69 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreApiSalesChannel(t *testing.T) {
+	cfg := &Config{URL: "https://shop.example.com"}
+
+	_, err := cfg.StoreApiSalesChannel("")
+	assert.ErrorContains(t, err, "no sales channels configured")
+
+	cfg.StoreApi = &ConfigStoreApi{SalesChannels: map[string]ConfigStoreApiSalesChannel{
+		"storefront": {AccessKey: "SWSCSTOREFRONT"},
+	}}
+
+	salesChannel, err := cfg.StoreApiSalesChannel("")
+	assert.NoError(t, err)
+	assert.Equal(t, "SWSCSTOREFRONT", salesChannel.AccessKey)
+	assert.Equal(t, "https://shop.example.com", salesChannel.URL)
+
+	cfg.StoreApi.SalesChannels["headless"] = ConfigStoreApiSalesChannel{AccessKey: "SWSCHEADLESS", URL: "https://api.example.com"}
+
+	_, err = cfg.StoreApiSalesChannel("")
+	assert.ErrorContains(t, err, "choose one of: headless, storefront")
+
+	salesChannel, err = cfg.StoreApiSalesChannel("headless")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com", salesChannel.URL)
+
+	_, err = cfg.StoreApiSalesChannel("missing")
+	assert.ErrorContains(t, err, "sales channel missing is not configured")
+}
+
+func TestStoreApiClientKeepsContextToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SWSCKEY", r.Header.Get("sw-access-key"))
+
+		switch r.URL.Path {
+		case "/store-api/context":
+			assert.Equal(t, "", r.Header.Get("sw-context-token"))
+			w.Header().Set("sw-context-token", "new-token")
+			_, _ = w.Write([]byte(`{"token": "new-token"}`))
+		case "/store-api/checkout/cart":
+			assert.Equal(t, "new-token", r.Header.Get("sw-context-token"))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewStoreApiClient(server.Client(), server.URL+"/", "SWSCKEY")
+
+	var salesChannelContext struct {
+		Token string `json:"token"`
+	}
+
+	assert.NoError(t, client.Request(context.Background(), http.MethodGet, "/store-api/context", nil, &salesChannelContext))
+	assert.Equal(t, "new-token", salesChannelContext.Token)
+	assert.Equal(t, "new-token", client.ContextToken())
+
+	err := client.Request(context.Background(), http.MethodGet, "/store-api/checkout/cart", nil, nil)
+	assert.ErrorContains(t, err, "GET /store-api/checkout/cart failed with status 403")
+}
@@ -0,0 +1,159 @@
+package shop
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultSystemdPrefix            = "shopware"
+	defaultSystemdMemoryLimit       = "512M"
+	defaultSystemdTimeLimit         = 3600
+	defaultSystemdScheduledInterval = "1min"
+)
+
+// ConfigSystemd configures the units of project generate systemd.
+type ConfigSystemd struct {
+	User   string `yaml:"user,omitempty"`
+	Prefix string `yaml:"prefix,omitempty"`
+	// Transports maps the messenger transport to the amount of worker instances
+	Transports  map[string]int `yaml:"transports,omitempty"`
+	MemoryLimit string         `yaml:"memory_limit,omitempty"`
+	// TimeLimit in seconds after a worker restarts
+	TimeLimit             int    `yaml:"time_limit,omitempty"`
+	ScheduledTaskInterval string `yaml:"scheduled_task_interval,omitempty"`
+}
+
+type SystemdUnit struct {
+	Name    string
+	Content string
+	// Instances is the amount of instances to enable of a template unit
+	Instances int
+}
+
+// EnableName returns the unit names to pass to systemctl enable.
+func (u SystemdUnit) EnableName() []string {
+	if u.Instances == 0 {
+		return []string{u.Name}
+	}
+
+	names := make([]string, 0, u.Instances)
+	base := strings.TrimSuffix(u.Name, "@.service")
+
+	for i := 1; i <= u.Instances; i++ {
+		names = append(names, fmt.Sprintf("%s@%d.service", base, i))
+	}
+
+	return names
+}
+
+// GenerateSystemdUnits returns a worker template unit per transport and a timer running the scheduled tasks.
+func GenerateSystemdUnits(projectRoot string, cfg ConfigSystemd, console ConfigBuildConsole) []SystemdUnit {
+	if cfg.Prefix == "" {
+		cfg.Prefix = defaultSystemdPrefix
+	}
+
+	if len(cfg.Transports) == 0 {
+		cfg.Transports = map[string]int{"async": 1}
+	}
+
+	if cfg.MemoryLimit == "" {
+		cfg.MemoryLimit = defaultSystemdMemoryLimit
+	}
+
+	if cfg.TimeLimit < 1 {
+		cfg.TimeLimit = defaultSystemdTimeLimit
+	}
+
+	if cfg.ScheduledTaskInterval == "" {
+		cfg.ScheduledTaskInterval = defaultSystemdScheduledInterval
+	}
+
+	if console.PHPBinary == "" {
+		console.PHPBinary = "/usr/bin/php"
+	}
+
+	if console.Path == "" {
+		console.Path = "bin/console"
+	}
+
+	consoleCommand := fmt.Sprintf("%s %s", console.PHPBinary, path.Join(projectRoot, console.Path))
+
+	transports := make([]string, 0, len(cfg.Transports))
+	for transport := range cfg.Transports {
+		transports = append(transports, transport)
+	}
+
+	sort.Strings(transports)
+
+	units := make([]SystemdUnit, 0, len(transports)+2)
+
+	for _, transport := range transports {
+		instances := cfg.Transports[transport]
+		if instances < 1 {
+			instances = 1
+		}
+
+		units = append(units, SystemdUnit{
+			Name:      fmt.Sprintf("%s-worker-%s@.service", cfg.Prefix, transport),
+			Instances: instances,
+			Content: renderSystemdService(cfg, projectRoot,
+				fmt.Sprintf("Shopware message queue worker for %s (%%i)", transport),
+				fmt.Sprintf("%s messenger:consume %s --memory-limit=%s --time-limit=%d", consoleCommand, transport, cfg.MemoryLimit, cfg.TimeLimit),
+				"[Service]\nRestart=always\nRestartSec=5\n",
+				"[Install]\nWantedBy=multi-user.target\n",
+			),
+		})
+	}
+
+	scheduledTaskName := fmt.Sprintf("%s-scheduled-task", cfg.Prefix)
+
+	units = append(units,
+		SystemdUnit{
+			Name: scheduledTaskName + ".service",
+			Content: renderSystemdService(cfg, projectRoot,
+				"Shopware scheduled tasks",
+				fmt.Sprintf("%s scheduled-task:run --no-wait", consoleCommand),
+				"[Service]\nType=oneshot\n",
+				"",
+			),
+		},
+		SystemdUnit{
+			Name: scheduledTaskName + ".timer",
+			Content: fmt.Sprintf(`[Unit]
+Description=Runs the Shopware scheduled tasks every %s
+
+[Timer]
+OnBootSec=%s
+OnUnitActiveSec=%s
+Unit=%s.service
+
+[Install]
+WantedBy=timers.target
+`, cfg.ScheduledTaskInterval, cfg.ScheduledTaskInterval, cfg.ScheduledTaskInterval, scheduledTaskName),
+		},
+	)
+
+	return units
+}
+
+func renderSystemdService(cfg ConfigSystemd, projectRoot, description, command, serviceSection, installSection string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[Unit]\nDescription=%s\nAfter=network.target\n\n", description)
+	b.WriteString(serviceSection)
+
+	if cfg.User != "" {
+		fmt.Fprintf(&b, "User=%s\n", cfg.User)
+	}
+
+	fmt.Fprintf(&b, "WorkingDirectory=%s\nExecStart=%s\n", projectRoot, command)
+
+	if installSection != "" {
+		b.WriteString("\n" + installSection)
+	}
+
+	return b.String()
+}
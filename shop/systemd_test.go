@@ -0,0 +1,58 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSystemdUnitsDefaults(t *testing.T) {
+	units := GenerateSystemdUnits("/var/www/shop", ConfigSystemd{}, ConfigBuildConsole{})
+
+	assert.Len(t, units, 3)
+	assert.Equal(t, "shopware-worker-async@.service", units[0].Name)
+	assert.Equal(t, []string{"shopware-worker-async@1.service"}, units[0].EnableName())
+	assert.Equal(t, `[Unit]
+Description=Shopware message queue worker for async (%i)
+After=network.target
+
+[Service]
+Restart=always
+RestartSec=5
+WorkingDirectory=/var/www/shop
+ExecStart=/usr/bin/php /var/www/shop/bin/console messenger:consume async --memory-limit=512M --time-limit=3600
+
+[Install]
+WantedBy=multi-user.target
+`, units[0].Content)
+
+	assert.Equal(t, "shopware-scheduled-task.service", units[1].Name)
+	assert.Contains(t, units[1].Content, "Type=oneshot\n")
+	assert.Contains(t, units[1].Content, "ExecStart=/usr/bin/php /var/www/shop/bin/console scheduled-task:run --no-wait\n")
+	assert.NotContains(t, units[1].Content, "[Install]")
+
+	assert.Equal(t, "shopware-scheduled-task.timer", units[2].Name)
+	assert.Equal(t, []string{"shopware-scheduled-task.timer"}, units[2].EnableName())
+	assert.Contains(t, units[2].Content, "OnUnitActiveSec=1min\n")
+	assert.Contains(t, units[2].Content, "Unit=shopware-scheduled-task.service\n")
+}
+
+func TestGenerateSystemdUnitsConfigured(t *testing.T) {
+	cfg := ConfigSystemd{
+		User:        "www-data",
+		Prefix:      "shop",
+		Transports:  map[string]int{"low_priority": 1, "async": 3},
+		MemoryLimit: "1G",
+		TimeLimit:   60,
+	}
+
+	units := GenerateSystemdUnits("/srv/shop", cfg, ConfigBuildConsole{PHPBinary: "/usr/bin/php8.2", Path: "vendor/bin/console"})
+
+	assert.Len(t, units, 4)
+	assert.Equal(t, "shop-worker-async@.service", units[0].Name)
+	assert.Equal(t, []string{"shop-worker-async@1.service", "shop-worker-async@2.service", "shop-worker-async@3.service"}, units[0].EnableName())
+	assert.Contains(t, units[0].Content, "User=www-data\n")
+	assert.Contains(t, units[0].Content, "ExecStart=/usr/bin/php8.2 /srv/shop/vendor/bin/console messenger:consume async --memory-limit=1G --time-limit=60\n")
+	assert.Equal(t, "shop-worker-low_priority@.service", units[1].Name)
+	assert.Equal(t, "shop-scheduled-task.timer", units[3].Name)
+}
@@ -0,0 +1,46 @@
+package shop
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ReadTableColumns returns the columns of the given tables in the current database by table name, missing tables are not included.
+func ReadTableColumns(ctx context.Context, db *sql.DB, tables []string) (map[string][]string, error) {
+	columns := make(map[string][]string)
+
+	if len(tables) == 0 {
+		return columns, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(tables)), ",")
+	args := make([]interface{}, 0, len(tables))
+
+	for _, table := range tables {
+		args = append(args, table)
+	}
+
+	//nolint: gosec
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT TABLE_NAME, COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME IN (%s) ORDER BY TABLE_NAME, ORDINAL_POSITION", placeholders), args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the table columns: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var table, column string
+
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+
+		columns[table] = append(columns[table], column)
+	}
+
+	return columns, rows.Err()
+}
@@ -0,0 +1,298 @@
+package shop
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FriendsOfShopware/shopware-cli/version"
+)
+
+// upgradePlatformPackages are updated together to the target version, when the project requires them.
+var upgradePlatformPackages = []string{"shopware/core", "shopware/administration", "shopware/storefront", "shopware/elasticsearch"}
+
+var upgradeTargetRegex = regexp.MustCompile(`^\d+\.\d+(\.\d+\.\d+)?$`)
+
+type UpgradePlan struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Constraint is required for the platform packages in the composer.json
+	Constraint string           `json:"constraint"`
+	Packages   []string         `json:"packages"`
+	Blockers   []UpgradeBlocker `json:"blockers"`
+}
+
+// UpgradeBlocker is an installed extension, which does not allow the target version.
+type UpgradeBlocker struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Constraint string `json:"constraint"`
+	// Source is the composer.json or composer.lock the extension was found in
+	Source string `json:"source"`
+}
+
+type upgradeComposerJson struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+}
+
+type upgradeComposerLock struct {
+	Packages []upgradeComposerJson `json:"packages"`
+}
+
+type upgradeAppManifest struct {
+	Meta struct {
+		Name          string `xml:"name"`
+		Version       string `xml:"version"`
+		Compatibility string `xml:"compatibility"`
+	} `xml:"meta"`
+}
+
+// upgradeExtensionConstraint returns the Shopware constraint of a plugin, themes often require only the storefront.
+func upgradeExtensionConstraint(pkg upgradeComposerJson) (string, bool) {
+	if constraint, ok := pkg.Require["shopware/core"]; ok {
+		return constraint, true
+	}
+
+	constraint, ok := pkg.Require["shopware/storefront"]
+
+	return constraint, ok
+}
+
+// PlanUpgrade determines the composer constraint for the target (f.e. 6.6 or 6.6.1.0) and all plugins, themes and apps
+// of the project, which are not compatible with it.
+func PlanUpgrade(projectRoot, to string) (*UpgradePlan, error) {
+	if !upgradeTargetRegex.MatchString(to) {
+		return nil, fmt.Errorf("invalid target version %s, use a minor version like 6.6 or an exact version like 6.6.1.0", to)
+	}
+
+	plan := &UpgradePlan{To: to, Constraint: to, Blockers: make([]UpgradeBlocker, 0)}
+	target := version.Must(version.NewVersion(to))
+
+	if strings.Count(to, ".") == 1 {
+		plan.Constraint = "~" + to + ".0"
+		target = version.Must(version.NewVersion(to + ".0.0"))
+	}
+
+	var project upgradeComposerJson
+	if err := readUpgradeComposerFile(filepath.Join(projectRoot, "composer.json"), &project); err != nil {
+		return nil, err
+	}
+
+	for _, name := range upgradePlatformPackages {
+		if _, ok := project.Require[name]; ok {
+			plan.Packages = append(plan.Packages, name)
+		}
+	}
+
+	if len(plan.Packages) == 0 {
+		return nil, fmt.Errorf("the composer.json in %s does not require shopware/core", projectRoot)
+	}
+
+	var lock upgradeComposerLock
+	if err := readUpgradeComposerFile(filepath.Join(projectRoot, "composer.lock"), &lock); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	extensions := make(map[string]upgradeComposerJson)
+	sources := make(map[string]string)
+
+	for _, pkg := range lock.Packages {
+		if pkg.Name == "shopware/core" {
+			plan.From = strings.TrimPrefix(pkg.Version, "v")
+		}
+
+		if pkg.Type == "shopware-platform-plugin" {
+			extensions[pkg.Name] = pkg
+			sources[pkg.Name] = "composer.lock"
+		}
+	}
+
+	if plan.From != "" {
+		current, err := version.NewVersion(plan.From)
+		if err == nil && !current.LessThan(target) {
+			return nil, fmt.Errorf("the project uses already Shopware %s, which is not older than %s", plan.From, to)
+		}
+	}
+
+	composerFiles, err := filepath.Glob(filepath.Join(projectRoot, "custom", "plugins", "*", "composer.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	staticComposerFiles, err := filepath.Glob(filepath.Join(projectRoot, "custom", "static-plugins", "*", "composer.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	composerFiles = append(composerFiles, staticComposerFiles...)
+
+	for _, composerFile := range composerFiles {
+		var pkg upgradeComposerJson
+		if err := readUpgradeComposerFile(composerFile, &pkg); err != nil {
+			return nil, err
+		}
+
+		// plugins installed with composer are already known by the lock
+		if _, ok := extensions[pkg.Name]; ok || pkg.Type != "shopware-platform-plugin" {
+			continue
+		}
+
+		extensions[pkg.Name] = pkg
+		sources[pkg.Name], _ = filepath.Rel(projectRoot, composerFile)
+	}
+
+	for name, pkg := range extensions {
+		constraint, ok := upgradeExtensionConstraint(pkg)
+		if !ok {
+			continue
+		}
+
+		parsed, err := parseComposerConstraint(constraint)
+		if err == nil && parsed.Check(target) {
+			continue
+		}
+
+		plan.Blockers = append(plan.Blockers, UpgradeBlocker{
+			Name:       name,
+			Version:    pkg.Version,
+			Constraint: constraint,
+			Source:     filepath.ToSlash(sources[name]),
+		})
+	}
+
+	manifestFiles, err := filepath.Glob(filepath.Join(projectRoot, "custom", "apps", "*", "manifest.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manifestFile := range manifestFiles {
+		content, err := os.ReadFile(manifestFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var manifest upgradeAppManifest
+		if err := xml.Unmarshal(content, &manifest); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", manifestFile, err)
+		}
+
+		// apps without a compatibility are installable in every version
+		if manifest.Meta.Compatibility == "" {
+			continue
+		}
+
+		if parsed, err := version.NewConstraint(manifest.Meta.Compatibility); err == nil && parsed.Check(target) {
+			continue
+		}
+
+		source, _ := filepath.Rel(projectRoot, manifestFile)
+
+		plan.Blockers = append(plan.Blockers, UpgradeBlocker{
+			Name:       manifest.Meta.Name,
+			Version:    manifest.Meta.Version,
+			Constraint: manifest.Meta.Compatibility,
+			Source:     filepath.ToSlash(source),
+		})
+	}
+
+	sort.Slice(plan.Blockers, func(i, j int) bool {
+		return plan.Blockers[i].Name < plan.Blockers[j].Name
+	})
+
+	return plan, nil
+}
+
+// ComposerRequireArgs returns the arguments of composer require to change the constraints of the platform packages.
+func (p UpgradePlan) ComposerRequireArgs() []string {
+	args := []string{"require", "--no-update", "--no-interaction"}
+
+	for _, name := range p.Packages {
+		args = append(args, name+":"+p.Constraint)
+	}
+
+	return args
+}
+
+func readUpgradeComposerFile(path string, target interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+type UpgradeStep struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	// Error is empty when the step succeeded
+	Error string `json:"error,omitempty"`
+	// Optional steps do not stop the upgrade on errors, but need a manual follow-up
+	Optional bool `json:"optional,omitempty"`
+}
+
+type UpgradeReport struct {
+	UpgradePlan
+	Steps []UpgradeStep `json:"steps"`
+}
+
+// Markdown renders the report for the merge request or ticket of the upgrade.
+func (r UpgradeReport) Markdown() string {
+	var sb strings.Builder
+
+	from := r.From
+	if from == "" {
+		from = "unknown version"
+	}
+
+	sb.WriteString(fmt.Sprintf("# Shopware upgrade from %s to %s\n\n", from, r.To))
+	sb.WriteString(fmt.Sprintf("Required `%s` for %s.\n\n", r.Constraint, strings.Join(r.Packages, ", ")))
+
+	sb.WriteString("## Blocking extensions\n\n")
+
+	if len(r.Blockers) == 0 {
+		sb.WriteString("All installed extensions allow the target version.\n\n")
+	} else {
+		sb.WriteString("| Extension | Version | Shopware constraint | Source |\n| --- | --- | --- | --- |\n")
+
+		for _, blocker := range r.Blockers {
+			sb.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s |\n", blocker.Name, blocker.Version, blocker.Constraint, blocker.Source))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Steps\n\n")
+
+	if len(r.Steps) == 0 {
+		sb.WriteString("No steps were executed.\n")
+	}
+
+	for _, step := range r.Steps {
+		status := "done"
+
+		if step.Error != "" {
+			status = "failed: " + step.Error
+
+			if step.Optional {
+				status = "needs manual follow-up: " + step.Error
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("- %s (`%s`): %s\n", step.Name, step.Command, status))
+	}
+
+	return sb.String()
+}
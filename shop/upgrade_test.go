@@ -0,0 +1,88 @@
+package shop
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeUpgradeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.json"), `{"require": {"shopware/core": "~6.5.0", "shopware/storefront": "~6.5.0", "symfony/flex": "^2"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.lock"), `{"packages": [
+		{"name": "shopware/core", "version": "v6.5.8.2"},
+		{"name": "frosh/tools", "type": "shopware-platform-plugin", "version": "1.2.0", "require": {"shopware/core": "~6.5.0"}},
+		{"name": "frosh/mail-archive", "type": "shopware-platform-plugin", "version": "3.0.0", "require": {"shopware/core": "~6.5.0 || ~6.6.0"}}
+	]}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "plugins", "SwagCustom", "composer.json"), `{"name": "swag/custom", "type": "shopware-platform-plugin", "version": "0.1.0", "require": {"shopware/core": "6.5.*"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "plugins", "SwagModern", "composer.json"), `{"name": "swag/modern", "type": "shopware-platform-plugin", "require": {"shopware/core": ">=6.5.0"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "static-plugins", "SwagTheme", "composer.json"), `{"name": "swag/theme", "type": "shopware-platform-plugin", "version": "2.0.0", "require": {"shopware/storefront": "~6.5.0"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "apps", "SwagApp", "manifest.xml"), `<manifest><meta><name>SwagApp</name><version>1.0.0</version><compatibility>~6.5.0</compatibility></meta></manifest>`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "custom", "apps", "SwagAnyApp", "manifest.xml"), `<manifest><meta><name>SwagAnyApp</name><version>1.0.0</version></meta></manifest>`)
+
+	plan, err := PlanUpgrade(projectRoot, "6.6")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "6.5.8.2", plan.From)
+	assert.Equal(t, "~6.6.0", plan.Constraint)
+	assert.Equal(t, []string{"shopware/core", "shopware/storefront"}, plan.Packages)
+	assert.Equal(t, []UpgradeBlocker{
+		{Name: "SwagApp", Version: "1.0.0", Constraint: "~6.5.0", Source: "custom/apps/SwagApp/manifest.xml"},
+		{Name: "frosh/tools", Version: "1.2.0", Constraint: "~6.5.0", Source: "composer.lock"},
+		{Name: "swag/custom", Version: "0.1.0", Constraint: "6.5.*", Source: "custom/plugins/SwagCustom/composer.json"},
+		{Name: "swag/theme", Version: "2.0.0", Constraint: "~6.5.0", Source: "custom/static-plugins/SwagTheme/composer.json"},
+	}, plan.Blockers)
+	assert.Equal(t, []string{"require", "--no-update", "--no-interaction", "shopware/core:~6.6.0", "shopware/storefront:~6.6.0"}, plan.ComposerRequireArgs())
+}
+
+func TestPlanUpgradeInvalidTarget(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.json"), `{"require": {"shopware/core": "~6.6.0"}}`)
+	writeUpgradeTestFile(t, filepath.Join(projectRoot, "composer.lock"), `{"packages": [{"name": "shopware/core", "version": "6.6.1.0"}]}`)
+
+	_, err := PlanUpgrade(projectRoot, "6")
+	assert.ErrorContains(t, err, "invalid target version 6")
+
+	_, err = PlanUpgrade(projectRoot, "6.6")
+	assert.EqualError(t, err, "the project uses already Shopware 6.6.1.0, which is not older than 6.6")
+
+	plan, err := PlanUpgrade(projectRoot, "6.6.2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "6.6.2.0", plan.Constraint)
+}
+
+func TestUpgradeReportMarkdown(t *testing.T) {
+	report := UpgradeReport{
+		UpgradePlan: UpgradePlan{
+			From:       "6.5.8.2",
+			To:         "6.6",
+			Constraint: "~6.6.0",
+			Packages:   []string{"shopware/core"},
+			Blockers:   []UpgradeBlocker{{Name: "frosh/tools", Version: "1.2.0", Constraint: "~6.5.0", Source: "composer.lock"}},
+		},
+		Steps: []UpgradeStep{
+			{Name: "Update the composer constraints", Command: "composer require"},
+			{Name: "Update the recipe of shopware/core", Command: "composer recipes:update", Error: "exit status 1", Optional: true},
+		},
+	}
+
+	assert.Equal(t, "# Shopware upgrade from 6.5.8.2 to 6.6\n\n"+
+		"Required `~6.6.0` for shopware/core.\n\n"+
+		"## Blocking extensions\n\n"+
+		"| Extension | Version | Shopware constraint | Source |\n| --- | --- | --- | --- |\n"+
+		"| frosh/tools | 1.2.0 | `~6.5.0` | composer.lock |\n\n"+
+		"## Steps\n\n"+
+		"- Update the composer constraints (`composer require`): done\n"+
+		"- Update the recipe of shopware/core (`composer recipes:update`): needs manual follow-up: exit status 1\n", report.Markdown())
+}
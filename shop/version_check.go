@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
-	"path"
+	"path/filepath"
 
 	"github.com/FriendsOfShopware/shopware-cli/version"
 )
@@ -15,8 +15,8 @@ var (
 )
 
 func IsShopwareVersion(projectRoot string, requiredVersion string) (bool, error) {
-	composerJson := path.Join(projectRoot, "composer.json")
-	composerLock := path.Join(projectRoot, "composer.lock")
+	composerJson := filepath.Join(projectRoot, "composer.json")
+	composerLock := filepath.Join(projectRoot, "composer.lock")
 
 	if _, err := os.Stat(composerLock); err == nil {
 		found, err := determineByComposerLock(composerLock, requiredVersion)